@@ -34,7 +34,9 @@ import (
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
 	sourcedebugger "github.com/rudderlabs/rudder-server/services/debugger/source"
 	transformationdebugger "github.com/rudderlabs/rudder-server/services/debugger/transformation"
+	"github.com/rudderlabs/rudder-server/services/dlq"
 	"github.com/rudderlabs/rudder-server/services/fileuploader"
+	"github.com/rudderlabs/rudder-server/services/lookup"
 	"github.com/rudderlabs/rudder-server/services/rmetrics"
 	"github.com/rudderlabs/rudder-server/services/transformer"
 	"github.com/rudderlabs/rudder-server/services/transientsource"
@@ -261,7 +263,17 @@ func (a *embeddedApp) StartRudderCore(ctx context.Context, options *app.Options)
 
 	adaptiveLimit := payload.SetupAdaptiveLimiter(ctx, g)
 
-	enrichers, err := setupPipelineEnrichers(config, a.log, statsFactory)
+	// the lookup tables' store keeps the reference data joined with the events of sources by the lookup enricher
+	var lookupStore lookup.Store
+	if lookup.Enabled(config) {
+		lookupStore, err = lookup.New(config, statsFactory, a.log.Child("lookup"), lookup.WithDB(dbPool))
+		if err != nil {
+			return fmt.Errorf("setting up lookup store: %w", err)
+		}
+		defer func() { _ = lookupStore.Close() }()
+	}
+
+	enrichers, err := setupPipelineEnrichers(config, a.log, statsFactory, lookupStore)
 	if err != nil {
 		return fmt.Errorf("setting up pipeline enrichers: %w", err)
 	}
@@ -274,6 +286,27 @@ func (a *embeddedApp) StartRudderCore(ctx context.Context, options *app.Options)
 
 	pendingEventsRegistry := rmetrics.NewPendingEventsRegistry()
 
+	// the dead-letter queue keeps a copy of the jobs aborted by routers and batch routers, so that they can be requeued.
+	// Jobs older than DLQ.retention expire, i.e. they get aborted and cleaned up along with the rest of the dataset.
+	var dlqDB jobsdb.JobsDB
+	var dlqService *dlq.Service
+	if dlq.Enabled(config) {
+		dlqJobsDB := jobsdb.NewForReadWrite(
+			dlq.TablePrefix,
+			jobsdb.WithClearDB(options.ClearDB),
+			jobsdb.WithStats(statsFactory),
+			jobsdb.WithDBHandle(dbPool),
+			jobsdb.WithJobMaxAge(config.GetReloadableDurationVar(7*24, time.Hour, "DLQ.retention")),
+		)
+		defer dlqJobsDB.Close()
+		if err = dlqJobsDB.Start(); err != nil {
+			return fmt.Errorf("could not start dlqDB: %w", err)
+		}
+		defer dlqJobsDB.Stop()
+		dlqDB = dlqJobsDB
+		dlqService = dlq.NewService(dlqJobsDB, []jobsdb.JobsDB{routerDB, batchRouterDB}, pendingEventsRegistry, config, a.log.Child("dlq"))
+	}
+
 	proc := processor.New(
 		ctx,
 		&options.ClearDB,
@@ -309,6 +342,7 @@ func (a *embeddedApp) StartRudderCore(ctx context.Context, options *app.Options)
 			routerDB,
 		),
 		ProcErrorDB:                errDBForWrite,
+		DLQDB:                      dlqDB,
 		TransientSources:           transientSources,
 		RsourcesService:            rsourcesService,
 		TransformerFeaturesService: transformerFeaturesService,
@@ -325,6 +359,7 @@ func (a *embeddedApp) StartRudderCore(ctx context.Context, options *app.Options)
 			batchRouterDB,
 		),
 		ProcErrorDB:           errDBForWrite,
+		DLQDB:                 dlqDB,
 		TransientSources:      transientSources,
 		RsourcesService:       rsourcesService,
 		Debugger:              destinationHandle,
@@ -369,14 +404,19 @@ func (a *embeddedApp) StartRudderCore(ctx context.Context, options *app.Options)
 		return drainConfigManager.CleanupRoutine(ctx)
 	}))
 	streamMsgValidator := stream.NewMessageValidator()
+	internalHttpHandlers := map[string]http.Handler{
+		"/drain": drainConfigManager.DrainConfigHttpHandler(),
+	}
+	if dlqService != nil {
+		internalHttpHandlers["/v1/dlq"] = dlqService.HttpHandler()
+	}
+	if lookupStore != nil {
+		internalHttpHandlers["/v1/lookup"] = lookup.HttpHandler(lookupStore, config, a.log)
+	}
 	gw := gateway.Handle{}
 	err = gw.Setup(ctx, config, logger.NewLogger().Child("gateway"), statsFactory, a.app, backendconfig.DefaultBackendConfig,
 		gatewayDB, errDBForWrite, rateLimiter, a.versionHandler, rsourcesService, transformerFeaturesService, sourceHandle,
-		streamMsgValidator, gateway.WithInternalHttpHandlers(
-			map[string]http.Handler{
-				"/drain": drainConfigManager.DrainConfigHttpHandler(),
-			},
-		))
+		streamMsgValidator, gateway.WithInternalHttpHandlers(internalHttpHandlers))
 	if err != nil {
 		return fmt.Errorf("could not setup gateway: %w", err)
 	}
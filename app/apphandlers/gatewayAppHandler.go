@@ -40,6 +40,10 @@ func (a *gatewayApp) Setup() error {
 	if err := rudderCoreDBValidator(); err != nil {
 		return err
 	}
+	// node migrations create the checkpoints table of the postgres cdc sources streamed by the gateway
+	if err := rudderCoreNodeSetup(); err != nil {
+		return err
+	}
 	a.setupDone = true
 	return nil
 }
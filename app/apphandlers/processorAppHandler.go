@@ -31,7 +31,9 @@ import (
 	schema_forwarder "github.com/rudderlabs/rudder-server/schema-forwarder"
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
 	transformationdebugger "github.com/rudderlabs/rudder-server/services/debugger/transformation"
+	"github.com/rudderlabs/rudder-server/services/dlq"
 	"github.com/rudderlabs/rudder-server/services/fileuploader"
+	"github.com/rudderlabs/rudder-server/services/lookup"
 	"github.com/rudderlabs/rudder-server/services/rmetrics"
 	"github.com/rudderlabs/rudder-server/services/transformer"
 	"github.com/rudderlabs/rudder-server/services/transientsource"
@@ -247,7 +249,17 @@ func (a *processorApp) StartRudderCore(ctx context.Context, options *app.Options
 
 	adaptiveLimit := payload.SetupAdaptiveLimiter(ctx, g)
 
-	enrichers, err := setupPipelineEnrichers(config, a.log, statsFactory)
+	// the lookup tables' store keeps the reference data joined with the events of sources by the lookup enricher
+	var lookupStore lookup.Store
+	if lookup.Enabled(config) {
+		lookupStore, err = lookup.New(config, statsFactory, a.log.Child("lookup"), lookup.WithDB(dbPool))
+		if err != nil {
+			return fmt.Errorf("setting up lookup store: %w", err)
+		}
+		defer func() { _ = lookupStore.Close() }()
+	}
+
+	enrichers, err := setupPipelineEnrichers(config, a.log, statsFactory, lookupStore)
 	if err != nil {
 		return fmt.Errorf("setting up pipeline enrichers: %w", err)
 	}
@@ -272,6 +284,27 @@ func (a *processorApp) StartRudderCore(ctx context.Context, options *app.Options
 
 	pendingEventsRegistry := rmetrics.NewPendingEventsRegistry()
 
+	// the dead-letter queue keeps a copy of the jobs aborted by routers and batch routers, so that they can be requeued.
+	// Jobs older than DLQ.retention expire, i.e. they get aborted and cleaned up along with the rest of the dataset.
+	var dlqDB jobsdb.JobsDB
+	var dlqService *dlq.Service
+	if dlq.Enabled(config) {
+		dlqJobsDB := jobsdb.NewForReadWrite(
+			dlq.TablePrefix,
+			jobsdb.WithClearDB(options.ClearDB),
+			jobsdb.WithStats(statsFactory),
+			jobsdb.WithDBHandle(dbPool),
+			jobsdb.WithJobMaxAge(config.GetReloadableDurationVar(7*24, time.Hour, "DLQ.retention")),
+		)
+		defer dlqJobsDB.Close()
+		if err = dlqJobsDB.Start(); err != nil {
+			return fmt.Errorf("could not start dlqDB: %w", err)
+		}
+		defer dlqJobsDB.Stop()
+		dlqDB = dlqJobsDB
+		dlqService = dlq.NewService(dlqJobsDB, []jobsdb.JobsDB{routerDB, batchRouterDB}, pendingEventsRegistry, config, a.log.Child("dlq"))
+	}
+
 	p := proc.New(
 		ctx,
 		&options.ClearDB,
@@ -307,6 +340,7 @@ func (a *processorApp) StartRudderCore(ctx context.Context, options *app.Options
 			routerDB,
 		),
 		ProcErrorDB:                errDBForWrite,
+		DLQDB:                      dlqDB,
 		TransientSources:           transientSources,
 		RsourcesService:            rsourcesService,
 		TransformerFeaturesService: transformerFeaturesService,
@@ -323,6 +357,7 @@ func (a *processorApp) StartRudderCore(ctx context.Context, options *app.Options
 			batchRouterDB,
 		),
 		ProcErrorDB:           errDBForWrite,
+		DLQDB:                 dlqDB,
 		TransientSources:      transientSources,
 		RsourcesService:       rsourcesService,
 		Debugger:              destinationHandle,
@@ -353,7 +388,11 @@ func (a *processorApp) StartRudderCore(ctx context.Context, options *app.Options
 	}
 
 	g.Go(func() error {
-		return a.startHealthWebHandler(ctx, gwDBForProcessor)
+		var lookupHttpHandler http.Handler
+		if lookupStore != nil {
+			lookupHttpHandler = lookup.HttpHandler(lookupStore, config, a.log)
+		}
+		return a.startHealthWebHandler(ctx, gwDBForProcessor, dlqService, lookupHttpHandler)
 	})
 
 	g.Go(func() error {
@@ -377,12 +416,19 @@ func (a *processorApp) StartRudderCore(ctx context.Context, options *app.Options
 	return g.Wait()
 }
 
-func (a *processorApp) startHealthWebHandler(ctx context.Context, db *jobsdb.Handle) error {
+func (a *processorApp) startHealthWebHandler(ctx context.Context, db *jobsdb.Handle, dlqService *dlq.Service, lookupHttpHandler http.Handler) error {
 	// Port where Processor health handler is running
 	a.log.Infof("Starting in %d", a.config.http.webPort)
 	srvMux := chi.NewMux()
 	srvMux.HandleFunc("/health", app.LivenessHandler(db))
 	srvMux.HandleFunc("/", app.LivenessHandler(db))
+	if dlqService != nil {
+		// the gateway is not running along with the processor, thus the dead-letter queue's api is served here
+		srvMux.Mount("/internal/v1/dlq", dlqService.HttpHandler())
+	}
+	if lookupHttpHandler != nil {
+		srvMux.Mount("/internal/v1/lookup", lookupHttpHandler)
+	}
 	srv := &http.Server{
 		Addr:              ":" + strconv.Itoa(a.config.http.webPort),
 		Handler:           crash.Handler(srvMux),
@@ -15,6 +15,7 @@ import (
 	"github.com/rudderlabs/rudder-server/app/cluster"
 	"github.com/rudderlabs/rudder-server/app/cluster/state"
 	"github.com/rudderlabs/rudder-server/internal/enricher"
+	"github.com/rudderlabs/rudder-server/services/lookup"
 	"github.com/rudderlabs/rudder-server/services/rsources"
 	"github.com/rudderlabs/rudder-server/services/validators"
 	"github.com/rudderlabs/rudder-server/utils/misc"
@@ -137,7 +138,7 @@ func terminalErrorFunction(ctx context.Context, g *errgroup.Group) func(error) {
 	return cancel
 }
 
-func setupPipelineEnrichers(conf *config.Config, log logger.Logger, stats stats.Stats) ([]enricher.PipelineEnricher, error) {
+func setupPipelineEnrichers(conf *config.Config, log logger.Logger, stats stats.Stats, lookupStore lookup.Store) ([]enricher.PipelineEnricher, error) {
 	var enrichers []enricher.PipelineEnricher
 
 	if conf.GetBool("GeoEnrichment.enabled", false) {
@@ -160,5 +161,25 @@ func setupPipelineEnrichers(conf *config.Config, log logger.Logger, stats stats.
 		enrichers = append(enrichers, botEnricher)
 	}
 
+	if conf.GetBool("UserAgentEnrichment.enabled", false) {
+		log.Infon("Setting up the user agent pipeline enricher")
+
+		userAgentEnricher, err := enricher.NewUserAgentEnricher(conf, log, stats)
+		if err != nil {
+			return nil, fmt.Errorf("starting user agent enrichment process for pipeline: %w", err)
+		}
+		enrichers = append(enrichers, userAgentEnricher)
+	}
+
+	if lookupStore != nil {
+		log.Infon("Setting up the lookup pipeline enricher")
+
+		lookupEnricher, err := enricher.NewLookupEnricher(lookupStore, log, stats)
+		if err != nil {
+			return nil, fmt.Errorf("starting lookup enrichment process for pipeline: %w", err)
+		}
+		enrichers = append(enrichers, lookupEnricher)
+	}
+
 	return enrichers, nil
 }
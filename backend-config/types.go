@@ -113,6 +113,74 @@ type SourceT struct {
 	GeoEnrichment              struct {
 		Enabled bool
 	}
+	SchemaEnforcement SchemaEnforcementT
+	Dedup             DedupConfigT
+	WebhookSignature  WebhookSignatureT
+	LookupEnrichment  LookupEnrichmentT
+}
+
+// SchemaEnforcementT contains the JSON Schema definitions used by the gateway for validating the events of a source at ingestion time.
+type SchemaEnforcementT struct {
+	Enabled bool `json:"enabled"`
+	// Mode is either "reject" (events violating their schema are rejected) or "flag" (violations are added to the event's context)
+	Mode string `json:"mode"`
+	// Schemas are keyed by event name for track events and by event type for all other events.
+	// The schema keyed by "*" applies to all events without a more specific schema.
+	Schemas map[string]json.RawMessage `json:"schemas"`
+}
+
+// DedupConfigT contains the content-based deduplication rules of a source, which are evaluated by the processor
+// in addition to the deduplication based on message ids.
+type DedupConfigT struct {
+	Rules []DedupRuleT `json:"rules"`
+}
+
+// DedupRuleT considers two events of a source as duplicates if all the values found at its key paths are equal and
+// both events have been received within a window of each other.
+type DedupRuleT struct {
+	Name string `json:"name"`
+	// KeyPaths are gjson paths evaluated against the event, e.g. "event" or "properties.order_id".
+	// Events missing any of the paths are not deduplicated by the rule.
+	KeyPaths []string `json:"keyPaths"`
+	// Window is a duration string, e.g. "10m". Windows longer than the deduplication service's window are capped by it.
+	Window string `json:"window"`
+}
+
+// WebhookSignatureT contains the signature verification settings of a webhook source, which are used by the gateway
+// for rejecting the requests not signed by the source's provider.
+type WebhookSignatureT struct {
+	// Provider is either one of the well-known providers "stripe", "github", "shopify" and "twilio",
+	// or "generic" for providers signing their payloads with an HMAC of the header, algorithm and encoding below.
+	// Signatures are not verified if empty.
+	Provider string `json:"provider"`
+	Secret   string `json:"secret"`
+	// Header, Algorithm ("sha1", "sha256" or "sha512"), Encoding ("hex" or "base64") and Prefix of the signature,
+	// e.g. "sha256=", are only used by the generic provider.
+	Header    string `json:"header"`
+	Algorithm string `json:"algorithm"`
+	Encoding  string `json:"encoding"`
+	Prefix    string `json:"prefix"`
+	// TimestampHeader is the header of the unix timestamp signed along with the payload by the generic provider,
+	// as "<timestamp>.<payload>", if any.
+	TimestampHeader string `json:"timestampHeader"`
+	// Tolerance is a duration string, e.g. "5m", above which signed timestamps are considered as replays.
+	Tolerance string `json:"tolerance"`
+}
+
+// LookupEnrichmentT contains the lookup tables joined with the events of a source by the processor,
+// whose rows are uploaded through the lookup tables' API.
+type LookupEnrichmentT struct {
+	Lookups []LookupT `json:"lookups"`
+}
+
+// LookupT joins the events of a source with the rows of a lookup table keyed by the value found at the key path of the event.
+type LookupT struct {
+	Table string `json:"table"`
+	// KeyPath is a dot-separated path evaluated against the event, e.g. "userId" or "context.page.url".
+	KeyPath string `json:"keyPath"`
+	// ContextKey is the key of the event's context where the matching row is written, defaults to the table's name.
+	// Rows are not written if the key is already present.
+	ContextKey string `json:"contextKey"`
 }
 
 type Credential struct {
@@ -186,6 +254,7 @@ type Settings struct {
 	DataRetention     DataRetention `json:"dataRetention"`
 	EventAuditEnabled bool          `json:"eventAuditEnabled"`
 	EventBlocking     EventBlocking `json:"eventBlocking"`
+	PIIMasking        PIIMasking    `json:"piiMasking"`
 }
 
 type DataRetention struct {
@@ -277,3 +346,8 @@ type TrackingPlanT struct {
 type EventBlocking struct {
 	Events map[string][]string `json:"events"`
 }
+
+type PIIMasking struct {
+	// Salt is prepended to the values of the workspace's events when hashing their PII
+	Salt string `json:"salt"`
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+
+	"github.com/rudderlabs/rudder-server/services/dedup"
+	"github.com/rudderlabs/rudder-server/services/dedup/postgres"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+func init() {
+	DefaultList = append(DefaultList, DEDUP())
+}
+
+func DEDUP() *cli.Command {
+	c := &cli.Command{
+		Name:  "dedup",
+		Usage: "interact with the deduplication backends",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "migrate",
+				Usage:  "copy all deduplication keys from one backend to another",
+				Action: DedupMigrate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "from",
+						Usage:    fmt.Sprintf("source backend, one of %v", dedup.Backends()),
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "to",
+						Usage:    fmt.Sprintf("destination backend, one of %v", dedup.Backends()),
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:  "batch-size",
+						Usage: "number of keys copied at once",
+						Value: 10000,
+					},
+				},
+			},
+		},
+	}
+
+	return c
+}
+
+// DedupMigrate copies all keys between two deduplication backends, configured the same way as rudder-server
+func DedupMigrate(c *cli.Context) error {
+	from, to := c.String("from"), c.String("to")
+	if from == to {
+		return fmt.Errorf("source and destination backends need to be different")
+	}
+	if c.Int("batch-size") < 1 {
+		return fmt.Errorf("batch size needs to be positive")
+	}
+	misc.Init()
+	conf := config.Default
+	log := logger.NewLogger().Child("dedup")
+
+	var opts []dedup.Option
+	if from == dedup.BackendPostgres || to == dedup.BackendPostgres {
+		db, err := misc.NewDatabaseConnectionPool(c.Context, conf, stats.NOP, "devtool-dedup")
+		if err != nil {
+			return fmt.Errorf("connecting to database: %w", err)
+		}
+		defer func() { _ = db.Close() }()
+		opts = append(opts, dedup.WithDB(postgres.NewTxRunner(db)))
+	}
+
+	src, err := dedup.NewBackend(from, conf, stats.NOP, log, opts...)
+	if err != nil {
+		return fmt.Errorf("creating source backend: %w", err)
+	}
+	defer src.Close()
+	dest, err := dedup.NewBackend(to, conf, stats.NOP, log, opts...)
+	if err != nil {
+		return fmt.Errorf("creating destination backend: %w", err)
+	}
+	defer dest.Close()
+
+	copied, err := dedup.Migrate(c.Context, src, dest, c.Int("batch-size"))
+	if err != nil {
+		return fmt.Errorf("migrating keys after copying %d: %w", copied, err)
+	}
+	fmt.Printf("copied %d keys from %s to %s\n", copied, from, to)
+	return nil
+}
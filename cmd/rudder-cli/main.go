@@ -65,6 +65,31 @@ func main() {
 				return err
 			},
 		},
+		{
+			Name:  "wh-plan",
+			Usage: "Show what a warehouse upload would do, without touching the underlying warehouse",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "dest",
+					Usage:   `Specify destination ID to plan the upload of pending staging files`,
+					Aliases: []string{"d"},
+				},
+				&cli.StringFlag{
+					Name:    "source",
+					Usage:   `Specify source ID to plan the upload of pending staging files`,
+					Aliases: []string{"src"},
+				},
+				&cli.Int64Flag{
+					Name:    "upload",
+					Usage:   `Specify upload ID to plan an existing upload`,
+					Aliases: []string{"u"},
+				},
+			},
+			Action: func(c *cli.Context) error {
+				err := warehouse.Plan(c)
+				return err
+			},
+		},
 		{
 			Name:  "wh-test",
 			Usage: "Test underlying warehouse",
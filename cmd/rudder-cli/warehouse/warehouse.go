@@ -3,6 +3,9 @@ package warehouse
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
@@ -30,6 +33,37 @@ type ConfigurationTestOutput struct {
 	Error string
 }
 
+type PlanInput struct {
+	DestID   string
+	SourceID string
+	UploadID int64
+}
+
+type UploadPlan struct {
+	UploadID        int64
+	SourceID        string
+	DestinationID   string
+	DestinationType string
+	Namespace       string
+	StagingFiles    int
+	Tables          []TablePlan
+}
+
+type TablePlan struct {
+	Name             string
+	TableToBeCreated bool
+	AddedColumns     map[string]string
+	AlteredColumns   map[string]string
+	Rows             int64
+	Columns          []ColumnPlan
+}
+
+type ColumnPlan struct {
+	Name                 string
+	Discards             int64
+	ConstraintViolations int64
+}
+
 func Query(c *cli.Context) (err error) {
 	reply := QueryResult{}
 
@@ -91,3 +125,60 @@ func ConfigurationTest(c *cli.Context) (err error) {
 	}
 	return
 }
+
+func Plan(c *cli.Context) (err error) {
+	reply := UploadPlan{}
+
+	input := PlanInput{
+		DestID:   c.String("dest"),
+		SourceID: c.String("source"),
+		UploadID: c.Int64("upload"),
+	}
+	err = client.GetUDSClient().Call("Warehouse.Plan", input, &reply)
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("Plan for %s namespace %s (sourceID: %s, destID: %s) using %d staging files\n",
+		reply.DestinationType, reply.Namespace, reply.SourceID, reply.DestinationID, reply.StagingFiles,
+	)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Table", "Action", "Added columns", "Altered columns", "Rows", "Discarded columns"})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+
+	for _, t := range reply.Tables {
+		action := "-"
+		if t.TableToBeCreated {
+			action = "create"
+		} else if len(t.AddedColumns) > 0 || len(t.AlteredColumns) > 0 {
+			action = "alter"
+		}
+
+		var discards []string
+		for _, column := range t.Columns {
+			discards = append(discards, fmt.Sprintf("%s (discards: %d, constraint violations: %d)", column.Name, column.Discards, column.ConstraintViolations))
+		}
+
+		table.Append([]string{
+			t.Name,
+			action,
+			formatColumns(t.AddedColumns),
+			formatColumns(t.AlteredColumns),
+			strconv.FormatInt(t.Rows, 10),
+			strings.Join(discards, "\n"),
+		})
+	}
+	table.Render()
+	return
+}
+
+func formatColumns(columns map[string]string) string {
+	formatted := make([]string, 0, len(columns))
+	for name, dataType := range columns {
+		formatted = append(formatted, name+" "+dataType)
+	}
+	sort.Strings(formatted)
+	return strings.Join(formatted, "\n")
+}
@@ -131,6 +131,7 @@ func (eir *ErrorIndexReporter) Report(ctx context.Context, metrics []*types.PURe
 				FailedStage:      metric.PU,
 				EventName:        metric.StatusDetail.EventName,
 				EventType:        metric.StatusDetail.EventType,
+				ErrorType:        metric.StatusDetail.ErrorType,
 			}
 			payload.SetReceivedAt(failedMessage.ReceivedAt)
 			payload.SetFailedAt(failedAt)
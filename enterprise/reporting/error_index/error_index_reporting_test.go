@@ -145,6 +145,7 @@ func TestErrorIndexReporter(t *testing.T) {
 						StatusDetail: &types.StatusDetail{
 							EventName: eventName,
 							EventType: eventType,
+							ErrorType: "denied_consent",
 							FailedMessages: []*types.FailedMessage{
 								{
 									MessageID:  messageID + "3",
@@ -192,6 +193,7 @@ func TestErrorIndexReporter(t *testing.T) {
 						TrackingPlanID:   trackingPlanID,
 						EventName:        eventName,
 						EventType:        eventType,
+						ErrorType:        "denied_consent",
 						FailedStage:      reportedBy,
 						FailedAt:         failedAt.UnixMicro(),
 					},
@@ -204,6 +206,7 @@ func TestErrorIndexReporter(t *testing.T) {
 						TrackingPlanID:   trackingPlanID,
 						EventName:        eventName,
 						EventType:        eventType,
+						ErrorType:        "denied_consent",
 						FailedStage:      reportedBy,
 						FailedAt:         failedAt.UnixMicro(),
 					},
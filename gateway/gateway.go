@@ -33,9 +33,11 @@ var (
 )
 
 var (
-	errRequestDropped    = errors.New("request dropped")
-	errRequestSuppressed = errors.New("request suppressed")
-	errEventSuppressed   = errors.New("event suppressed")
+	errRequestDropped       = errors.New("request dropped")
+	errSourceRequestDropped = errors.New("request dropped: source limit reached")
+	errUserRequestDropped   = errors.New("request dropped: user limit reached")
+	errRequestSuppressed    = errors.New("request suppressed")
+	errEventSuppressed      = errors.New("event suppressed")
 )
 
 //go:embed openapi/index.html
@@ -49,6 +49,7 @@ import (
 	"github.com/rudderlabs/rudder-server/enterprise/suppress-user/model"
 	gwstats "github.com/rudderlabs/rudder-server/gateway/internal/stats"
 	"github.com/rudderlabs/rudder-server/gateway/response"
+	gwThrottler "github.com/rudderlabs/rudder-server/gateway/throttler"
 	webhookModel "github.com/rudderlabs/rudder-server/gateway/webhook/model"
 	"github.com/rudderlabs/rudder-server/jobsdb"
 	mocksApp "github.com/rudderlabs/rudder-server/mocks/app"
@@ -992,6 +993,7 @@ var _ = Describe("Gateway", func() {
 			err        error
 			gateway    *Handle
 			statsStore *memstats.Store
+			noTokens   = func(context.Context) error { return nil }
 		)
 
 		BeforeEach(func() {
@@ -1001,6 +1003,7 @@ var _ = Describe("Gateway", func() {
 
 			gateway = &Handle{}
 			conf.Set("Gateway.enableRateLimit", true)
+			conf.Set("Gateway.enableSourceAndUserRateLimit", true)
 			err := gateway.Setup(context.Background(), conf, logger.NOP, statsStore, c.mockApp, c.mockBackendConfig, c.mockJobsDB, c.mockErrJobsDB, c.mockRateLimiter, c.mockVersionHandler, rsources.NewNoOpService(), transformer.NewNoOpService(), sourcedebugger.NewNoOpService(), nil)
 			Expect(err).To(BeNil())
 			waitForBackendConfigInit(gateway)
@@ -1012,6 +1015,7 @@ var _ = Describe("Gateway", func() {
 		})
 
 		It("should store messages successfully if rate limit is not reached for workspace", func() {
+			c.mockRateLimiter.EXPECT().CheckSourceAndUserLimitsReached(gomock.Any(), SourceIDEnabled, gomock.Len(1)).Return("", noTokens, nil).Times(1)
 			c.mockRateLimiter.EXPECT().CheckLimitReached(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(1)
 			c.mockJobsDB.EXPECT().WithStoreSafeTx(gomock.Any(), gomock.Any()).Times(1).Do(func(ctx context.Context, f func(tx jobsdb.StoreSafeTx) error) {
 				_ = f(jobsdb.EmptyStoreSafeTx())
@@ -1054,6 +1058,11 @@ var _ = Describe("Gateway", func() {
 
 		It("should reject messages if rate limit is reached for workspace", func() {
 			conf.Set("Gateway.allowReqsWithoutUserIDAndAnonymousID", true)
+			var tokensGivenBack bool
+			c.mockRateLimiter.EXPECT().CheckSourceAndUserLimitsReached(gomock.Any(), SourceIDEnabled, gomock.Len(1)).Return("", func(context.Context) error {
+				tokensGivenBack = true
+				return nil
+			}, nil).Times(1)
 			c.mockRateLimiter.EXPECT().CheckLimitReached(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(1)
 			expectHandlerResponse(
 				gateway.webAliasHandler(),
@@ -1062,6 +1071,7 @@ var _ = Describe("Gateway", func() {
 				response.TooManyRequests+"\n",
 				"alias",
 			)
+			Expect(tokensGivenBack).To(BeTrue(), "source and user limit tokens should be given back")
 			Eventually(
 				func() bool {
 					stat := statsStore.Get(
@@ -1082,6 +1092,41 @@ var _ = Describe("Gateway", func() {
 				1*time.Second,
 			).Should(BeTrue())
 		})
+
+		It("should reject messages if rate limit is reached for source", func() {
+			c.mockRateLimiter.EXPECT().CheckSourceAndUserLimitsReached(gomock.Any(), SourceIDEnabled, gomock.Len(1)).Return(gwThrottler.SourceLimit, noTokens, nil).Times(1)
+			expectHandlerResponse(
+				gateway.webAliasHandler(),
+				authorizedRequest(WriteKeyEnabled, bytes.NewBufferString(fmt.Sprintf(`{"userId":"dummyId",%s}`, sdkContext))),
+				http.StatusTooManyRequests,
+				response.SourceTooManyRequests+"\n",
+				"alias",
+			)
+		})
+
+		It("should reject messages if rate limit is reached for user", func() {
+			c.mockRateLimiter.EXPECT().CheckSourceAndUserLimitsReached(gomock.Any(), SourceIDEnabled, gomock.Len(1)).Return(gwThrottler.UserLimit, noTokens, nil).Times(1)
+			expectHandlerResponse(
+				gateway.webAliasHandler(),
+				authorizedRequest(WriteKeyEnabled, bytes.NewBufferString(fmt.Sprintf(`{"userId":"dummyId",%s}`, sdkContext))),
+				http.StatusTooManyRequests,
+				response.UserTooManyRequests+"\n",
+				"alias",
+			)
+		})
+
+		It("should check source and user limits if workspace rate limits are disabled", func() {
+			conf.Set("Gateway.enableRateLimit", false)
+			defer conf.Set("Gateway.enableRateLimit", true)
+			c.mockRateLimiter.EXPECT().CheckSourceAndUserLimitsReached(gomock.Any(), SourceIDEnabled, gomock.Len(1)).Return(gwThrottler.UserLimit, noTokens, nil).Times(1)
+			expectHandlerResponse(
+				gateway.webAliasHandler(),
+				authorizedRequest(WriteKeyEnabled, bytes.NewBufferString(fmt.Sprintf(`{"userId":"dummyId",%s}`, sdkContext))),
+				http.StatusTooManyRequests,
+				response.UserTooManyRequests+"\n",
+				"alias",
+			)
+		})
 	})
 
 	Context("Invalid requests", func() {
@@ -2186,7 +2231,7 @@ var _ = Describe("Gateway", func() {
 				done:           make(chan<- string),
 				requestPayload: payload,
 			}
-			jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(BeNil())
 			Expect(jobsWithStats).To(HaveLen(1))
 			Expect(jobsWithStats[0].stat).To(Equal(
@@ -2234,7 +2279,7 @@ var _ = Describe("Gateway", func() {
 				done:           make(chan<- string),
 				requestPayload: payload,
 			}
-			jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(BeNil())
 			Expect(jobsWithStats).To(HaveLen(1))
 			Expect(jobsWithStats[0].stat).To(Equal(gwstats.SourceStat{
@@ -2279,7 +2324,7 @@ var _ = Describe("Gateway", func() {
 				done:           make(chan<- string),
 				requestPayload: payload,
 			}
-			jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(BeNil())
 			Expect(jobsWithStats).To(HaveLen(1))
 			Expect(jobsWithStats[0].stat).To(Equal(gwstats.SourceStat{
@@ -2325,7 +2370,7 @@ var _ = Describe("Gateway", func() {
 				done:           make(chan<- string),
 				requestPayload: payload,
 			}
-			jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(BeNil())
 			Expect(jobsWithStats).To(HaveLen(1))
 			Expect(jobsWithStats[0].stat).To(Equal(
@@ -2371,7 +2416,7 @@ var _ = Describe("Gateway", func() {
 				done:           make(chan<- string),
 				requestPayload: payload,
 			}
-			jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(BeNil())
 			Expect(jobsWithStats).To(HaveLen(1))
 			Expect(jobsWithStats[0].stat).To(Equal(gwstats.SourceStat{
@@ -2416,7 +2461,7 @@ var _ = Describe("Gateway", func() {
 					done:           make(chan<- string),
 					requestPayload: payload,
 				}
-				jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+				jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 				Expect(err).To(BeNil())
 				Expect(jobsWithStats).To(HaveLen(1))
 				Expect(jobsWithStats[0].stat).To(Equal(gwstats.SourceStat{
@@ -2465,7 +2510,7 @@ var _ = Describe("Gateway", func() {
 				done:           make(chan<- string),
 				requestPayload: payload,
 			}
-			jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(BeNil())
 			Expect(jobsWithStats).To(HaveLen(1))
 
@@ -2475,7 +2520,7 @@ var _ = Describe("Gateway", func() {
 			payload, err = jsonrs.Marshal(messages)
 			Expect(err).To(BeNil())
 			req.requestPayload = payload
-			_, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			_, _, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(Not(BeNil()))
 			Expect(err.Error()).To(Equal(response.NotRudderEvent))
 
@@ -2485,7 +2530,7 @@ var _ = Describe("Gateway", func() {
 			payload, err = jsonrs.Marshal(messages)
 			Expect(err).To(BeNil())
 			req.requestPayload = payload
-			_, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			_, _, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(Not(BeNil()))
 			Expect(err.Error()).To(Equal(response.NotRudderEvent))
 
@@ -2496,7 +2541,7 @@ var _ = Describe("Gateway", func() {
 			payload, err = jsonrs.Marshal(messages)
 			Expect(err).To(BeNil())
 			req.requestPayload = payload
-			_, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			_, _, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(Not(BeNil()))
 			Expect(err.Error()).To(Equal(response.NotRudderEvent))
 
@@ -2506,7 +2551,7 @@ var _ = Describe("Gateway", func() {
 			payload, err = jsonrs.Marshal(messages)
 			Expect(err).To(BeNil())
 			req.requestPayload = payload
-			_, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			_, _, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(Not(BeNil()))
 			Expect(err.Error()).To(Equal(response.NotRudderEvent))
 
@@ -2516,7 +2561,7 @@ var _ = Describe("Gateway", func() {
 			payload, err = jsonrs.Marshal(messages)
 			Expect(err).To(BeNil())
 			req.requestPayload = payload
-			_, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			_, _, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(Not(BeNil()))
 			Expect(err.Error()).To(Equal(response.NotRudderEvent))
 
@@ -2526,7 +2571,7 @@ var _ = Describe("Gateway", func() {
 			payload, err = jsonrs.Marshal(messages)
 			Expect(err).To(BeNil())
 			req.requestPayload = payload
-			_, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			_, _, err = gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(Not(BeNil()))
 			Expect(err.Error()).To(Equal(response.NotRudderEvent))
 		})
@@ -2559,7 +2604,7 @@ var _ = Describe("Gateway", func() {
 				done:           make(chan<- string),
 				requestPayload: payload,
 			}
-			jobsWithStats, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
+			jobsWithStats, _, err := gateway.extractJobsFromInternalBatchPayload("batch", req.requestPayload)
 			Expect(err).To(BeNil())
 			Expect(jobsWithStats).To(HaveLen(1))
 
@@ -2771,7 +2816,7 @@ func TestLeakyUploader(t *testing.T) {
 		invalidPayload := []byte(`{"invalid": "json`)
 
 		// Call internal batch handler
-		jobs, err := gw.extractJobsFromInternalBatchPayload("batch", invalidPayload)
+		jobs, _, err := gw.extractJobsFromInternalBatchPayload("batch", invalidPayload)
 
 		// Verify error response
 		require.Error(t, err)
@@ -2810,7 +2855,7 @@ func TestLeakyUploader(t *testing.T) {
 		t.Cleanup(cleanupFn)
 
 		// Call internal batch handler
-		jobs, err := gw.extractJobsFromInternalBatchPayload("batch", validPayload)
+		jobs, _, err := gw.extractJobsFromInternalBatchPayload("batch", validPayload)
 
 		// Verify successful processing
 		require.NoError(t, err)
@@ -40,6 +40,7 @@ import (
 	gwstats "github.com/rudderlabs/rudder-server/gateway/internal/stats"
 	"github.com/rudderlabs/rudder-server/gateway/response"
 	"github.com/rudderlabs/rudder-server/gateway/throttler"
+	"github.com/rudderlabs/rudder-server/gateway/validator"
 	"github.com/rudderlabs/rudder-server/gateway/webhook"
 	"github.com/rudderlabs/rudder-server/jobsdb"
 	sourcedebugger "github.com/rudderlabs/rudder-server/services/debugger/source"
@@ -112,6 +113,7 @@ type Handle struct {
 	sourceIDSourceMap                 map[string]backendconfig.SourceT
 	nonEventStreamSources             map[string]bool
 	blockedEventsWorkspaceTypeNameMap map[string]map[string]map[string]bool
+	schemaValidators                  map[string]*validator.SchemaValidator // keyed by source id
 
 	conf struct { // configuration parameters
 		webPort, maxUserWebRequestWorkerProcess, maxDBWriterProcess                       int
@@ -120,6 +122,7 @@ type Handle struct {
 
 		maxReqSize                           config.ValueLoader[int]
 		enableRateLimit                      config.ValueLoader[bool]
+		enableSourceAndUserRateLimit         config.ValueLoader[bool]
 		enableSuppressUserFeature            bool
 		diagnosisTickerTime                  time.Duration
 		ReadTimeout                          time.Duration
@@ -132,6 +135,12 @@ type Handle struct {
 		enableInternalBatchEnrichment        config.ValueLoader[bool]
 		enableEventBlocking                  config.ValueLoader[bool]
 		webhookV2HandlerEnabled              bool
+		otlpGRPCPort                         int
+		pgCDCEnabled                         bool
+		pgCDCMaxBatchSize                    int
+		pgCDCMaxTransactionChanges           int
+		pgCDCStandbyInterval                 time.Duration
+		pgCDCRetryInterval                   time.Duration
 	}
 
 	// additional internal http handlers
@@ -203,6 +212,7 @@ func (gw *Handle) userWebRequestWorkerProcess(userWebRequestWorker *userWebReque
 		var jobBatches [][]*jobsdb.JobT
 		jobIDReqMap := make(map[uuid.UUID]*webRequestT)
 		jobSourceTagMap := make(map[uuid.UUID]string)
+		jobSchemaRejectedMap := make(map[uuid.UUID][]eventSchemaViolations)
 		sourceStats := make(map[string]*gwstats.SourceStat)
 		// Saving the event data read from req.request.Body to the splice.
 		// Using this to send event schema to the config backend.
@@ -222,9 +232,18 @@ func (gw *Handle) userWebRequestWorkerProcess(userWebRequestWorker *userWebReque
 				case errors.Is(err, errRequestDropped):
 					req.done <- response.TooManyRequests
 					sourceStats[sourceTag].RequestDropped()
+				case errors.Is(err, errSourceRequestDropped):
+					req.done <- response.SourceTooManyRequests
+					sourceStats[sourceTag].RequestDropped()
+				case errors.Is(err, errUserRequestDropped):
+					req.done <- response.UserTooManyRequests
+					sourceStats[sourceTag].RequestDropped()
 				case errors.Is(err, errRequestSuppressed):
 					req.done <- "" // no error
 					sourceStats[sourceTag].RequestSuppressed()
+				case errors.As(err, new(*schemaViolationsError)):
+					req.done <- err.Error()
+					sourceStats[sourceTag].RequestEventsFailed(jobData.numEvents, response.SchemaViolation)
 				default:
 					req.done <- err.Error()
 					sourceStats[sourceTag].RequestEventsFailed(jobData.numEvents, err.Error())
@@ -235,6 +254,9 @@ func (gw *Handle) userWebRequestWorkerProcess(userWebRequestWorker *userWebReque
 				jobBatches = append(jobBatches, jobData.jobs)
 				jobIDReqMap[jobData.jobs[0].UUID] = req
 				jobSourceTagMap[jobData.jobs[0].UUID] = sourceTag
+				if len(jobData.schemaRejected) > 0 {
+					jobSchemaRejectedMap[jobData.jobs[0].UUID] = jobData.schemaRejected
+				}
 				for _, job := range jobData.jobs {
 					eventBatchesToRecord = append(
 						eventBatchesToRecord,
@@ -267,6 +289,10 @@ func (gw *Handle) userWebRequestWorkerProcess(userWebRequestWorker *userWebReque
 				jobIDReqMap[batch[0].UUID].errors = append(jobIDReqMap[batch[0].UUID].errors, err)
 			} else {
 				sourceStats[sourceTag].RequestEventsSucceeded(len(batch))
+				if rejected := jobSchemaRejectedMap[batch[0].UUID]; len(rejected) > 0 {
+					sourceStats[sourceTag].EventsFailed(len(rejected), response.SchemaViolation)
+					err = schemaViolationsStatus(response.PartialSchemaViolation, rejected)
+				}
 			}
 			jobIDReqMap[batch[0].UUID].done <- err
 		}
@@ -329,7 +355,7 @@ func (gw *Handle) getJobDataFromRequest(req *webRequestT) (jobData *jobFromReq,
 	}
 
 	gw.requestSizeStat.Observe(float64(len(body)))
-	if req.reqType != "batch" && req.reqType != "replay" && req.reqType != "retl" {
+	if req.reqType != "batch" && req.reqType != "replay" && req.reqType != "retl" && req.reqType != "otlp" && req.reqType != "pgcdc" {
 		body, err = sjson.SetBytes(body, "type", req.reqType)
 		if err != nil {
 			err = errors.New((response.NotRudderEvent))
@@ -356,7 +382,11 @@ func (gw *Handle) getJobDataFromRequest(req *webRequestT) (jobData *jobFromReq,
 		containsAudienceList, suppressed bool
 	)
 
-	isUserSuppressed := gw.memoizedIsUserSuppressed()
+	var (
+		isUserSuppressed = gw.memoizedIsUserSuppressed()
+		schemaValidator  = gw.schemaValidatorForSource(sourceID)
+		schemaRejected   []eventSchemaViolations
+	)
 	for idx, v := range eventsBatch {
 		toSet, ok := v.Value().(map[string]interface{})
 		if !ok {
@@ -440,6 +470,20 @@ func (gw *Handle) getJobDataFromRequest(req *webRequestT) (jobData *jobFromReq,
 			toSet["request_ip"] = ipAddr
 		}
 		fillMessageID(toSet)
+		if schemaValidator != nil {
+			violations, validationErr := schemaValidator.Validate(toSet)
+			if validationErr != nil {
+				gw.logger.Warnn("failed to validate event against source schema", obskit.SourceID(sourceID), obskit.Error(validationErr))
+			} else if len(violations) > 0 {
+				gw.reportSchemaViolations(workspaceId, sourceID, schemaValidator.Mode())
+				if schemaValidator.Mode() == validator.SchemaEnforcementReject {
+					messageID, _ := toSet["messageId"].(string)
+					schemaRejected = append(schemaRejected, eventSchemaViolations{Index: idx, MessageID: messageID, Violations: violations})
+					continue
+				}
+				flagSchemaViolations(toSet, violations)
+			}
+		}
 		if eventTypeFromReq == "audiencelist" {
 			containsAudienceList = true
 		}
@@ -451,15 +495,36 @@ func (gw *Handle) getJobDataFromRequest(req *webRequestT) (jobData *jobFromReq,
 		})
 	}
 
-	if gw.conf.enableRateLimit.Load() && sourcesJobRunID == "" && sourcesTaskRunID == "" {
-		// In case of "batch" requests, if rate-limiter returns true for LimitReached, just drop the event batch and continue.
-		ok, errCheck := gw.rateLimiter.CheckLimitReached(context.TODO(), workspaceId, int64(len(eventsBatch)))
-		if errCheck != nil {
-			gw.stats.NewTaggedStat("gateway.rate_limiter_error", stats.CountType, stats.Tags{"workspaceId": workspaceId}).Increment()
-			gw.logger.Errorf("Rate limiter error: %v Allowing the request", errCheck)
+	// the request is rejected only if none of its events conforms to the schema,
+	// otherwise the valid events are accepted and the rejected ones are reported in the response
+	jobData.schemaRejected = schemaRejected
+	if len(out) == 0 && len(schemaRejected) > 0 {
+		err = &schemaViolationsError{events: schemaRejected}
+		return
+	}
+
+	if sourcesJobRunID == "" && sourcesTaskRunID == "" {
+		// the source and user limits are checked first, as their tokens can be given back if the workspace limit is reached
+		giveBackTokens := func(context.Context) error { return nil }
+		if gw.conf.enableSourceAndUserRateLimit.Load() {
+			giveBackTokens, err = gw.checkSourceAndUserLimits(req.ctx, workspaceId, sourceID, lo.Map(out, func(job jobObject, _ int) string { return job.userID }))
+			if err != nil {
+				return
+			}
 		}
-		if ok {
-			return jobData, errRequestDropped
+		if gw.conf.enableRateLimit.Load() {
+			// In case of "batch" requests, if rate-limiter returns true for LimitReached, just drop the event batch and continue.
+			ok, errCheck := gw.rateLimiter.CheckLimitReached(req.ctx, workspaceId, int64(len(eventsBatch)))
+			if errCheck != nil {
+				gw.stats.NewTaggedStat("gateway.rate_limiter_error", stats.CountType, stats.Tags{"workspaceId": workspaceId}).Increment()
+				gw.logger.Errorf("Rate limiter error: %v Allowing the request", errCheck)
+			}
+			if ok {
+				if errGiveBack := giveBackTokens(req.ctx); errGiveBack != nil {
+					gw.reportRateLimiterError(workspaceId, sourceID, errGiveBack)
+				}
+				return jobData, errRequestDropped
+			}
 		}
 	}
 
@@ -684,6 +749,7 @@ func (gw *Handle) addToWebRequestQ(_ *http.ResponseWriter, req *http.Request, do
 	}
 
 	webReq := webRequestT{
+		ctx:            req.Context(),
 		done:           done,
 		reqType:        reqType,
 		requestPayload: requestPayload,
@@ -701,6 +767,7 @@ func (gw *Handle) internalBatchHandlerFunc() http.HandlerFunc {
 			ctx              = r.Context()
 			reqType          = ctx.Value(gwtypes.CtxParamCallType).(string)
 			jobsWithMetadata []jobWithMetadata
+			schemaRejected   []eventSchemaViolations
 			body             []byte
 			err              error
 			status           int
@@ -717,7 +784,7 @@ func (gw *Handle) internalBatchHandlerFunc() http.HandlerFunc {
 			stat.Report(gw.stats)
 			goto requestError
 		}
-		jobsWithMetadata, err = gw.extractJobsFromInternalBatchPayload(reqType, body)
+		jobsWithMetadata, schemaRejected, err = gw.extractJobsFromInternalBatchPayload(reqType, body)
 		if err != nil {
 			goto requestError
 		}
@@ -756,6 +823,10 @@ func (gw *Handle) internalBatchHandlerFunc() http.HandlerFunc {
 
 		status = http.StatusOK
 		responseBody = response.GetStatus(response.Ok)
+		if len(schemaRejected) > 0 {
+			// the accepted events are stored, the response reports the rejected ones
+			responseBody = response.GetStatus(schemaViolationsStatus(response.PartialSchemaViolation, schemaRejected))
+		}
 		gw.logger.Debugn("response",
 			logger.NewStringField("ip", kithttputil.GetRequestIP(r)),
 			logger.NewStringField("path", r.URL.Path),
@@ -791,8 +862,10 @@ type jobWithMetadata struct {
 	skipLiveEventRecording bool
 }
 
+// extractJobsFromInternalBatchPayload returns the jobs of the messages of the internal batch payload,
+// along with the messages rejected for violating their source's schema
 func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byte) (
-	[]jobWithMetadata, error,
+	[]jobWithMetadata, []eventSchemaViolations, error,
 ) {
 	type params struct {
 		MessageID           string `json:"message_id"`
@@ -823,6 +896,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 		isUserSuppressed = gw.memoizedIsUserSuppressed()
 		isEventBlocked   = gw.memoizedIsEventBlocked()
 		res              []jobWithMetadata
+		rejected         []eventSchemaViolations
 		stat             = gwstats.SourceStat{ReqType: reqType}
 		err              error
 	)
@@ -845,7 +919,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 		stat.RequestFailed(response.InvalidJSON)
 		stat.Report(gw.stats)
 		gw.logger.Errorn("invalid json in request", obskit.Error(err))
-		return nil, errors.New((response.InvalidJSON))
+		return nil, nil, errors.New((response.InvalidJSON))
 	}
 	gw.requestSizeStat.Observe(float64(len(body)))
 
@@ -853,7 +927,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 		stat.RequestFailed(response.NotRudderEvent)
 		stat.Report(gw.stats)
 		gw.logger.Errorn("no messages in request")
-		return nil, errors.New((response.NotRudderEvent))
+		return nil, nil, errors.New((response.NotRudderEvent))
 	}
 
 	internalBatchValidatorEnabled := gw.conf.enableInternalBatchValidator.Load()
@@ -861,7 +935,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 
 	res = make([]jobWithMetadata, 0, len(messages))
 
-	for _, msg := range messages {
+	for idx, msg := range messages {
 		var (
 			rudderId         uuid.UUID
 			messageID        string
@@ -880,7 +954,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 					loggerFields...)
 				stat.RequestEventsFailed(1, (response.InvalidStreamMessage))
 				stat.Report(gw.stats)
-				return nil, errors.New((response.InvalidStreamMessage))
+				return nil, nil, errors.New((response.InvalidStreamMessage))
 			}
 			// TODO: get rid of this check
 			if msg.Properties.RequestType != "" {
@@ -894,7 +968,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 						loggerFields := msg.Properties.LoggerFields()
 						loggerFields = append(loggerFields, obskit.Error(err))
 						gw.logger.Errorn("failed to set type in message", loggerFields...)
-						return nil, errors.New((response.NotRudderEvent))
+						return nil, nil, errors.New((response.NotRudderEvent))
 					}
 				}
 			}
@@ -910,7 +984,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 					stat.Report(gw.stats)
 					gw.logger.Errorn("failed to set messageID in message",
 						obskit.Error(err))
-					return nil, errors.New((response.NotRudderEvent))
+					return nil, nil, errors.New((response.NotRudderEvent))
 				}
 			}
 			rudderId, err = getRudderId(userIDFromReq, anonIDFromReq)
@@ -919,7 +993,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 				stat.Report(gw.stats)
 				gw.logger.Errorn("failed to get rudderId",
 					obskit.Error(err))
-				return nil, errors.New((response.NotRudderEvent))
+				return nil, nil, errors.New((response.NotRudderEvent))
 			}
 			msg.Payload, err = sjson.SetBytes(msg.Payload, "rudderId", rudderId.String())
 			if err != nil {
@@ -929,7 +1003,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 				loggerFields = append(loggerFields, obskit.Error(err))
 				gw.logger.Errorn("failed to set rudderId in message",
 					loggerFields...)
-				return nil, errors.New((response.NotRudderEvent))
+				return nil, nil, errors.New((response.NotRudderEvent))
 			}
 
 			msg.Payload, err = fillReceivedAt(msg.Payload, msg.Properties.ReceivedAt)
@@ -939,7 +1013,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 				stat.Report(gw.stats)
 				gw.logger.Errorn("failed to fill receivedAt in message",
 					obskit.Error(err))
-				return nil, fmt.Errorf("filling receivedAt: %w", err)
+				return nil, nil, fmt.Errorf("filling receivedAt: %w", err)
 			}
 			msg.Payload, err = fillRequestIP(msg.Payload, msg.Properties.RequestIP)
 			if err != nil {
@@ -948,7 +1022,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 				stat.Report(gw.stats)
 				gw.logger.Errorn("failed to fill request_ip in message",
 					obskit.Error(err))
-				return nil, fmt.Errorf("filling request_ip: %w", err)
+				return nil, nil, fmt.Errorf("filling request_ip: %w", err)
 			}
 		}
 
@@ -968,7 +1042,38 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 					loggerFields...)
 				stat.RequestEventsFailed(1, errMsg)
 				stat.Report(gw.stats)
-				return nil, errors.New(response.NotRudderEvent)
+				return nil, nil, errors.New(response.NotRudderEvent)
+			}
+		}
+
+		if schemaValidator := gw.schemaValidatorForSource(msg.Properties.SourceID); schemaValidator != nil {
+			violations, validationErr := schemaValidator.ValidatePayload(msg.Payload)
+			if validationErr != nil {
+				loggerFields := msg.Properties.LoggerFields()
+				loggerFields = append(loggerFields, obskit.Error(validationErr))
+				gw.logger.Warnn("failed to validate event against source schema", loggerFields...)
+			} else if len(violations) > 0 {
+				gw.reportSchemaViolations(msg.Properties.WorkspaceID, msg.Properties.SourceID, schemaValidator.Mode())
+				if schemaValidator.Mode() == validator.SchemaEnforcementReject {
+					rejected = append(rejected, eventSchemaViolations{
+						Index:      idx,
+						MessageID:  gjson.GetBytes(msg.Payload, "messageId").String(),
+						Violations: violations,
+					})
+					rejectedStat := gwstats.SourceStat{ReqType: reqType, SourceID: msg.Properties.SourceID, WorkspaceID: msg.Properties.WorkspaceID}
+					rejectedStat.EventsFailed(1, response.SchemaViolation)
+					rejectedStat.Report(gw.stats)
+					continue
+				}
+				msg.Payload, err = flagPayloadSchemaViolations(msg.Payload, violations)
+				if err != nil {
+					stat.RequestEventsFailed(1, response.NotRudderEvent)
+					stat.Report(gw.stats)
+					loggerFields := msg.Properties.LoggerFields()
+					loggerFields = append(loggerFields, obskit.Error(err))
+					gw.logger.Errorn("failed to set schema violations in message", loggerFields...)
+					return nil, nil, errors.New(response.NotRudderEvent)
+				}
 			}
 		}
 
@@ -1054,7 +1159,7 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 			loggerFields = append(loggerFields, obskit.Error(err))
 			gw.logger.Errorn("failed to marshal event batch",
 				loggerFields...)
-			return nil, fmt.Errorf("marshalling event batch: %w", err)
+			return nil, nil, fmt.Errorf("marshalling event batch: %w", err)
 		}
 		jobUUID := uuid.New()
 		res = append(res, jobWithMetadata{
@@ -1071,11 +1176,15 @@ func (gw *Handle) extractJobsFromInternalBatchPayload(reqType string, body []byt
 			},
 		})
 	}
+	if len(res) == 0 && len(rejected) > 0 { // all events rejected
+		err = &schemaViolationsError{events: rejected}
+		return nil, nil, err
+	}
 	if len(res) == 0 { // events suppressed - but return success
-		return nil, nil
+		return nil, nil, nil
 	}
 
-	return res, nil
+	return res, rejected, nil
 }
 
 // getMessageID returns the messageID from the event payload.
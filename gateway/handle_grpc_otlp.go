@@ -0,0 +1,156 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+
+	"github.com/rudderlabs/rudder-server/gateway/internal/otlp"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+)
+
+// otlpLogsServer implements the OTLP/gRPC logs service, mapping every log record to a rudder event
+type otlpLogsServer struct {
+	collogspb.UnimplementedLogsServiceServer
+	gw *Handle
+}
+
+// startOTLPGRPCServer starts the OTLP/gRPC server on the configured port, if enabled. This function will block until the context is cancelled.
+func (gw *Handle) startOTLPGRPCServer(ctx context.Context) error {
+	if gw.conf.otlpGRPCPort == 0 {
+		return nil
+	}
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(gw.conf.otlpGRPCPort))
+	if err != nil {
+		return fmt.Errorf("listening on otlp grpc port %d: %w", gw.conf.otlpGRPCPort, err)
+	}
+	srv := grpc.NewServer(grpc.MaxRecvMsgSize(gw.conf.maxReqSize.Load() * 1024))
+	collogspb.RegisterLogsServiceServer(srv, &otlpLogsServer{gw: gw})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	gw.logger.Infof("OTLP gRPC server starting on %d", gw.conf.otlpGRPCPort)
+	if err := srv.Serve(lis); err != nil {
+		return fmt.Errorf("serving otlp grpc: %w", err)
+	}
+	<-done
+	return nil
+}
+
+// Export maps the log records of the request to rudder events and enqueues them like a regular batch request.
+// The writeKey is read either from the basic `authorization` metadata or from the `rudder.write_key` resource attribute.
+func (s *otlpLogsServer) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	gw := s.gw
+	gw.inFlightRequests.Add(1)
+	defer gw.inFlightRequests.Done()
+
+	r, err := otlpHTTPRequest(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	writeKey, _, ok := r.BasicAuth()
+	if !ok || writeKey == "" {
+		if writeKey, err = otlp.WriteKey(req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	var errorMessage string
+	if writeKey == "" {
+		errorMessage = response.NoWriteKeyInBasicAuth
+		gw.handleFailureStats(errorMessage, "otlp", nil)
+		return nil, otlpStatusError(errorMessage)
+	}
+	arctx := gw.authRequestContextForWriteKey(writeKey)
+	if arctx == nil {
+		errorMessage = response.InvalidWriteKey
+		gw.handleFailureStats(errorMessage, "otlp", nil)
+		return nil, otlpStatusError(errorMessage)
+	}
+	if !arctx.SourceEnabled {
+		errorMessage = response.SourceDisabled
+		gw.handleFailureStats(errorMessage, "otlp", arctx)
+		return nil, otlpStatusError(errorMessage)
+	}
+	augmentAuthRequestContext(arctx, r)
+
+	payload, err := otlp.ToBatchPayload(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	errorMessage = gw.rrh.ProcessRequest(nil, r, "otlp", payload, arctx)
+	if response.IsPartialSuccess(errorMessage) {
+		gw.TrackRequestMetrics("")
+		return &collogspb.ExportLogsServiceResponse{PartialSuccess: &collogspb.ExportLogsPartialSuccess{
+			RejectedLogRecords: gjson.Get(response.Details(errorMessage), "#").Int(),
+			ErrorMessage:       response.GetStatus(errorMessage),
+		}}, nil
+	}
+	gw.TrackRequestMetrics(errorMessage)
+	if errorMessage != "" {
+		return nil, otlpStatusError(errorMessage)
+	}
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+// otlpHTTPRequest builds an http request carrying the incoming grpc metadata as headers along with the peer's address,
+// so that it can be handled by the same request handlers as regular http requests.
+func otlpHTTPRequest(ctx context.Context) (*http.Request, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/otlp/v1/logs", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, values := range md {
+			for _, v := range values {
+				r.Header.Add(k, v)
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil && r.Header.Get("X-Forwarded-For") == "" {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			r.Header.Set("X-Forwarded-For", host)
+		}
+	}
+	return r, nil
+}
+
+// otlpStatusError converts a gateway error message to a grpc status error with the equivalent code
+func otlpStatusError(errorMessage string) error {
+	return status.Error(otlpStatusCode(response.GetErrorStatusCode(errorMessage)), response.GetStatus(errorMessage))
+}
+
+// otlpStatusCode returns the grpc code equivalent to an http status code
+func otlpStatusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
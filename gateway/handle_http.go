@@ -120,12 +120,17 @@ func (gw *Handle) webRequestHandler(rh RequestHandler, w http.ResponseWriter, r
 		return
 	}
 	errorMessage = rh.ProcessRequest(&w, r, reqType, payload, arctx)
+	responseBody := response.GetStatus(response.Ok)
+	if response.IsPartialSuccess(errorMessage) {
+		// the accepted events are stored, the response reports the rejected ones
+		responseBody = response.GetStatus(errorMessage)
+		errorMessage = ""
+	}
 	gw.TrackRequestMetrics(errorMessage)
 	if errorMessage != "" {
 		return
 	}
 
-	responseBody := response.GetStatus(response.Ok)
 	gw.logger.Debugw("response",
 		"ip", kithttputil.GetRequestIP(r),
 		"path", r.URL.Path,
@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"net/http"
+	"time"
 
 	gwtypes "github.com/rudderlabs/rudder-server/gateway/types"
 
@@ -15,6 +16,7 @@ import (
 	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
 	gwstats "github.com/rudderlabs/rudder-server/gateway/internal/stats"
 	"github.com/rudderlabs/rudder-server/gateway/response"
+	"github.com/rudderlabs/rudder-server/gateway/webhook/auth"
 )
 
 // writeKeyAuth middleware to authenticate writeKey in the Authorization header.
@@ -89,6 +91,10 @@ func (gw *Handle) webhookAuth(delegate http.HandlerFunc) http.HandlerFunc {
 			errorMessage = response.SourceDisabled
 			return
 		}
+		if err := auth.VerifySignature(r, arctx.Source.WebhookSignature, time.Now()); err != nil {
+			errorMessage = auth.SignatureErrorMessage(err)
+			return
+		}
 		augmentAuthRequestContext(arctx, r)
 		delegate.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), gwtypes.CtxParamAuthRequestContext, arctx)))
 	}
@@ -297,7 +303,7 @@ func (gw *Handle) handleFailureStats(errorMessage, reqType string, arctx *gwtype
 				ReqType:  reqType,
 				Source:   "noSourceIDInHeader",
 			}
-		case response.SourceDisabled, response.NoDestinationIDInHeader, response.InvalidDestinationID, response.DestinationDisabled:
+		case response.SourceDisabled, response.InvalidWebhookSignature, response.NoDestinationIDInHeader, response.InvalidDestinationID, response.DestinationDisabled:
 			stat = gwstats.SourceStat{
 				SourceID:      arctx.SourceID,
 				WriteKey:      arctx.WriteKey,
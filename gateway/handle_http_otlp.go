@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+
+	kithttputil "github.com/rudderlabs/rudder-go-kit/httputil"
+
+	"github.com/rudderlabs/rudder-server/gateway/internal/otlp"
+	gwstats "github.com/rudderlabs/rudder-server/gateway/internal/stats"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+)
+
+// otlpLogsHandler can handle OTLP/HTTP log export requests (protobuf or json), mapping every log record to a rudder event
+func (gw *Handle) otlpLogsHandler() http.HandlerFunc {
+	return gw.otlpInterceptor(gw.callType("otlp", gw.writeKeyAuth(gw.webHandler())))
+}
+
+// otlpInterceptor converts the OTLP payload of the request into a rudder batch payload before passing it to the next handler.
+// If no writeKey is present in the request's Authorization header, the writeKey is read from the `rudder.write_key` resource attribute.
+// Successful responses are encoded as OTLP export responses and error responses as google.rpc.Status messages,
+// using the content type of the request.
+func (gw *Handle) otlpInterceptor(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.Header.Get("Content-Type")
+		var errorMessage string
+		defer func() {
+			if errorMessage != "" {
+				stat := gwstats.SourceStat{
+					Source:   "invalidOTLPPayload",
+					SourceID: "invalidOTLPPayload",
+					WriteKey: "invalidOTLPPayload",
+					ReqType:  "otlp",
+				}
+				stat.RequestFailed(errorMessage)
+				stat.Report(gw.stats)
+				gw.handleOTLPHttpError(w, r, contentType, response.GetErrorStatusCode(errorMessage), response.GetStatus(errorMessage))
+			}
+		}()
+
+		body, err := gw.getPayloadFromRequest(r)
+		if err != nil {
+			errorMessage = err.Error()
+			return
+		}
+		req, err := otlp.UnmarshalLogsRequest(contentType, body)
+		if err != nil {
+			if errors.Is(err, otlp.ErrUnsupportedContentType) {
+				errorMessage = response.UnsupportedContentType
+				return
+			}
+			gw.logger.Infow("invalid otlp payload", "ip", kithttputil.GetRequestIP(r), "error", err.Error())
+			errorMessage = response.InvalidOTLPPayload
+			return
+		}
+		if _, _, ok := r.BasicAuth(); !ok {
+			writeKey, err := otlp.WriteKey(req)
+			if err != nil {
+				errorMessage = response.InvalidOTLPPayload
+				return
+			}
+			if writeKey != "" {
+				r.SetBasicAuth(writeKey, "")
+			}
+		}
+		payload, err := otlp.ToBatchPayload(req)
+		if err != nil {
+			errorMessage = response.InvalidOTLPPayload
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(payload))
+		r.ContentLength = int64(len(payload))
+
+		rw := newPixelWriter() // capture the response so that it can be encoded as an OTLP response
+		next(rw, r)
+		if rw.status != http.StatusOK {
+			gw.handleOTLPHttpError(w, r, contentType, rw.status, string(bytes.TrimSpace(rw.body)))
+			return
+		}
+		res, err := otlp.MarshalLogsResponse(contentType, &collogspb.ExportLogsServiceResponse{})
+		if err != nil {
+			errorMessage = response.ErrorInMarshal
+			return
+		}
+		w.Header().Set("Content-Type", otlp.MediaType(contentType))
+		_, _ = w.Write(res)
+	}
+}
+
+// handleOTLPHttpError writes an OTLP/HTTP error response, i.e. a google.rpc.Status encoded using the content type of the request
+func (gw *Handle) handleOTLPHttpError(w http.ResponseWriter, r *http.Request, contentType string, status int, message string) {
+	gw.logger.Infow("response",
+		"ip", kithttputil.GetRequestIP(r),
+		"path", r.URL.Path,
+		"status", status,
+		"body", message)
+	body, err := otlp.MarshalStatus(contentType, otlpStatusCode(status), message)
+	if err != nil {
+		http.Error(w, message, status)
+		return
+	}
+	w.Header().Set("Content-Type", otlp.ResponseMediaType(contentType))
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,170 @@
+package gateway
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+
+	"github.com/rudderlabs/rudder-server/gateway/response"
+)
+
+func TestOTLPInterceptor(t *testing.T) {
+	newGateway := func() *Handle {
+		return &Handle{
+			logger:           logger.NOP,
+			stats:            stats.NOP,
+			bodyReadTimeStat: stats.NOP.NewStat("gateway.http_body_read_time", stats.TimerType),
+		}
+	}
+	logsRequest := func(writeKey string) *collogspb.ExportLogsServiceRequest {
+		var resourceAttributes []*commonpb.KeyValue
+		if writeKey != "" {
+			resourceAttributes = append(resourceAttributes, &commonpb.KeyValue{
+				Key:   "rudder.write_key",
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: writeKey}},
+			})
+		}
+		return &collogspb.ExportLogsServiceRequest{
+			ResourceLogs: []*logspb.ResourceLogs{{
+				Resource: &resourcepb.Resource{Attributes: resourceAttributes},
+				ScopeLogs: []*logspb.ScopeLogs{{
+					LogRecords: []*logspb.LogRecord{{EventName: "Order Completed"}},
+				}},
+			}},
+		}
+	}
+
+	t.Run("protobuf request with writeKey in resource attributes", func(t *testing.T) {
+		var payload []byte
+		var writeKey string
+		delegate := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			payload, _ = io.ReadAll(r.Body)
+			writeKey, _, _ = r.BasicAuth()
+			_, _ = w.Write([]byte("OK"))
+		})
+		body, err := proto.Marshal(logsRequest("123"))
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/otlp/v1/logs", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-protobuf")
+		w := httptest.NewRecorder()
+		newGateway().otlpInterceptor(delegate).ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+		var res collogspb.ExportLogsServiceResponse
+		require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &res))
+
+		require.Equal(t, "123", writeKey)
+		require.Equal(t, "track", gjson.GetBytes(payload, "batch.0.type").String())
+		require.Equal(t, "Order Completed", gjson.GetBytes(payload, "batch.0.event").String())
+	})
+
+	t.Run("json request with writeKey in basic auth", func(t *testing.T) {
+		var writeKey string
+		delegate := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeKey, _, _ = r.BasicAuth()
+			_, _ = w.Write([]byte("OK"))
+		})
+		body, err := protojson.Marshal(logsRequest("123"))
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/otlp/v1/logs", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.SetBasicAuth("456", "")
+		w := httptest.NewRecorder()
+		newGateway().otlpInterceptor(delegate).ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.JSONEq(t, "{}", w.Body.String())
+		require.Equal(t, "456", writeKey, "basic auth should take precedence over resource attributes")
+	})
+
+	t.Run("delegate failure", func(t *testing.T) {
+		delegate := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, response.InvalidWriteKey, http.StatusUnauthorized)
+		})
+		body, err := proto.Marshal(logsRequest(""))
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/otlp/v1/logs", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		newGateway().otlpInterceptor(delegate).ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+		var s statuspb.Status
+		require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &s))
+		require.EqualValues(t, codes.Unauthenticated, s.GetCode())
+		require.Equal(t, response.InvalidWriteKey, s.GetMessage())
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/otlp/v1/logs", bytes.NewReader([]byte("not-a-valid-json")))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		newGateway().otlpInterceptor(nil).ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var s statuspb.Status
+		require.NoError(t, protojson.Unmarshal(w.Body.Bytes(), &s))
+		require.EqualValues(t, codes.InvalidArgument, s.GetCode())
+		require.Equal(t, response.GetStatus(response.InvalidOTLPPayload), s.GetMessage())
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/otlp/v1/logs", bytes.NewReader([]byte("text")))
+		r.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		newGateway().otlpInterceptor(nil).ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		require.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"), "unsupported content types get protobuf responses")
+		var s statuspb.Status
+		require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &s))
+		require.Equal(t, response.GetStatus(response.UnsupportedContentType), s.GetMessage())
+	})
+
+	t.Run("no log records", func(t *testing.T) {
+		body, err := proto.Marshal(&collogspb.ExportLogsServiceRequest{})
+		require.NoError(t, err)
+		r := httptest.NewRequest(http.MethodPost, "/otlp/v1/logs", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		newGateway().otlpInterceptor(nil).ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestOTLPStatusError(t *testing.T) {
+	for errorMessage, code := range map[string]codes.Code{
+		response.InvalidWriteKey:   codes.Unauthenticated,
+		response.SourceDisabled:    codes.NotFound,
+		response.TooManyRequests:   codes.ResourceExhausted,
+		response.InvalidJSON:       codes.InvalidArgument,
+		response.GatewayTimeout:    codes.DeadlineExceeded,
+		"unexpected error message": codes.Internal,
+	} {
+		s, ok := status.FromError(otlpStatusError(errorMessage))
+		require.True(t, ok)
+		require.Equal(t, code, s.Code(), errorMessage)
+		require.Equal(t, response.GetStatus(errorMessage), s.Message())
+	}
+}
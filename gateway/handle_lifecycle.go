@@ -110,6 +110,8 @@ func (gw *Handle) Setup(
 	gw.conf.maxReqSize = config.GetReloadableIntVar(4000, 1024, "Gateway.maxReqSizeInKB")
 	// Enable rate limit on incoming events. false by default
 	gw.conf.enableRateLimit = config.GetReloadableBoolVar(false, "Gateway.enableRateLimit")
+	// Enable the per-source and per-user rate limits on incoming events, independently of the workspace ones. false by default
+	gw.conf.enableSourceAndUserRateLimit = config.GetReloadableBoolVar(false, "Gateway.enableSourceAndUserRateLimit")
 	// Enable suppress user feature. false by default
 	gw.conf.enableSuppressUserFeature = config.GetBoolVar(true, "Gateway.enableSuppressUserFeature")
 	// Time period for diagnosis ticker
@@ -129,6 +131,18 @@ func (gw *Handle) Setup(
 	gw.conf.enableInternalBatchEnrichment = config.GetReloadableBoolVar(true, "gateway.enableBatchEnrichment")
 	// enable webhook v2 handler. disabled by default
 	gw.conf.webhookV2HandlerEnabled = config.GetBoolVar(false, "Gateway.webhookV2HandlerEnabled")
+	// Port where the OTLP/gRPC logs server is running. '0' means disabled.
+	gw.conf.otlpGRPCPort = config.GetIntVar(0, 1, "Gateway.otlp.grpcPort")
+	// enable streaming postgres logical replication sources. false by default
+	gw.conf.pgCDCEnabled = config.GetBoolVar(false, "Gateway.pgCDC.enabled")
+	// Maximum number of events of a replicated transaction enqueued as a single batch request
+	gw.conf.pgCDCMaxBatchSize = config.GetIntVar(128, 1, "Gateway.pgCDC.maxBatchSize")
+	// Maximum number of changes of a replicated transaction kept in memory before storing them
+	gw.conf.pgCDCMaxTransactionChanges = config.GetIntVar(10000, 1, "Gateway.pgCDC.maxTransactionChanges")
+	// Interval for reporting the stored position back to the replicated database
+	gw.conf.pgCDCStandbyInterval = config.GetDurationVar(10, time.Second, "Gateway.pgCDC.standbyInterval")
+	// Delay before restarting a failed replication
+	gw.conf.pgCDCRetryInterval = config.GetDurationVar(30, time.Second, "Gateway.pgCDC.retryInterval")
 	// enable event blocking. false by default
 	gw.conf.enableEventBlocking = config.GetReloadableBoolVar(false, "enableEventBlocking")
 	// Registering stats
@@ -232,6 +246,10 @@ func (gw *Handle) Setup(
 		gw.collectMetrics(ctx)
 		return nil
 	}))
+	g.Go(crash.Wrapper(func() error {
+		gw.runPgCDC(ctx)
+		return nil
+	}))
 
 	if leakyUploaderEnabled {
 		leakyUploaderDone = make(chan struct{})
@@ -355,6 +373,7 @@ func (gw *Handle) processBackendConfig(configData map[string]backendconfig.Confi
 		sourceIDSourceMap                 = map[string]backendconfig.SourceT{}
 		nonEventStreamSources             = map[string]bool{}
 		blockedEventsWorkspaceTypeNameMap = map[string]map[string]map[string]bool{}
+		schemaValidators                  = map[string]*validator.SchemaValidator{}
 	)
 
 	for workspaceID, wsConfig := range configData {
@@ -369,6 +388,18 @@ func (gw *Handle) processBackendConfig(configData map[string]backendconfig.Confi
 			if source.SourceDefinition.Category != "" && !strings.EqualFold(source.SourceDefinition.Category, webhookSourceCategory) {
 				nonEventStreamSources[source.ID] = true
 			}
+			if source.SchemaEnforcement.Enabled {
+				schemaValidator, err := validator.NewSchemaValidator(source.SchemaEnforcement)
+				if err != nil {
+					gw.logger.Errorn("invalid schema enforcement config, schema enforcement disabled for source",
+						obskit.SourceID(source.ID),
+						obskit.WorkspaceID(workspaceID),
+						obskit.Error(err),
+					)
+				} else if schemaValidator != nil {
+					schemaValidators[source.ID] = schemaValidator
+				}
+			}
 		}
 
 		if len(wsConfig.Settings.EventBlocking.Events) > 0 {
@@ -389,6 +420,7 @@ func (gw *Handle) processBackendConfig(configData map[string]backendconfig.Confi
 	gw.sourceIDSourceMap = sourceIDSourceMap
 	gw.nonEventStreamSources = nonEventStreamSources
 	gw.blockedEventsWorkspaceTypeNameMap = blockedEventsWorkspaceTypeNameMap
+	gw.schemaValidators = schemaValidators
 	gw.configSubscriberLock.Unlock()
 }
 
@@ -650,6 +682,7 @@ func (gw *Handle) StartWebHandler(ctx context.Context) error {
 		r.Get("/page", gw.pixelPageHandler())
 	})
 	srvMux.Post("/beacon/v1/batch", gw.beaconBatchHandler())
+	srvMux.Post("/otlp/v1/logs", gw.otlpLogsHandler())
 	srvMux.Get("/version", withContentType("application/json; charset=utf-8", gw.versionHandler))
 	srvMux.Get("/robots.txt", gw.robotsHandler)
 
@@ -674,7 +707,14 @@ func (gw *Handle) StartWebHandler(ctx context.Context) error {
 		MaxHeaderBytes:    gw.conf.maxHeaderBytes,
 	}
 
-	return kithttputil.ListenAndServe(ctx, srv)
+	g, ctx = errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return kithttputil.ListenAndServe(ctx, srv)
+	})
+	g.Go(func() error {
+		return gw.startOTLPGRPCServer(ctx)
+	})
+	return g.Wait()
 }
 
 // Shutdown the gateway
@@ -0,0 +1,155 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/gateway/internal/pgcdc"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+	"github.com/rudderlabs/rudder-server/utils/crash"
+)
+
+// pgCDCStream is the running logical replication of a source
+type pgCDCStream struct {
+	conf   pgcdc.Config
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// runPgCDC keeps a logical replication stream running for every enabled postgres cdc source of the backend config,
+// storing the events of every replicated transaction like a regular batch request. This function will block until the context is cancelled.
+//
+// A replication slot can only be streamed by a single connection at a time, thus with multiple gateways
+// only one of them is streaming a source's changes, while the rest keep retrying.
+func (gw *Handle) runPgCDC(ctx context.Context) {
+	if !gw.conf.pgCDCEnabled {
+		return
+	}
+	checkpoints := pgcdc.NewCheckpoints(gw.jobsDB)
+	streams := make(map[string]*pgCDCStream)
+	stop := func(sourceID string) {
+		streams[sourceID].cancel()
+		<-streams[sourceID].done
+		delete(streams, sourceID)
+	}
+	defer func() {
+		for sourceID := range streams {
+			stop(sourceID)
+		}
+	}()
+
+	ch := gw.backendConfig.Subscribe(ctx, backendconfig.TopicProcessConfig)
+	for data := range ch {
+		configs := make(map[string]pgcdc.Config)
+		for _, wsConfig := range data.Data.(map[string]backendconfig.ConfigT) {
+			for _, source := range wsConfig.Sources {
+				if !source.Enabled || source.SourceDefinition.Name != pgcdc.SourceDefinitionName {
+					continue
+				}
+				conf, err := pgcdc.ParseConfig(source.Config)
+				if err != nil {
+					gw.logger.Errorn("Invalid postgres cdc source config", obskit.SourceID(source.ID), obskit.Error(err))
+					continue
+				}
+				configs[source.ID] = conf
+			}
+		}
+
+		for sourceID, stream := range streams {
+			if conf, ok := configs[sourceID]; !ok || !reflect.DeepEqual(conf, stream.conf) {
+				stop(sourceID)
+			}
+		}
+		for sourceID, conf := range configs {
+			if _, ok := streams[sourceID]; ok {
+				continue
+			}
+			streamCtx, cancel := context.WithCancel(ctx)
+			stream := &pgCDCStream{conf: conf, cancel: cancel, done: make(chan struct{})}
+			streams[sourceID] = stream
+			go crash.Wrapper(func() error {
+				defer close(stream.done)
+				gw.runPgCDCStream(streamCtx, sourceID, conf, checkpoints)
+				return nil
+			})()
+		}
+	}
+}
+
+// runPgCDCStream streams the changes of the source, restarting the replication after a delay whenever it fails
+func (gw *Handle) runPgCDCStream(ctx context.Context, sourceID string, conf pgcdc.Config, checkpoints pgcdc.CheckpointStore) {
+	log := gw.logger.Child("pgcdc")
+	errorsStat := gw.stats.NewTaggedStat("gateway.pgcdc_errors", stats.CountType, stats.Tags{"sourceID": sourceID})
+	replicator := pgcdc.NewReplicator(sourceID, conf, checkpoints, func(ctx context.Context, tx pgcdc.Transaction) error {
+		return gw.storePgCDCTransaction(ctx, sourceID, conf, tx)
+	}, gw.conf.pgCDCMaxTransactionChanges, gw.conf.pgCDCStandbyInterval, log)
+	for {
+		err := replicator.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		errorsStat.Count(1)
+		log.Errorn("Logical replication failed, retrying",
+			obskit.SourceID(sourceID),
+			logger.NewDurationField("retryInterval", gw.conf.pgCDCRetryInterval),
+			obskit.Error(err),
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(gw.conf.pgCDCRetryInterval):
+		}
+	}
+}
+
+// storePgCDCTransaction maps the changes of a replicated transaction to rudder events and enqueues them like regular batch requests,
+// returning once all of them are stored. The slot name and publication are used as the rsources job and task run ids respectively,
+// so that the replication's progress is reported through the job status api.
+func (gw *Handle) storePgCDCTransaction(ctx context.Context, sourceID string, conf pgcdc.Config, tx pgcdc.Transaction) error {
+	payloads, err := conf.BatchPayloads(sourceID, tx, gw.conf.pgCDCMaxBatchSize)
+	if err != nil {
+		return err
+	}
+	if len(payloads) == 0 {
+		return nil
+	}
+
+	arctx := gw.authRequestContextForSourceID(sourceID)
+	if arctx == nil {
+		return errors.New(response.InvalidSourceID)
+	}
+	if !arctx.SourceEnabled {
+		return errors.New(response.SourceDisabled)
+	}
+	arctx.SourceJobRunID = conf.SlotName
+	arctx.SourceTaskRunID = conf.Publication
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/pgcdc", http.NoBody)
+	if err != nil {
+		return err
+	}
+	gw.inFlightRequests.Add(1)
+	defer gw.inFlightRequests.Done()
+	for _, payload := range payloads {
+		errorMessage := gw.rrh.ProcessRequest(nil, r, "pgcdc", payload, arctx)
+		if response.IsPartialSuccess(errorMessage) {
+			// retrying would reject the same events again
+			gw.logger.Warnn("pg cdc events rejected", obskit.SourceID(arctx.SourceID), logger.NewStringField("reason", response.GetStatus(errorMessage)))
+			errorMessage = ""
+		}
+		gw.TrackRequestMetrics(errorMessage)
+		if errorMessage != "" {
+			return fmt.Errorf("storing events: %s", errorMessage)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/gateway/internal/pgcdc"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+	gwtypes "github.com/rudderlabs/rudder-server/gateway/types"
+)
+
+type mockPgCDCRequestHandler struct {
+	errorMessage string
+	payloads     [][]byte
+	arctxs       []*gwtypes.AuthRequestContext
+}
+
+func (m *mockPgCDCRequestHandler) ProcessRequest(_ *http.ResponseWriter, _ *http.Request, reqType string, payload []byte, arctx *gwtypes.AuthRequestContext) string {
+	if reqType != "pgcdc" {
+		return response.InvalidRequestMethod
+	}
+	m.payloads = append(m.payloads, payload)
+	m.arctxs = append(m.arctxs, arctx)
+	return m.errorMessage
+}
+
+func TestStorePgCDCTransaction(t *testing.T) {
+	conf := pgcdc.Config{Publication: "rudder", SlotName: "rudder_slot"}
+	tx := pgcdc.Transaction{
+		XID:        7,
+		EndLSN:     300,
+		CommitTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Changes: []pgcdc.Change{
+			{LSN: 100, Operation: pgcdc.InsertOperation, Table: "public.users", Columns: map[string]any{"id": int64(1)}, Key: []string{"1"}},
+			{LSN: 200, Operation: pgcdc.InsertOperation, Table: "public.users", Columns: map[string]any{"id": int64(2)}, Key: []string{"2"}},
+			{LSN: 250, Operation: pgcdc.UpdateOperation, Table: "public.users", Columns: map[string]any{"id": int64(2)}, Key: []string{"2"}},
+		},
+	}
+	newGateway := func(rrh RequestHandler, enabled bool) *Handle {
+		gw := &Handle{
+			rrh:              rrh,
+			inFlightRequests: new(sync.WaitGroup),
+			sourceIDSourceMap: map[string]backendconfig.SourceT{
+				"source_id": {ID: "source_id", Enabled: enabled, WorkspaceID: "workspace_id"},
+			},
+		}
+		gw.conf.pgCDCMaxBatchSize = 2
+		return gw
+	}
+
+	t.Run("stored", func(t *testing.T) {
+		rrh := &mockPgCDCRequestHandler{}
+		require.NoError(t, newGateway(rrh, true).storePgCDCTransaction(context.Background(), "source_id", conf, tx))
+
+		require.Len(t, rrh.payloads, 2)
+		require.Len(t, gjson.GetBytes(rrh.payloads[0], "batch").Array(), 2)
+		require.Len(t, gjson.GetBytes(rrh.payloads[1], "batch").Array(), 1)
+		require.Equal(t, "users updated", gjson.GetBytes(rrh.payloads[1], "batch.0.event").String())
+		for _, arctx := range rrh.arctxs {
+			require.Equal(t, "source_id", arctx.SourceID)
+			require.Equal(t, "rudder_slot", arctx.SourceJobRunID)
+			require.Equal(t, "rudder", arctx.SourceTaskRunID)
+		}
+	})
+
+	t.Run("no events", func(t *testing.T) {
+		rrh := &mockPgCDCRequestHandler{}
+		require.NoError(t, newGateway(rrh, true).storePgCDCTransaction(context.Background(), "source_id", conf, pgcdc.Transaction{EndLSN: 300}))
+		require.Empty(t, rrh.payloads)
+	})
+
+	t.Run("request error", func(t *testing.T) {
+		rrh := &mockPgCDCRequestHandler{errorMessage: response.TooManyRequests}
+		err := newGateway(rrh, true).storePgCDCTransaction(context.Background(), "source_id", conf, tx)
+		require.ErrorContains(t, err, response.TooManyRequests)
+		require.Len(t, rrh.payloads, 1, "remaining batches are not enqueued after a failure")
+	})
+
+	t.Run("disabled source", func(t *testing.T) {
+		err := newGateway(&mockPgCDCRequestHandler{}, false).storePgCDCTransaction(context.Background(), "source_id", conf, tx)
+		require.ErrorContains(t, err, response.SourceDisabled)
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		err := newGateway(&mockPgCDCRequestHandler{}, true).storePgCDCTransaction(context.Background(), "other_source_id", conf, tx)
+		require.ErrorContains(t, err, response.InvalidSourceID)
+	})
+}
@@ -0,0 +1,44 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/gateway/throttler"
+)
+
+// checkSourceAndUserLimits checks the source's events limit and the events limit of every user in the request at once,
+// returning [errSourceRequestDropped] or [errUserRequestDropped] if any of them is reached, in which case no tokens are taken.
+// Otherwise, it returns a function giving the taken tokens back, if the request is dropped afterwards.
+// The user IDs of the request's events are provided, one per event.
+// Limiter errors are reported and the request is allowed, the same way as for workspace limits.
+func (gw *Handle) checkSourceAndUserLimits(ctx context.Context, workspaceID, sourceID string, eventUserIDs []string) (func(context.Context) error, error) {
+	noTokens := func(context.Context) error { return nil }
+	if len(eventUserIDs) == 0 {
+		return noTokens, nil
+	}
+	userEventCounts := make(map[string]int64)
+	for _, userID := range eventUserIDs {
+		userEventCounts[userID]++
+	}
+
+	limit, giveBackTokens, err := gw.rateLimiter.CheckSourceAndUserLimitsReached(ctx, sourceID, userEventCounts)
+	if err != nil {
+		gw.reportRateLimiterError(workspaceID, sourceID, err)
+		return noTokens, nil
+	}
+	switch limit {
+	case throttler.SourceLimit:
+		return noTokens, errSourceRequestDropped
+	case throttler.UserLimit:
+		return noTokens, errUserRequestDropped
+	}
+	return giveBackTokens, nil
+}
+
+func (gw *Handle) reportRateLimiterError(workspaceID, sourceID string, err error) {
+	gw.stats.NewTaggedStat("gateway.rate_limiter_error", stats.CountType, stats.Tags{"workspaceId": workspaceID}).Increment()
+	gw.logger.Errorn("Rate limiter error, allowing the request", obskit.WorkspaceID(workspaceID), obskit.SourceID(sourceID), obskit.Error(err))
+}
@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"github.com/tidwall/sjson"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+
+	"github.com/rudderlabs/rudder-server/gateway/response"
+	"github.com/rudderlabs/rudder-server/gateway/validator"
+)
+
+// eventSchemaViolations holds the schema violations of a single event of a request
+type eventSchemaViolations struct {
+	Index      int                         `json:"index"`
+	MessageID  string                      `json:"messageId"`
+	Violations []validator.SchemaViolation `json:"violations"`
+}
+
+// schemaViolationsError is returned when all the events of a request are rejected for violating their source's schema.
+// Its message contains the violations of every rejected event, so that they can be returned in the response.
+type schemaViolationsError struct {
+	events []eventSchemaViolations
+}
+
+func (e *schemaViolationsError) Error() string {
+	return schemaViolationsStatus(response.SchemaViolation, e.events)
+}
+
+// schemaViolationsStatus returns the status key along with the violations of the rejected events as its details
+func schemaViolationsStatus(key string, events []eventSchemaViolations) string {
+	details, err := jsonrs.Marshal(events)
+	if err != nil {
+		return key
+	}
+	return response.WithDetails(key, string(details))
+}
+
+// schemaValidatorForSource returns the schema validator of a source, or nil if schema enforcement is disabled for it
+func (gw *Handle) schemaValidatorForSource(sourceID string) *validator.SchemaValidator {
+	gw.configSubscriberLock.RLock()
+	defer gw.configSubscriberLock.RUnlock()
+	return gw.schemaValidators[sourceID]
+}
+
+// flagSchemaViolations adds the schema violations to the event's context, the same way tracking plan violations are reported
+func flagSchemaViolations(event map[string]any, violations []validator.SchemaViolation) {
+	eventContext, ok := event["context"].(map[string]any)
+	if !ok {
+		eventContext = map[string]any{}
+		event["context"] = eventContext
+	}
+	eventContext["violationErrors"] = violations
+}
+
+// flagPayloadSchemaViolations adds the schema violations to the context of the event's payload
+func flagPayloadSchemaViolations(payload []byte, violations []validator.SchemaViolation) ([]byte, error) {
+	return sjson.SetBytes(payload, "context.violationErrors", violations)
+}
+
+// reportSchemaViolations counts the events violating their source's schema
+func (gw *Handle) reportSchemaViolations(workspaceID, sourceID string, mode validator.SchemaEnforcementMode) {
+	gw.stats.NewTaggedStat("gateway.schema_violations", stats.CountType, stats.Tags{
+		"workspaceId": workspaceID,
+		"sourceID":    sourceID,
+		"mode":        string(mode),
+	}).Increment()
+}
@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
+	"github.com/rudderlabs/rudder-schemas/go/stream"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+)
+
+func TestSchemaEnforcement(t *testing.T) {
+	newGateway := func(t *testing.T, mode string) *Handle {
+		gw := createTestGateway(t, false, backendconfig.EventBlocking{})
+		gw.now = time.Now
+		gw.conf.enableRateLimit = config.SingleValueLoader(false)
+		gw.conf.maxReqSize = config.SingleValueLoader(4000 * 1024)
+		gw.conf.allowReqsWithoutUserIDAndAnonymousID = config.SingleValueLoader(false)
+		gw.processBackendConfig(map[string]backendconfig.ConfigT{
+			"workspace1": {
+				Sources: []backendconfig.SourceT{{
+					ID:          "source-id-1",
+					WriteKey:    "write-key-1",
+					WorkspaceID: "workspace1",
+					Enabled:     true,
+					SchemaEnforcement: backendconfig.SchemaEnforcementT{
+						Enabled: true,
+						Mode:    mode,
+						Schemas: map[string]json.RawMessage{
+							"Order Completed": json.RawMessage(`{"type":"object","required":["properties"],"properties":{"properties":{"type":"object","required":["order_id"]}}}`),
+						},
+					},
+				}},
+			},
+		})
+		return gw
+	}
+	webRequest := func(gw *Handle, payload string) *webRequestT {
+		return &webRequestT{
+			reqType:        "batch",
+			requestPayload: []byte(payload),
+			authContext:    gw.authRequestContextForWriteKey("write-key-1"),
+		}
+	}
+	const batch = `{"batch":[
+		{"type":"track","event":"Order Completed","messageId":"msg-1","userId":"user1","properties":{"order_id":"1"}},
+		{"type":"track","event":"Order Completed","messageId":"msg-2","userId":"user1","properties":{}},
+		{"type":"track","event":"Product Viewed","messageId":"msg-3","userId":"user1"}
+	]}`
+
+	t.Run("reject mode rejects the invalid events with per-event violations", func(t *testing.T) {
+		gw := newGateway(t, "reject")
+		jobData, err := gw.getJobDataFromRequest(webRequest(gw, batch))
+		require.NoError(t, err)
+		require.Len(t, jobData.jobs, 2)
+		require.Equal(t, "msg-1", gjson.GetBytes(jobData.jobs[0].EventPayload, "batch.0.messageId").String())
+		require.Equal(t, "msg-3", gjson.GetBytes(jobData.jobs[1].EventPayload, "batch.0.messageId").String())
+
+		status := schemaViolationsStatus(response.PartialSchemaViolation, jobData.schemaRejected)
+		require.True(t, response.IsPartialSuccess(status))
+		require.Equal(t, http.StatusOK, response.GetErrorStatusCode(status))
+		details := []byte(response.Details(status))
+		require.Equal(t, int64(1), gjson.GetBytes(details, "#").Int())
+		require.Equal(t, int64(1), gjson.GetBytes(details, "0.index").Int())
+		require.Equal(t, "msg-2", gjson.GetBytes(details, "0.messageId").String())
+		require.Equal(t, "required", gjson.GetBytes(details, "0.violations.0.type").String())
+		require.Equal(t, "properties", gjson.GetBytes(details, "0.violations.0.property").String())
+
+		require.EqualValues(t, 1, gw.stats.(*memstats.Store).Get("gateway.schema_violations", map[string]string{
+			"workspaceId": "workspace1",
+			"sourceID":    "source-id-1",
+			"mode":        "reject",
+		}).LastValue())
+	})
+
+	t.Run("reject mode rejects the request if all events are invalid", func(t *testing.T) {
+		gw := newGateway(t, "reject")
+		jobData, err := gw.getJobDataFromRequest(webRequest(gw, `{"batch":[
+			{"type":"track","event":"Order Completed","messageId":"msg-1","userId":"user1"},
+			{"type":"track","event":"Order Completed","messageId":"msg-2","userId":"user1","properties":{}}
+		]}`))
+		require.Error(t, err)
+		require.True(t, errors.As(err, new(*schemaViolationsError)))
+		require.Empty(t, jobData.jobs)
+
+		require.Equal(t, http.StatusBadRequest, response.GetErrorStatusCode(err.Error()))
+		require.False(t, response.IsPartialSuccess(err.Error()))
+		status := response.GetStatus(err.Error())
+		require.Contains(t, status, response.SchemaViolation+": ")
+		details := []byte(response.Details(err.Error()))
+		require.Equal(t, []string{"msg-1", "msg-2"}, lo.Map(gjson.GetBytes(details, "#.messageId").Array(), func(r gjson.Result, _ int) string { return r.String() }))
+	})
+
+	t.Run("flag mode accepts the request with violations in the context", func(t *testing.T) {
+		gw := newGateway(t, "flag")
+		jobData, err := gw.getJobDataFromRequest(webRequest(gw, batch))
+		require.NoError(t, err)
+		require.Len(t, jobData.jobs, 3)
+		require.False(t, gjson.GetBytes(jobData.jobs[0].EventPayload, "batch.0.context.violationErrors").Exists())
+		require.Equal(t, "required", gjson.GetBytes(jobData.jobs[1].EventPayload, "batch.0.context.violationErrors.0.type").String())
+		require.False(t, gjson.GetBytes(jobData.jobs[2].EventPayload, "batch.0.context.violationErrors").Exists())
+	})
+
+	t.Run("internal batch", func(t *testing.T) {
+		messages := func(payloads ...string) []byte {
+			var msgs []stream.Message
+			for _, payload := range payloads {
+				msgs = append(msgs, stream.Message{
+					Properties: stream.MessageProperties{
+						RequestType: "track",
+						RoutingKey:  "routing-key",
+						WorkspaceID: "workspace1",
+						SourceID:    "source-id-1",
+						ReceivedAt:  time.Now(),
+						RequestIP:   "1.1.1.1",
+					},
+					Payload: json.RawMessage(payload),
+				})
+			}
+			body, err := jsonrs.Marshal(msgs)
+			require.NoError(t, err)
+			return body
+		}
+		valid := `{"type":"track","event":"Order Completed","messageId":"msg-1","userId":"user1","properties":{"order_id":"1"}}`
+		invalid := `{"type":"track","event":"Order Completed","messageId":"msg-2","userId":"user1","properties":{}}`
+
+		t.Run("reject", func(t *testing.T) {
+			gw := newGateway(t, "reject")
+			jobs, rejected, err := gw.extractJobsFromInternalBatchPayload("batch", messages(valid, invalid))
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			require.Equal(t, "msg-1", gjson.GetBytes(jobs[0].job.EventPayload, "batch.0.messageId").String())
+			require.Len(t, rejected, 1)
+			require.Equal(t, 1, rejected[0].Index)
+			require.Equal(t, "msg-2", rejected[0].MessageID)
+
+			_, _, err = gw.extractJobsFromInternalBatchPayload("batch", messages(invalid))
+			require.Error(t, err)
+			require.Equal(t, http.StatusBadRequest, response.GetErrorStatusCode(err.Error()))
+			require.Contains(t, err.Error(), `"messageId":"msg-2"`)
+
+			jobs, rejected, err = gw.extractJobsFromInternalBatchPayload("batch", messages(valid))
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			require.Empty(t, rejected)
+		})
+
+		t.Run("flag", func(t *testing.T) {
+			gw := newGateway(t, "flag")
+			jobs, _, err := gw.extractJobsFromInternalBatchPayload("batch", messages(valid, invalid))
+			require.NoError(t, err)
+			require.Len(t, jobs, 2)
+			require.False(t, gjson.GetBytes(jobs[0].job.EventPayload, "batch.0.context.violationErrors").Exists())
+			require.Equal(t, "required", gjson.GetBytes(jobs[1].job.EventPayload, "batch.0.context.violationErrors.0.type").String())
+		})
+	})
+
+	t.Run("import requests merge the rejected events of every user", func(t *testing.T) {
+		msg1 := schemaViolationsStatus(response.PartialSchemaViolation, []eventSchemaViolations{{MessageID: "msg-1"}})
+		msg2 := schemaViolationsStatus(response.SchemaViolation, []eventSchemaViolations{{MessageID: "msg-2"}})
+		merged := mergeSchemaViolations(response.PartialSchemaViolation, []string{msg1, msg2})
+		require.True(t, response.IsPartialSuccess(merged))
+		require.Equal(t, []string{"msg-1", "msg-2"}, lo.Map(gjson.Get(response.Details(merged), "#.messageId").Array(), func(r gjson.Result, _ int) string { return r.String() }))
+	})
+
+	t.Run("invalid schema enforcement config disables enforcement", func(t *testing.T) {
+		gw := newGateway(t, "invalid")
+		require.Nil(t, gw.schemaValidatorForSource("source-id-1"))
+		_, err := gw.getJobDataFromRequest(webRequest(gw, batch))
+		require.NoError(t, err)
+	})
+}
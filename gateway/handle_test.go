@@ -80,6 +80,7 @@ func createTestGateway(t *testing.T, enableEventBlocking bool, eventBlockingSett
 			userWebRequestBatchTimeout, dbBatchWriteTimeout                                   config.ValueLoader[time.Duration]
 			maxReqSize                                                                        config.ValueLoader[int]
 			enableRateLimit                                                                   config.ValueLoader[bool]
+			enableSourceAndUserRateLimit                                                      config.ValueLoader[bool]
 			enableSuppressUserFeature                                                         bool
 			diagnosisTickerTime                                                               time.Duration
 			ReadTimeout                                                                       time.Duration
@@ -92,10 +93,17 @@ func createTestGateway(t *testing.T, enableEventBlocking bool, eventBlockingSett
 			enableInternalBatchEnrichment                                                     config.ValueLoader[bool]
 			enableEventBlocking                                                               config.ValueLoader[bool]
 			webhookV2HandlerEnabled                                                           bool
+			otlpGRPCPort                                                                      int
+			pgCDCEnabled                                                                      bool
+			pgCDCMaxBatchSize                                                                 int
+			pgCDCMaxTransactionChanges                                                        int
+			pgCDCStandbyInterval                                                              time.Duration
+			pgCDCRetryInterval                                                                time.Duration
 		}{
 			enableEventBlocking:           config.SingleValueLoader(enableEventBlocking),
 			enableInternalBatchValidator:  config.SingleValueLoader(false),
 			enableInternalBatchEnrichment: config.SingleValueLoader(false),
+			enableSourceAndUserRateLimit:  config.SingleValueLoader(false),
 			webhookV2HandlerEnabled:       false,
 		},
 		configSubscriberLock: sync.RWMutex{},
@@ -643,7 +651,7 @@ func TestExtractJobsFromInternalBatchPayload_EventBlocking(t *testing.T) {
 			require.NoError(t, err)
 
 			// Extract jobs
-			jobs, err := gw.extractJobsFromInternalBatchPayload("batch", payloadBytes)
+			jobs, _, err := gw.extractJobsFromInternalBatchPayload("batch", payloadBytes)
 			require.NoError(t, err, "extractJobsFromInternalBatchPayload should not return error")
 
 			// Verify we got the expected number of jobs
@@ -955,7 +963,7 @@ func TestExtractJobsFromInternalBatchPayload_LiveEventRecording(t *testing.T) {
 			payloadBytes, err := jsonrs.Marshal(tt.messages)
 			require.NoError(t, err, "Failed to marshal test messages")
 
-			jobs, err := gw.extractJobsFromInternalBatchPayload("batch", payloadBytes)
+			jobs, _, err := gw.extractJobsFromInternalBatchPayload("batch", payloadBytes)
 			require.NoError(t, err, "extractJobsFromInternalBatchPayload should not return error")
 
 			require.Len(t, jobs, len(tt.expectedSkipLiveEventRecs), "Number of jobs should match expected")
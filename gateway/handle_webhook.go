@@ -9,6 +9,9 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+	"github.com/rudderlabs/rudder-server/gateway/response"
 	"github.com/rudderlabs/rudder-server/gateway/webhook/model"
 	"github.com/rudderlabs/rudder-server/jobsdb"
 )
@@ -22,7 +25,13 @@ func (gw *Handle) webhookHandler() http.HandlerFunc {
 
 // ProcessTransformedWebhookRequest is an interface wrapper for webhook
 func (gw *Handle) ProcessTransformedWebhookRequest(w *http.ResponseWriter, r *http.Request, reqType string, payload []byte, arctx *gwtypes.AuthRequestContext) string {
-	return gw.rrh.ProcessRequest(w, r, reqType, payload, arctx)
+	errorMessage := gw.rrh.ProcessRequest(w, r, reqType, payload, arctx)
+	if response.IsPartialSuccess(errorMessage) {
+		// webhook senders cannot act on events rejected by the source's schema, the accepted ones are stored though
+		gw.logger.Warnn("webhook events rejected", obskit.SourceID(arctx.SourceID), logger.NewStringField("reason", response.GetStatus(errorMessage)))
+		return ""
+	}
+	return errorMessage
 }
 
 // SaveWebhookFailures saves errors to the error db
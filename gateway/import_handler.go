@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
 	kituuid "github.com/rudderlabs/rudder-go-kit/uuid"
 
 	"github.com/rudderlabs/rudder-server/gateway/response"
@@ -33,12 +35,48 @@ func (irh *ImportRequestHandler) ProcessRequest(w *http.ResponseWriter, r *http.
 		irh.addToWebRequestQ(w, r, done, "batch", usersPayload[key], arctx)
 	}
 
-	var interimMsgs []string
+	var (
+		interimMsgs, schemaMsgs []string
+		accepted                bool
+	)
 	for index := 0; index < count; index++ {
 		interimErrorMessage := <-done
-		interimMsgs = append(interimMsgs, interimErrorMessage)
+		switch {
+		case response.IsPartialSuccess(interimErrorMessage):
+			schemaMsgs = append(schemaMsgs, interimErrorMessage)
+			accepted = true
+		case strings.HasPrefix(interimErrorMessage, response.SchemaViolation):
+			schemaMsgs = append(schemaMsgs, interimErrorMessage)
+		case interimErrorMessage == "":
+			accepted = true
+		default:
+			interimMsgs = append(interimMsgs, interimErrorMessage)
+		}
+	}
+	if errorMessage := strings.Join(interimMsgs, ""); errorMessage != "" || len(schemaMsgs) == 0 {
+		return errorMessage
+	}
+	// the events rejected for violating the source's schema are reported together, the request being rejected only if none was accepted
+	key := response.SchemaViolation
+	if accepted {
+		key = response.PartialSchemaViolation
+	}
+	return mergeSchemaViolations(key, schemaMsgs)
+}
+
+// mergeSchemaViolations merges the events rejected from the payloads of every user into a single status
+func mergeSchemaViolations(key string, msgs []string) string {
+	var rejected []json.RawMessage
+	for _, msg := range msgs {
+		for _, event := range gjson.Parse(response.Details(msg)).Array() {
+			rejected = append(rejected, json.RawMessage(event.Raw))
+		}
+	}
+	details, err := jsonrs.Marshal(rejected)
+	if err != nil {
+		return key
 	}
-	return strings.Join(interimMsgs, "")
+	return response.WithDetails(key, string(details))
 }
 
 // getPayloadFromRequest reads the request body and returns event payloads grouped by user id
@@ -0,0 +1,301 @@
+// Package otlp converts OpenTelemetry log payloads (OTLP) into rudder batch payloads.
+//
+// Every log record is mapped to a single rudder event:
+//   - the event type is read from the `rudder.type` attribute (track or identify, defaults to track)
+//   - the event name is the record's event name, the `event.name` attribute or the record's string body
+//   - userId is read from the `user.id` or `enduser.id` attributes and anonymousId from `rudder.anonymous_id`
+//   - messageId is read from the `rudder.message_id` or `log.record.uid` attributes
+//   - all other attributes, along with a key-value list body, become the event's properties (track) or traits (identify)
+//   - resource attributes, trace and span ids are kept under `context.otel`
+//
+// The source's write key can be provided through the `rudder.write_key` resource attribute.
+package otlp
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+)
+
+const (
+	// ContentTypeProtobuf is the content type of binary protobuf encoded OTLP payloads
+	ContentTypeProtobuf = "application/x-protobuf"
+	// ContentTypeJSON is the content type of JSON encoded OTLP payloads
+	ContentTypeJSON = "application/json"
+
+	// WriteKeyAttribute is the resource attribute carrying the source's write key
+	WriteKeyAttribute = "rudder.write_key"
+
+	typeAttribute        = "rudder.type"
+	anonymousIDAttribute = "rudder.anonymous_id"
+	messageIDAttribute   = "rudder.message_id"
+)
+
+var (
+	// ErrUnsupportedContentType is returned when the payload's content type is neither protobuf nor JSON
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	// ErrMultipleWriteKeys is returned when resources of the same request carry different write keys
+	ErrMultipleWriteKeys = errors.New("multiple write keys in request")
+	// ErrNoLogRecords is returned when the request doesn't contain any log record
+	ErrNoLogRecords = errors.New("no log records in request")
+
+	userIDAttributes    = []string{"user.id", "enduser.id"}
+	messageIDAttributes = []string{messageIDAttribute, "log.record.uid"}
+	eventNameAttributes = []string{"event.name"}
+
+	// reservedAttributes are mapped to top-level event fields and are not copied to properties or traits
+	reservedAttributes = lo.Flatten([][]string{{typeAttribute, anonymousIDAttribute}, userIDAttributes, messageIDAttributes, eventNameAttributes})
+)
+
+// MediaType returns the media type of the provided content type header, defaulting to protobuf if empty
+func MediaType(contentType string) string {
+	if contentType == "" {
+		return ContentTypeProtobuf
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+// UnmarshalLogsRequest decodes an OTLP/HTTP logs export request according to its content type
+func UnmarshalLogsRequest(contentType string, body []byte) (*collogspb.ExportLogsServiceRequest, error) {
+	var req collogspb.ExportLogsServiceRequest
+	switch MediaType(contentType) {
+	case ContentTypeProtobuf:
+		if err := proto.Unmarshal(body, &req); err != nil {
+			return nil, fmt.Errorf("unmarshalling protobuf logs request: %w", err)
+		}
+	case ContentTypeJSON:
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &req); err != nil {
+			return nil, fmt.Errorf("unmarshalling json logs request: %w", err)
+		}
+	default:
+		return nil, ErrUnsupportedContentType
+	}
+	return &req, nil
+}
+
+// MarshalLogsResponse encodes an OTLP/HTTP logs export response according to the request's content type
+func MarshalLogsResponse(contentType string, res *collogspb.ExportLogsServiceResponse) ([]byte, error) {
+	if MediaType(contentType) == ContentTypeJSON {
+		return protojson.Marshal(res)
+	}
+	return proto.Marshal(res)
+}
+
+// MarshalStatus encodes the google.rpc.Status body of an OTLP/HTTP error response according to the request's content type
+func MarshalStatus(contentType string, code codes.Code, message string) ([]byte, error) {
+	s := &statuspb.Status{Code: int32(code), Message: message}
+	if MediaType(contentType) == ContentTypeJSON {
+		return protojson.Marshal(s)
+	}
+	return proto.Marshal(s)
+}
+
+// ResponseMediaType returns the media type of responses to requests of the provided content type,
+// i.e. JSON for JSON requests and protobuf otherwise
+func ResponseMediaType(contentType string) string {
+	if MediaType(contentType) == ContentTypeJSON {
+		return ContentTypeJSON
+	}
+	return ContentTypeProtobuf
+}
+
+// WriteKey returns the write key found in the request's resource attributes, if any.
+// An error is returned if resources carry different write keys.
+func WriteKey(req *collogspb.ExportLogsServiceRequest) (string, error) {
+	var writeKey string
+	for _, rl := range req.GetResourceLogs() {
+		wk := stringAttribute(rl.GetResource().GetAttributes(), WriteKeyAttribute)
+		if wk == "" {
+			continue
+		}
+		if writeKey != "" && writeKey != wk {
+			return "", ErrMultipleWriteKeys
+		}
+		writeKey = wk
+	}
+	return writeKey, nil
+}
+
+// ToBatchPayload converts the logs request into a rudder batch payload, i.e. {"batch": [...]}
+func ToBatchPayload(req *collogspb.ExportLogsServiceRequest) ([]byte, error) {
+	var batch []map[string]any
+	for _, rl := range req.GetResourceLogs() {
+		resource := attributesToMap(rl.GetResource().GetAttributes(), WriteKeyAttribute)
+		for _, sl := range rl.GetScopeLogs() {
+			for _, lr := range sl.GetLogRecords() {
+				batch = append(batch, toEvent(resource, sl.GetScope(), lr))
+			}
+		}
+	}
+	if len(batch) == 0 {
+		return nil, ErrNoLogRecords
+	}
+	return jsonrs.Marshal(map[string]any{"batch": batch})
+}
+
+// toEvent maps a single log record to a rudder event
+func toEvent(resource map[string]any, scope *commonpb.InstrumentationScope, lr *logspb.LogRecord) map[string]any {
+	attributes := lr.GetAttributes()
+	eventType := strings.ToLower(stringAttribute(attributes, typeAttribute))
+	if eventType != "identify" {
+		eventType = "track"
+	}
+
+	fields := attributesToMap(attributes, reservedAttributes...)
+	if kvs := lr.GetBody().GetKvlistValue(); kvs != nil {
+		for k, v := range attributesToMap(kvs.GetValues()) {
+			if _, ok := fields[k]; !ok {
+				fields[k] = v
+			}
+		}
+	}
+
+	otelContext := map[string]any{}
+	if len(resource) > 0 {
+		otelContext["resource"] = resource
+	}
+	if len(lr.GetTraceId()) > 0 {
+		otelContext["traceId"] = hex.EncodeToString(lr.GetTraceId())
+	}
+	if len(lr.GetSpanId()) > 0 {
+		otelContext["spanId"] = hex.EncodeToString(lr.GetSpanId())
+	}
+	if lr.GetSeverityText() != "" {
+		otelContext["severityText"] = lr.GetSeverityText()
+	}
+	eventContext := map[string]any{"otel": otelContext}
+	if scope.GetName() != "" {
+		eventContext["library"] = map[string]any{"name": scope.GetName(), "version": scope.GetVersion()}
+	}
+
+	event := map[string]any{
+		"type":    eventType,
+		"context": eventContext,
+	}
+	if userID := firstStringAttribute(attributes, userIDAttributes...); userID != "" {
+		event["userId"] = userID
+	}
+	if anonymousID := stringAttribute(attributes, anonymousIDAttribute); anonymousID != "" {
+		event["anonymousId"] = anonymousID
+	}
+	if messageID := firstStringAttribute(attributes, messageIDAttributes...); messageID != "" {
+		event["messageId"] = messageID
+	}
+	// sentAt is left unset, so that the processor keeps the record's time as the event's timestamp
+	// instead of rebasing it on receivedAt
+	if ts := recordTime(lr); !ts.IsZero() {
+		event["originalTimestamp"] = ts.Format(time.RFC3339Nano)
+	}
+	switch eventType {
+	case "identify":
+		event["traits"] = fields
+	default:
+		event["event"] = eventName(lr)
+		event["properties"] = fields
+	}
+	return event
+}
+
+// eventName returns the name of the event represented by the log record
+func eventName(lr *logspb.LogRecord) string {
+	if lr.GetEventName() != "" {
+		return lr.GetEventName()
+	}
+	if name := firstStringAttribute(lr.GetAttributes(), eventNameAttributes...); name != "" {
+		return name
+	}
+	return lr.GetBody().GetStringValue()
+}
+
+// recordTime returns the time of the log record, falling back to its observed time
+func recordTime(lr *logspb.LogRecord) time.Time {
+	nanos := lr.GetTimeUnixNano()
+	if nanos == 0 {
+		nanos = lr.GetObservedTimeUnixNano()
+	}
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nanos)).UTC()
+}
+
+func stringAttribute(kvs []*commonpb.KeyValue, key string) string {
+	for _, kv := range kvs {
+		if kv.GetKey() == key {
+			if s, ok := anyValue(kv.GetValue()).(string); ok {
+				return s
+			}
+			return fmt.Sprint(anyValue(kv.GetValue()))
+		}
+	}
+	return ""
+}
+
+func firstStringAttribute(kvs []*commonpb.KeyValue, keys ...string) string {
+	for _, key := range keys {
+		if v := stringAttribute(kvs, key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// attributesToMap converts a list of key values to a map, skipping the excluded keys
+func attributesToMap(kvs []*commonpb.KeyValue, exclude ...string) map[string]any {
+	m := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		if kv.GetKey() == "" || lo.Contains(exclude, kv.GetKey()) {
+			continue
+		}
+		m[kv.GetKey()] = anyValue(kv.GetValue())
+	}
+	return m
+}
+
+// anyValue converts an OTLP value to its JSON-friendly go representation
+func anyValue(v *commonpb.AnyValue) any {
+	switch value := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return value.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return value.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return value.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return value.DoubleValue
+	case *commonpb.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(value.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		values := value.ArrayValue.GetValues()
+		arr := make([]any, len(values))
+		for i := range values {
+			arr[i] = anyValue(values[i])
+		}
+		return arr
+	case *commonpb.AnyValue_KvlistValue:
+		return attributesToMap(value.KvlistValue.GetValues())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,211 @@
+package otlp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	statuspb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/rudderlabs/rudder-server/gateway/internal/otlp"
+)
+
+func TestUnmarshalLogsRequest(t *testing.T) {
+	req := logsRequest("writeKey", trackRecord())
+
+	t.Run("protobuf", func(t *testing.T) {
+		body, err := proto.Marshal(req)
+		require.NoError(t, err)
+		decoded, err := otlp.UnmarshalLogsRequest("application/x-protobuf", body)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(req, decoded))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		body, err := protojson.Marshal(req)
+		require.NoError(t, err)
+		decoded, err := otlp.UnmarshalLogsRequest("application/json; charset=utf-8", body)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(req, decoded))
+	})
+
+	t.Run("empty content type defaults to protobuf", func(t *testing.T) {
+		body, err := proto.Marshal(req)
+		require.NoError(t, err)
+		_, err = otlp.UnmarshalLogsRequest("", body)
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		_, err := otlp.UnmarshalLogsRequest("text/plain", []byte("{}"))
+		require.ErrorIs(t, err, otlp.ErrUnsupportedContentType)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := otlp.UnmarshalLogsRequest("application/json", []byte("not-json"))
+		require.Error(t, err)
+	})
+}
+
+func TestWriteKey(t *testing.T) {
+	t.Run("from resource attribute", func(t *testing.T) {
+		writeKey, err := otlp.WriteKey(logsRequest("writeKey", trackRecord()))
+		require.NoError(t, err)
+		require.Equal(t, "writeKey", writeKey)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		writeKey, err := otlp.WriteKey(logsRequest("", trackRecord()))
+		require.NoError(t, err)
+		require.Empty(t, writeKey)
+	})
+
+	t.Run("conflicting", func(t *testing.T) {
+		req := logsRequest("writeKey1", trackRecord())
+		req.ResourceLogs = append(req.ResourceLogs, logsRequest("writeKey2", trackRecord()).ResourceLogs...)
+		_, err := otlp.WriteKey(req)
+		require.ErrorIs(t, err, otlp.ErrMultipleWriteKeys)
+	})
+}
+
+func TestToBatchPayload(t *testing.T) {
+	t.Run("track", func(t *testing.T) {
+		payload, err := otlp.ToBatchPayload(logsRequest("writeKey", trackRecord()))
+		require.NoError(t, err)
+
+		events := gjson.GetBytes(payload, "batch").Array()
+		require.Len(t, events, 1)
+		event := events[0]
+		require.Equal(t, "track", event.Get("type").String())
+		require.Equal(t, "Order Completed", event.Get("event").String())
+		require.Equal(t, "user-1", event.Get("userId").String())
+		require.Equal(t, "anon-1", event.Get("anonymousId").String())
+		require.Equal(t, "message-1", event.Get("messageId").String())
+		require.Equal(t, "2024-01-02T03:04:05Z", event.Get("originalTimestamp").String())
+		require.False(t, event.Get("sentAt").Exists(), "sentAt should not be set, otherwise the timestamp would be rebased on receivedAt")
+		require.Equal(t, int64(42), event.Get("properties.order_id").Int())
+		require.Equal(t, "EUR", event.Get("properties.currency").String())
+		require.Equal(t, []any{"a", "b"}, event.Get("properties.tags").Value())
+		require.False(t, event.Get("properties.user\\.id").Exists(), "reserved attributes should not be copied to properties")
+		require.Equal(t, "checkout", event.Get("context.otel.resource.service\\.name").String())
+		require.False(t, event.Get("context.otel.resource.rudder\\.write_key").Exists(), "write key should not be part of the event")
+		require.Equal(t, "0102", event.Get("context.otel.traceId").String())
+		require.Equal(t, "INFO", event.Get("context.otel.severityText").String())
+		require.Equal(t, "checkout-lib", event.Get("context.library.name").String())
+		require.Equal(t, "1.0.0", event.Get("context.library.version").String())
+	})
+
+	t.Run("identify", func(t *testing.T) {
+		record := &logspb.LogRecord{
+			Attributes: []*commonpb.KeyValue{
+				stringKV("rudder.type", "identify"),
+				stringKV("enduser.id", "user-1"),
+				stringKV("email", "user@example.com"),
+			},
+		}
+		payload, err := otlp.ToBatchPayload(logsRequest("writeKey", record))
+		require.NoError(t, err)
+
+		event := gjson.GetBytes(payload, "batch.0")
+		require.Equal(t, "identify", event.Get("type").String())
+		require.Equal(t, "user-1", event.Get("userId").String())
+		require.Equal(t, "user@example.com", event.Get("traits.email").String())
+		require.False(t, event.Get("event").Exists())
+		require.False(t, event.Get("originalTimestamp").Exists())
+	})
+
+	t.Run("event name from attribute and body", func(t *testing.T) {
+		payload, err := otlp.ToBatchPayload(logsRequest("writeKey",
+			&logspb.LogRecord{Attributes: []*commonpb.KeyValue{stringKV("event.name", "from attribute")}},
+			&logspb.LogRecord{Body: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "from body"}}},
+		))
+		require.NoError(t, err)
+		require.Equal(t, "from attribute", gjson.GetBytes(payload, "batch.0.event").String())
+		require.Equal(t, "from body", gjson.GetBytes(payload, "batch.1.event").String())
+	})
+
+	t.Run("no log records", func(t *testing.T) {
+		_, err := otlp.ToBatchPayload(logsRequest("writeKey"))
+		require.ErrorIs(t, err, otlp.ErrNoLogRecords)
+	})
+}
+
+func TestMarshalLogsResponse(t *testing.T) {
+	res := &collogspb.ExportLogsServiceResponse{}
+
+	body, err := otlp.MarshalLogsResponse("application/json", res)
+	require.NoError(t, err)
+	require.JSONEq(t, "{}", string(body))
+
+	body, err = otlp.MarshalLogsResponse("application/x-protobuf", res)
+	require.NoError(t, err)
+	require.Empty(t, body)
+}
+
+func TestMarshalStatus(t *testing.T) {
+	body, err := otlp.MarshalStatus("application/json; charset=utf-8", codes.InvalidArgument, "invalid payload")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"code":3,"message":"invalid payload"}`, string(body))
+
+	body, err = otlp.MarshalStatus("application/x-protobuf", codes.Unauthenticated, "invalid write key")
+	require.NoError(t, err)
+	var s statuspb.Status
+	require.NoError(t, proto.Unmarshal(body, &s))
+	require.EqualValues(t, codes.Unauthenticated, s.GetCode())
+	require.Equal(t, "invalid write key", s.GetMessage())
+
+	require.Equal(t, "application/json", otlp.ResponseMediaType("application/json; charset=utf-8"))
+	require.Equal(t, "application/x-protobuf", otlp.ResponseMediaType("text/plain"))
+}
+
+func logsRequest(writeKey string, records ...*logspb.LogRecord) *collogspb.ExportLogsServiceRequest {
+	resourceAttributes := []*commonpb.KeyValue{stringKV("service.name", "checkout")}
+	if writeKey != "" {
+		resourceAttributes = append(resourceAttributes, stringKV(otlp.WriteKeyAttribute, writeKey))
+	}
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: &resourcepb.Resource{Attributes: resourceAttributes},
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: "checkout-lib", Version: "1.0.0"},
+				LogRecords: records,
+			}},
+		}},
+	}
+}
+
+func trackRecord() *logspb.LogRecord {
+	return &logspb.LogRecord{
+		TimeUnixNano: 1704164645000000000,
+		SeverityText: "INFO",
+		EventName:    "Order Completed",
+		TraceId:      []byte{1, 2},
+		Attributes: []*commonpb.KeyValue{
+			stringKV("user.id", "user-1"),
+			stringKV("rudder.anonymous_id", "anon-1"),
+			stringKV("rudder.message_id", "message-1"),
+			{Key: "order_id", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 42}}},
+			{Key: "tags", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{
+				Values: []*commonpb.AnyValue{
+					{Value: &commonpb.AnyValue_StringValue{StringValue: "a"}},
+					{Value: &commonpb.AnyValue_StringValue{StringValue: "b"}},
+				},
+			}}}},
+		},
+		Body: &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{
+			Values: []*commonpb.KeyValue{stringKV("currency", "EUR")},
+		}}},
+	}
+}
+
+func stringKV(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
@@ -0,0 +1,56 @@
+package pgcdc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rudderlabs/rudder-server/utils/tx"
+)
+
+const checkpointsTableName = "pg_cdc_checkpoints"
+
+// TxRunner runs functions inside database transactions, e.g. a jobsdb
+type TxRunner interface {
+	WithTx(func(tx *tx.Tx) error) error
+}
+
+// Checkpoints keeps the end LSN of the last stored transaction of every source and replication slot in the server's own database.
+// Its table is created by the node migrations.
+type Checkpoints struct {
+	db TxRunner
+}
+
+// NewCheckpoints creates a new checkpoint store, using the database of the provided transaction runner
+func NewCheckpoints(db TxRunner) *Checkpoints {
+	return &Checkpoints{db: db}
+}
+
+// Get returns the checkpointed LSN of the source's slot, or zero if there is none
+func (c *Checkpoints) Get(ctx context.Context, sourceID, slotName string) (LSN, error) {
+	var lsn string
+	err := c.db.WithTx(func(tx *tx.Tx) error {
+		return tx.QueryRowContext(ctx, `SELECT lsn::TEXT FROM `+checkpointsTableName+` WHERE source_id = $1 AND slot_name = $2`, sourceID, slotName).Scan(&lsn)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("getting checkpoint: %w", err)
+	}
+	return ParseLSN(lsn)
+}
+
+// Set checkpoints the LSN of the source's slot
+func (c *Checkpoints) Set(ctx context.Context, sourceID, slotName string, lsn LSN) error {
+	err := c.db.WithTx(func(tx *tx.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO `+checkpointsTableName+` (source_id, slot_name, lsn) VALUES ($1, $2, $3::PG_LSN)
+			ON CONFLICT (source_id, slot_name) DO UPDATE SET lsn = EXCLUDED.lsn, updated_at = NOW()`, sourceID, slotName, lsn.String())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("setting checkpoint: %w", err)
+	}
+	return nil
+}
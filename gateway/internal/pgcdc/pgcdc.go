@@ -0,0 +1,259 @@
+// Package pgcdc tails a postgres publication through logical replication (pgoutput) and converts row changes into rudder batch payloads.
+//
+// Every inserted or updated row of a configured table is mapped to a single rudder event:
+//   - the event type is the table's configured event type (track or identify, defaults to track)
+//   - the event name of track events is the table's configured event name for the operation, defaulting to `<table> inserted` or `<table> updated`
+//   - userId is read from the table's configured user id column, otherwise anonymousId is set to the row's replica identity
+//   - messageId is derived from the source, the change's LSN and the row's replica identity, so that replayed changes keep the same messageId
+//   - the row's columns become the event's properties (track) or traits (identify)
+//   - the table, operation, LSN and transaction id are kept under `context.pgcdc`
+//
+// Changes are delivered per transaction once it is committed, and the end LSN of every delivered transaction is checkpointed,
+// so that replication resumes after the last stored transaction. Large transactions are delivered in chunks of bounded size,
+// a restart delivering all of the chunks of a transaction that wasn't checkpointed again.
+package pgcdc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+// SourceDefinitionName is the name of the source definition of postgres logical replication sources
+const SourceDefinitionName = "PostgresCDC"
+
+// Operation is the kind of row change
+type Operation string
+
+const (
+	InsertOperation Operation = "insert"
+	UpdateOperation Operation = "update"
+)
+
+const (
+	trackEventType    = "track"
+	identifyEventType = "identify"
+	defaultNamespace  = "public"
+	libraryName       = "rudder-pgcdc"
+)
+
+var (
+	// ErrInvalidConfig is returned when the source's config is not a valid logical replication config
+	ErrInvalidConfig = errors.New("invalid postgres cdc config")
+
+	slotNameRegexp = regexp.MustCompile(`^[a-z0-9_]{1,63}$`)
+)
+
+// Config is the logical replication config of a source
+type Config struct {
+	// ConnectionString is the connection string of the database to replicate from
+	ConnectionString string `json:"connectionString"`
+	// Publication is the name of the publication to subscribe to
+	Publication string `json:"publication"`
+	// SlotName is the name of the logical replication slot, created if missing
+	SlotName string `json:"slotName"`
+	// Tables are the tables whose changes are converted to events. Changes of all tables are converted to track events if empty.
+	Tables []TableConfig `json:"tables"`
+}
+
+// TableConfig describes how the changes of a table are converted to events
+type TableConfig struct {
+	// Table is the table's name, optionally qualified by its schema, e.g. public.users
+	Table string `json:"table"`
+	// EventType is the type of the events, either track or identify
+	EventType string `json:"eventType"`
+	// InsertEventName is the name of the track events of inserted rows
+	InsertEventName string `json:"insertEventName"`
+	// UpdateEventName is the name of the track events of updated rows
+	UpdateEventName string `json:"updateEventName"`
+	// UserIDColumn is the column holding the userId of the events
+	UserIDColumn string `json:"userIdColumn"`
+	// Operations are the operations converted to events, both inserts and updates if empty
+	Operations []Operation `json:"operations"`
+}
+
+// ParseConfig parses and validates the logical replication config of a source
+func ParseConfig(sourceConfig []byte) (Config, error) {
+	var c Config
+	if err := jsonrs.Unmarshal(sourceConfig, &c); err != nil {
+		return Config{}, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	switch {
+	case c.ConnectionString == "":
+		return Config{}, fmt.Errorf("%w: connectionString is required", ErrInvalidConfig)
+	case c.Publication == "":
+		return Config{}, fmt.Errorf("%w: publication is required", ErrInvalidConfig)
+	case !slotNameRegexp.MatchString(c.SlotName):
+		return Config{}, fmt.Errorf("%w: slotName %q must consist of up to 63 lower case letters, digits and underscores", ErrInvalidConfig, c.SlotName)
+	}
+	for i := range c.Tables {
+		table := &c.Tables[i]
+		if table.Table == "" {
+			return Config{}, fmt.Errorf("%w: table name is required", ErrInvalidConfig)
+		}
+		if !strings.Contains(table.Table, ".") {
+			table.Table = defaultNamespace + "." + table.Table
+		}
+		if table.EventType == "" {
+			table.EventType = trackEventType
+		}
+		if table.EventType != trackEventType && table.EventType != identifyEventType {
+			return Config{}, fmt.Errorf("%w: unsupported event type %q for table %s", ErrInvalidConfig, table.EventType, table.Table)
+		}
+		for _, op := range table.Operations {
+			if op != InsertOperation && op != UpdateOperation {
+				return Config{}, fmt.Errorf("%w: unsupported operation %q for table %s", ErrInvalidConfig, op, table.Table)
+			}
+		}
+	}
+	return c, nil
+}
+
+// tableConfig returns the config of the table, if its changes of the given operation are converted to events
+func (c Config) tableConfig(table string, op Operation) (TableConfig, bool) {
+	if len(c.Tables) == 0 {
+		return TableConfig{Table: table, EventType: trackEventType}, true
+	}
+	for _, tc := range c.Tables {
+		if tc.Table == table {
+			return tc, len(tc.Operations) == 0 || lo.Contains(tc.Operations, op)
+		}
+	}
+	return TableConfig{}, false
+}
+
+// Change is an inserted or updated row
+type Change struct {
+	// LSN is the position of the change in the write-ahead log
+	LSN LSN
+	// Operation is the kind of the change
+	Operation Operation
+	// Table is the schema qualified name of the changed table
+	Table string
+	// Columns are the row's column values, unchanged TOAST values are omitted
+	Columns map[string]any
+	// Key is the row's replica identity, i.e. the values of its key columns
+	Key []string
+}
+
+// Transaction is a committed transaction along with its changes
+type Transaction struct {
+	XID uint32
+	// EndLSN is the end position of the transaction, zero for the chunks of a large transaction handed over before its commit
+	EndLSN     LSN
+	CommitTime time.Time
+	Changes    []Change
+}
+
+// BatchPayloads converts the changes of the transaction to batch payloads of up to maxBatchSize events each.
+// Changes of tables that are not configured are skipped.
+func (c Config) BatchPayloads(sourceID string, tx Transaction, maxBatchSize int) ([][]byte, error) {
+	var events []map[string]any
+	for _, change := range tx.Changes {
+		if tc, ok := c.tableConfig(change.Table, change.Operation); ok {
+			events = append(events, toEvent(sourceID, tc, tx, change))
+		}
+	}
+	payloads := make([][]byte, 0, len(events)/maxBatchSize+1)
+	for _, chunk := range lo.Chunk(events, maxBatchSize) {
+		payload, err := jsonrs.Marshal(map[string]any{"batch": chunk})
+		if err != nil {
+			return nil, fmt.Errorf("marshalling batch payload: %w", err)
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+func toEvent(sourceID string, tc TableConfig, tx Transaction, change Change) map[string]any {
+	key := strings.Join(change.Key, ":")
+	event := map[string]any{
+		"type":              tc.EventType,
+		"messageId":         uuid.NewSHA1(uuid.NameSpaceOID, []byte(sourceID+"/"+change.LSN.String()+"/"+change.Table+"/"+key)).String(),
+		"originalTimestamp": tx.CommitTime.UTC().Format(misc.RFC3339Milli),
+		"timestamp":         tx.CommitTime.UTC().Format(misc.RFC3339Milli),
+		"context": map[string]any{
+			"library": map[string]any{"name": libraryName},
+			"pgcdc": map[string]any{
+				"table":     change.Table,
+				"operation": string(change.Operation),
+				"lsn":       change.LSN.String(),
+				"xid":       tx.XID,
+			},
+		},
+	}
+	if userID := change.Columns[tc.UserIDColumn]; tc.UserIDColumn != "" && userID != nil {
+		event["userId"] = fmt.Sprint(userID)
+	} else {
+		event["anonymousId"] = change.Table + ":" + key
+	}
+	switch tc.EventType {
+	case identifyEventType:
+		event["traits"] = change.Columns
+	default:
+		event["event"] = eventName(tc, change)
+		event["properties"] = change.Columns
+	}
+	return event
+}
+
+func eventName(tc TableConfig, change Change) string {
+	if change.Operation == InsertOperation && tc.InsertEventName != "" {
+		return tc.InsertEventName
+	}
+	if change.Operation == UpdateOperation && tc.UpdateEventName != "" {
+		return tc.UpdateEventName
+	}
+	_, table, _ := strings.Cut(change.Table, ".")
+	if change.Operation == InsertOperation {
+		return table + " inserted"
+	}
+	return table + " updated"
+}
+
+// postgres type oids of the values converted to json types other than strings
+const (
+	boolOID    = 16
+	int8OID    = 20
+	int2OID    = 21
+	int4OID    = 23
+	jsonOID    = 114
+	float4OID  = 700
+	float8OID  = 701
+	numericOID = 1700
+	jsonbOID   = 3802
+)
+
+// columnValue converts the text representation of a column's value to its json equivalent, falling back to the text itself
+func columnValue(typeOID uint32, text string) any {
+	switch typeOID {
+	case boolOID:
+		return text == "t"
+	case int2OID, int4OID, int8OID:
+		if v, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return v
+		}
+	case float4OID, float8OID, numericOID:
+		// NaN and infinity values can't be represented in json, so they are kept as text
+		if v, err := strconv.ParseFloat(text, 64); err == nil && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			return v
+		}
+	case jsonOID, jsonbOID:
+		var v any
+		if err := jsonrs.Unmarshal([]byte(text), &v); err == nil {
+			return v
+		}
+	}
+	return text
+}
@@ -0,0 +1,134 @@
+package pgcdc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		conf, err := ParseConfig([]byte(`{
+			"connectionString": "postgres://localhost:5432/db",
+			"publication": "rudder",
+			"slotName": "rudder_slot",
+			"tables": [
+				{"table": "users", "eventType": "identify", "userIdColumn": "id"},
+				{"table": "sales.orders", "insertEventName": "Order Created", "operations": ["insert"]}
+			]
+		}`))
+		require.NoError(t, err)
+		require.Equal(t, Config{
+			ConnectionString: "postgres://localhost:5432/db",
+			Publication:      "rudder",
+			SlotName:         "rudder_slot",
+			Tables: []TableConfig{
+				{Table: "public.users", EventType: "identify", UserIDColumn: "id"},
+				{Table: "sales.orders", EventType: "track", InsertEventName: "Order Created", Operations: []Operation{InsertOperation}},
+			},
+		}, conf)
+	})
+
+	for name, config := range map[string]string{
+		"invalid json":          `{`,
+		"no connection string":  `{"publication": "rudder", "slotName": "rudder_slot"}`,
+		"no publication":        `{"connectionString": "postgres://", "slotName": "rudder_slot"}`,
+		"invalid slot name":     `{"connectionString": "postgres://", "publication": "rudder", "slotName": "Rudder-Slot"}`,
+		"no table name":         `{"connectionString": "postgres://", "publication": "rudder", "slotName": "rudder_slot", "tables": [{}]}`,
+		"unsupported type":      `{"connectionString": "postgres://", "publication": "rudder", "slotName": "rudder_slot", "tables": [{"table": "users", "eventType": "page"}]}`,
+		"unsupported operation": `{"connectionString": "postgres://", "publication": "rudder", "slotName": "rudder_slot", "tables": [{"table": "users", "operations": ["delete"]}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(config))
+			require.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestBatchPayloads(t *testing.T) {
+	commitTime := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	tx := Transaction{
+		XID:        7,
+		EndLSN:     300,
+		CommitTime: commitTime,
+		Changes: []Change{
+			{LSN: 100, Operation: InsertOperation, Table: "public.users", Columns: map[string]any{"id": int64(1), "email": "a@example.com"}, Key: []string{"1"}},
+			{LSN: 150, Operation: UpdateOperation, Table: "public.users", Columns: map[string]any{"id": int64(2), "email": nil}, Key: []string{"2"}},
+			{LSN: 200, Operation: InsertOperation, Table: "public.orders", Columns: map[string]any{"id": int64(3), "user_id": nil}, Key: []string{"3"}},
+			{LSN: 250, Operation: UpdateOperation, Table: "public.orders", Columns: map[string]any{"id": int64(3), "user_id": int64(1)}, Key: []string{"3"}},
+			{LSN: 260, Operation: InsertOperation, Table: "public.other", Columns: map[string]any{"id": int64(4)}, Key: []string{"4"}},
+		},
+	}
+
+	t.Run("configured tables", func(t *testing.T) {
+		conf := Config{Tables: []TableConfig{
+			{Table: "public.users", EventType: "identify", UserIDColumn: "id"},
+			{Table: "public.orders", EventType: "track", UserIDColumn: "user_id", InsertEventName: "Order Created", Operations: []Operation{InsertOperation}},
+		}}
+		payloads, err := conf.BatchPayloads("source_id", tx, 2)
+		require.NoError(t, err)
+		require.Len(t, payloads, 2)
+
+		batch := gjson.GetBytes(payloads[0], "batch").Array()
+		require.Len(t, batch, 2)
+		identify := batch[0]
+		require.Equal(t, "identify", identify.Get("type").String())
+		require.Equal(t, "1", identify.Get("userId").String())
+		require.False(t, identify.Get("anonymousId").Exists())
+		require.Equal(t, "a@example.com", identify.Get("traits.email").String())
+		require.Equal(t, "2024-01-02T03:04:05.123Z", identify.Get("originalTimestamp").String())
+		require.Equal(t, "rudder-pgcdc", identify.Get("context.library.name").String())
+		require.Equal(t, "public.users", identify.Get("context.pgcdc.table").String())
+		require.Equal(t, "insert", identify.Get("context.pgcdc.operation").String())
+		require.Equal(t, "0/64", identify.Get("context.pgcdc.lsn").String())
+		require.EqualValues(t, 7, identify.Get("context.pgcdc.xid").Int())
+		require.Equal(t, "2", batch[1].Get("userId").String())
+
+		batch = gjson.GetBytes(payloads[1], "batch").Array()
+		require.Len(t, batch, 1, "updates of orders and changes of other tables are skipped")
+		track := batch[0]
+		require.Equal(t, "track", track.Get("type").String())
+		require.Equal(t, "Order Created", track.Get("event").String())
+		require.False(t, track.Get("userId").Exists())
+		require.Equal(t, "public.orders:3", track.Get("anonymousId").String())
+		require.EqualValues(t, 3, track.Get("properties.id").Int())
+
+		again, err := conf.BatchPayloads("source_id", tx, 2)
+		require.NoError(t, err)
+		require.Equal(t, payloads, again, "replayed changes keep the same message ids")
+		other, err := conf.BatchPayloads("other_source_id", tx, 2)
+		require.NoError(t, err)
+		require.NotEqual(t, identify.Get("messageId").String(), gjson.GetBytes(other[0], "batch.0.messageId").String())
+	})
+
+	t.Run("all tables", func(t *testing.T) {
+		payloads, err := Config{}.BatchPayloads("source_id", tx, 10)
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+		var names []string
+		for _, event := range gjson.GetBytes(payloads[0], "batch").Array() {
+			require.Equal(t, "track", event.Get("type").String())
+			names = append(names, event.Get("event").String())
+		}
+		require.Equal(t, []string{"users inserted", "users updated", "orders inserted", "orders updated", "other inserted"}, names)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		payloads, err := Config{}.BatchPayloads("source_id", Transaction{}, 10)
+		require.NoError(t, err)
+		require.Empty(t, payloads)
+	})
+}
+
+func TestColumnValue(t *testing.T) {
+	require.Equal(t, true, columnValue(boolOID, "t"))
+	require.Equal(t, false, columnValue(boolOID, "f"))
+	require.Equal(t, int64(42), columnValue(int4OID, "42"))
+	require.Equal(t, 1.5, columnValue(numericOID, "1.5"))
+	require.Equal(t, "NaN", columnValue(numericOID, "NaN"))
+	require.Equal(t, "Infinity", columnValue(float8OID, "Infinity"))
+	require.Equal(t, map[string]any{"a": []any{1.0}}, columnValue(jsonbOID, `{"a": [1]}`))
+	require.Equal(t, "2024-01-02 03:04:05+00", columnValue(1184, "2024-01-02 03:04:05+00"))
+}
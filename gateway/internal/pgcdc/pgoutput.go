@@ -0,0 +1,233 @@
+package pgcdc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// pgoutput message types, see https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+const (
+	beginMessageType    = 'B'
+	commitMessageType   = 'C'
+	relationMessageType = 'R'
+	insertMessageType   = 'I'
+	updateMessageType   = 'U'
+)
+
+// tuple column kinds
+const (
+	nullColumn           = 'n'
+	unchangedToastColumn = 'u'
+	textColumn           = 't'
+)
+
+// postgresEpoch is the epoch of the timestamps sent by the server (2000-01-01)
+var postgresEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var errShortMessage = errors.New("short pgoutput message")
+
+// LSN is a postgres log sequence number
+type LSN uint64
+
+// String formats the LSN the way postgres does, e.g. 16/B374D848
+func (lsn LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(lsn>>32), uint32(lsn))
+}
+
+// ParseLSN parses an LSN formatted as postgres does
+func ParseLSN(s string) (LSN, error) {
+	var upper, lower uint32
+	if _, err := fmt.Sscanf(s, "%X/%X", &upper, &lower); err != nil {
+		return 0, fmt.Errorf("parsing lsn %q: %w", s, err)
+	}
+	return LSN(uint64(upper)<<32 | uint64(lower)), nil
+}
+
+// beginMessage marks the start of a transaction
+type beginMessage struct {
+	FinalLSN   LSN
+	CommitTime time.Time
+	XID        uint32
+}
+
+// commitMessage marks the end of a transaction
+type commitMessage struct {
+	CommitLSN  LSN
+	EndLSN     LSN
+	CommitTime time.Time
+}
+
+// relationColumn describes a column of a relation
+type relationColumn struct {
+	Name    string
+	Key     bool
+	TypeOID uint32
+}
+
+// relationMessage describes a relation before any of its changes is sent
+type relationMessage struct {
+	ID        uint32
+	Namespace string
+	Name      string
+	Columns   []relationColumn
+}
+
+// tupleColumn is the value of a column in a tuple
+type tupleColumn struct {
+	Kind byte
+	Data []byte
+}
+
+// changeMessage is an insert or update of a row
+type changeMessage struct {
+	Operation  Operation
+	RelationID uint32
+	New        []tupleColumn
+}
+
+// parseMessage parses a pgoutput message, returning nil for message types that are not of interest, e.g. deletes, truncates or origins
+func parseMessage(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, errShortMessage
+	}
+	r := &messageReader{data: data[1:]}
+	var msg any
+	switch data[0] {
+	case beginMessageType:
+		msg = &beginMessage{
+			FinalLSN:   LSN(r.uint64()),
+			CommitTime: r.time(),
+			XID:        r.uint32(),
+		}
+	case commitMessageType:
+		_ = r.byte() // flags, unused
+		msg = &commitMessage{
+			CommitLSN:  LSN(r.uint64()),
+			EndLSN:     LSN(r.uint64()),
+			CommitTime: r.time(),
+		}
+	case relationMessageType:
+		rel := &relationMessage{
+			ID:        r.uint32(),
+			Namespace: r.string(),
+			Name:      r.string(),
+		}
+		_ = r.byte() // replica identity setting, unused
+		rel.Columns = make([]relationColumn, r.uint16())
+		for i := range rel.Columns {
+			rel.Columns[i].Key = r.byte()&1 == 1
+			rel.Columns[i].Name = r.string()
+			rel.Columns[i].TypeOID = r.uint32()
+			_ = r.uint32() // type modifier, unused
+		}
+		msg = rel
+	case insertMessageType:
+		change := &changeMessage{Operation: InsertOperation, RelationID: r.uint32()}
+		if kind := r.byte(); kind != 'N' && r.err == nil {
+			return nil, fmt.Errorf("unexpected tuple type %q in insert message", kind)
+		}
+		change.New = r.tuple()
+		msg = change
+	case updateMessageType:
+		change := &changeMessage{Operation: UpdateOperation, RelationID: r.uint32()}
+		kind := r.byte()
+		if kind == 'K' || kind == 'O' { // old key or old tuple, skipped
+			_ = r.tuple()
+			kind = r.byte()
+		}
+		if kind != 'N' && r.err == nil {
+			return nil, fmt.Errorf("unexpected tuple type %q in update message", kind)
+		}
+		change.New = r.tuple()
+		msg = change
+	default:
+		return nil, nil
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("parsing pgoutput message %q: %w", data[0], r.err)
+	}
+	return msg, nil
+}
+
+// messageReader reads the big-endian fields of a pgoutput message, remembering the first error
+type messageReader struct {
+	data []byte
+	err  error
+}
+
+func (r *messageReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = errShortMessage
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *messageReader) byte() byte {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *messageReader) uint16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *messageReader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *messageReader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *messageReader) time() time.Time {
+	return postgresEpoch.Add(time.Duration(int64(r.uint64())) * time.Microsecond)
+}
+
+func (r *messageReader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(r.data, 0)
+	if i < 0 {
+		r.err = errShortMessage
+		return ""
+	}
+	s := string(r.data[:i])
+	r.data = r.data[i+1:]
+	return s
+}
+
+func (r *messageReader) tuple() []tupleColumn {
+	columns := make([]tupleColumn, r.uint16())
+	for i := range columns {
+		columns[i].Kind = r.byte()
+		if columns[i].Kind == textColumn {
+			// the message buffer is reused by the connection, so the data is copied to outlive it
+			columns[i].Data = bytes.Clone(r.next(int(r.uint32())))
+		}
+	}
+	if r.err != nil {
+		return nil
+	}
+	return columns
+}
@@ -0,0 +1,156 @@
+package pgcdc
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// messageBuilder builds pgoutput messages
+type messageBuilder []byte
+
+func (b messageBuilder) byte(v byte) messageBuilder { return append(b, v) }
+
+func (b messageBuilder) uint16(v uint16) messageBuilder { return binary.BigEndian.AppendUint16(b, v) }
+
+func (b messageBuilder) uint32(v uint32) messageBuilder { return binary.BigEndian.AppendUint32(b, v) }
+
+func (b messageBuilder) uint64(v uint64) messageBuilder { return binary.BigEndian.AppendUint64(b, v) }
+
+func (b messageBuilder) time(t time.Time) messageBuilder {
+	return b.uint64(uint64(t.Sub(postgresEpoch).Microseconds()))
+}
+
+func (b messageBuilder) string(v string) messageBuilder { return append(append(b, v...), 0) }
+
+// tuple appends a tuple of text columns, nil values being encoded as null columns
+func (b messageBuilder) tuple(values ...*string) messageBuilder {
+	b = b.uint16(uint16(len(values)))
+	for _, v := range values {
+		if v == nil {
+			b = b.byte(nullColumn)
+			continue
+		}
+		b = b.byte(textColumn).uint32(uint32(len(*v)))
+		b = append(b, *v...)
+	}
+	return b
+}
+
+func text(v string) *string { return &v }
+
+func beginMsg(finalLSN LSN, commitTime time.Time, xid uint32) []byte {
+	return messageBuilder{beginMessageType}.uint64(uint64(finalLSN)).time(commitTime).uint32(xid)
+}
+
+func commitMsg(commitLSN, endLSN LSN, commitTime time.Time) []byte {
+	return messageBuilder{commitMessageType}.byte(0).uint64(uint64(commitLSN)).uint64(uint64(endLSN)).time(commitTime)
+}
+
+func relationMsg(id uint32, namespace, name string, columns ...relationColumn) []byte {
+	b := messageBuilder{relationMessageType}.uint32(id).string(namespace).string(name).byte('d').uint16(uint16(len(columns)))
+	for _, c := range columns {
+		var flags byte
+		if c.Key {
+			flags = 1
+		}
+		b = b.byte(flags).string(c.Name).uint32(c.TypeOID).uint32(0xFFFFFFFF)
+	}
+	return b
+}
+
+func insertMsg(relationID uint32, values ...*string) []byte {
+	return messageBuilder{insertMessageType}.uint32(relationID).byte('N').tuple(values...)
+}
+
+func updateMsg(relationID uint32, old []*string, values ...*string) []byte {
+	b := messageBuilder{updateMessageType}.uint32(relationID)
+	if old != nil {
+		b = b.byte('O').tuple(old...)
+	}
+	return b.byte('N').tuple(values...)
+}
+
+func TestLSN(t *testing.T) {
+	lsn, err := ParseLSN("16/B374D848")
+	require.NoError(t, err)
+	require.Equal(t, LSN(0x16B374D848), lsn)
+	require.Equal(t, "16/B374D848", lsn.String())
+	require.Equal(t, "0/0", LSN(0).String())
+
+	_, err = ParseLSN("invalid")
+	require.Error(t, err)
+}
+
+func TestParseMessage(t *testing.T) {
+	commitTime := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+
+	t.Run("begin", func(t *testing.T) {
+		msg, err := parseMessage(beginMsg(100, commitTime, 7))
+		require.NoError(t, err)
+		require.Equal(t, &beginMessage{FinalLSN: 100, CommitTime: commitTime, XID: 7}, msg)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		msg, err := parseMessage(commitMsg(100, 120, commitTime))
+		require.NoError(t, err)
+		require.Equal(t, &commitMessage{CommitLSN: 100, EndLSN: 120, CommitTime: commitTime}, msg)
+	})
+
+	t.Run("relation", func(t *testing.T) {
+		columns := []relationColumn{
+			{Name: "id", Key: true, TypeOID: int8OID},
+			{Name: "email", TypeOID: 25},
+		}
+		msg, err := parseMessage(relationMsg(16384, "public", "users", columns...))
+		require.NoError(t, err)
+		require.Equal(t, &relationMessage{ID: 16384, Namespace: "public", Name: "users", Columns: columns}, msg)
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		msg, err := parseMessage(insertMsg(16384, text("1"), nil))
+		require.NoError(t, err)
+		require.Equal(t, &changeMessage{
+			Operation:  InsertOperation,
+			RelationID: 16384,
+			New:        []tupleColumn{{Kind: textColumn, Data: []byte("1")}, {Kind: nullColumn}},
+		}, msg)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		for _, old := range [][]*string{nil, {text("1"), text("old@example.com")}} {
+			msg, err := parseMessage(updateMsg(16384, old, text("1"), text("new@example.com")))
+			require.NoError(t, err)
+			require.Equal(t, &changeMessage{
+				Operation:  UpdateOperation,
+				RelationID: 16384,
+				New:        []tupleColumn{{Kind: textColumn, Data: []byte("1")}, {Kind: textColumn, Data: []byte("new@example.com")}},
+			}, msg)
+		}
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		msg, err := parseMessage(messageBuilder{'D'}.uint32(16384).byte('K').tuple(text("1")))
+		require.NoError(t, err)
+		require.Nil(t, msg)
+	})
+
+	t.Run("short", func(t *testing.T) {
+		_, err := parseMessage(nil)
+		require.ErrorIs(t, err, errShortMessage)
+
+		data := insertMsg(16384, text("1"))
+		_, err = parseMessage(data[:len(data)-1])
+		require.ErrorIs(t, err, errShortMessage)
+
+		_, err = parseMessage(relationMsg(16384, "public", "users")[:8])
+		require.ErrorIs(t, err, errShortMessage)
+	})
+
+	t.Run("unexpected tuple type", func(t *testing.T) {
+		_, err := parseMessage(messageBuilder{insertMessageType}.uint32(16384).byte('X').tuple(text("1")))
+		require.Error(t, err)
+	})
+}
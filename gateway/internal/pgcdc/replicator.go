@@ -0,0 +1,277 @@
+package pgcdc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+)
+
+// replication protocol message types
+const (
+	xLogDataMessageType            = 'w'
+	primaryKeepaliveMessageType    = 'k'
+	standbyStatusUpdateMessageType = 'r'
+)
+
+// duplicateObjectErrorCode is the error code returned when creating a replication slot that already exists
+const duplicateObjectErrorCode = "42710"
+
+// Handler stores the events of a committed transaction. Its checkpoint is advanced only if the handler succeeds.
+// Transactions with more changes than the replicator's maximum are handed over in chunks, only the last one having its EndLSN set.
+type Handler func(ctx context.Context, tx Transaction) error
+
+// CheckpointStore keeps the end LSN of the last stored transaction of every source and replication slot
+type CheckpointStore interface {
+	Get(ctx context.Context, sourceID, slotName string) (LSN, error)
+	Set(ctx context.Context, sourceID, slotName string, lsn LSN) error
+}
+
+// Replicator streams the changes of a source's publication, handing every committed transaction over to a handler
+type Replicator struct {
+	sourceID        string
+	conf            Config
+	checkpoints     CheckpointStore
+	handler         Handler
+	maxChanges      int // maximum number of changes buffered before handing them over
+	standbyInterval time.Duration
+	logger          logger.Logger
+	now             func() time.Time
+
+	relations map[uint32]*relationMessage
+	current   *Transaction
+	stored    LSN // end LSN of the last stored transaction
+}
+
+// NewReplicator creates a new replicator for the source, buffering up to maxChanges changes of a transaction before handing them over
+func NewReplicator(sourceID string, conf Config, checkpoints CheckpointStore, handler Handler, maxChanges int, standbyInterval time.Duration, log logger.Logger) *Replicator {
+	return &Replicator{
+		sourceID:        sourceID,
+		conf:            conf,
+		checkpoints:     checkpoints,
+		handler:         handler,
+		maxChanges:      maxChanges,
+		standbyInterval: standbyInterval,
+		logger:          log.Withn(obskit.SourceID(sourceID), logger.NewStringField("slotName", conf.SlotName)),
+		now:             time.Now,
+	}
+}
+
+// Run creates the replication slot if missing and streams changes after the last checkpoint until the context is cancelled or an error occurs.
+func (r *Replicator) Run(ctx context.Context) error {
+	pgConf, err := pgconn.ParseConfig(r.conf.ConnectionString)
+	if err != nil {
+		return fmt.Errorf("parsing connection string: %w", err)
+	}
+	pgConf.RuntimeParams["replication"] = "database"
+	conn, err := pgconn.ConnectConfig(ctx, pgConf)
+	if err != nil {
+		return fmt.Errorf("connecting for replication: %w", err)
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if err := r.createSlot(ctx, conn); err != nil {
+		return err
+	}
+	if r.stored, err = r.checkpoints.Get(ctx, r.sourceID, r.conf.SlotName); err != nil {
+		return err
+	}
+	if err := r.startReplication(ctx, conn); err != nil {
+		return err
+	}
+	r.logger.Infon("Logical replication started", logger.NewStringField("lsn", r.stored.String()))
+	r.relations = map[uint32]*relationMessage{}
+	r.current = nil
+
+	nextStandbyUpdate := r.now().Add(r.standbyInterval)
+	for {
+		if !r.now().Before(nextStandbyUpdate) {
+			if err := r.sendStandbyStatusUpdate(conn); err != nil {
+				return err
+			}
+			nextStandbyUpdate = r.now().Add(r.standbyInterval)
+		}
+
+		receiveCtx, cancel := context.WithDeadline(ctx, nextStandbyUpdate)
+		msg, err := conn.ReceiveMessage(receiveCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if pgconn.Timeout(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("receiving replication message: %w", err)
+		}
+
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+			if len(msg.Data) == 0 {
+				continue
+			}
+			switch msg.Data[0] {
+			case primaryKeepaliveMessageType:
+				// walEnd (8), server time (8), reply requested (1)
+				if len(msg.Data) >= 18 && msg.Data[17] == 1 {
+					nextStandbyUpdate = time.Time{}
+				}
+			case xLogDataMessageType:
+				// walStart (8), walEnd (8), server time (8), data
+				if len(msg.Data) < 25 {
+					return fmt.Errorf("parsing xlog data: %w", errShortMessage)
+				}
+				committed, err := r.handleMessage(ctx, LSN(binary.BigEndian.Uint64(msg.Data[1:])), msg.Data[25:])
+				if err != nil {
+					return err
+				}
+				if committed {
+					// acknowledge stored transactions right away, so that the server can recycle their WAL
+					nextStandbyUpdate = time.Time{}
+				}
+			}
+		case *pgproto3.ErrorResponse:
+			return fmt.Errorf("replication error: %w", pgconn.ErrorResponseToPgError(msg))
+		case *pgproto3.CopyDone:
+			return errors.New("replication stream closed by the server")
+		}
+	}
+}
+
+// handleMessage applies a pgoutput message, handing the transaction over to the handler on commit.
+// The changes of large transactions are handed over in chunks of maxChanges, keeping the buffer bounded.
+// Since only committed transactions are checkpointed, the chunks of a transaction interrupted by a restart are handed over again,
+// their events keeping the same messageIds.
+// Returns true if a transaction was stored and checkpointed.
+func (r *Replicator) handleMessage(ctx context.Context, walStart LSN, data []byte) (bool, error) {
+	msg, err := parseMessage(data)
+	if err != nil {
+		return false, err
+	}
+	switch msg := msg.(type) {
+	case *relationMessage:
+		r.relations[msg.ID] = msg
+	case *beginMessage:
+		r.current = &Transaction{XID: msg.XID, CommitTime: msg.CommitTime}
+	case *changeMessage:
+		if r.current == nil {
+			return false, errors.New("change received outside of a transaction")
+		}
+		rel, ok := r.relations[msg.RelationID]
+		if !ok {
+			return false, fmt.Errorf("change received for unknown relation %d", msg.RelationID)
+		}
+		r.current.Changes = append(r.current.Changes, toChange(walStart, rel, msg))
+		if len(r.current.Changes) >= r.maxChanges {
+			if err := r.handler(ctx, *r.current); err != nil {
+				return false, fmt.Errorf("handling changes of transaction %d: %w", r.current.XID, err)
+			}
+			r.current.Changes = nil
+		}
+	case *commitMessage:
+		if r.current == nil {
+			return false, errors.New("commit received outside of a transaction")
+		}
+		tx := *r.current
+		r.current = nil
+		tx.EndLSN = msg.EndLSN
+		if tx.EndLSN <= r.stored { // already stored before a restart
+			return false, nil
+		}
+		if err := r.handler(ctx, tx); err != nil {
+			return false, fmt.Errorf("handling transaction %d: %w", tx.XID, err)
+		}
+		if err := r.checkpoints.Set(ctx, r.sourceID, r.conf.SlotName, tx.EndLSN); err != nil {
+			return false, err
+		}
+		r.stored = tx.EndLSN
+		return true, nil
+	}
+	return false, nil
+}
+
+func toChange(lsn LSN, rel *relationMessage, msg *changeMessage) Change {
+	change := Change{
+		LSN:       lsn,
+		Operation: msg.Operation,
+		Table:     rel.Namespace + "." + rel.Name,
+		Columns:   make(map[string]any, len(rel.Columns)),
+	}
+	for i, column := range msg.New {
+		if i >= len(rel.Columns) {
+			break
+		}
+		relColumn := rel.Columns[i]
+		switch column.Kind {
+		case nullColumn:
+			change.Columns[relColumn.Name] = nil
+		case textColumn:
+			change.Columns[relColumn.Name] = columnValue(relColumn.TypeOID, string(column.Data))
+		case unchangedToastColumn:
+			continue
+		}
+		if relColumn.Key {
+			change.Key = append(change.Key, string(column.Data))
+		}
+	}
+	return change
+}
+
+// createSlot creates the logical replication slot, unless it already exists
+func (r *Replicator) createSlot(ctx context.Context, conn *pgconn.PgConn) error {
+	_, err := conn.Exec(ctx, "CREATE_REPLICATION_SLOT "+r.conf.SlotName+" LOGICAL pgoutput NOEXPORT_SNAPSHOT").ReadAll()
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == duplicateObjectErrorCode {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("creating replication slot %s: %w", r.conf.SlotName, err)
+	}
+	r.logger.Infon("Replication slot created")
+	return nil
+}
+
+// startReplication starts streaming the slot's changes after the last stored transaction
+func (r *Replicator) startReplication(ctx context.Context, conn *pgconn.PgConn) error {
+	query := fmt.Sprintf("START_REPLICATION SLOT %s LOGICAL %s (proto_version '1', publication_names '%s')",
+		r.conf.SlotName, r.stored, strings.ReplaceAll(r.conf.Publication, "'", "''"))
+	conn.Frontend().Send(&pgproto3.Query{String: query})
+	if err := conn.Frontend().Flush(); err != nil {
+		return fmt.Errorf("starting replication: %w", err)
+	}
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("starting replication: %w", err)
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.CopyBothResponse:
+			return nil
+		case *pgproto3.ErrorResponse:
+			return fmt.Errorf("starting replication: %w", pgconn.ErrorResponseToPgError(msg))
+		}
+	}
+}
+
+// sendStandbyStatusUpdate reports the end LSN of the last stored transaction as written, flushed and applied
+func (r *Replicator) sendStandbyStatusUpdate(conn *pgconn.PgConn) error {
+	data := make([]byte, 34)
+	data[0] = standbyStatusUpdateMessageType
+	binary.BigEndian.PutUint64(data[1:], uint64(r.stored))
+	binary.BigEndian.PutUint64(data[9:], uint64(r.stored))
+	binary.BigEndian.PutUint64(data[17:], uint64(r.stored))
+	binary.BigEndian.PutUint64(data[25:], uint64(r.now().Sub(postgresEpoch).Microseconds()))
+	conn.Frontend().Send(&pgproto3.CopyData{Data: data})
+	if err := conn.Frontend().Flush(); err != nil {
+		return fmt.Errorf("sending standby status update: %w", err)
+	}
+	return nil
+}
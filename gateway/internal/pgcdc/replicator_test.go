@@ -0,0 +1,269 @@
+package pgcdc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/testhelper/docker/resource/postgres"
+
+	dpostgres "github.com/rudderlabs/rudder-server/services/dedup/postgres"
+	migrator "github.com/rudderlabs/rudder-server/services/sql-migrator"
+)
+
+type mockCheckpoints struct {
+	mu     sync.Mutex
+	lsn    LSN
+	setErr error
+}
+
+func (m *mockCheckpoints) Get(context.Context, string, string) (LSN, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lsn, nil
+}
+
+func (m *mockCheckpoints) Set(_ context.Context, _, _ string, lsn LSN) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.setErr != nil {
+		return m.setErr
+	}
+	m.lsn = lsn
+	return nil
+}
+
+func TestReplicatorHandleMessage(t *testing.T) {
+	commitTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newReplicator := func(checkpoints *mockCheckpoints, handler Handler) *Replicator {
+		r := NewReplicator("source_id", Config{SlotName: "slot"}, checkpoints, handler, 10, time.Second, logger.NOP)
+		r.relations = map[uint32]*relationMessage{}
+		return r
+	}
+	users := relationMsg(1, "public", "users",
+		relationColumn{Name: "id", Key: true, TypeOID: int8OID},
+		relationColumn{Name: "email", TypeOID: 25},
+		relationColumn{Name: "bio", TypeOID: 25},
+	)
+	toasted := messageBuilder{insertMessageType}.uint32(1).byte('N').uint16(3).
+		byte(textColumn).uint32(1).byte('2').
+		byte(nullColumn).
+		byte(unchangedToastColumn)
+
+	t.Run("transaction", func(t *testing.T) {
+		checkpoints := &mockCheckpoints{}
+		var handled []Transaction
+		r := newReplicator(checkpoints, func(_ context.Context, tx Transaction) error {
+			handled = append(handled, tx)
+			return nil
+		})
+
+		for lsn, msg := range [][]byte{
+			beginMsg(400, commitTime, 7),
+			users,
+			insertMsg(1, text("1"), text("a@example.com"), nil),
+			toasted,
+		} {
+			committed, err := r.handleMessage(context.Background(), LSN(lsn+100), msg)
+			require.NoError(t, err)
+			require.False(t, committed)
+		}
+		committed, err := r.handleMessage(context.Background(), 400, commitMsg(400, 420, commitTime))
+		require.NoError(t, err)
+		require.True(t, committed)
+
+		require.Equal(t, []Transaction{{
+			XID:        7,
+			EndLSN:     420,
+			CommitTime: commitTime,
+			Changes: []Change{
+				{LSN: 102, Operation: InsertOperation, Table: "public.users", Columns: map[string]any{"id": int64(1), "email": "a@example.com", "bio": nil}, Key: []string{"1"}},
+				{LSN: 103, Operation: InsertOperation, Table: "public.users", Columns: map[string]any{"id": int64(2), "email": nil}, Key: []string{"2"}},
+			},
+		}}, handled)
+		require.Equal(t, LSN(420), checkpoints.lsn)
+		require.Equal(t, LSN(420), r.stored)
+
+		t.Run("already stored", func(t *testing.T) {
+			_, err := r.handleMessage(context.Background(), 300, beginMsg(400, commitTime, 7))
+			require.NoError(t, err)
+			committed, err := r.handleMessage(context.Background(), 400, commitMsg(400, 420, commitTime))
+			require.NoError(t, err)
+			require.False(t, committed)
+			require.Len(t, handled, 1)
+		})
+	})
+
+	t.Run("large transaction", func(t *testing.T) {
+		checkpoints := &mockCheckpoints{}
+		var handled []Transaction
+		r := newReplicator(checkpoints, func(_ context.Context, tx Transaction) error {
+			handled = append(handled, tx)
+			return nil
+		})
+		r.maxChanges = 2
+
+		_, err := r.handleMessage(context.Background(), 100, beginMsg(400, commitTime, 8))
+		require.NoError(t, err)
+		_, err = r.handleMessage(context.Background(), 101, users)
+		require.NoError(t, err)
+		for i := range 5 {
+			_, err := r.handleMessage(context.Background(), LSN(102+i), insertMsg(1, text(strconv.Itoa(i)), nil, nil))
+			require.NoError(t, err)
+		}
+		require.Len(t, handled, 2, "changes are handed over in chunks before the commit")
+		require.Zero(t, checkpoints.lsn, "chunks are not checkpointed")
+
+		committed, err := r.handleMessage(context.Background(), 400, commitMsg(400, 420, commitTime))
+		require.NoError(t, err)
+		require.True(t, committed)
+		require.Len(t, handled, 3)
+		for i, tx := range handled {
+			require.EqualValues(t, 8, tx.XID)
+			if i < 2 {
+				require.Len(t, tx.Changes, 2)
+				require.Zero(t, tx.EndLSN)
+			}
+		}
+		require.Len(t, handled[2].Changes, 1)
+		require.Equal(t, LSN(420), handled[2].EndLSN)
+		require.Equal(t, LSN(420), checkpoints.lsn)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		checkpoints := &mockCheckpoints{}
+		r := newReplicator(checkpoints, func(context.Context, Transaction) error {
+			return errors.New("handler error")
+		})
+		_, err := r.handleMessage(context.Background(), 100, beginMsg(200, commitTime, 7))
+		require.NoError(t, err)
+		_, err = r.handleMessage(context.Background(), 200, commitMsg(200, 220, commitTime))
+		require.ErrorContains(t, err, "handler error")
+		require.Zero(t, checkpoints.lsn)
+		require.Zero(t, r.stored)
+	})
+
+	t.Run("checkpoint error", func(t *testing.T) {
+		checkpoints := &mockCheckpoints{setErr: errors.New("checkpoint error")}
+		r := newReplicator(checkpoints, func(context.Context, Transaction) error { return nil })
+		_, err := r.handleMessage(context.Background(), 100, beginMsg(200, commitTime, 7))
+		require.NoError(t, err)
+		_, err = r.handleMessage(context.Background(), 200, commitMsg(200, 220, commitTime))
+		require.ErrorContains(t, err, "checkpoint error")
+		require.Zero(t, r.stored)
+	})
+
+	t.Run("unknown relation", func(t *testing.T) {
+		r := newReplicator(&mockCheckpoints{}, nil)
+		_, err := r.handleMessage(context.Background(), 100, beginMsg(200, commitTime, 7))
+		require.NoError(t, err)
+		_, err = r.handleMessage(context.Background(), 100, insertMsg(2, text("1")))
+		require.Error(t, err)
+	})
+
+	t.Run("outside of a transaction", func(t *testing.T) {
+		r := newReplicator(&mockCheckpoints{}, nil)
+		_, err := r.handleMessage(context.Background(), 100, users)
+		require.NoError(t, err)
+		_, err = r.handleMessage(context.Background(), 100, insertMsg(1, text("1"), nil, nil))
+		require.Error(t, err)
+		_, err = r.handleMessage(context.Background(), 200, commitMsg(200, 220, commitTime))
+		require.Error(t, err)
+	})
+}
+
+func TestReplicator(t *testing.T) {
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err)
+	postgresContainer, err := postgres.Setup(pool, t, postgres.WithOptions("wal_level=logical"))
+	require.NoError(t, err)
+	db := postgresContainer.DB
+	require.NoError(t, (&migrator.Migrator{
+		Handle:          db,
+		MigrationsTable: "node_migrations",
+	}).Migrate("node"))
+
+	_, err = db.Exec(`CREATE TABLE users (id BIGINT PRIMARY KEY, email TEXT, active BOOLEAN)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE PUBLICATION rudder FOR TABLE users`)
+	require.NoError(t, err)
+
+	conf := Config{
+		ConnectionString: postgresContainer.DBDsn,
+		Publication:      "rudder",
+		SlotName:         "rudder_slot",
+	}
+	checkpoints := NewCheckpoints(dpostgres.NewTxRunner(db))
+
+	// run replicates until the expected number of changes is handled and checkpointed
+	run := func(t *testing.T, changes int) []Change {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var (
+			mu      sync.Mutex
+			handled []Change
+			endLSN  LSN
+		)
+		r := NewReplicator("source_id", conf, checkpoints, func(_ context.Context, tx Transaction) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, tx.Changes...)
+			endLSN = tx.EndLSN
+			return nil
+		}, 10, 100*time.Millisecond, logger.NOP)
+		done := make(chan error, 1)
+		go func() { done <- r.Run(ctx) }()
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			lsn, err := checkpoints.Get(ctx, "source_id", "rudder_slot")
+			require.NoError(t, err)
+			return len(handled) >= changes && lsn == endLSN
+		}, 30*time.Second, 100*time.Millisecond)
+		cancel()
+		require.NoError(t, <-done)
+		mu.Lock()
+		defer mu.Unlock()
+		return handled
+	}
+
+	// creating the slot before any change, so that the following changes are streamed
+	_, err = db.Exec(`SELECT pg_create_logical_replication_slot('rudder_slot', 'pgoutput')`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO users VALUES (1, 'a@example.com', true), (2, NULL, false)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE users SET email = 'b@example.com' WHERE id = 2`)
+	require.NoError(t, err)
+
+	changes := run(t, 3)
+	require.Len(t, changes, 3)
+	require.Equal(t, InsertOperation, changes[0].Operation)
+	require.Equal(t, "public.users", changes[0].Table)
+	require.Equal(t, map[string]any{"id": int64(1), "email": "a@example.com", "active": true}, changes[0].Columns)
+	require.Equal(t, []string{"1"}, changes[0].Key)
+	require.Equal(t, UpdateOperation, changes[2].Operation)
+	require.Equal(t, map[string]any{"id": int64(2), "email": "b@example.com", "active": false}, changes[2].Columns)
+
+	lsn, err := checkpoints.Get(context.Background(), "source_id", "rudder_slot")
+	require.NoError(t, err)
+	require.NotZero(t, lsn)
+
+	t.Run("resumes after the checkpoint", func(t *testing.T) {
+		_, err = db.Exec(`INSERT INTO users VALUES (3, 'c@example.com', true)`)
+		require.NoError(t, err)
+
+		changes := run(t, 1)
+		require.Len(t, changes, 1)
+		require.Equal(t, int64(3), changes[0].Columns["id"])
+	})
+}
@@ -3,6 +3,7 @@ package response
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const (
@@ -14,6 +15,10 @@ const (
 	InvalidRequestMethod = "invalid http request method"
 	// TooManyRequests - too many requests
 	TooManyRequests = "max requests limit reached"
+	// SourceTooManyRequests - too many requests for the source
+	SourceTooManyRequests = "max requests limit reached for source"
+	// UserTooManyRequests - too many requests for the user
+	UserTooManyRequests = "max requests limit reached for user"
 	// NoWriteKeyInBasicAuth - Failed to read writeKey from header
 	NoWriteKeyInBasicAuth = "failed to read writekey from header"
 	// NoWriteKeyInQueryParams - Failed to read writeKey from Query Params
@@ -78,6 +83,16 @@ const (
 	NoDestinationIDInHeader = "failed to read destination id from header"
 	// ErrAuthenticatingWebhookRequest = "error occurred while authenticating the webhook request"
 	ErrAuthenticatingWebhookRequest = "error occurred while authenticating the webhook request"
+	// InvalidWebhookSignature - webhook request is not signed by the provider of its source
+	InvalidWebhookSignature = "invalid webhook signature"
+	// InvalidOTLPPayload - otlp payload cannot be decoded or mapped to rudder events
+	InvalidOTLPPayload = "invalid otlp payload"
+	// UnsupportedContentType - request content type is not supported
+	UnsupportedContentType = "unsupported content type"
+	// SchemaViolation - event does not conform to the schema configured for its source
+	SchemaViolation = "event does not conform to the source schema"
+	// PartialSchemaViolation - some events of the request have been rejected for not conforming to their source's schema, while the rest have been accepted
+	PartialSchemaViolation = "events not conforming to the source schema have been rejected"
 
 	transPixelResponse = "\x47\x49\x46\x38\x39\x61\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\x00\x00\x00\x21\xF9\x04" +
 		"\x01\x00\x00\x00\x00\x2C\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02\x44\x01\x00\x3B"
@@ -90,6 +105,8 @@ var statusMap = map[string]status{
 	RequestBodyNil:          {message: RequestBodyNil, code: http.StatusBadRequest},
 	InvalidRequestMethod:    {message: InvalidRequestMethod, code: http.StatusBadRequest},
 	TooManyRequests:         {message: TooManyRequests, code: http.StatusTooManyRequests},
+	SourceTooManyRequests:   {message: SourceTooManyRequests, code: http.StatusTooManyRequests},
+	UserTooManyRequests:     {message: UserTooManyRequests, code: http.StatusTooManyRequests},
 	NoWriteKeyInBasicAuth:   {message: NoWriteKeyInBasicAuth, code: http.StatusUnauthorized},
 	NoWriteKeyInQueryParams: {message: NoWriteKeyInQueryParams, code: http.StatusUnauthorized},
 	RequestBodyReadFailed:   {message: RequestBodyReadFailed, code: http.StatusInternalServerError},
@@ -105,6 +122,10 @@ var statusMap = map[string]status{
 	InvalidDestinationID:    {message: InvalidDestinationID, code: http.StatusBadRequest},
 	NoDestinationIDInHeader: {message: NoDestinationIDInHeader, code: http.StatusBadRequest},
 	InvalidStreamMessage:    {message: InvalidStreamMessage, code: http.StatusBadRequest},
+	InvalidOTLPPayload:      {message: InvalidOTLPPayload, code: http.StatusBadRequest},
+	UnsupportedContentType:  {message: UnsupportedContentType, code: http.StatusUnsupportedMediaType},
+	SchemaViolation:         {message: SchemaViolation, code: http.StatusBadRequest},
+	PartialSchemaViolation:  {message: PartialSchemaViolation, code: http.StatusOK},
 
 	// webhook specific status
 	InvalidWebhookSource:                           {message: InvalidWebhookSource, code: http.StatusNotFound},
@@ -123,6 +144,7 @@ var statusMap = map[string]status{
 	GatewayTimeout:                                 {message: GatewayTimeout, code: http.StatusGatewayTimeout},
 	ServiceUnavailable:                             {message: ServiceUnavailable, code: http.StatusServiceUnavailable},
 	ErrAuthenticatingWebhookRequest:                {message: ErrAuthenticatingWebhookRequest, code: http.StatusInternalServerError},
+	InvalidWebhookSignature:                        {message: InvalidWebhookSignature, code: http.StatusUnauthorized},
 }
 
 // status holds the gateway response status message and code
@@ -131,8 +153,19 @@ type status struct {
 	code    int
 }
 
+// detailsSeparator separates a status key from its details, see [WithDetails]
+const detailsSeparator = ": "
+
+// WithDetails appends details to a status key. The status code of the key is preserved, while the details are included in the status message.
+func WithDetails(key, details string) string {
+	return key + detailsSeparator + details
+}
+
 func GetStatus(key string) string {
-	if status, ok := statusMap[key]; ok {
+	if status, ok := lookupStatus(key); ok {
+		if _, details, found := strings.Cut(key, detailsSeparator); found && status.message != key {
+			return status.message + detailsSeparator + details
+		}
 		return status.message
 	}
 	return key
@@ -143,12 +176,40 @@ func GetPixelResponse() string {
 }
 
 func GetErrorStatusCode(key string) int {
-	if status, ok := statusMap[key]; ok {
+	if status, ok := lookupStatus(key); ok {
 		return status.code
 	}
 	return http.StatusInternalServerError
 }
 
+// IsPartialSuccess returns true if the status key reports events of a request which have been rejected,
+// while the rest of the request's events have been accepted
+func IsPartialSuccess(key string) bool {
+	status, ok := lookupStatus(key)
+	return ok && status.code == http.StatusOK && status.message != Ok
+}
+
+// Details returns the details appended to a status key through [WithDetails], or an empty string if there are none
+func Details(key string) string {
+	if _, ok := statusMap[key]; ok {
+		return ""
+	}
+	_, details, _ := strings.Cut(key, detailsSeparator)
+	return details
+}
+
+// lookupStatus looks up the status of a key, ignoring any details appended to it through [WithDetails]
+func lookupStatus(key string) (status, bool) {
+	if status, ok := statusMap[key]; ok {
+		return status, true
+	}
+	if k, _, found := strings.Cut(key, detailsSeparator); found {
+		status, ok := statusMap[k]
+		return status, ok
+	}
+	return status{}, false
+}
+
 func MakeResponse(msg string) string {
 	return fmt.Sprintf(`{"msg": %q}`, msg)
 }
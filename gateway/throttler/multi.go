@@ -0,0 +1,164 @@
+package throttler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// multiLimiter applies GCRA limits to several keys at once: tokens are taken from every key only if none of their limits is reached,
+// so that requests rejected by one of the limits don't count against the others.
+type multiLimiter interface {
+	// allow takes the tokens of every limit and returns -1, unless a limit is reached, in which case no token is taken
+	// and the index of the limit is returned
+	allow(ctx context.Context, limits []keyLimit) (int, error)
+	// giveBack returns the tokens taken by a successful allow call, e.g. if the request is dropped by another limit
+	giveBack(ctx context.Context, limits []keyLimit) error
+}
+
+// keyLimit is the limit of a key, allowing up to limit events in a window, along with the number of events to take
+type keyLimit struct {
+	key    string
+	cost   int64
+	limit  int64
+	window time.Duration
+}
+
+// increment returns the increase of the key's theoretical arrival time for the events taken, in microseconds
+func (l keyLimit) increment() int64 {
+	return l.cost * max(l.window.Microseconds()/l.limit, 1)
+}
+
+// newInMemoryMultiLimiter returns a multi limiter keeping the limits' state in memory, i.e. limits apply per gateway node
+func newInMemoryMultiLimiter() *inMemoryMultiLimiter {
+	return &inMemoryMultiLimiter{
+		now:  time.Now,
+		tats: make(map[string]int64),
+	}
+}
+
+type inMemoryMultiLimiter struct {
+	now func() time.Time
+
+	mu          sync.Mutex
+	tats        map[string]int64 // theoretical arrival times of keys in microseconds
+	lastCleanup int64
+}
+
+func (l *inMemoryMultiLimiter) allow(_ context.Context, limits []keyLimit) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now().UnixMicro()
+	l.cleanup(now)
+
+	tats := make([]int64, len(limits))
+	for i, limit := range limits {
+		tat := max(l.tats[limit.key], now) + limit.increment()
+		if tat-now > limit.window.Microseconds() {
+			return i, nil
+		}
+		tats[i] = tat
+	}
+	for i, limit := range limits {
+		l.tats[limit.key] = tats[i]
+	}
+	return -1, nil
+}
+
+func (l *inMemoryMultiLimiter) giveBack(_ context.Context, limits []keyLimit) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now().UnixMicro()
+	for _, limit := range limits {
+		tat, ok := l.tats[limit.key]
+		if !ok {
+			continue
+		}
+		if tat -= limit.increment(); tat > now {
+			l.tats[limit.key] = tat
+		} else {
+			delete(l.tats, limit.key)
+		}
+	}
+	return nil
+}
+
+// cleanup deletes the keys whose limits are fully replenished, at most once per minute
+func (l *inMemoryMultiLimiter) cleanup(now int64) {
+	if now-l.lastCleanup < time.Minute.Microseconds() {
+		return
+	}
+	l.lastCleanup = now
+	for key, tat := range l.tats {
+		if tat <= now {
+			delete(l.tats, key)
+		}
+	}
+}
+
+// allowScript checks the limits of all the keys before taking their tokens, in a single round trip.
+// ARGV holds the increment and the window of every key, in microseconds.
+var allowScript = redis.NewScript(`
+redis.replicate_commands()
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local tats = {}
+for i, key in ipairs(KEYS) do
+	local tat = math.max(tonumber(redis.call('GET', key)) or now, now) + tonumber(ARGV[2 * i - 1])
+	if tat - now > tonumber(ARGV[2 * i]) then
+		return i - 1
+	end
+	tats[i] = tat
+end
+for i, key in ipairs(KEYS) do
+	redis.call('SET', key, string.format('%.0f', tats[i]), 'PX', math.ceil((tats[i] - now) / 1000))
+end
+return -1
+`)
+
+// giveBackScript returns the tokens taken from the keys. ARGV holds the increment of every key, in microseconds.
+var giveBackScript = redis.NewScript(`
+redis.replicate_commands()
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+for i, key in ipairs(KEYS) do
+	local tat = tonumber(redis.call('GET', key))
+	if tat then
+		tat = tat - tonumber(ARGV[i])
+		if tat > now then
+			redis.call('SET', key, string.format('%.0f', tat), 'PX', math.ceil((tat - now) / 1000))
+		else
+			redis.call('DEL', key)
+		end
+	end
+end
+return 0
+`)
+
+// redisMultiLimiter keeps the limits' state in redis, i.e. limits apply across gateway nodes.
+// Keys of the same call need to share the same hash tag for redis cluster, e.g. "{sourceId}".
+type redisMultiLimiter struct {
+	client redis.Scripter
+}
+
+func (l *redisMultiLimiter) allow(ctx context.Context, limits []keyLimit) (int, error) {
+	keys := make([]string, 0, len(limits))
+	args := make([]any, 0, 2*len(limits))
+	for _, limit := range limits {
+		keys = append(keys, limit.key)
+		args = append(args, limit.increment(), limit.window.Microseconds())
+	}
+	return allowScript.Run(ctx, l.client, keys, args...).Int()
+}
+
+func (l *redisMultiLimiter) giveBack(ctx context.Context, limits []keyLimit) error {
+	keys := make([]string, 0, len(limits))
+	args := make([]any, 0, len(limits))
+	for _, limit := range limits {
+		keys = append(keys, limit.key)
+		args = append(args, limit.increment())
+	}
+	return giveBackScript.Run(ctx, l.client, keys, args...).Err()
+}
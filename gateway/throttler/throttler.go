@@ -5,16 +5,31 @@ package throttler
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/go-redis/redis/v8"
+
 	"github.com/rudderlabs/rudder-go-kit/config"
 	"github.com/rudderlabs/rudder-go-kit/stats"
 	"github.com/rudderlabs/rudder-go-kit/throttling"
 )
 
 const (
-	throttlingAlgoTypeGCRA = "gcra"
+	throttlingAlgoTypeGCRA           = "gcra"
+	throttlingAlgoTypeRedisGCRA      = "redis-gcra"
+	throttlingAlgoTypeRedisSortedSet = "redis-sorted-set"
+)
+
+// limit dimensions, used as prefixes of the throttlers' keys
+const (
+	workspaceLimit = "workspace"
+	// SourceLimit is the events limit of a source
+	SourceLimit = "source"
+	// UserLimit is the events limit of every user of a source
+	UserLimit = "user"
 )
 
 type Limiter interface {
@@ -23,13 +38,20 @@ type Limiter interface {
 }
 
 type Throttler interface {
+	// CheckLimitReached returns true if the workspace has reached its events limit
 	CheckLimitReached(context context.Context, workspaceId string, eventCount int64) (bool, error)
+	// CheckSourceAndUserLimitsReached checks the events limit of the source along with the ones of the users (identified by their userId
+	// and/or anonymousId) sending the events, provided as the number of events of every user.
+	// Tokens are taken only if none of the limits is reached, otherwise [SourceLimit] or [UserLimit] is returned.
+	// The returned function gives the taken tokens back, e.g. if the request is dropped by another limit.
+	CheckSourceAndUserLimitsReached(ctx context.Context, sourceId string, userEventCounts map[string]int64) (string, func(context.Context) error, error)
 }
 
 type Factory struct {
 	Stats        stats.Stats
 	limiter      Limiter
-	throttlers   map[string]*throttler // map key is the workspaceId
+	multiLimiter multiLimiter          // for source and user limits
+	throttlers   map[string]*throttler // map key is the limit dimension followed by the workspaceId or sourceId
 	throttlersMu sync.Mutex
 }
 
@@ -46,28 +68,83 @@ func New(stats stats.Stats) (*Factory, error) {
 }
 
 func (f *Factory) CheckLimitReached(context context.Context, workspaceId string, eventCount int64) (bool, error) {
-	t := f.get(workspaceId)
+	t := f.get(workspaceLimit, workspaceId)
 	return t.checkLimitReached(context, workspaceId, eventCount)
 }
 
-func (f *Factory) get(workspaceId string) *throttler {
+func (f *Factory) CheckSourceAndUserLimitsReached(ctx context.Context, sourceId string, userEventCounts map[string]int64) (string, func(context.Context) error, error) {
+	var (
+		limits     []keyLimit
+		dimensions []string // of the limits
+	)
+	// keys are hash tagged by source, so that they can be checked at once on redis cluster
+	if t := f.get(SourceLimit, sourceId); t.config.limit > 0 {
+		var eventCount int64
+		for _, count := range userEventCounts {
+			eventCount += count
+		}
+		limits = append(limits, keyLimit{key: SourceLimit + ":{" + sourceId + "}", cost: eventCount, limit: t.config.limit, window: t.config.window})
+		dimensions = append(dimensions, SourceLimit)
+	}
+	if t := f.get(UserLimit, sourceId); t.config.limit > 0 {
+		for _, userId := range slices.Sorted(maps.Keys(userEventCounts)) {
+			limits = append(limits, keyLimit{key: UserLimit + ":{" + sourceId + "}:" + userId, cost: userEventCounts[userId], limit: t.config.limit, window: t.config.window})
+			dimensions = append(dimensions, UserLimit)
+		}
+	}
+
+	noTokens := func(context.Context) error { return nil }
+	if len(limits) == 0 {
+		return "", noTokens, nil
+	}
+	reached, err := f.multiLimiter.allow(ctx, limits)
+	if err != nil {
+		return "", noTokens, fmt.Errorf("could not limit: %w", err)
+	}
+	if reached >= 0 {
+		return dimensions[reached], noTokens, nil
+	}
+	return "", func(ctx context.Context) error { return f.multiLimiter.giveBack(ctx, limits) }, nil
+}
+
+func (f *Factory) get(dimension, id string) *throttler {
 	f.throttlersMu.Lock()
 	defer f.throttlersMu.Unlock()
-	if t, ok := f.throttlers[workspaceId]; ok {
+	key := dimension + ":" + id
+	if t, ok := f.throttlers[key]; ok {
 		return t
 	}
 
 	var conf throttlingConfig
-	conf.readThrottlingConfig(workspaceId)
-	f.throttlers[workspaceId] = &throttler{
+	switch dimension {
+	case workspaceLimit:
+		conf.readThrottlingConfig(id)
+	default:
+		conf.readDimensionThrottlingConfig(dimension, id)
+	}
+	f.throttlers[key] = &throttler{
 		limiter: f.limiter,
 		config:  conf,
 	}
-	return f.throttlers[workspaceId]
+	return f.throttlers[key]
 }
 
 func (f *Factory) initThrottlerFactory() error {
+	var redisClient *redis.Client
+	if config.IsSet("Gateway.throttler.redis.addr") {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:     config.GetString("Gateway.throttler.redis.addr", "localhost:6379"),
+			Username: config.GetString("Gateway.throttler.redis.username", ""),
+			Password: config.GetString("Gateway.throttler.redis.password", ""),
+		})
+	}
+
 	throttlingAlgorithm := config.GetString("Gateway.throttler.algorithm", throttlingAlgoTypeGCRA)
+	if throttlingAlgorithm == throttlingAlgoTypeRedisGCRA || throttlingAlgorithm == throttlingAlgoTypeRedisSortedSet {
+		if redisClient == nil {
+			return fmt.Errorf("redis client is nil with algorithm %s", throttlingAlgorithm)
+		}
+	}
 
 	var (
 		err  error
@@ -80,6 +157,10 @@ func (f *Factory) initThrottlerFactory() error {
 	switch throttlingAlgorithm {
 	case throttlingAlgoTypeGCRA:
 		l, err = throttling.New(append(opts, throttling.WithInMemoryGCRA(0))...)
+	case throttlingAlgoTypeRedisGCRA:
+		l, err = throttling.New(append(opts, throttling.WithRedisGCRA(redisClient, 0))...)
+	case throttlingAlgoTypeRedisSortedSet:
+		l, err = throttling.New(append(opts, throttling.WithRedisSortedSet(redisClient))...)
 	default:
 		return fmt.Errorf("invalid throttling algorithm: %s", throttlingAlgorithm)
 	}
@@ -88,6 +169,11 @@ func (f *Factory) initThrottlerFactory() error {
 	}
 
 	f.limiter = l
+	if redisClient != nil && throttlingAlgorithm != throttlingAlgoTypeGCRA {
+		f.multiLimiter = &redisMultiLimiter{client: redisClient}
+	} else {
+		f.multiLimiter = newInMemoryMultiLimiter()
+	}
 
 	return nil
 }
@@ -130,6 +216,23 @@ func (c *throttlingConfig) readThrottlingConfig(workspaceID string) {
 	}
 }
 
+// readDimensionThrottlingConfig reads the throttling configuration of a source or of the users of a source, e.g.
+//
+//	RateLimit.source.<sourceID>.eventLimit, RateLimit.source.eventLimit
+//	RateLimit.user.<sourceID>.eventLimit, RateLimit.user.eventLimit
+//
+// A limit of zero (the default) means that no limit is applied. Limits are shared by gateway nodes only with the redis algorithms.
+func (c *throttlingConfig) readDimensionThrottlingConfig(dimension, sourceID string) {
+	c.limit = config.GetInt64Var(0, 1,
+		fmt.Sprintf("RateLimit.%s.%s.eventLimit", dimension, sourceID),
+		fmt.Sprintf("RateLimit.%s.eventLimit", dimension),
+	)
+	c.window = config.GetDurationVar(60, time.Second,
+		fmt.Sprintf("RateLimit.%s.%s.rateLimitWindow", dimension, sourceID),
+		fmt.Sprintf("RateLimit.%s.rateLimitWindow", dimension),
+	)
+}
+
 func getWindowInSecs(d time.Duration) int64 {
 	return int64(d.Seconds())
 }
@@ -5,10 +5,13 @@ import (
 	"testing"
 	"time"
 
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/require"
 
 	"github.com/rudderlabs/rudder-go-kit/config"
 	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/testhelper/docker/resource/redis"
 	"github.com/rudderlabs/rudder-go-kit/throttling"
 )
 
@@ -103,3 +106,142 @@ func Test_readThrottlingConfig(t *testing.T) {
 	require.Equal(t, conf.limit, int64(eventLimit))
 	require.Equal(t, conf.window, time.Duration(timeWindow)*time.Minute)
 }
+
+func TestGateway_FactorySourceAndUserLimits(t *testing.T) {
+	config.Set("RateLimit.source.eventLimit", 10)
+	config.Set("RateLimit.source.limited-source.eventLimit", 5)
+	config.Set("RateLimit.user.eventLimit", 2)
+	defer config.Reset()
+	rateLimiter, err := New(stats.NOP)
+	require.NoError(t, err)
+
+	// passed returns the number of requests allowed out of the provided number of requests, checked one by one
+	passed := func(t *testing.T, requests int, sourceId string, userEventCounts map[string]int64) int {
+		var passed int
+		for range requests {
+			limit, _, err := rateLimiter.CheckSourceAndUserLimitsReached(context.TODO(), sourceId, userEventCounts)
+			require.NoError(t, err)
+			if limit == "" {
+				passed++
+			}
+		}
+		return passed
+	}
+
+	t.Run("source", func(t *testing.T) {
+		p := passed(t, 20, "limited-source", map[string]int64{"user-1": 1, "user-2": 1, "user-3": 1})
+		require.Equal(t, 1, p, "source specific limit should apply")
+
+		p = passed(t, 20, "other-source", map[string]int64{"user-1": 1, "user-2": 1, "user-3": 1, "user-4": 1, "user-5": 1})
+		require.Equal(t, 2, p, "default source limit should apply")
+
+		limit, _, err := rateLimiter.CheckSourceAndUserLimitsReached(context.TODO(), "other-source", map[string]int64{"user-6": 1})
+		require.NoError(t, err)
+		require.Equal(t, SourceLimit, limit)
+	})
+
+	t.Run("user", func(t *testing.T) {
+		require.Equal(t, 2, passed(t, 10, "source", map[string]int64{"user-1": 1}))
+
+		limit, _, err := rateLimiter.CheckSourceAndUserLimitsReached(context.TODO(), "source", map[string]int64{"user-1": 1})
+		require.NoError(t, err)
+		require.Equal(t, UserLimit, limit)
+		require.Equal(t, 1, passed(t, 1, "source", map[string]int64{"user-2": 1}), "users should be limited independently")
+		require.Equal(t, 1, passed(t, 1, "other-source-2", map[string]int64{"user-1": 1}), "users should be limited independently per source")
+	})
+
+	t.Run("tokens are taken only if no limit is reached", func(t *testing.T) {
+		require.Equal(t, 2, passed(t, 2, "source-2", map[string]int64{"user-1": 1}))
+		// user-1 is limited, so the events of user-2 don't count against the source limit
+		require.Equal(t, 0, passed(t, 20, "source-2", map[string]int64{"user-1": 1, "user-2": 1}))
+		require.Equal(t, 2, passed(t, 20, "source-2", map[string]int64{"user-2": 1, "user-3": 1}))
+		for _, userId := range []string{"user-4", "user-5", "user-6", "user-7"} {
+			require.Equal(t, 1, passed(t, 1, "source-2", map[string]int64{userId: 1}), "6 out of 10 source tokens should have been taken")
+		}
+		limit, _, err := rateLimiter.CheckSourceAndUserLimitsReached(context.TODO(), "source-2", map[string]int64{"user-8": 1})
+		require.NoError(t, err)
+		require.Equal(t, SourceLimit, limit)
+	})
+
+	t.Run("tokens can be given back", func(t *testing.T) {
+		limit, giveBack, err := rateLimiter.CheckSourceAndUserLimitsReached(context.TODO(), "source-3", map[string]int64{"user-1": 2})
+		require.NoError(t, err)
+		require.Empty(t, limit)
+		require.NoError(t, giveBack(context.TODO()))
+		require.Equal(t, 1, passed(t, 2, "source-3", map[string]int64{"user-1": 2}))
+	})
+
+	t.Run("no limit configured", func(t *testing.T) {
+		config.Reset()
+		rateLimiter, err := New(stats.NOP)
+		require.NoError(t, err)
+		for range 100 {
+			limit, _, err := rateLimiter.CheckSourceAndUserLimitsReached(context.TODO(), "source", map[string]int64{"user": 1000})
+			require.NoError(t, err)
+			require.Empty(t, limit)
+		}
+	})
+
+	t.Run("redis algorithm requires a redis address", func(t *testing.T) {
+		config.Reset()
+		config.Set("Gateway.throttler.algorithm", "redis-gcra")
+		_, err := New(stats.NOP)
+		require.Error(t, err)
+	})
+}
+
+func TestMultiLimiter(t *testing.T) {
+	limits := func(userCost int64) []keyLimit {
+		return []keyLimit{
+			{key: "source:{source}", cost: 1 + userCost, limit: 10, window: time.Second},
+			{key: "user:{source}:user", cost: userCost, limit: 4, window: time.Second},
+		}
+	}
+	test := func(t *testing.T, l multiLimiter, advance func(time.Duration)) {
+		ctx := context.Background()
+		reached, err := l.allow(ctx, limits(4))
+		require.NoError(t, err)
+		require.Equal(t, -1, reached)
+
+		reached, err = l.allow(ctx, limits(1))
+		require.NoError(t, err)
+		require.Equal(t, 1, reached, "user limit should be reached")
+
+		// no token has been taken from the source limit by the rejected call
+		reached, err = l.allow(ctx, limits(0))
+		require.NoError(t, err)
+		require.Equal(t, -1, reached)
+		require.NoError(t, l.giveBack(ctx, limits(0)))
+		for range 5 {
+			reached, err = l.allow(ctx, []keyLimit{limits(0)[0]})
+			require.NoError(t, err)
+			require.Equal(t, -1, reached)
+		}
+		reached, err = l.allow(ctx, limits(0))
+		require.NoError(t, err)
+		require.Equal(t, 0, reached, "source limit should be reached")
+
+		advance(time.Second)
+		require.NoError(t, l.giveBack(ctx, limits(4)))
+		reached, err = l.allow(ctx, limits(4))
+		require.NoError(t, err)
+		require.Equal(t, -1, reached, "limits should be replenished")
+	}
+
+	t.Run("in memory", func(t *testing.T) {
+		now := time.Now()
+		l := newInMemoryMultiLimiter()
+		l.now = func() time.Time { return now }
+		test(t, l, func(d time.Duration) { now = now.Add(d) })
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		pool, err := dockertest.NewPool("")
+		require.NoError(t, err)
+		redisResource, err := redis.Setup(context.Background(), pool, t)
+		require.NoError(t, err)
+		client := goredis.NewClient(&goredis.Options{Addr: redisResource.Addr})
+		defer func() { _ = client.Close() }()
+		test(t, &redisMultiLimiter{client: client}, func(d time.Duration) { time.Sleep(d) })
+	})
+}
@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"context"
 	"net/http"
 
 	gwtypes "github.com/rudderlabs/rudder-server/gateway/types"
@@ -20,6 +21,7 @@ type RequestHandler interface {
 // Contains some payload, could be of several types(batch, identify, track etc.)
 // has a `done` channel that receives a response(error if any)
 type webRequestT struct {
+	ctx            context.Context
 	done           chan<- string
 	reqType        string
 	requestPayload []byte
@@ -66,4 +68,6 @@ type jobFromReq struct {
 	numEvents int
 	botEvents int
 	version   string
+	// schemaRejected are the events of the request rejected for violating their source's schema
+	schemaRejected []eventSchemaViolations
 }
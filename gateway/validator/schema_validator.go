@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/tidwall/gjson"
+	"github.com/xeipuuv/gojsonschema"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+)
+
+// SchemaEnforcementMode defines how events violating their schema are handled
+type SchemaEnforcementMode string
+
+const (
+	// SchemaEnforcementReject rejects events violating their schema
+	SchemaEnforcementReject SchemaEnforcementMode = "reject"
+	// SchemaEnforcementFlag accepts events violating their schema, flagging them with the violations found
+	SchemaEnforcementFlag SchemaEnforcementMode = "flag"
+
+	// defaultSchemaKey is the key of the schema applied to events without a more specific schema
+	defaultSchemaKey = "*"
+)
+
+// SchemaViolation describes a single violation of an event's schema, using the same format as tracking plan violations
+type SchemaViolation struct {
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+	Property string `json:"property"`
+}
+
+// SchemaValidator validates events against the JSON Schema definitions configured for a source.
+// Schemas are looked up by event name for track events and by event type for all other events.
+type SchemaValidator struct {
+	mode    SchemaEnforcementMode
+	schemas map[string]*gojsonschema.Schema
+}
+
+// NewSchemaValidator compiles the schemas of the provided configuration. It returns nil if schema enforcement is disabled.
+func NewSchemaValidator(conf backendconfig.SchemaEnforcementT) (*SchemaValidator, error) {
+	if !conf.Enabled || len(conf.Schemas) == 0 {
+		return nil, nil
+	}
+	mode := SchemaEnforcementMode(conf.Mode)
+	switch mode {
+	case SchemaEnforcementReject, SchemaEnforcementFlag:
+	case "":
+		mode = SchemaEnforcementFlag
+	default:
+		return nil, fmt.Errorf("invalid schema enforcement mode: %q", conf.Mode)
+	}
+	v := &SchemaValidator{
+		mode:    mode,
+		schemas: make(map[string]*gojsonschema.Schema, len(conf.Schemas)),
+	}
+	for key, definition := range conf.Schemas {
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(definition))
+		if err != nil {
+			return nil, fmt.Errorf("compiling schema for %q: %w", key, err)
+		}
+		v.schemas[key] = schema
+	}
+	return v, nil
+}
+
+// Mode returns the enforcement mode of the validator
+func (v *SchemaValidator) Mode() SchemaEnforcementMode {
+	return v.mode
+}
+
+// Validate validates an event against its schema, returning the violations found, if any
+func (v *SchemaValidator) Validate(event map[string]any) ([]SchemaViolation, error) {
+	eventType, _ := event["type"].(string)
+	eventName, _ := event["event"].(string)
+	return v.validate(eventType, eventName, gojsonschema.NewGoLoader(event))
+}
+
+// ValidatePayload validates an event's payload against its schema, returning the violations found, if any
+func (v *SchemaValidator) ValidatePayload(payload []byte) ([]SchemaViolation, error) {
+	eventType := gjson.GetBytes(payload, "type").String()
+	eventName := gjson.GetBytes(payload, "event").String()
+	return v.validate(eventType, eventName, gojsonschema.NewBytesLoader(payload))
+}
+
+func (v *SchemaValidator) validate(eventType, eventName string, event gojsonschema.JSONLoader) ([]SchemaViolation, error) {
+	schema := v.schemaFor(eventType, eventName)
+	if schema == nil {
+		return nil, nil
+	}
+	result, err := schema.Validate(event)
+	if err != nil {
+		return nil, fmt.Errorf("validating event against schema: %w", err)
+	}
+	if result.Valid() {
+		return nil, nil
+	}
+	violations := make([]SchemaViolation, 0, len(result.Errors()))
+	for _, re := range result.Errors() {
+		violations = append(violations, SchemaViolation{
+			Type:     re.Type(),
+			Message:  re.Description(),
+			Property: re.Field(),
+		})
+	}
+	return violations, nil
+}
+
+// schemaFor returns the most specific schema for the event, or nil if no schema applies
+func (v *SchemaValidator) schemaFor(eventType, eventName string) *gojsonschema.Schema {
+	if eventType == "track" && eventName != "" {
+		if schema, ok := v.schemas[eventName]; ok {
+			return schema
+		}
+	}
+	if schema, ok := v.schemas[eventType]; ok && eventType != "" {
+		return schema
+	}
+	return v.schemas[defaultSchemaKey]
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -10,6 +11,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/rudderlabs/rudder-schemas/go/stream"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
 )
 
 func TestMessageIDValidator(t *testing.T) {
@@ -178,3 +181,90 @@ func TestMediator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaValidator(t *testing.T) {
+	conf := backendconfig.SchemaEnforcementT{
+		Enabled: true,
+		Mode:    "reject",
+		Schemas: map[string]json.RawMessage{
+			"Order Completed": json.RawMessage(`{"type":"object","required":["properties"],"properties":{"properties":{"type":"object","required":["order_id"],"properties":{"order_id":{"type":"string"}}}}}`),
+			"identify":        json.RawMessage(`{"type":"object","required":["userId"]}`),
+			"*":               json.RawMessage(`{"type":"object","required":["anonymousId"]}`),
+		},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		v, err := NewSchemaValidator(backendconfig.SchemaEnforcementT{Schemas: conf.Schemas})
+		require.NoError(t, err)
+		require.Nil(t, v)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := NewSchemaValidator(backendconfig.SchemaEnforcementT{Enabled: true, Mode: "invalid", Schemas: conf.Schemas})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := NewSchemaValidator(backendconfig.SchemaEnforcementT{Enabled: true, Schemas: map[string]json.RawMessage{"*": json.RawMessage(`{"type":1}`)}})
+		require.Error(t, err)
+	})
+
+	t.Run("default mode", func(t *testing.T) {
+		v, err := NewSchemaValidator(backendconfig.SchemaEnforcementT{Enabled: true, Schemas: conf.Schemas})
+		require.NoError(t, err)
+		require.Equal(t, SchemaEnforcementFlag, v.Mode())
+	})
+
+	v, err := NewSchemaValidator(conf)
+	require.NoError(t, err)
+	require.Equal(t, SchemaEnforcementReject, v.Mode())
+
+	tests := []struct {
+		name       string
+		payload    string
+		violations []SchemaViolation
+	}{
+		{
+			name:    "valid track event with event specific schema",
+			payload: `{"type":"track","event":"Order Completed","properties":{"order_id":"1"}}`,
+		},
+		{
+			name:    "invalid track event with event specific schema",
+			payload: `{"type":"track","event":"Order Completed","properties":{"order_id":1}}`,
+			violations: []SchemaViolation{
+				{Type: "invalid_type", Message: "Invalid type. Expected: string, given: integer", Property: "properties.order_id"},
+			},
+		},
+		{
+			name:    "invalid identify event with event type schema",
+			payload: `{"type":"identify","anonymousId":"a"}`,
+			violations: []SchemaViolation{
+				{Type: "required", Message: "userId is required", Property: "(root)"},
+			},
+		},
+		{
+			name:    "valid track event with default schema",
+			payload: `{"type":"track","event":"Product Viewed","anonymousId":"a"}`,
+		},
+		{
+			name:    "invalid page event with default schema",
+			payload: `{"type":"page"}`,
+			violations: []SchemaViolation{
+				{Type: "required", Message: "anonymousId is required", Property: "(root)"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := v.ValidatePayload([]byte(tt.payload))
+			require.NoError(t, err)
+			require.Equal(t, tt.violations, violations)
+
+			var event map[string]any
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &event))
+			violations, err = v.Validate(event)
+			require.NoError(t, err)
+			require.Equal(t, tt.violations, violations)
+		})
+	}
+}
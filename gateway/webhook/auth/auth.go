@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	gwtypes "github.com/rudderlabs/rudder-server/gateway/types"
 
@@ -58,6 +59,10 @@ func (wa *WebhookAuth) AuthHandler(next http.HandlerFunc) http.HandlerFunc {
 			wa.onFailure(w, r, response.SourceDisabled, arctx)
 			return
 		}
+		if err := VerifySignature(r, arctx.Source.WebhookSignature, time.Now()); err != nil {
+			wa.onFailure(w, r, SignatureErrorMessage(err), arctx)
+			return
+		}
 		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), gwtypes.CtxParamAuthRequestContext, arctx)))
 	}
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
 	"github.com/rudderlabs/rudder-server/gateway/response"
 
 	gwtypes "github.com/rudderlabs/rudder-server/gateway/types"
@@ -105,6 +106,24 @@ func TestNewWebhookAuth(t *testing.T) {
 			expectedResponseCode:    http.StatusBadRequest,
 			expectedResponseMessage: fmt.Sprintf("%s\n", response.InvalidWriteKey),
 		},
+		{
+			name: "invalid webhook signature",
+			mockOnFailure: func(w http.ResponseWriter, r *http.Request, errorMessage string, _ *gwtypes.AuthRequestContext) {
+				http.Error(w, errorMessage, http.StatusUnauthorized)
+			},
+			mockAuthReqCtxForWriteKey: func(writeKey string) (*gwtypes.AuthRequestContext, error) {
+				return &gwtypes.AuthRequestContext{
+					SourceCategory: "webhook",
+					SourceEnabled:  true,
+					Source: backendconfig.SourceT{
+						WebhookSignature: backendconfig.WebhookSignatureT{Provider: ProviderGitHub, Secret: "secret"},
+					},
+				}, nil
+			},
+			writeKey:                "signed-source-key",
+			expectedResponseCode:    http.StatusUnauthorized,
+			expectedResponseMessage: fmt.Sprintf("%s\n", response.InvalidWebhookSignature),
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" // nolint:gosec // twilio and some generic providers still sign their payloads with HMAC-SHA1
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+)
+
+// Well-known webhook providers whose signatures can be verified
+const (
+	ProviderStripe  = "stripe"
+	ProviderGitHub  = "github"
+	ProviderShopify = "shopify"
+	ProviderTwilio  = "twilio"
+	ProviderGeneric = "generic"
+)
+
+// defaultTolerance is the tolerance of signed timestamps if the source doesn't configure any
+const defaultTolerance = 5 * time.Minute
+
+var (
+	ErrInvalidSignatureConfig = errors.New("invalid webhook signature config")
+	ErrMissingSignature       = errors.New("missing webhook signature")
+	ErrInvalidSignature       = errors.New("invalid webhook signature")
+	ErrSignatureExpired       = errors.New("webhook signature timestamp outside of tolerance")
+)
+
+// VerifySignature verifies the signature of a webhook request according to the signature settings of its source.
+// Requests of sources without any provider are not verified. The body of the request is restored after being read.
+func VerifySignature(r *http.Request, conf backendconfig.WebhookSignatureT, now time.Time) error {
+	if conf.Provider == "" {
+		return nil
+	}
+	if conf.Secret == "" {
+		return fmt.Errorf("%w: no secret", ErrInvalidSignatureConfig)
+	}
+	tolerance := defaultTolerance
+	if conf.Tolerance != "" {
+		var err error
+		if tolerance, err = time.ParseDuration(conf.Tolerance); err != nil {
+			return fmt.Errorf("%w: tolerance: %v", ErrInvalidSignatureConfig, err)
+		}
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return fmt.Errorf("reading request body: %w", err)
+		}
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	switch strings.ToLower(conf.Provider) {
+	case ProviderStripe:
+		return verifyStripe(r, body, conf.Secret, tolerance, now)
+	case ProviderGitHub:
+		signature, ok := strings.CutPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+		if !ok {
+			return ErrMissingSignature
+		}
+		return compareSignature(signature, computeHMAC(sha256.New, conf.Secret, body), hex.DecodeString)
+	case ProviderShopify:
+		return compareSignature(r.Header.Get("X-Shopify-Hmac-Sha256"), computeHMAC(sha256.New, conf.Secret, body), base64.StdEncoding.DecodeString)
+	case ProviderTwilio:
+		return compareSignature(r.Header.Get("X-Twilio-Signature"), computeHMAC(sha1.New, conf.Secret, twilioPayload(r, body)), base64.StdEncoding.DecodeString)
+	case ProviderGeneric:
+		return verifyGeneric(r, body, conf, tolerance, now)
+	default:
+		return fmt.Errorf("%w: unknown provider %q", ErrInvalidSignatureConfig, conf.Provider)
+	}
+}
+
+// SignatureErrorMessage returns the response error message of a signature verification error. Forged requests
+// are rejected as unauthorized, whereas misconfigured sources fail as internal errors, so that providers retry them.
+func SignatureErrorMessage(err error) string {
+	if errors.Is(err, ErrMissingSignature) || errors.Is(err, ErrInvalidSignature) || errors.Is(err, ErrSignatureExpired) {
+		return response.InvalidWebhookSignature
+	}
+	return response.ErrAuthenticatingWebhookRequest
+}
+
+// verifyStripe verifies the Stripe-Signature header, i.e. "t=<timestamp>,v1=<signature>[,v1=<signature>...]",
+// signing "<timestamp>.<payload>". Any of the v1 signatures may match, since Stripe signs with all of the secrets being rolled.
+func verifyStripe(r *http.Request, body []byte, secret string, tolerance time.Duration, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(r.Header.Get("Stripe-Signature"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+	if err := checkTimestamp(timestamp, tolerance, now); err != nil {
+		return err
+	}
+	expected := computeHMAC(sha256.New, secret, append([]byte(timestamp+"."), body...))
+	for _, signature := range signatures {
+		if compareSignature(signature, expected, hex.DecodeString) == nil {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func verifyGeneric(r *http.Request, body []byte, conf backendconfig.WebhookSignatureT, tolerance time.Duration, now time.Time) error {
+	if conf.Header == "" {
+		return fmt.Errorf("%w: no signature header", ErrInvalidSignatureConfig)
+	}
+	var newHash func() hash.Hash
+	switch strings.ToLower(conf.Algorithm) {
+	case "", "sha256":
+		newHash = sha256.New
+	case "sha1":
+		newHash = sha1.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return fmt.Errorf("%w: unknown algorithm %q", ErrInvalidSignatureConfig, conf.Algorithm)
+	}
+	var decode func(string) ([]byte, error)
+	switch strings.ToLower(conf.Encoding) {
+	case "", "hex":
+		decode = hex.DecodeString
+	case "base64":
+		decode = base64.StdEncoding.DecodeString
+	default:
+		return fmt.Errorf("%w: unknown encoding %q", ErrInvalidSignatureConfig, conf.Encoding)
+	}
+
+	payload := body
+	if conf.TimestampHeader != "" {
+		timestamp := r.Header.Get(conf.TimestampHeader)
+		if timestamp == "" {
+			return ErrMissingSignature
+		}
+		if err := checkTimestamp(timestamp, tolerance, now); err != nil {
+			return err
+		}
+		payload = append([]byte(timestamp+"."), body...)
+	}
+	signature, ok := strings.CutPrefix(r.Header.Get(conf.Header), conf.Prefix)
+	if !ok {
+		return ErrMissingSignature
+	}
+	return compareSignature(signature, computeHMAC(newHash, conf.Secret, payload), decode)
+}
+
+// twilioPayload returns the payload signed by Twilio, i.e. the full url of the request followed by
+// the sorted keys and values of its form parameters, if any.
+func twilioPayload(r *http.Request, body []byte) []byte {
+	scheme := "https"
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	} else if r.TLS == nil {
+		scheme = "http"
+	}
+	var payload bytes.Buffer
+	payload.WriteString(scheme + "://" + r.Host + r.URL.RequestURI())
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		params, _ := url.ParseQuery(string(body))
+		keys := make([]string, 0, len(params))
+		for key := range params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			values := params[key]
+			sort.Strings(values)
+			for _, value := range values {
+				payload.WriteString(key + value)
+			}
+		}
+	}
+	return payload.Bytes()
+}
+
+// checkTimestamp returns an error if the unix timestamp isn't within the tolerance of now
+func checkTimestamp(timestamp string, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: timestamp %q", ErrInvalidSignature, timestamp)
+	}
+	if diff := now.Sub(time.Unix(seconds, 0)); diff > tolerance || diff < -tolerance {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func computeHMAC(newHash func() hash.Hash, secret string, payload []byte) []byte {
+	mac := hmac.New(newHash, []byte(secret))
+	_, _ = mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// compareSignature compares the encoded signature with the expected one in constant time
+func compareSignature(signature string, expected []byte, decode func(string) ([]byte, error)) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	decoded, err := decode(strings.TrimSpace(signature))
+	if err != nil || !hmac.Equal(decoded, expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const (
+		secret = "whsec_test"
+		body   = `{"id":"evt_1","type":"order.created"}`
+	)
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	sign := func(newHash func() hash.Hash, payload string) []byte {
+		mac := hmac.New(newHash, []byte(secret))
+		_, _ = mac.Write([]byte(payload))
+		return mac.Sum(nil)
+	}
+	newRequest := func(body string, headers map[string]string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "https://example.com/v1/webhook?writeKey=wk", strings.NewReader(body))
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		return r
+	}
+
+	stripe := backendconfig.WebhookSignatureT{Provider: ProviderStripe, Secret: secret}
+	github := backendconfig.WebhookSignatureT{Provider: ProviderGitHub, Secret: secret}
+	shopify := backendconfig.WebhookSignatureT{Provider: ProviderShopify, Secret: secret}
+	twilio := backendconfig.WebhookSignatureT{Provider: ProviderTwilio, Secret: secret}
+	generic := backendconfig.WebhookSignatureT{
+		Provider:        ProviderGeneric,
+		Secret:          secret,
+		Header:          "X-Signature",
+		Encoding:        "base64",
+		Prefix:          "v1=",
+		TimestampHeader: "X-Timestamp",
+		Tolerance:       "1m",
+	}
+	stripeSignature := hex.EncodeToString(sign(sha256.New, timestamp+"."+body))
+	twilioForm := "To=%2B123&From=%2B456&Body=hello"
+	twilioSignature := base64.StdEncoding.EncodeToString(sign(sha1.New, "https://example.com/v1/webhook?writeKey=wk"+"Bodyhello"+"From+456"+"To+123"))
+	genericSignature := "v1=" + base64.StdEncoding.EncodeToString(sign(sha256.New, timestamp+"."+body))
+
+	tests := []struct {
+		name     string
+		conf     backendconfig.WebhookSignatureT
+		body     string
+		headers  map[string]string
+		expected error
+	}{
+		{name: "no provider", body: body},
+		{
+			name:    "stripe",
+			conf:    stripe,
+			body:    body,
+			headers: map[string]string{"Stripe-Signature": "t=" + timestamp + ",v1=" + stripeSignature},
+		},
+		{
+			name:    "stripe with rolled secrets",
+			conf:    stripe,
+			body:    body,
+			headers: map[string]string{"Stripe-Signature": "t=" + timestamp + ",v1=" + hex.EncodeToString([]byte("old")) + ",v1=" + stripeSignature},
+		},
+		{
+			name:     "stripe forged",
+			conf:     stripe,
+			body:     `{"id":"evt_2"}`,
+			headers:  map[string]string{"Stripe-Signature": "t=" + timestamp + ",v1=" + stripeSignature},
+			expected: ErrInvalidSignature,
+		},
+		{
+			name:     "stripe replayed",
+			conf:     stripe,
+			body:     body,
+			headers:  map[string]string{"Stripe-Signature": "t=" + strconv.FormatInt(now.Add(-time.Hour).Unix(), 10) + ",v1=" + stripeSignature},
+			expected: ErrSignatureExpired,
+		},
+		{
+			name:     "stripe unsigned",
+			conf:     stripe,
+			body:     body,
+			expected: ErrMissingSignature,
+		},
+		{
+			name:    "github",
+			conf:    github,
+			body:    body,
+			headers: map[string]string{"X-Hub-Signature-256": "sha256=" + hex.EncodeToString(sign(sha256.New, body))},
+		},
+		{
+			name:     "github forged",
+			conf:     github,
+			body:     body,
+			headers:  map[string]string{"X-Hub-Signature-256": "sha256=" + hex.EncodeToString(sign(sha256.New, "{}"))},
+			expected: ErrInvalidSignature,
+		},
+		{
+			name:    "shopify",
+			conf:    shopify,
+			body:    body,
+			headers: map[string]string{"X-Shopify-Hmac-Sha256": base64.StdEncoding.EncodeToString(sign(sha256.New, body))},
+		},
+		{
+			name:     "shopify malformed",
+			conf:     shopify,
+			body:     body,
+			headers:  map[string]string{"X-Shopify-Hmac-Sha256": "not base64!"},
+			expected: ErrInvalidSignature,
+		},
+		{
+			name:    "twilio",
+			conf:    twilio,
+			body:    twilioForm,
+			headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded", "X-Twilio-Signature": twilioSignature},
+		},
+		{
+			name:     "twilio forged",
+			conf:     twilio,
+			body:     "To=%2B123&From=%2B789&Body=hello",
+			headers:  map[string]string{"Content-Type": "application/x-www-form-urlencoded", "X-Twilio-Signature": twilioSignature},
+			expected: ErrInvalidSignature,
+		},
+		{
+			name:    "generic",
+			conf:    generic,
+			body:    body,
+			headers: map[string]string{"X-Signature": genericSignature, "X-Timestamp": timestamp},
+		},
+		{
+			name:     "generic without timestamp",
+			conf:     generic,
+			body:     body,
+			headers:  map[string]string{"X-Signature": genericSignature},
+			expected: ErrMissingSignature,
+		},
+		{
+			name:     "generic without prefix",
+			conf:     generic,
+			body:     body,
+			headers:  map[string]string{"X-Signature": strings.TrimPrefix(genericSignature, "v1="), "X-Timestamp": timestamp},
+			expected: ErrMissingSignature,
+		},
+		{
+			name:     "no secret",
+			conf:     backendconfig.WebhookSignatureT{Provider: ProviderGitHub},
+			body:     body,
+			expected: ErrInvalidSignatureConfig,
+		},
+		{
+			name:     "unknown provider",
+			conf:     backendconfig.WebhookSignatureT{Provider: "paypal", Secret: secret},
+			body:     body,
+			expected: ErrInvalidSignatureConfig,
+		},
+		{
+			name:     "generic unknown algorithm",
+			conf:     backendconfig.WebhookSignatureT{Provider: ProviderGeneric, Secret: secret, Header: "X-Signature", Algorithm: "md5"},
+			body:     body,
+			expected: ErrInvalidSignatureConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRequest(tt.body, tt.headers)
+			err := VerifySignature(r, tt.conf, now)
+			if tt.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.expected)
+			}
+			restored, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, tt.body, string(restored), "the body is restored")
+		})
+	}
+
+	require.Equal(t, response.InvalidWebhookSignature, SignatureErrorMessage(ErrSignatureExpired))
+	require.Equal(t, response.ErrAuthenticatingWebhookRequest, SignatureErrorMessage(ErrInvalidSignatureConfig))
+}
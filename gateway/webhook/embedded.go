@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/requesttojson"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/gateway/response"
+	utils "github.com/rudderlabs/rudder-server/gateway/webhook/embedded"
+	"github.com/rudderlabs/rudder-server/gateway/webhook/embedded/generic"
+	"github.com/rudderlabs/rudder-server/gateway/webhook/embedded/shopify"
+	"github.com/rudderlabs/rudder-server/gateway/webhook/embedded/stripe"
+	"github.com/rudderlabs/rudder-server/services/transformer"
+)
+
+type embeddedTransformer func(req utils.Request) utils.Response
+
+// embeddedTransformerImpls are the in-process transformations of sources, keyed by their lowercase source type.
+// They are only used if enabled by Gateway.webhook.embedded.<sourceType>.enabled, and are verified against
+// the transformer's unless Gateway.webhook.embedded.<sourceType>.verify is disabled.
+var embeddedTransformerImpls = map[string]embeddedTransformer{
+	"webhook": generic.Transform,
+	"shopify": shopify.Transform,
+	"stripe":  stripe.Transform,
+}
+
+// embeddedTransformerConfig are the settings of the embedded transformation of a source type
+type embeddedTransformerConfig struct {
+	enabled config.ValueLoader[bool]
+	verify  config.ValueLoader[bool]
+}
+
+// volatileEventKeys are the keys of the events generated randomly by either transformation, which are not compared
+var volatileEventKeys = []string{"anonymousId", "messageId"}
+
+// transformBatch transforms the event requests of a batch, either by the transformer or in process
+func (bt *batchWebhookTransformerT) transformBatch(sourceType, adapterVersion string, webRequests []*webhookT, eventRequests, payloads [][]byte, transformerURL string) transformerBatchResponseT {
+	key := strings.ToLower(sourceType)
+	impl, ok := embeddedTransformerImpls[key]
+	conf := bt.webhook.config.embeddedTransformers[key]
+	if !ok || !conf.enabled.Load() {
+		return bt.transform(payloads, transformerURL)
+	}
+	if conf.verify.Load() {
+		legacyResponse := bt.transform(payloads, transformerURL)
+		if legacyResponse.batchError == nil {
+			embeddedResponse := bt.transformEmbedded(impl, sourceType, adapterVersion, webRequests, eventRequests)
+			bt.compareEmbedded(sourceType, webRequests, embeddedResponse, legacyResponse)
+		}
+		return legacyResponse
+	}
+	return bt.transformEmbedded(impl, sourceType, adapterVersion, webRequests, eventRequests)
+}
+
+func (bt *batchWebhookTransformerT) transformEmbedded(impl embeddedTransformer, sourceType, adapterVersion string, webRequests []*webhookT, eventRequests [][]byte) transformerBatchResponseT {
+	tags := stats.Tags{"sourceType": sourceType}
+	defer bt.statsFactory.NewTaggedStat("webhook_embedded_transform_time", stats.TimerType, tags).RecordDuration()()
+	bt.statsFactory.NewTaggedStat("webhook_embedded_transform_events", stats.CountType, tags).Count(len(eventRequests))
+
+	batchResponse := transformerBatchResponseT{responses: make([]transformerResponse, len(eventRequests))}
+	for i, eventRequest := range eventRequests {
+		req, err := newEmbeddedRequest(adapterVersion, eventRequest)
+		if err != nil {
+			batchResponse.responses[i] = transformerResponse{Err: err.Error(), StatusCode: http.StatusBadRequest}
+			continue
+		}
+		req.Source = webRequests[i].authContext.Source
+		resp := impl(req)
+		batchResponse.responses[i] = transformerResponse{Output: resp.Output, Err: resp.Err, StatusCode: resp.StatusCode}
+	}
+	return batchResponse
+}
+
+// newEmbeddedRequest returns the embedded request of an event request, prepared for the adapter's version
+func newEmbeddedRequest(adapterVersion string, eventRequest []byte) (utils.Request, error) {
+	var req utils.Request
+	if adapterVersion == transformer.V1 {
+		if err := jsonrs.Unmarshal(eventRequest, &req.Body); err != nil || req.Body == nil {
+			return req, errors.New(response.InvalidJSON)
+		}
+		return req, nil
+	}
+	var requestJSON requesttojson.RequestJSON
+	if err := jsonrs.Unmarshal(eventRequest, &requestJSON); err != nil {
+		return req, errors.New(response.InvalidJSON)
+	}
+	if err := jsonrs.Unmarshal([]byte(requestJSON.Body), &req.Body); err != nil || req.Body == nil {
+		return req, errors.New(response.InvalidJSON)
+	}
+	req.Query = requestJSON.Query
+	req.Headers = requestJSON.Headers
+	return req, nil
+}
+
+// compareEmbedded compares the responses of the embedded transformation with the transformer's, logging the paths
+// of the differing fields of a limited number of mismatching requests, without their values
+func (bt *batchWebhookTransformerT) compareEmbedded(sourceType string, webRequests []*webhookT, embeddedResponse, legacyResponse transformerBatchResponseT) {
+	var matched, mismatched int
+	for i := range legacyResponse.responses {
+		differences := responseDifferences(embeddedResponse.responses[i], legacyResponse.responses[i])
+		if len(differences) == 0 {
+			matched++
+			continue
+		}
+		mismatched++
+		if bt.webhook.embeddedLoggedMismatches.Add(1) > int64(bt.webhook.config.embeddedMaxLoggedMismatches.Load()) {
+			continue
+		}
+		bt.webhook.logger.Warnn("Embedded webhook transformation differs from the transformer's",
+			obskit.SourceType(sourceType),
+			obskit.SourceID(webRequests[i].sourceID),
+			logger.NewStringField("differences", strings.Join(differences, ", ")),
+		)
+	}
+	tags := stats.Tags{"sourceType": sourceType}
+	bt.statsFactory.NewTaggedStat("webhook_embedded_transform_matched_events", stats.CountType, tags).Count(matched)
+	bt.statsFactory.NewTaggedStat("webhook_embedded_transform_mismatched_events", stats.CountType, tags).Count(mismatched)
+}
+
+// responseDifferences returns the paths of the fields of the responses that differ
+func responseDifferences(embedded, legacy transformerResponse) []string {
+	if embedded.StatusCode != legacy.StatusCode {
+		return []string{"statusCode"}
+	}
+	if (embedded.Err == "") != (legacy.Err == "") {
+		return []string{"error"}
+	}
+	if legacy.OutputToSource != nil {
+		return []string{"outputToSource"}
+	}
+	embeddedOutput, legacyOutput := normalizeOutput(embedded.Output), normalizeOutput(legacy.Output)
+	embeddedBatch, _ := embeddedOutput["batch"].([]any)
+	legacyBatch, _ := legacyOutput["batch"].([]any)
+	if len(embeddedBatch) != len(legacyBatch) {
+		return []string{"output.batch"}
+	}
+	var differences []string
+	for i := range legacyBatch {
+		embeddedEvent, _ := embeddedBatch[i].(map[string]any)
+		legacyEvent, _ := legacyBatch[i].(map[string]any)
+		for _, key := range volatileEventKeys {
+			if (embeddedEvent[key] == nil) == (legacyEvent[key] == nil) {
+				delete(embeddedEvent, key)
+				delete(legacyEvent, key)
+			}
+		}
+		differences = append(differences, differingPaths(fmt.Sprintf("output.batch.%d", i), embeddedEvent, legacyEvent)...)
+	}
+	return differences
+}
+
+// normalizeOutput returns the output as decoded from json, so that its values are comparable with the transformer's
+func normalizeOutput(output map[string]any) map[string]any {
+	var normalized map[string]any
+	if data, err := jsonrs.Marshal(output); err == nil {
+		_ = jsonrs.Unmarshal(data, &normalized)
+	}
+	return normalized
+}
+
+func differingPaths(path string, a, b any) []string {
+	aMap, aOk := a.(map[string]any)
+	bMap, bOk := b.(map[string]any)
+	if !aOk || !bOk {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return []string{path}
+	}
+	keys := make(map[string]struct{}, len(aMap)+len(bMap))
+	for k := range aMap {
+		keys[k] = struct{}{}
+	}
+	for k := range bMap {
+		keys[k] = struct{}{}
+	}
+	var paths []string
+	for k := range keys {
+		paths = append(paths, differingPaths(path+"."+k, aMap[k], bMap[k])...)
+	}
+	sort.Strings(paths)
+	return paths
+}
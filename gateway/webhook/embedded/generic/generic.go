@@ -0,0 +1,19 @@
+// Package generic transforms the requests of the generic JSON webhook source, i.e. "webhook",
+// to track events of the request's payload
+package generic
+
+import (
+	utils "github.com/rudderlabs/rudder-server/gateway/webhook/embedded"
+)
+
+const eventName = "webhook_source_event"
+
+func Transform(req utils.Request) utils.Response {
+	event := map[string]any{
+		"type":       "track",
+		"event":      eventName,
+		"properties": req.Body,
+	}
+	utils.SetUser(event, "")
+	return utils.Success(event)
+}
@@ -0,0 +1,28 @@
+package generic
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	utils "github.com/rudderlabs/rudder-server/gateway/webhook/embedded"
+)
+
+func TestTransform(t *testing.T) {
+	body := map[string]any{"hello": "world", "nested": map[string]any{"a": 1.0}}
+	resp := Transform(utils.Request{Body: body})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Empty(t, resp.Err)
+
+	batch := resp.Output["batch"].([]any)
+	require.Len(t, batch, 1)
+	event := batch[0].(map[string]any)
+	require.NotEmpty(t, event["anonymousId"])
+	delete(event, "anonymousId")
+	require.Equal(t, map[string]any{
+		"type":       "track",
+		"event":      "webhook_source_event",
+		"properties": body,
+	}, event)
+}
@@ -0,0 +1,86 @@
+// Package shopify transforms the webhook requests of Shopify, whose topic is either found in their
+// X-Shopify-Topic header or in their topic query parameter, e.g. "orders_create", to events.
+// Customer topics are transformed to identify events, all other topics to track events.
+package shopify
+
+import (
+	"strings"
+
+	utils "github.com/rudderlabs/rudder-server/gateway/webhook/embedded"
+)
+
+const (
+	integration = "SHOPIFY"
+	libraryName = "RudderStack Shopify Cloud"
+)
+
+var identifyTopics = map[string]struct{}{
+	"customers_create": {},
+	"customers_update": {},
+}
+
+// trackEvents are the names of the track events of well-known topics, other topics are named after their title case
+var trackEvents = map[string]string{
+	"carts_create":               "Cart Create",
+	"carts_update":               "Cart Update",
+	"checkouts_create":           "Checkout Started",
+	"checkouts_update":           "Checkout Updated",
+	"checkouts_delete":           "Checkout Deleted",
+	"orders_create":              "Order Created",
+	"orders_updated":             "Order Updated",
+	"orders_paid":                "Order Paid",
+	"orders_cancelled":           "Order Cancelled",
+	"orders_fulfilled":           "Order Fulfilled",
+	"orders_delete":              "Order Deleted",
+	"orders_partially_fulfilled": "Order Partially Fulfilled",
+}
+
+func Transform(req utils.Request) utils.Response {
+	topic := req.Headers.Get("X-Shopify-Topic")
+	if topic == "" {
+		topic = utils.QueryParameter(req, "topic")
+	}
+	topic = utils.Topic(topic)
+	if topic == "" {
+		return utils.Failure("Invalid topic")
+	}
+
+	properties := utils.Properties(req.Body)
+	context := utils.Context(integration, libraryName)
+	context["topic"] = topic
+	event := map[string]any{
+		"context":      context,
+		"integrations": map[string]any{integration: true},
+	}
+	if _, ok := identifyTopics[topic]; ok {
+		event["type"] = "identify"
+		event["traits"] = properties
+		utils.SetUser(event, utils.ID(properties["id"]))
+	} else {
+		event["type"] = "track"
+		event["event"] = trackEventName(topic)
+		event["properties"] = properties
+		customer, _ := properties["customer"].(map[string]any)
+		utils.SetUser(event, utils.ID(customer["id"]))
+	}
+	for _, key := range []string{"updated_at", "created_at"} {
+		if timestamp, ok := properties[key].(string); ok && timestamp != "" {
+			event["originalTimestamp"] = timestamp
+			break
+		}
+	}
+	return utils.Success(event)
+}
+
+func trackEventName(topic string) string {
+	if name, ok := trackEvents[topic]; ok {
+		return name
+	}
+	words := strings.Split(topic, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
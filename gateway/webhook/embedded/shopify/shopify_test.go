@@ -0,0 +1,74 @@
+package shopify
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	utils "github.com/rudderlabs/rudder-server/gateway/webhook/embedded"
+)
+
+func TestTransform(t *testing.T) {
+	context := func(topic string) map[string]any {
+		return map[string]any{
+			"library":     map[string]any{"name": "RudderStack Shopify Cloud", "version": "1.0.0"},
+			"integration": map[string]any{"name": "SHOPIFY"},
+			"topic":       topic,
+		}
+	}
+	singleEvent := func(t *testing.T, resp utils.Response) map[string]any {
+		t.Helper()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		batch := resp.Output["batch"].([]any)
+		require.Len(t, batch, 1)
+		return batch[0].(map[string]any)
+	}
+
+	t.Run("order created from query parameters", func(t *testing.T) {
+		body := map[string]any{
+			"id":         5678901234.0,
+			"created_at": "2024-01-01T10:00:00-05:00",
+			"customer":   map[string]any{"id": 7071570313458.0},
+			utils.QueryParametersKey: map[string]any{
+				"topic": []any{"orders_create"},
+			},
+		}
+		event := singleEvent(t, Transform(utils.Request{Body: body}))
+		require.Equal(t, map[string]any{
+			"type":              "track",
+			"event":             "Order Created",
+			"userId":            "7071570313458",
+			"originalTimestamp": "2024-01-01T10:00:00-05:00",
+			"properties":        utils.Properties(body),
+			"context":           context("orders_create"),
+			"integrations":      map[string]any{"SHOPIFY": true},
+		}, event)
+	})
+
+	t.Run("customer from header", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("X-Shopify-Topic", "customers/update")
+		body := map[string]any{"id": 42.0, "email": "john@example.com"}
+		event := singleEvent(t, Transform(utils.Request{Body: body, Headers: headers}))
+		require.Equal(t, "identify", event["type"])
+		require.Equal(t, "42", event["userId"])
+		require.Equal(t, body, event["traits"])
+		require.Equal(t, context("customers_update"), event["context"])
+	})
+
+	t.Run("unknown topic of anonymous user", func(t *testing.T) {
+		headers := http.Header{}
+		headers.Set("X-Shopify-Topic", "fulfillment_events/create")
+		event := singleEvent(t, Transform(utils.Request{Body: map[string]any{"id": 1.0}, Headers: headers}))
+		require.Equal(t, "Fulfillment Events Create", event["event"])
+		require.NotEmpty(t, event["anonymousId"])
+		require.NotContains(t, event, "userId")
+	})
+
+	t.Run("no topic", func(t *testing.T) {
+		resp := Transform(utils.Request{Body: map[string]any{"id": 1.0}})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, "Invalid topic", resp.Err)
+	})
+}
@@ -0,0 +1,58 @@
+// Package stripe transforms the webhook requests of Stripe, i.e. Stripe event objects, to track events
+// named after the event type, e.g. "customer.created", with the event's data object as properties.
+package stripe
+
+import (
+	"time"
+
+	utils "github.com/rudderlabs/rudder-server/gateway/webhook/embedded"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+const (
+	integration = "STRIPE"
+	libraryName = "RudderStack Stripe Cloud"
+)
+
+func Transform(req utils.Request) utils.Response {
+	eventType, _ := req.Body["type"].(string)
+	if eventType == "" {
+		return utils.Failure("Missing event type")
+	}
+	data, _ := req.Body["data"].(map[string]any)
+	object, _ := data["object"].(map[string]any)
+	if object == nil {
+		return utils.Failure("Missing data object")
+	}
+
+	event := map[string]any{
+		"type":         "track",
+		"event":        eventType,
+		"properties":   object,
+		"context":      utils.Context(integration, libraryName),
+		"integrations": map[string]any{integration: true},
+	}
+	if id := utils.ID(req.Body["id"]); id != "" {
+		// stripe retries deliveries with the same event id, which deduplicates them
+		event["messageId"] = id
+	}
+	if created, ok := req.Body["created"].(float64); ok {
+		event["originalTimestamp"] = time.Unix(int64(created), 0).UTC().Format(misc.RFC3339Milli)
+	}
+	utils.SetUser(event, userID(object))
+	return utils.Success(event)
+}
+
+// userID returns the id of the stripe customer of the object, if any
+func userID(object map[string]any) string {
+	if object["object"] == "customer" {
+		return utils.ID(object["id"])
+	}
+	switch customer := object["customer"].(type) {
+	case string:
+		return customer
+	case map[string]any: // expanded customer
+		return utils.ID(customer["id"])
+	}
+	return ""
+}
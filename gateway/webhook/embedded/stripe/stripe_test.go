@@ -0,0 +1,76 @@
+package stripe
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	utils "github.com/rudderlabs/rudder-server/gateway/webhook/embedded"
+)
+
+func TestTransform(t *testing.T) {
+	singleEvent := func(t *testing.T, resp utils.Response) map[string]any {
+		t.Helper()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		batch := resp.Output["batch"].([]any)
+		require.Len(t, batch, 1)
+		return batch[0].(map[string]any)
+	}
+
+	t.Run("charge of customer", func(t *testing.T) {
+		object := map[string]any{"id": "ch_1", "object": "charge", "amount": 1000.0, "customer": "cus_1"}
+		event := singleEvent(t, Transform(utils.Request{Body: map[string]any{
+			"id":      "evt_1",
+			"type":    "charge.succeeded",
+			"created": 1700000000.0,
+			"data":    map[string]any{"object": object},
+		}}))
+		require.Equal(t, map[string]any{
+			"type":              "track",
+			"event":             "charge.succeeded",
+			"messageId":         "evt_1",
+			"userId":            "cus_1",
+			"originalTimestamp": "2023-11-14T22:13:20.000Z",
+			"properties":        object,
+			"context": map[string]any{
+				"library":     map[string]any{"name": "RudderStack Stripe Cloud", "version": "1.0.0"},
+				"integration": map[string]any{"name": "STRIPE"},
+			},
+			"integrations": map[string]any{"STRIPE": true},
+		}, event)
+	})
+
+	t.Run("customer", func(t *testing.T) {
+		event := singleEvent(t, Transform(utils.Request{Body: map[string]any{
+			"type": "customer.created",
+			"data": map[string]any{"object": map[string]any{"id": "cus_2", "object": "customer"}},
+		}}))
+		require.Equal(t, "cus_2", event["userId"])
+		require.NotContains(t, event, "messageId")
+	})
+
+	t.Run("expanded customer", func(t *testing.T) {
+		event := singleEvent(t, Transform(utils.Request{Body: map[string]any{
+			"type": "invoice.paid",
+			"data": map[string]any{"object": map[string]any{"id": "in_1", "customer": map[string]any{"id": "cus_3"}}},
+		}}))
+		require.Equal(t, "cus_3", event["userId"])
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		event := singleEvent(t, Transform(utils.Request{Body: map[string]any{
+			"type": "product.created",
+			"data": map[string]any{"object": map[string]any{"id": "prod_1", "object": "product"}},
+		}}))
+		require.NotEmpty(t, event["anonymousId"])
+		require.NotContains(t, event, "userId")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := Transform(utils.Request{Body: map[string]any{"data": map[string]any{}}})
+		require.Equal(t, utils.Failure("Missing event type"), resp)
+		resp = Transform(utils.Request{Body: map[string]any{"type": "charge.succeeded"}})
+		require.Equal(t, utils.Failure("Missing data object"), resp)
+	})
+}
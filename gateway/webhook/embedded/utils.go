@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+)
+
+// QueryParametersKey is the key of the query parameters added to the body of the webhook requests of the sources
+// whose query parameters are parsed, when sent to the transformer's v1 source endpoints
+const QueryParametersKey = "query_parameters"
+
+// Request is a webhook request of a source, as sent to the source endpoints of the transformer
+type Request struct {
+	// Body is the JSON body of the request, along with its query parameters if they are parsed for the source
+	Body    map[string]any
+	Query   url.Values
+	Headers http.Header
+	Source  backendconfig.SourceT
+}
+
+// Response is the response of a source transformation, in the same format as the transformer's.
+// The events of the request are found in the batch of the output of successful responses.
+type Response struct {
+	Output     map[string]any
+	Err        string
+	StatusCode int
+}
+
+// Success returns the successful response of a request transformed to the events
+func Success(events ...map[string]any) Response {
+	batch := make([]any, len(events))
+	for i := range events {
+		batch[i] = events[i]
+	}
+	return Response{Output: map[string]any{"batch": batch}, StatusCode: http.StatusOK}
+}
+
+// Failure returns the response of a request which cannot be transformed
+func Failure(format string, args ...any) Response {
+	return Response{Err: fmt.Sprintf(format, args...), StatusCode: http.StatusBadRequest}
+}
+
+// Context returns the context of the events of a source's integration
+func Context(integration, libraryName string) map[string]any {
+	return map[string]any{
+		"library": map[string]any{
+			"name":    libraryName,
+			"version": "1.0.0",
+		},
+		"integration": map[string]any{
+			"name": integration,
+		},
+	}
+}
+
+// Properties returns a copy of the body of the request without its query parameters
+func Properties(body map[string]any) map[string]any {
+	properties := make(map[string]any, len(body))
+	for k, v := range body {
+		if k != QueryParametersKey {
+			properties[k] = v
+		}
+	}
+	return properties
+}
+
+// QueryParameter returns the first value of a query parameter of the request, either found in its query
+// or in the query parameters added to its body
+func QueryParameter(req Request, key string) string {
+	if v := req.Query.Get(key); v != "" {
+		return v
+	}
+	params, _ := req.Body[QueryParametersKey].(map[string]any)
+	switch v := params[key].(type) {
+	case string:
+		return v
+	case []any:
+		if len(v) > 0 {
+			s, _ := v[0].(string)
+			return s
+		}
+	}
+	return ""
+}
+
+// ID returns the string form of an id, which are either strings or numbers in the payloads of most sources
+func ID(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return ""
+	}
+}
+
+// SetUser sets the user id of the event, or a random anonymous id if the user is unknown
+func SetUser(event map[string]any, userID string) {
+	if userID != "" {
+		event["userId"] = userID
+		return
+	}
+	event["anonymousId"] = uuid.NewString()
+}
+
+// Topic returns a topic, e.g. "orders/create", in lower snake case, e.g. "orders_create"
+func Topic(topic string) string {
+	return strings.ToLower(strings.NewReplacer("/", "_", "-", "_", ".", "_", " ", "_").Replace(strings.TrimSpace(topic)))
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryParameter(t *testing.T) {
+	req := Request{
+		Body:  map[string]any{QueryParametersKey: map[string]any{"topic": []any{"orders_create"}, "shop": "acme"}},
+		Query: url.Values{"version": []string{"1"}},
+	}
+	require.Equal(t, "orders_create", QueryParameter(req, "topic"))
+	require.Equal(t, "acme", QueryParameter(req, "shop"))
+	require.Equal(t, "1", QueryParameter(req, "version"))
+	require.Empty(t, QueryParameter(req, "missing"))
+	require.Empty(t, QueryParameter(Request{}, "topic"))
+}
+
+func TestProperties(t *testing.T) {
+	body := map[string]any{"id": 1.0, QueryParametersKey: map[string]any{}}
+	require.Equal(t, map[string]any{"id": 1.0}, Properties(body))
+	require.Len(t, body, 2, "the body is left untouched")
+}
+
+func TestID(t *testing.T) {
+	require.Equal(t, "cus_1", ID("cus_1"))
+	require.Equal(t, "7071570313458", ID(7071570313458.0))
+	require.Equal(t, "3", ID(3))
+	require.Empty(t, ID(nil))
+	require.Empty(t, ID(map[string]any{}))
+}
+
+func TestSetUser(t *testing.T) {
+	event := map[string]any{}
+	SetUser(event, "user-1")
+	require.Equal(t, map[string]any{"userId": "user-1"}, event)
+
+	event = map[string]any{}
+	SetUser(event, "")
+	require.NotEmpty(t, event["anonymousId"])
+	require.NotContains(t, event, "userId")
+}
+
+func TestResponses(t *testing.T) {
+	require.Equal(t, Response{Output: map[string]any{"batch": []any{map[string]any{"type": "track"}}}, StatusCode: http.StatusOK}, Success(map[string]any{"type": "track"}))
+	require.Equal(t, Response{Err: "Invalid topic: x", StatusCode: http.StatusBadRequest}, Failure("Invalid topic: %s", "x"))
+	require.Equal(t, "orders_create", Topic(" Orders/Create "))
+}
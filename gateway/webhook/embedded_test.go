@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
+
+	mockWebhook "github.com/rudderlabs/rudder-server/gateway/mocks"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+	gwtypes "github.com/rudderlabs/rudder-server/gateway/types"
+	"github.com/rudderlabs/rudder-server/services/transformer"
+)
+
+func TestEmbeddedWebhookTransformation(t *testing.T) {
+	initWebhook()
+
+	legacyEvent := map[string]any{
+		"type":        "track",
+		"event":       "webhook_source_event",
+		"properties":  map[string]any{"hello": "world"},
+		"anonymousId": "a1b2",
+	}
+	run := func(t *testing.T, verify bool, legacyEvent map[string]any) (*memstats.Store, int64, map[string]any) {
+		var transformerRequests atomic.Int64
+		transformerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			transformerRequests.Add(1)
+			respBody, _ := jsonrs.Marshal([]transformerResponse{{
+				Output:     map[string]any{"batch": []any{legacyEvent}},
+				StatusCode: http.StatusOK,
+			}})
+			_, _ = w.Write(respBody)
+		}))
+		t.Cleanup(transformerServer.Close)
+
+		statsStore, err := memstats.New()
+		require.NoError(t, err)
+		c := config.New()
+		c.Set("Gateway.webhook.maxTransformerProcess", 1)
+		c.Set("Gateway.webhook.embedded.webhook.enabled", true)
+		c.Set("Gateway.webhook.embedded.webhook.verify", verify)
+
+		ctrl := gomock.NewController(t)
+		mockGW := mockWebhook.NewMockGateway(ctrl)
+		mockGW.EXPECT().TrackRequestMetrics("").Times(1)
+		var gwEvent map[string]any
+		mockGW.EXPECT().ProcessTransformedWebhookRequest(gomock.Any(), gomock.Any(), "batch", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ *http.ResponseWriter, _ *http.Request, _ string, payload []byte, _ *gwtypes.AuthRequestContext) string {
+				var output map[string]any
+				require.NoError(t, jsonrs.Unmarshal(payload, &output))
+				gwEvent = output["batch"].([]any)[0].(map[string]any)
+				return ""
+			}).Times(1)
+
+		webhookHandler := Setup(mockGW, transformer.NewNoOpService(), statsStore, c, newSourceStatReporter, func(bt *batchWebhookTransformerT) {
+			bt.sourceTransformAdapter = getMockSourceTransformAdapterFunc(transformerServer.URL)
+		})
+		webhookHandler.Register(sourceDefName)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/webhook", bytes.NewBufferString(sampleJson))
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(req.Context(), gwtypes.CtxParamCallType, "webhook")
+		ctx = context.WithValue(ctx, gwtypes.CtxParamAuthRequestContext, &gwtypes.AuthRequestContext{
+			WriteKey:      sampleWriteKey,
+			SourceDefName: sourceDefName,
+		})
+		webhookHandler.RequestHandler(w, req.WithContext(ctx))
+		require.NoError(t, webhookHandler.Shutdown())
+
+		require.Equal(t, http.StatusOK, w.Result().StatusCode)
+		body, _ := io.ReadAll(w.Result().Body)
+		require.Equal(t, response.Ok, string(body))
+		return statsStore, transformerRequests.Load(), gwEvent
+	}
+	tags := stats.Tags{"sourceType": sourceDefName}
+
+	t.Run("embedded", func(t *testing.T) {
+		statsStore, transformerRequests, gwEvent := run(t, false, legacyEvent)
+		require.Zero(t, transformerRequests, "the transformer is not called")
+		require.Equal(t, "webhook_source_event", gwEvent["event"])
+		require.Equal(t, map[string]any{"hello": "world"}, gwEvent["properties"])
+		require.NotEqual(t, "a1b2", gwEvent["anonymousId"])
+		require.EqualValues(t, 1, statsStore.Get("webhook_embedded_transform_events", tags).LastValue())
+	})
+
+	t.Run("verified", func(t *testing.T) {
+		statsStore, transformerRequests, gwEvent := run(t, true, legacyEvent)
+		require.EqualValues(t, 1, transformerRequests)
+		require.Equal(t, legacyEvent, gwEvent, "the transformer's events are sent to the gateway")
+		require.EqualValues(t, 1, statsStore.Get("webhook_embedded_transform_matched_events", tags).LastValue())
+		require.EqualValues(t, 0, statsStore.Get("webhook_embedded_transform_mismatched_events", tags).LastValue())
+	})
+
+	t.Run("verified with mismatch", func(t *testing.T) {
+		mismatchingEvent := map[string]any{
+			"type":        "track",
+			"event":       "webhook_source_event",
+			"properties":  map[string]any{"hello": "world", "extra": true},
+			"anonymousId": "a1b2",
+		}
+		statsStore, _, gwEvent := run(t, true, mismatchingEvent)
+		require.Equal(t, mismatchingEvent, gwEvent)
+		require.EqualValues(t, 0, statsStore.Get("webhook_embedded_transform_matched_events", tags).LastValue())
+		require.EqualValues(t, 1, statsStore.Get("webhook_embedded_transform_mismatched_events", tags).LastValue())
+	})
+}
+
+func TestResponseDifferences(t *testing.T) {
+	event := func(properties map[string]any) map[string]any {
+		return map[string]any{"type": "track", "properties": properties, "anonymousId": "random"}
+	}
+	output := func(events ...any) map[string]any {
+		return map[string]any{"batch": events}
+	}
+
+	require.Empty(t, responseDifferences(
+		transformerResponse{Output: output(event(map[string]any{"a": 1})), StatusCode: http.StatusOK},
+		transformerResponse{Output: output(map[string]any{"type": "track", "properties": map[string]any{"a": 1.0}, "anonymousId": "other"}), StatusCode: http.StatusOK},
+	), "numbers and volatile keys are normalized")
+	require.Equal(t, []string{"output.batch.0.properties.a", "output.batch.0.properties.b"}, responseDifferences(
+		transformerResponse{Output: output(event(map[string]any{"a": 1, "b": "x"})), StatusCode: http.StatusOK},
+		transformerResponse{Output: output(event(map[string]any{"a": 2})), StatusCode: http.StatusOK},
+	))
+	require.Equal(t, []string{"output.batch.0.anonymousId"}, responseDifferences(
+		transformerResponse{Output: output(event(nil)), StatusCode: http.StatusOK},
+		transformerResponse{Output: output(map[string]any{"type": "track", "properties": nil, "userId": "u1"}), StatusCode: http.StatusOK},
+	)[:1])
+	require.Equal(t, []string{"output.batch"}, responseDifferences(
+		transformerResponse{Output: output(event(nil), event(nil)), StatusCode: http.StatusOK},
+		transformerResponse{Output: output(event(nil)), StatusCode: http.StatusOK},
+	))
+	require.Equal(t, []string{"statusCode"}, responseDifferences(
+		transformerResponse{Err: "Invalid topic", StatusCode: http.StatusBadRequest},
+		transformerResponse{Output: output(event(nil)), StatusCode: http.StatusOK},
+	))
+}
@@ -82,6 +82,16 @@ func Setup(gwHandle Gateway, transformerFeaturesService TransformerFeaturesServi
 			return strings.ToLower(item), struct{}{}
 		},
 	)
+	// Maximum number of requests whose embedded transformation differs from the transformer's that are logged
+	webhook.config.embeddedMaxLoggedMismatches = conf.GetReloadableIntVar(100, 1, "Gateway.webhook.embedded.maxLoggedMismatches")
+	// Whether the embedded transformation of a source type is used, and verified against the transformer's
+	webhook.config.embeddedTransformers = make(map[string]embeddedTransformerConfig, len(embeddedTransformerImpls))
+	for sourceType := range embeddedTransformerImpls {
+		webhook.config.embeddedTransformers[sourceType] = embeddedTransformerConfig{
+			enabled: conf.GetReloadableBoolVar(false, "Gateway.webhook.embedded."+sourceType+".enabled"),
+			verify:  conf.GetReloadableBoolVar(true, "Gateway.webhook.embedded."+sourceType+".verify"),
+		}
+	}
 	// enable webhook v2 handler
 	webhook.config.webhookV2HandlerEnabled = conf.GetBoolVar(true, "Gateway.webhookV2HandlerEnabled")
 
@@ -70,6 +70,9 @@ type HandleT struct {
 	ackCount      atomic.Uint64
 	recvCount     atomic.Uint64
 
+	// embeddedLoggedMismatches is the number of mismatches of the embedded transformations logged so far
+	embeddedLoggedMismatches atomic.Int64
+
 	batchRequestsWg  sync.WaitGroup
 	backgroundWait   func() error
 	backgroundCancel context.CancelFunc
@@ -81,6 +84,10 @@ type HandleT struct {
 		sourceListForParsingParams []string
 		forwardGetRequestForSrcMap map[string]struct{}
 		webhookV2HandlerEnabled    bool
+
+		embeddedMaxLoggedMismatches config.ValueLoader[int]
+		// embeddedTransformers are the settings of the embedded transformations, keyed by their lowercase source type
+		embeddedTransformers map[string]embeddedTransformerConfig
 	}
 	statReporterCreator StatReporterCreator
 	httpClient          retryablehttp.HttpClient
@@ -340,6 +347,7 @@ func (bt *batchWebhookTransformerT) batchTransformLoop() {
 		}
 
 		var payloadArr [][]byte
+		var eventRequests [][]byte
 		var webRequests []*webhookT
 		for _, req := range breq.batchRequest {
 			var payload []byte
@@ -377,6 +385,7 @@ func (bt *batchWebhookTransformerT) batchTransformLoop() {
 			}
 
 			payloadArr = append(payloadArr, payload)
+			eventRequests = append(eventRequests, eventRequest)
 
 			webRequests = append(webRequests, req)
 		}
@@ -393,7 +402,7 @@ func (bt *batchWebhookTransformerT) batchTransformLoop() {
 		bt.stats.sourceStats[breq.sourceType].numEvents.Count(len(payloadArr))
 
 		transformStart := time.Now()
-		batchResponse := bt.transform(payloadArr, transformerURL)
+		batchResponse := bt.transformBatch(breq.sourceType, sourceTransformAdapter.getAdapterVersion(), webRequests, eventRequests, payloadArr, transformerURL)
 
 		// stats
 		bt.stats.sourceStats[breq.sourceType].sourceTransform.Since(transformStart)
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/samber/lo"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/rudderlabs/rudder-go-kit/filemanager"
 	"github.com/rudderlabs/rudder-go-kit/logger"
 	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
 	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
 	"github.com/rudderlabs/rudder-server/processor/types"
 	"github.com/rudderlabs/rudder-server/services/geolocation"
@@ -32,12 +36,28 @@ type Geolocation struct {
 	Postal   string `json:"postal"`
 	Location string `json:"location"`
 	Timezone string `json:"timezone"`
+	// the network information below is only set if asn databases are configured
+	ASN            uint   `json:"asn,omitempty"`
+	Organization   string `json:"organization,omitempty"`
+	ISP            string `json:"isp,omitempty"`
+	ConnectionType string `json:"connectionType,omitempty"`
+	IsHosting      bool   `json:"isHosting,omitempty"`
 }
 
+// defaultHostingASNs are the autonomous systems of the major cloud providers, i.e. Amazon, Google, Microsoft,
+// DigitalOcean, OVH, Hetzner, Linode, Oracle and Alibaba
+var defaultHostingASNs = []string{"16509", "14618", "15169", "396982", "8075", "14061", "16276", "24940", "63949", "31898", "45102"}
+
 type geoEnricher struct {
-	fetcher geolocation.GeoFetcher
-	logger  logger.Logger
-	stats   stats.Stats
+	// fetcherMu guards the fetcher, which is replaced when its databases are reloaded
+	fetcherMu sync.RWMutex
+	fetcher   geolocation.GeoFetcher
+	conf      *config.Config
+	logger    logger.Logger
+	stats     stats.Stats
+
+	reloadCancel context.CancelFunc
+	reloadDone   chan struct{}
 }
 
 func NewGeoEnricher(conf *config.Config, log logger.Logger, statClient stats.Stats) (PipelineEnricher, error) {
@@ -47,17 +67,130 @@ func NewGeoEnricher(conf *config.Config, log logger.Logger, statClient stats.Sta
 	if err != nil {
 		return nil, fmt.Errorf("downloading instance of maxmind db: %w", err)
 	}
+	var asnDBPaths []string
+	for _, db := range geolocationASNDBs(conf) {
+		asnDBPath, err := downloadStorageDB(context.Background(), conf, log, db)
+		if err != nil {
+			return nil, fmt.Errorf("downloading instance of asn db: %w", err)
+		}
+		asnDBPaths = append(asnDBPaths, asnDBPath)
+	}
 
-	fetcher, err := geolocation.NewMaxmindDBReader(dbPath)
+	fetcher, err := newGeoFetcher(conf, dbPath, asnDBPaths)
 	if err != nil {
-		return nil, fmt.Errorf("creating new instance of maxmind's geolocation db reader: %w", err)
+		return nil, err
 	}
 
-	return &geoEnricher{
+	e := &geoEnricher{
 		fetcher: fetcher,
+		conf:    conf,
 		stats:   statClient,
 		logger:  log.Child("geolocation"),
-	}, nil
+	}
+
+	// databases are periodically downloaded again if they have been modified in object storage
+	if reloadInterval := conf.GetDurationVar(0, time.Second, "Geolocation.db.reloadInterval"); reloadInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		e.reloadCancel = cancel
+		e.reloadDone = make(chan struct{})
+		go func() {
+			defer close(e.reloadDone)
+			e.reloadLoop(ctx, reloadInterval)
+		}()
+	}
+	return e, nil
+}
+
+func newGeoFetcher(conf *config.Config, dbPath string, asnDBPaths []string) (geolocation.GeoFetcher, error) {
+	var hostingASNs []uint
+	for _, asn := range conf.GetStringSliceVar(defaultHostingASNs, "Geolocation.hostingASNs") {
+		number, err := strconv.ParseUint(asn, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parsing hosting asn %q: %w", asn, err)
+		}
+		hostingASNs = append(hostingASNs, uint(number))
+	}
+
+	fetcher, err := geolocation.NewMaxmindDBReader(dbPath,
+		geolocation.WithASNDatabases(asnDBPaths...),
+		geolocation.WithHostingASNs(hostingASNs...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating new instance of maxmind's geolocation db reader: %w", err)
+	}
+	return fetcher, nil
+}
+
+// geolocationASNDBs returns the network databases, e.g. GeoLite2-ASN or GeoIP2-Connection-Type, configured by Geolocation.db.asnKeys,
+// which are kept in the same bucket as the city database.
+func geolocationASNDBs(conf *config.Config) []storageDB {
+	var dbs []storageDB
+	for _, key := range conf.GetStringSliceVar(nil, "Geolocation.db.asnKeys") {
+		dbs = append(dbs, storageDB{
+			confPrefix:    "Geolocation",
+			name:          "geolocation",
+			key:           key,
+			defaultBucket: "rudderstack-geolocation",
+		})
+	}
+	return dbs
+}
+
+// reloadLoop replaces the fetcher if any of its databases has been modified in object storage,
+// until the context is cancelled
+func (e *geoEnricher) reloadLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		reloaded, err := e.reload(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			e.logger.Errorn("reloading geolocation databases", obskit.Error(err))
+		}
+		e.stats.NewTaggedStat("proc_geo_enricher_db_reload", stats.CountType, stats.Tags{
+			"reloaded": strconv.FormatBool(reloaded),
+			"error":    strconv.FormatBool(err != nil),
+		}).Increment()
+	}
+}
+
+func (e *geoEnricher) reload(ctx context.Context) (bool, error) {
+	var modified bool
+	dbs := append([]storageDB{maxmindDB}, geolocationASNDBs(e.conf)...)
+	dbPaths := make([]string, len(dbs))
+	for i, db := range dbs {
+		dbPath, refreshed, err := refreshStorageDB(ctx, e.conf, e.logger, db)
+		if err != nil {
+			return false, err
+		}
+		dbPaths[i] = dbPath
+		modified = modified || refreshed
+	}
+	if !modified {
+		return false, nil
+	}
+
+	fetcher, err := newGeoFetcher(e.conf, dbPaths[0], dbPaths[1:])
+	if err != nil {
+		return false, err
+	}
+	e.fetcherMu.Lock()
+	previous := e.fetcher
+	e.fetcher = fetcher
+	e.fetcherMu.Unlock()
+	e.logger.Infon("reloaded geolocation databases")
+
+	if err := previous.Close(); err != nil {
+		e.logger.Warnn("closing the previous geolocation db reader", obskit.Error(err))
+	}
+	return true, nil
 }
 
 // Enrich function runs on a request of GatewayBatchRequest which contains
@@ -79,6 +212,10 @@ func (e *geoEnricher) Enrich(source *backendconfig.SourceT, request *types.Gatew
 		},
 	).RecordDuration()()
 
+	// the fetcher is not replaced while the request is being enriched
+	e.fetcherMu.RLock()
+	defer e.fetcherMu.RUnlock()
+
 	var enrichErrs []error
 	for _, event := range request.Batch {
 		// if the context section is missing on the event
@@ -141,45 +278,148 @@ func (e *geoEnricher) Enrich(source *backendconfig.SourceT, request *types.Gatew
 func (e *geoEnricher) Close() error {
 	e.logger.Info("closing the geolocation enricher")
 
+	if e.reloadCancel != nil {
+		e.reloadCancel()
+		<-e.reloadDone
+	}
+
+	e.fetcherMu.Lock()
+	defer e.fetcherMu.Unlock()
 	if err := e.fetcher.Close(); err != nil {
 		return fmt.Errorf("closing the geo enricher: %w", err)
 	}
 	return nil
 }
 
+var maxmindDB = storageDB{
+	confPrefix:    "Geolocation",
+	name:          "geolocation",
+	defaultKey:    "geolite2City.mmdb",
+	defaultBucket: "rudderstack-geolocation",
+}
+
 // downloadMaxmindDB downloads database file from upstream s3 and stores it in
 // a specified location. Download is skipped if the file already exists in the expected path.
 func downloadMaxmindDB(ctx context.Context, conf *config.Config, log logger.Logger) (string, error) {
-	var (
-		dbKey            = conf.GetString("Geolocation.db.key", "geolite2City.mmdb")
-		bucket           = conf.GetString("Geolocation.db.storage.bucket", "rudderstack-geolocation")
-		region           = conf.GetString("Geolocation.db.storage.region", "us-east-1")
-		endpoint         = conf.GetString("Geolocation.db.storage.endpoint", "")
-		accessKeyID      = conf.GetString("Geolocation.db.storage.accessKey", "")
-		secretAccessKey  = conf.GetString("Geolocation.db.storage.secretAccessKey", "")
-		s3ForcePathStyle = conf.GetBool("Geolocation.db.storage.s3ForcePathStyle", false)
-		disableSSL       = conf.GetBool("Geolocation.db.storage.disableSSL", false)
-	)
+	return downloadStorageDB(ctx, conf, log, maxmindDB)
+}
 
-	var (
-		baseDIR      = path.Join(conf.GetString("RUDDER_TMPDIR", "."), "geolocation")
-		downloadPath = path.Join(baseDIR, dbKey)
-	)
+// storageDB is a database file kept in object storage, whose location is configured under <confPrefix>.db
+type storageDB struct {
+	confPrefix string
+	name       string
+	// key overrides the key configured by <confPrefix>.db.key
+	key           string
+	defaultKey    string
+	defaultBucket string
+}
+
+func (db storageDB) dbKey(conf *config.Config) string {
+	if db.key != "" {
+		return db.key
+	}
+	return conf.GetString(db.confPrefix+".db.key", db.defaultKey)
+}
+
+func (db storageDB) baseDIR(conf *config.Config) string {
+	return path.Join(conf.GetString("RUDDER_TMPDIR", "."), db.name)
+}
+
+func (db storageDB) bucket(conf *config.Config) string {
+	return conf.GetString(db.confPrefix+".db.storage.bucket", db.defaultBucket)
+}
+
+func (db storageDB) region(conf *config.Config) string {
+	return conf.GetString(db.confPrefix+".db.storage.region", "us-east-1")
+}
+
+func (db storageDB) manager(conf *config.Config) (filemanager.FileManager, error) {
+	manager, err := filemanager.New(&filemanager.Settings{
+		Provider: "S3",
+		Config: map[string]interface{}{
+			"bucketName":       db.bucket(conf),
+			"region":           db.region(conf),
+			"endpoint":         conf.GetString(db.confPrefix+".db.storage.endpoint", ""),
+			"accessKeyID":      conf.GetString(db.confPrefix+".db.storage.accessKey", ""),
+			"secretAccessKey":  conf.GetString(db.confPrefix+".db.storage.secretAccessKey", ""),
+			"s3ForcePathStyle": conf.GetBool(db.confPrefix+".db.storage.s3ForcePathStyle", false),
+			"disableSSL":       conf.GetBool(db.confPrefix+".db.storage.disableSSL", false),
+		},
+		Conf: conf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating a new s3 manager client: %w", err)
+	}
+	return manager, nil
+}
+
+// downloadStorageDB downloads the database file from upstream s3 and stores it under RUDDER_TMPDIR/<name>.
+// Download is skipped if the file already exists in the expected path.
+func downloadStorageDB(ctx context.Context, conf *config.Config, log logger.Logger, db storageDB) (string, error) {
+	downloadPath := path.Join(db.baseDIR(conf), db.dbKey(conf))
 
 	// If the filepath exists return
 	if _, err := os.Stat(downloadPath); err == nil {
 		return downloadPath, nil
 	}
 
-	log.Infof("downloading new geolocation db from key: %s", dbKey)
+	manager, err := db.manager(conf)
+	if err != nil {
+		return "", err
+	}
+	if err := fetchStorageDB(ctx, conf, log, db, manager, downloadPath); err != nil {
+		return "", err
+	}
+	return downloadPath, nil
+}
+
+// refreshStorageDB downloads the database file again if it has been modified in upstream s3 after its last download,
+// returning true if it has been downloaded.
+func refreshStorageDB(ctx context.Context, conf *config.Config, log logger.Logger, db storageDB) (string, bool, error) {
+	var (
+		dbKey        = db.dbKey(conf)
+		downloadPath = path.Join(db.baseDIR(conf), dbKey)
+	)
+
+	manager, err := db.manager(conf)
+	if err != nil {
+		return "", false, err
+	}
+	files, err := manager.ListFilesWithPrefix(ctx, "", dbKey, 1).Next()
+	if err != nil {
+		return "", false, fmt.Errorf("listing file with key: %s from bucket: %s, err: %w", dbKey, db.bucket(conf), err)
+	}
+	file, ok := lo.Find(files, func(f *filemanager.FileInfo) bool { return f.Key == dbKey })
+	if !ok {
+		return "", false, fmt.Errorf("file with key: %s not found in bucket: %s", dbKey, db.bucket(conf))
+	}
+	if stat, err := os.Stat(downloadPath); err == nil && !file.LastModified.After(stat.ModTime()) {
+		return downloadPath, false, nil
+	}
+
+	if err := fetchStorageDB(ctx, conf, log, db, manager, downloadPath); err != nil {
+		return "", false, err
+	}
+	return downloadPath, true, nil
+}
+
+// fetchStorageDB downloads the database file into a temporary file, which is then moved to the download path.
+// Readers of a previous file at the same path keep reading it until they are closed.
+func fetchStorageDB(ctx context.Context, conf *config.Config, log logger.Logger, db storageDB, manager filemanager.FileManager, downloadPath string) error {
+	var (
+		dbKey   = db.dbKey(conf)
+		baseDIR = db.baseDIR(conf)
+	)
+
+	log.Infof("downloading new %s db from key: %s", db.name, dbKey)
 
 	if err := os.MkdirAll(baseDIR, os.ModePerm); err != nil {
-		return "", fmt.Errorf("creating directory for storing db: %w", err)
+		return fmt.Errorf("creating directory for storing db: %w", err)
 	}
 
-	f, err := os.CreateTemp(baseDIR, "geodb-*.mmdb")
+	f, err := os.CreateTemp(baseDIR, db.name+"-*"+path.Ext(dbKey))
 	if err != nil {
-		return "", fmt.Errorf("creating a temporary file: %w", err)
+		return fmt.Errorf("creating a temporary file: %w", err)
 	}
 
 	defer func() {
@@ -187,42 +427,25 @@ func downloadMaxmindDB(ctx context.Context, conf *config.Config, log logger.Logg
 		_ = os.Remove(f.Name())
 	}()
 
-	manager, err := filemanager.New(&filemanager.Settings{
-		Provider: "S3",
-		Config: map[string]interface{}{
-			"bucketName":       bucket,
-			"region":           region,
-			"endpoint":         endpoint,
-			"accessKeyID":      accessKeyID,
-			"secretAccessKey":  secretAccessKey,
-			"s3ForcePathStyle": s3ForcePathStyle,
-			"disableSSL":       disableSSL,
-		},
-		Conf: conf,
-	})
-	if err != nil {
-		return "", fmt.Errorf("creating a new s3 manager client: %w", err)
-	}
-
 	if err := manager.Download(ctx, f, dbKey); err != nil {
-		return "", fmt.Errorf("downloading file with key: %s from bucket: %s and region: %s, err: %w",
+		return fmt.Errorf("downloading file with key: %s from bucket: %s and region: %s, err: %w",
 			dbKey,
-			bucket,
-			region,
+			db.bucket(conf),
+			db.region(conf),
 			err)
 	}
 
 	// before renaming, we need to sync data to the disk
 	if err := f.Sync(); err != nil {
-		return "", fmt.Errorf("syncing file to disk: %w", err)
+		return fmt.Errorf("syncing file to disk: %w", err)
 	}
 
 	// Finally move the downloaded file from previous temp location to new location
 	if err := os.Rename(f.Name(), downloadPath); err != nil {
-		return "", fmt.Errorf("renaming file: %w", err)
+		return fmt.Errorf("renaming file: %w", err)
 	}
 
-	return downloadPath, nil
+	return nil
 }
 
 func extractGeolocationData(ip string, geoCity geolocation.GeoInfo) Geolocation {
@@ -232,6 +455,12 @@ func extractGeolocationData(ip string, geoCity geolocation.GeoInfo) Geolocation
 		Country:  geoCity.Country.ISOCode,
 		Postal:   geoCity.Postal.Code,
 		Timezone: geoCity.Location.Timezone,
+
+		ASN:            geoCity.ASN.Number,
+		Organization:   geoCity.ASN.Organization,
+		ISP:            geoCity.ASN.ISP,
+		ConnectionType: geoCity.ASN.ConnectionType,
+		IsHosting:      geoCity.ASN.IsHosting,
 	}
 
 	if len(geoCity.Subdivisions) > 0 {
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"testing"
+	"time"
 
 	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/require"
@@ -492,6 +494,145 @@ func TestDownloadMaxmindDB_success(t *testing.T) {
 	require.NoError(t, os.Remove(downloadPath)) // Clean the downloaded file
 }
 
+// geolocationTestdata is where the test databases shared with the geolocation service are kept
+const geolocationTestdata = "../../services/geolocation/testdata"
+
+// geolocationTestDir copies the databases from the geolocation service's testdata into the geolocation directory
+// of a temporary RUDDER_TMPDIR, which is returned
+func geolocationTestDir(t *testing.T, dbs ...string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(tmpDir, "geolocation"), 0o755))
+	for _, db := range dbs {
+		data, err := os.ReadFile(path.Join(geolocationTestdata, db))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path.Join(tmpDir, "geolocation", db), data, 0o644))
+	}
+	return tmpDir
+}
+
+func TestGeolocationEnrichment_ASN(t *testing.T) {
+	c := config.New()
+	c.Set("RUDDER_TMPDIR", geolocationTestDir(t, "city_test.mmdb", "asn_test.mmdb", "connection_type_test.mmdb"))
+	c.Set("Geolocation.db.key", "city_test.mmdb")
+	c.Set("Geolocation.db.asnKeys", []string{"asn_test.mmdb", "connection_type_test.mmdb"})
+
+	enricher, err := NewGeoEnricher(c, logger.NOP, stats.NOP)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, enricher.Close())
+	}()
+
+	input := &types.GatewayBatchRequest{
+		RequestIP: `2.125.160.216`,
+		Batch: []types.SingularEventT{
+			{"userId": "u1", "context": map[string]interface{}{}},
+			{"userId": "u2", "context": map[string]interface{}{"ip": "3.5.140.2"}},
+		},
+	}
+	require.NoError(t, enricher.Enrich(NewSourceBuilder("source-id").WithGeoEnrichment(true).Build(), input, nil))
+
+	require.Equal(t, Geolocation{
+		IP:             "2.125.160.216",
+		City:           "Boxford",
+		Country:        "GB",
+		Postal:         "OX1",
+		Region:         "England",
+		Location:       "51.750000,-1.250000",
+		Timezone:       "Europe/London",
+		ASN:            5607,
+		Organization:   "Sky UK Limited",
+		ConnectionType: "Cable/DSL",
+	}, input.Batch[0]["context"].(map[string]interface{})["geo"])
+	require.Equal(t, Geolocation{
+		IP:             "3.5.140.2",
+		ASN:            16509,
+		Organization:   "AMAZON-02",
+		ConnectionType: "Corporate",
+		IsHosting:      true,
+	}, input.Batch[1]["context"].(map[string]interface{})["geo"], "datacenter traffic is flagged as hosting")
+
+	t.Run("invalid hosting asns", func(t *testing.T) {
+		c.Set("Geolocation.hostingASNs", []string{"AS16509"})
+		_, err := NewGeoEnricher(c, logger.NOP, stats.NOP)
+		require.Error(t, err)
+	})
+}
+
+func TestGeolocationEnrichment_Reload(t *testing.T) {
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err)
+
+	minio, err := miniodocker.Setup(pool, t)
+	require.NoError(t, err)
+
+	minioManager, err := filemanager.New(
+		&filemanager.Settings{
+			Provider: "MINIO",
+			Config: map[string]interface{}{
+				"bucketName":      minio.BucketName,
+				"endPoint":        minio.Endpoint,
+				"accessKeyID":     minio.AccessKeyID,
+				"secretAccessKey": minio.AccessKeySecret,
+			},
+			Conf: config.Default,
+		})
+	require.NoError(t, err)
+
+	upload := func(uploadPath string) string {
+		f, err := os.Open(uploadPath)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, f.Close()) }()
+		uploaded, err := minioManager.Upload(context.Background(), f)
+		require.NoError(t, err)
+		return uploaded.ObjectName
+	}
+	cityKey := upload(path.Join(geolocationTestdata, "city_test.mmdb"))
+	asnKey := upload(path.Join(geolocationTestdata, "asn_test.mmdb"))
+
+	conf := config.New()
+	conf.Set("Geolocation.db.key", cityKey)
+	conf.Set("Geolocation.db.asnKeys", []string{asnKey})
+	conf.Set("Geolocation.db.storage.bucket", minio.BucketName)
+	conf.Set("Geolocation.db.storage.endpoint", minio.Endpoint)
+	conf.Set("Geolocation.db.storage.accessKey", minio.AccessKeyID)
+	conf.Set("Geolocation.db.storage.secretAccessKey", minio.AccessKeySecret)
+	conf.Set("Geolocation.db.storage.s3ForcePathStyle", true)
+	conf.Set("Geolocation.db.storage.disableSSL", true)
+	conf.Set("RUDDER_TMPDIR", t.TempDir())
+
+	enricher, err := NewGeoEnricher(conf, logger.NOP, stats.NOP)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, enricher.Close())
+	}()
+	e := enricher.(*geoEnricher)
+
+	reloaded, err := e.reload(context.Background())
+	require.NoError(t, err)
+	require.False(t, reloaded, "databases haven't been modified since their download")
+
+	// simulate a modification in object storage after the last download
+	asnDBPath := path.Join(conf.GetString("RUDDER_TMPDIR", ""), "geolocation", asnKey)
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(asnDBPath, past, past))
+
+	previous := e.fetcher
+	reloaded, err = e.reload(context.Background())
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	require.NotSame(t, previous, e.fetcher)
+
+	input := &types.GatewayBatchRequest{
+		RequestIP: `2.125.160.216`,
+		Batch:     []types.SingularEventT{{"userId": "u1"}},
+	}
+	require.NoError(t, enricher.Enrich(NewSourceBuilder("source-id").WithGeoEnrichment(true).Build(), input, nil))
+	geo := input.Batch[0]["context"].(map[string]interface{})["geo"].(Geolocation)
+	require.Equal(t, "Boxford", geo.City)
+	require.EqualValues(t, 5607, geo.ASN)
+}
+
 type SourceBuilder struct {
 	source *backendconfig.SourceT
 }
@@ -0,0 +1,145 @@
+package enricher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	"github.com/rudderlabs/rudder-server/services/lookup"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+const (
+	LOOKUP_HIT     = "hit"
+	LOOKUP_MISS    = "miss"
+	LOOKUP_NO_KEY  = "no_key"
+	LOOKUP_FAILED  = "failed"
+	LOOKUP_PRESENT = "present"
+)
+
+type lookupEnricher struct {
+	store  lookup.Store
+	logger logger.Logger
+	stats  stats.Stats
+}
+
+// NewLookupEnricher returns an enricher joining the events of sources with the rows of their lookup tables kept by the store.
+// The store is not closed by the enricher.
+func NewLookupEnricher(store lookup.Store, log logger.Logger, statClient stats.Stats) (PipelineEnricher, error) {
+	log.Infof("Setting up new event lookup enricher")
+	return &lookupEnricher{
+		store:  store,
+		logger: log.Child("lookup"),
+		stats:  statClient,
+	}, nil
+}
+
+// Enrich writes the rows of the lookup tables of the source matching the events
+// under their context, fetching the rows of each table once per request.
+func (e *lookupEnricher) Enrich(source *backendconfig.SourceT, request *types.GatewayBatchRequest, _ *types.EventParams) error {
+	if len(source.LookupEnrichment.Lookups) == 0 {
+		return nil
+	}
+
+	defer e.stats.NewTaggedStat(
+		"proc_lookup_enricher_request_latency",
+		stats.TimerType,
+		stats.Tags{
+			"sourceId":    source.ID,
+			"sourceType":  source.SourceDefinition.Type,
+			"workspaceId": source.WorkspaceID,
+		},
+	).RecordDuration()()
+
+	var enrichErrs []error
+	for _, l := range source.LookupEnrichment.Lookups {
+		contextKey := l.ContextKey
+		if contextKey == "" {
+			contextKey = l.Table
+		}
+		keyPath := strings.Split(l.KeyPath, ".")
+
+		results := make(map[string]int)
+		eventKeys := make([]string, len(request.Batch))
+		var keys []string
+		for i, event := range request.Batch {
+			// if the context is present and other than map[string]any, it is reported only once by the enricher
+			if context, ok := event["context"].(map[string]any); ok {
+				if _, ok := context[contextKey]; ok {
+					results[LOOKUP_PRESENT]++
+					continue
+				}
+			}
+			key, ok := lookup.KeyOf(misc.MapLookup(event, keyPath...))
+			if !ok {
+				results[LOOKUP_NO_KEY]++
+				continue
+			}
+			eventKeys[i] = key
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			e.reportLookupStats(source, l.Table, results)
+			continue
+		}
+
+		rows, err := e.store.Get(context.TODO(), l.Table, keys)
+		if err != nil {
+			results[LOOKUP_FAILED] += len(keys)
+			e.reportLookupStats(source, l.Table, results)
+			enrichErrs = append(enrichErrs, fmt.Errorf("looking up table %q: %w", l.Table, err))
+			continue
+		}
+		for i, event := range request.Batch {
+			if eventKeys[i] == "" {
+				continue
+			}
+			row, ok := rows[eventKeys[i]]
+			if !ok {
+				results[LOOKUP_MISS]++
+				continue
+			}
+			results[LOOKUP_HIT]++
+
+			// if the context section is missing on the event
+			// set it with default as map[string]any
+			if _, ok := event["context"]; !ok {
+				event["context"] = map[string]any{}
+			}
+			context, ok := event["context"].(map[string]any)
+			if !ok {
+				enrichErrs = append(enrichErrs, fmt.Errorf("event on source: %s doesn't have a valid context section", source.ID))
+				continue
+			}
+			context[contextKey] = maps.Clone(row)
+		}
+		e.reportLookupStats(source, l.Table, results)
+	}
+
+	return errors.Join(enrichErrs...)
+}
+
+func (e *lookupEnricher) reportLookupStats(source *backendconfig.SourceT, table string, results map[string]int) {
+	for result, count := range results {
+		e.stats.NewTaggedStat(
+			"proc_lookup_enricher_request",
+			stats.CountType,
+			stats.Tags{
+				"sourceId":    source.ID,
+				"workspaceId": source.WorkspaceID,
+				"sourceType":  source.SourceDefinition.Type,
+				"table":       table,
+				"result":      result,
+			}).Count(count)
+	}
+}
+
+func (e *lookupEnricher) Close() error {
+	return nil
+}
@@ -0,0 +1,154 @@
+package enricher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	"github.com/rudderlabs/rudder-server/services/lookup"
+)
+
+type memoryLookupStore struct {
+	tables map[string]map[string]lookup.Row
+	gets   int
+	err    error
+}
+
+func (s *memoryLookupStore) Get(_ context.Context, table string, keys []string) (map[string]lookup.Row, error) {
+	s.gets++
+	if s.err != nil {
+		return nil, s.err
+	}
+	rows := make(map[string]lookup.Row)
+	for _, key := range keys {
+		if row, ok := s.tables[table][key]; ok {
+			rows[key] = row
+		}
+	}
+	return rows, nil
+}
+
+func (s *memoryLookupStore) Replace(_ context.Context, table string, rows map[string]lookup.Row) error {
+	s.tables[table] = rows
+	return nil
+}
+
+func (s *memoryLookupStore) Delete(_ context.Context, table string) error {
+	delete(s.tables, table)
+	return nil
+}
+
+func (s *memoryLookupStore) Close() error {
+	return nil
+}
+
+func TestLookupEnricher(t *testing.T) {
+	source := &backendconfig.SourceT{
+		ID:          "source-1",
+		WorkspaceID: "workspace-1",
+		LookupEnrichment: backendconfig.LookupEnrichmentT{
+			Lookups: []backendconfig.LookupT{
+				{Table: "tiers", KeyPath: "userId", ContextKey: "account"},
+				{Table: "campaigns", KeyPath: "context.page.url"},
+			},
+		},
+	}
+	newStore := func() *memoryLookupStore {
+		return &memoryLookupStore{tables: map[string]map[string]lookup.Row{
+			"tiers": {
+				"u1": {"tier": "gold"},
+				"2":  {"tier": "silver"},
+			},
+			"campaigns": {
+				"https://example.com": {"campaign": "spring"},
+			},
+		}}
+	}
+	tags := func(table, result string) stats.Tags {
+		return stats.Tags{
+			"sourceId":    source.ID,
+			"workspaceId": source.WorkspaceID,
+			"sourceType":  "",
+			"table":       table,
+			"result":      result,
+		}
+	}
+
+	t.Run("enriches events", func(t *testing.T) {
+		statsStore, err := memstats.New()
+		require.NoError(t, err)
+		store := newStore()
+		enricher, err := NewLookupEnricher(store, logger.NOP, statsStore)
+		require.NoError(t, err)
+
+		request := &types.GatewayBatchRequest{Batch: []types.SingularEventT{
+			{"userId": "u1", "context": map[string]any{"page": map[string]any{"url": "https://example.com"}}},
+			{"userId": 2.0},
+			{"userId": "u3", "context": map[string]any{"account": "existing"}},
+			{"anonymousId": "a1"},
+		}}
+		require.NoError(t, enricher.Enrich(source, request, &types.EventParams{}))
+
+		require.Equal(t, []types.SingularEventT{
+			{"userId": "u1", "context": map[string]any{
+				"page":      map[string]any{"url": "https://example.com"},
+				"account":   map[string]any{"tier": "gold"},
+				"campaigns": map[string]any{"campaign": "spring"},
+			}},
+			{"userId": 2.0, "context": map[string]any{"account": map[string]any{"tier": "silver"}}},
+			{"userId": "u3", "context": map[string]any{"account": "existing"}},
+			{"anonymousId": "a1"},
+		}, request.Batch)
+		require.Equal(t, 2, store.gets, "rows are fetched once per table")
+
+		require.EqualValues(t, 2, statsStore.Get("proc_lookup_enricher_request", tags("tiers", "hit")).LastValue())
+		require.EqualValues(t, 1, statsStore.Get("proc_lookup_enricher_request", tags("tiers", "present")).LastValue())
+		require.EqualValues(t, 1, statsStore.Get("proc_lookup_enricher_request", tags("tiers", "no_key")).LastValue())
+		require.EqualValues(t, 1, statsStore.Get("proc_lookup_enricher_request", tags("campaigns", "hit")).LastValue())
+		require.EqualValues(t, 3, statsStore.Get("proc_lookup_enricher_request", tags("campaigns", "no_key")).LastValue())
+	})
+
+	t.Run("rows are copied", func(t *testing.T) {
+		store := newStore()
+		enricher, err := NewLookupEnricher(store, logger.NOP, stats.NOP)
+		require.NoError(t, err)
+
+		request := &types.GatewayBatchRequest{Batch: []types.SingularEventT{{"userId": "u1"}, {"userId": "u1"}}}
+		require.NoError(t, enricher.Enrich(source, request, &types.EventParams{}))
+		request.Batch[0]["context"].(map[string]any)["account"].(map[string]any)["tier"] = "changed"
+		require.Equal(t, map[string]any{"tier": "gold"}, request.Batch[1]["context"].(map[string]any)["account"])
+		require.Equal(t, lookup.Row{"tier": "gold"}, store.tables["tiers"]["u1"])
+	})
+
+	t.Run("source without lookups", func(t *testing.T) {
+		store := newStore()
+		enricher, err := NewLookupEnricher(store, logger.NOP, stats.NOP)
+		require.NoError(t, err)
+
+		request := &types.GatewayBatchRequest{Batch: []types.SingularEventT{{"userId": "u1"}}}
+		require.NoError(t, enricher.Enrich(&backendconfig.SourceT{ID: "source-2"}, request, &types.EventParams{}))
+		require.Equal(t, []types.SingularEventT{{"userId": "u1"}}, request.Batch)
+		require.Zero(t, store.gets)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		store := newStore()
+		enricher, err := NewLookupEnricher(store, logger.NOP, stats.NOP)
+		require.NoError(t, err)
+
+		request := &types.GatewayBatchRequest{Batch: []types.SingularEventT{{"userId": "u1", "context": "invalid"}}}
+		require.Error(t, enricher.Enrich(source, request, &types.EventParams{}), "invalid context")
+
+		store.err = errors.New("store failure")
+		request = &types.GatewayBatchRequest{Batch: []types.SingularEventT{{"userId": "u1"}}}
+		require.ErrorIs(t, enricher.Enrich(source, request, &types.EventParams{}), store.err)
+		require.Equal(t, []types.SingularEventT{{"userId": "u1"}}, request.Batch)
+	})
+}
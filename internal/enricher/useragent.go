@@ -0,0 +1,139 @@
+package enricher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	"github.com/rudderlabs/rudder-server/services/useragent"
+)
+
+const (
+	USERAGENT_PARSED  = "parsed"
+	USERAGENT_UNKNOWN = "unknown"
+	USERAGENT_EMPTY   = "empty"
+)
+
+type userAgentEnricher struct {
+	parser *useragent.Parser
+	logger logger.Logger
+	stats  stats.Stats
+}
+
+// NewUserAgentEnricher returns an enricher parsing the user agents of events into their browser, os and device.
+// The parser's database is downloaded from object storage if UserAgentEnrichment.db.key is set, otherwise the embedded one is used.
+func NewUserAgentEnricher(conf *config.Config, log logger.Logger, statClient stats.Stats) (PipelineEnricher, error) {
+	log.Infof("Setting up new event user agent enricher")
+
+	var (
+		parser *useragent.Parser
+		dbPath string
+		err    error
+	)
+	if conf.GetString("UserAgentEnrichment.db.key", "") != "" {
+		dbPath, err = downloadStorageDB(context.Background(), conf, log, storageDB{
+			confPrefix: "UserAgentEnrichment",
+			name:       "useragent",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("downloading instance of user agent db: %w", err)
+		}
+		parser, err = useragent.NewParserFromFile(dbPath)
+		if err != nil {
+			return nil, fmt.Errorf("creating new instance of user agent parser: %w", err)
+		}
+	} else {
+		parser, err = useragent.NewDefaultParser()
+		if err != nil {
+			return nil, fmt.Errorf("creating new instance of default user agent parser: %w", err)
+		}
+	}
+
+	return &userAgentEnricher{
+		parser: parser,
+		stats:  statClient,
+		logger: log.Child("useragent"),
+	}, nil
+}
+
+// Enrich sets the browser, os and device of events parsed from their context.userAgent,
+// unless they are already present in the context, e.g. populated by mobile SDKs.
+func (e *userAgentEnricher) Enrich(source *backendconfig.SourceT, request *types.GatewayBatchRequest, _ *types.EventParams) error {
+	defer e.stats.NewTaggedStat(
+		"proc_useragent_enricher_request_latency",
+		stats.TimerType,
+		stats.Tags{
+			"sourceId":    source.ID,
+			"sourceType":  source.SourceDefinition.Type,
+			"workspaceId": source.WorkspaceID,
+		},
+	).RecordDuration()()
+
+	// events of a batch are usually sent by the same user agent
+	parsed := make(map[string]useragent.Info)
+	results := make(map[string]int)
+	var enrichErrs []error
+	for _, event := range request.Batch {
+		// if the context is missing there is no user agent to parse
+		if _, ok := event["context"]; !ok {
+			results[USERAGENT_EMPTY]++
+			continue
+		}
+
+		// if the context is other than map[string]any, add error and continue
+		context, ok := event["context"].(map[string]any)
+		if !ok {
+			enrichErrs = append(enrichErrs, fmt.Errorf("event on source: %s doesn't have a valid context section", source.ID))
+			continue
+		}
+
+		userAgent, _ := context["userAgent"].(string)
+		if userAgent == "" {
+			results[USERAGENT_EMPTY]++
+			continue
+		}
+		info, ok := parsed[userAgent]
+		if !ok {
+			info = e.parser.Parse(userAgent)
+			parsed[userAgent] = info
+		}
+		if info == (useragent.Info{}) {
+			results[USERAGENT_UNKNOWN]++
+			continue
+		}
+		results[USERAGENT_PARSED]++
+
+		if _, ok := context["browser"]; !ok && info.Browser != (useragent.Browser{}) {
+			context["browser"] = info.Browser
+		}
+		if _, ok := context["os"]; !ok && info.OS != (useragent.OS{}) {
+			context["os"] = info.OS
+		}
+		if _, ok := context["device"]; !ok && info.Device != (useragent.Device{}) {
+			context["device"] = info.Device
+		}
+	}
+
+	for result, count := range results {
+		e.stats.NewTaggedStat(
+			"proc_useragent_enricher_request",
+			stats.CountType,
+			stats.Tags{
+				"sourceId":    source.ID,
+				"workspaceId": source.WorkspaceID,
+				"sourceType":  source.SourceDefinition.Type,
+				"result":      result,
+			}).Count(count)
+	}
+
+	return errors.Join(enrichErrs...)
+}
+
+func (e *userAgentEnricher) Close() error {
+	return nil
+}
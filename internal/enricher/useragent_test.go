@@ -0,0 +1,100 @@
+package enricher
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	"github.com/rudderlabs/rudder-server/services/useragent"
+)
+
+func TestUserAgentEnricher(t *testing.T) {
+	const iPhoneUserAgent = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1.2 Mobile/15E148 Safari/604.1"
+	source := &backendconfig.SourceT{ID: "source-1", WorkspaceID: "workspace-1"}
+
+	t.Run("enriches events", func(t *testing.T) {
+		statsStore, err := memstats.New()
+		require.NoError(t, err)
+		enricher, err := NewUserAgentEnricher(config.New(), logger.NOP, statsStore)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, enricher.Close()) }()
+
+		request := &types.GatewayBatchRequest{Batch: []types.SingularEventT{
+			{"context": map[string]any{"userAgent": iPhoneUserAgent}},
+			{"context": map[string]any{"userAgent": iPhoneUserAgent, "device": map[string]any{"model": "iPhone15,2"}}},
+			{"context": map[string]any{"userAgent": "unknown-agent"}},
+			{"context": map[string]any{}},
+			{"event": "no context"},
+		}}
+		require.NoError(t, enricher.Enrich(source, request, &types.EventParams{}))
+
+		browser := useragent.Browser{Name: "Safari", Version: "17.1.2", Type: "browser"}
+		osInfo := useragent.OS{Name: "iOS", Version: "17.1.2"}
+		require.Equal(t, []types.SingularEventT{
+			{"context": map[string]any{
+				"userAgent": iPhoneUserAgent,
+				"browser":   browser,
+				"os":        osInfo,
+				"device":    useragent.Device{Type: "mobile", Manufacturer: "Apple", Model: "iPhone"},
+			}},
+			{"context": map[string]any{
+				"userAgent": iPhoneUserAgent,
+				"browser":   browser,
+				"os":        osInfo,
+				"device":    map[string]any{"model": "iPhone15,2"},
+			}},
+			{"context": map[string]any{"userAgent": "unknown-agent"}},
+			{"context": map[string]any{}},
+			{"event": "no context"},
+		}, request.Batch)
+
+		tags := func(result string) stats.Tags {
+			return stats.Tags{"sourceId": "source-1", "workspaceId": "workspace-1", "sourceType": "", "result": result}
+		}
+		require.EqualValues(t, 2, statsStore.Get("proc_useragent_enricher_request", tags("parsed")).LastValue())
+		require.EqualValues(t, 1, statsStore.Get("proc_useragent_enricher_request", tags("unknown")).LastValue())
+		require.EqualValues(t, 2, statsStore.Get("proc_useragent_enricher_request", tags("empty")).LastValue())
+	})
+
+	t.Run("invalid context", func(t *testing.T) {
+		enricher, err := NewUserAgentEnricher(config.New(), logger.NOP, stats.NOP)
+		require.NoError(t, err)
+
+		request := &types.GatewayBatchRequest{Batch: []types.SingularEventT{{"context": "invalid"}}}
+		require.Error(t, enricher.Enrich(source, request, &types.EventParams{}))
+	})
+
+	t.Run("database from storage", func(t *testing.T) {
+		// the download is skipped as the database is already present in the expected path
+		tmpDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(path.Join(tmpDir, "useragent"), os.ModePerm))
+		require.NoError(t, os.WriteFile(
+			path.Join(tmpDir, "useragent", "regexes-v2.json"),
+			[]byte(`{"browsers": [{"regex": "Custom/(\\d+)", "name": "Custom", "version": "$1", "type": "browser"}]}`),
+			0o644,
+		))
+		c := config.New()
+		c.Set("RUDDER_TMPDIR", tmpDir)
+		c.Set("UserAgentEnrichment.db.key", "regexes-v2.json")
+		enricher, err := NewUserAgentEnricher(c, logger.NOP, stats.NOP)
+		require.NoError(t, err)
+
+		request := &types.GatewayBatchRequest{Batch: []types.SingularEventT{{"context": map[string]any{"userAgent": "Custom/2"}}}}
+		require.NoError(t, enricher.Enrich(source, request, &types.EventParams{}))
+		require.Equal(t, useragent.Browser{Name: "Custom", Version: "2", Type: "browser"}, request.Batch[0]["context"].(map[string]any)["browser"])
+		require.NotContains(t, request.Batch[0]["context"], "os")
+
+		c.Set("UserAgentEnrichment.db.key", "missing.json")
+		c.Set("UserAgentEnrichment.db.storage.endpoint", "http://localhost:1")
+		_, err = NewUserAgentEnricher(c, logger.NOP, stats.NOP)
+		require.Error(t, err)
+	})
+}
@@ -55,22 +55,28 @@ func (c *Client) NewProducer(opts pulsar.ProducerOptions) (ProducerAdapter, erro
 	}, nil
 }
 
-func newPulsarClient(conf ClientConf, log logger.Logger) (Client, error) {
-	if conf.url == "" {
+// NewClientWithOptions returns a new instance of Pulsar client using the provided client options,
+// e.g. for connecting to a destination's cluster with its own TLS & authentication settings
+func NewClientWithOptions(opts pulsar.ClientOptions, log logger.Logger) (Client, error) {
+	if opts.URL == "" {
 		return Client{}, errors.New("pulsar url is empty")
 	}
-	client, err := pulsar.NewClient(pulsar.ClientOptions{
-		URL:               conf.url,
-		OperationTimeout:  conf.operationTimeout,
-		ConnectionTimeout: conf.connectionTimeout,
-		Logger:            &pulsarLogAdapter{Logger: log},
-	})
+	opts.Logger = &pulsarLogAdapter{Logger: log}
+	client, err := pulsar.NewClient(opts)
 	if err != nil {
 		return Client{}, err
 	}
 	return Client{client}, nil
 }
 
+func newPulsarClient(conf ClientConf, log logger.Logger) (Client, error) {
+	return NewClientWithOptions(pulsar.ClientOptions{
+		URL:               conf.url,
+		OperationTimeout:  conf.operationTimeout,
+		ConnectionTimeout: conf.connectionTimeout,
+	}, log)
+}
+
 // SendMessage sends a message to pulsar synchronously
 func (p *Producer) SendMessage(ctx context.Context, key, orderingKey string, msg []byte) error {
 	_, err := p.Send(ctx, &pulsar.ProducerMessage{
@@ -0,0 +1,194 @@
+// Package compression provides the codecs that can be used for compressing job payloads stored in BYTEA columns.
+//
+// Every codec produces a self-describing frame, starting with the codec's magic bytes, so that payloads can be
+// decoded without knowing which codec (if any) was used for encoding them. This allows changing the codec of a
+// jobsdb at any time, since datasets written with a previous codec (or without compression) remain readable.
+// Since none of the magic byte sequences can be the beginning of a valid UTF-8 encoded JSON document,
+// uncompressed payloads are always returned as-is by [Decode].
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Codec compresses and decompresses payloads
+type Codec interface {
+	// Name returns the name of the codec, as used in configuration
+	Name() string
+	// Magic returns the byte sequence every payload encoded by the codec starts with
+	Magic() []byte
+	// Encode compresses the provided payload
+	Encode(payload []byte) ([]byte, error)
+	// Decode decompresses the provided payload, previously compressed by Encode
+	Decode(payload []byte) ([]byte, error)
+}
+
+const (
+	// None is the name used for disabling compression
+	None = "none"
+	// Zstd is the name of the zstd codec
+	Zstd = "zstd"
+	// LZ4 is the name of the lz4 codec
+	LZ4 = "lz4"
+	// Snappy is the name of the snappy codec
+	Snappy = "snappy"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Codec{}
+)
+
+func init() {
+	Register(&zstdCodec{})
+	Register(&lz4Codec{})
+	Register(&snappyCodec{})
+}
+
+// Register makes a codec available by its name. It panics if a codec with the same name or magic bytes is already registered.
+func Register(codec Codec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if len(codec.Magic()) == 0 {
+		panic(fmt.Errorf("compression codec %q has no magic bytes", codec.Name()))
+	}
+	for name, registered := range registry {
+		if name == codec.Name() {
+			panic(fmt.Errorf("compression codec %q already registered", name))
+		}
+		if bytes.HasPrefix(codec.Magic(), registered.Magic()) || bytes.HasPrefix(registered.Magic(), codec.Magic()) {
+			panic(fmt.Errorf("compression codec %q magic bytes conflict with codec %q", codec.Name(), name))
+		}
+	}
+	registry[codec.Name()] = codec
+}
+
+// Get returns the codec registered with the provided name, or nil if the name is empty or [None]
+func Get(name string) (Codec, error) {
+	if name == "" || name == None {
+		return nil, nil
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	codec, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown compression codec: %q", name)
+	}
+	return codec, nil
+}
+
+// Detect returns the codec that was used for encoding the payload, or nil if the payload is not compressed
+func Detect(payload []byte) Codec {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, codec := range registry {
+		if bytes.HasPrefix(payload, codec.Magic()) {
+			return codec
+		}
+	}
+	return nil
+}
+
+// Decode decompresses the payload using the codec it was encoded with. Uncompressed payloads are returned as-is.
+func Decode(payload []byte) ([]byte, error) {
+	codec := Detect(payload)
+	if codec == nil {
+		return payload, nil
+	}
+	decoded, err := codec.Decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s payload: %w", codec.Name(), err)
+	}
+	return decoded, nil
+}
+
+// zstdCodec uses the zstd frame format
+type zstdCodec struct {
+	once    sync.Once
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+	err     error
+}
+
+func (*zstdCodec) Name() string { return Zstd }
+
+func (*zstdCodec) Magic() []byte { return []byte{0x28, 0xb5, 0x2f, 0xfd} }
+
+func (c *zstdCodec) init() error {
+	c.once.Do(func() {
+		if c.encoder, c.err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1)); c.err != nil {
+			return
+		}
+		c.decoder, c.err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+	return c.err
+}
+
+func (c *zstdCodec) Encode(payload []byte) ([]byte, error) {
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+	return c.encoder.EncodeAll(payload, make([]byte, 0, len(payload)/2)), nil
+}
+
+func (c *zstdCodec) Decode(payload []byte) ([]byte, error) {
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+	return c.decoder.DecodeAll(payload, nil)
+}
+
+// lz4Codec uses the lz4 frame format
+type lz4Codec struct{}
+
+func (*lz4Codec) Name() string { return LZ4 }
+
+func (*lz4Codec) Magic() []byte { return []byte{0x04, 0x22, 0x4d, 0x18} }
+
+func (*lz4Codec) Encode(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := lz4.NewWriter(&buf)
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (*lz4Codec) Decode(payload []byte) ([]byte, error) {
+	return io.ReadAll(lz4.NewReader(bytes.NewReader(payload)))
+}
+
+// snappyCodec uses the snappy framing format
+type snappyCodec struct{}
+
+func (*snappyCodec) Name() string { return Snappy }
+
+func (*snappyCodec) Magic() []byte {
+	return []byte{0xff, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}
+}
+
+func (*snappyCodec) Encode(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (*snappyCodec) Decode(payload []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(payload)))
+}
@@ -0,0 +1,69 @@
+package compression_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/jobsdb/internal/compression"
+)
+
+func TestCodecs(t *testing.T) {
+	payload := []byte(`{"batch":[{"type":"track","event":"` + strings.Repeat("Order Completed ", 1000) + `"}]}`)
+
+	for _, name := range []string{compression.Zstd, compression.LZ4, compression.Snappy} {
+		t.Run(name, func(t *testing.T) {
+			codec, err := compression.Get(name)
+			require.NoError(t, err)
+			require.NotNil(t, codec)
+			require.Equal(t, name, codec.Name())
+
+			encoded, err := codec.Encode(payload)
+			require.NoError(t, err)
+			require.Less(t, len(encoded), len(payload))
+			require.Equal(t, codec, compression.Detect(encoded))
+
+			decoded, err := compression.Decode(encoded)
+			require.NoError(t, err)
+			require.Equal(t, payload, decoded)
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		for _, name := range []string{"", compression.None} {
+			codec, err := compression.Get(name)
+			require.NoError(t, err)
+			require.Nil(t, codec)
+		}
+	})
+
+	t.Run("unknown codec", func(t *testing.T) {
+		_, err := compression.Get("gzip")
+		require.Error(t, err)
+	})
+
+	t.Run("uncompressed payloads are returned as-is", func(t *testing.T) {
+		for _, uncompressed := range [][]byte{nil, {}, []byte(`{}`), []byte(` {"key":"value"}`), []byte(`"(string"`), payload} {
+			require.Nil(t, compression.Detect(uncompressed))
+			decoded, err := compression.Decode(uncompressed)
+			require.NoError(t, err)
+			require.Equal(t, uncompressed, decoded)
+		}
+	})
+
+	t.Run("corrupted payload", func(t *testing.T) {
+		codec, err := compression.Get(compression.Zstd)
+		require.NoError(t, err)
+		encoded, err := codec.Encode(payload)
+		require.NoError(t, err)
+		_, err = compression.Decode(encoded[:len(encoded)/2])
+		require.Error(t, err)
+	})
+
+	t.Run("conflicting registration", func(t *testing.T) {
+		codec, err := compression.Get(compression.LZ4)
+		require.NoError(t, err)
+		require.Panics(t, func() { compression.Register(codec) })
+	})
+}
@@ -51,6 +51,7 @@ import (
 
 	"github.com/rudderlabs/rudder-go-kit/jsonrs"
 	"github.com/rudderlabs/rudder-server/jobsdb/internal/cache"
+	"github.com/rudderlabs/rudder-server/jobsdb/internal/compression"
 	"github.com/rudderlabs/rudder-server/jobsdb/internal/lock"
 	"github.com/rudderlabs/rudder-server/services/rmetrics"
 	"github.com/rudderlabs/rudder-server/utils/crash"
@@ -94,6 +95,10 @@ type GetQueryParams struct {
 	ParameterFilters              []ParameterFilterT
 	stateFilters                  []string
 	afterJobID                    *int64
+	// JobIDs selects only the jobs with the provided ids
+	JobIDs []int64
+	// ParameterPathFilters select jobs by the values nested in their parameters, all filters need to match
+	ParameterPathFilters []ParameterPathFilterT
 
 	// query limits
 
@@ -514,6 +519,7 @@ type Handle struct {
 	config *config.Config
 	conf   struct {
 		payloadColumnType              payloadColumnType
+		payloadCodec                   compression.Codec // codec used for compressing payloads, only applicable to BYTEA payload columns
 		maxTableSize                   config.ValueLoader[int64]
 		cacheExpiration                config.ValueLoader[time.Duration]
 		addNewDSLoopSleepDuration      config.ValueLoader[time.Duration]
@@ -566,6 +572,15 @@ type ParameterFilterT struct {
 	Value string
 }
 
+// ParameterPathFilterT selects jobs by the value found at a path of their parameters, e.g. []string{"dlq", "error_code"}
+type ParameterPathFilterT struct {
+	Path []string
+	// Values selects jobs having any of the values at the path, if not empty
+	Values []string
+	// From and To limit the range of the timestamps found at the path, From is inclusive and To is exclusive. Zero values are ignored.
+	From, To time.Time
+}
+
 func (p ParameterFilterT) GetName() string {
 	return p.Name
 }
@@ -788,8 +803,9 @@ func (jd *Handle) init() {
 	}
 
 	if string(jd.conf.payloadColumnType) == "" {
-		jd.conf.payloadColumnType = TEXT
+		jd.conf.payloadColumnType = payloadColumnType(jd.config.GetStringVar(string(TEXT), jd.configKeys("payloadColumnType")...))
 	}
+	jd.initPayloadCodec()
 
 	if jd.stats == nil {
 		jd.stats = stats.Default
@@ -1798,6 +1814,11 @@ type moreToken struct {
 	afterJobID *int64
 }
 
+// NewMoreToken returns a token for fetching the jobs with an id greater than afterJobID
+func NewMoreToken(afterJobID int64) MoreToken {
+	return &moreToken{afterJobID: &afterJobID}
+}
+
 func (jd *Handle) GetToProcess(ctx context.Context, params GetQueryParams, more MoreToken) (*MoreJobsResult, error) { // skipcq: CRT-P0003
 
 	if params.JobsLimit == 0 {
@@ -1993,7 +2014,11 @@ func (jd *Handle) doStoreJobsInTx(ctx context.Context, tx *Tx, ds dataSetT, jobL
 				eventCount = job.EventCount
 			}
 
-			if _, err = stmt.ExecContext(ctx, job.UUID, job.UserID, job.CustomVal, string(job.Parameters), string(job.EventPayload), eventCount, job.WorkspaceId); err != nil {
+			payload, err := jd.encodePayload(job.EventPayload)
+			if err != nil {
+				return fmt.Errorf("encoding payload of job %s: %w", job.UUID, err)
+			}
+			if _, err = stmt.ExecContext(ctx, job.UUID, job.UserID, job.CustomVal, string(job.Parameters), payload, eventCount, job.WorkspaceId); err != nil {
 				return err
 			}
 		}
@@ -2073,7 +2098,8 @@ func (jd *Handle) getJobsDS(ctx context.Context, ds dataSetT, lastDS bool, param
 	defer jd.getTimerStat("jobsdb_get_jobs_ds_time", &tags).RecordDuration()()
 
 	containsUnprocessed := lo.Contains(stateFilters, Unprocessed.State)
-	skipCacheResult := params.afterJobID != nil
+	// results of queries filtering by job ids or nested parameters cannot be cached, since the cache doesn't take these filters into account
+	skipCacheResult := params.afterJobID != nil || len(params.JobIDs) > 0 || len(params.ParameterPathFilters) > 0
 	cacheTx := map[string]*cache.NoResultTx[ParameterFilterT]{}
 	if !skipCacheResult {
 		for _, state := range stateFilters {
@@ -2101,6 +2127,10 @@ func (jd *Handle) getJobsDS(ctx context.Context, ds dataSetT, lastDS bool, param
 		filterConditions = append(filterConditions, fmt.Sprintf("jobs.job_id > %d", *params.afterJobID))
 	}
 
+	if len(params.JobIDs) > 0 {
+		filterConditions = append(filterConditions, constructJobIDsQuery("jobs", params.JobIDs))
+	}
+
 	if len(customValFilters) > 0 && !params.IgnoreCustomValFiltersInQuery {
 		filterConditions = append(filterConditions, constructQueryOR("jobs.custom_val", customValFilters))
 	}
@@ -2109,6 +2139,10 @@ func (jd *Handle) getJobsDS(ctx context.Context, ds dataSetT, lastDS bool, param
 		filterConditions = append(filterConditions, constructParameterJSONQuery("jobs", parameterFilters))
 	}
 
+	for _, filter := range params.ParameterPathFilters {
+		filterConditions = append(filterConditions, constructParameterPathQuery("jobs", filter)...)
+	}
+
 	if workspaceID != "" {
 		filterConditions = append(filterConditions, fmt.Sprintf("jobs.workspace_id = '%s'", workspaceID))
 	}
@@ -2210,7 +2244,9 @@ func (jd *Handle) getJobsDS(ctx context.Context, ds dataSetT, lastDS bool, param
 		if err != nil {
 			return JobsResult{}, false, err
 		}
-		job.EventPayload = payload
+		if job.EventPayload, err = decodePayload(payload); err != nil {
+			return JobsResult{}, false, fmt.Errorf("decoding payload of job %d: %w", job.JobID, err)
+		}
 		if jsState.Valid {
 			resultsetStates[jsState.String] = struct{}{}
 			job.LastJobStatus.JobState = jsState.String
@@ -3277,6 +3313,8 @@ func (jd *Handle) GetLastJob(ctx context.Context) *JobT {
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		jd.assertError(err)
 	}
+	job.EventPayload, err = decodePayload(job.EventPayload)
+	jd.assertError(err)
 	return &job
 }
 
@@ -1321,6 +1321,23 @@ func TestConstructParameterJSONQuery(t *testing.T) {
 	require.Equal(t, `(alias.parameters->>'name'='value')`, q)
 }
 
+func TestConstructParameterPathQuery(t *testing.T) {
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	conditions := constructParameterPathQuery("alias", ParameterPathFilterT{
+		Path:   []string{"dlq", "error_code"},
+		Values: []string{"400", "it's"},
+		From:   from,
+		To:     from.Add(time.Hour),
+	})
+	require.Equal(t, []string{
+		`(alias.parameters #>> '{dlq,error_code}') IN ('400','it''s')`,
+		`(alias.parameters #>> '{dlq,error_code}')::timestamptz >= '2024-01-01T10:00:00Z'`,
+		`(alias.parameters #>> '{dlq,error_code}')::timestamptz < '2024-01-01T11:00:00Z'`,
+	}, conditions)
+	require.Empty(t, constructParameterPathQuery("alias", ParameterPathFilterT{Path: []string{"dlq"}}))
+	require.Equal(t, `alias.job_id IN (1,2)`, constructJobIDsQuery("alias", []int64{1, 2}))
+}
+
 func TestGetActiveWorkspaces(t *testing.T) {
 	_ = startPostgres(t)
 	c := config.New()
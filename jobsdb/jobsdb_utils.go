@@ -4,8 +4,11 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/samber/lo"
 
 	"github.com/rudderlabs/rudder-go-kit/stats"
@@ -132,6 +135,30 @@ func constructParameterJSONQuery(alias string, parameterFilters []ParameterFilte
 	return "(" + strings.Join(conditions, " OR ") + ")"
 }
 
+func constructJobIDsQuery(alias string, jobIDs []int64) string {
+	return fmt.Sprintf(`%s.job_id IN (%s)`, alias, strings.Join(lo.Map(jobIDs, func(jobID int64, _ int) string {
+		return strconv.FormatInt(jobID, 10)
+	}), ","))
+}
+
+// constructParameterPathQuery returns the conditions of a parameter path filter, e.g. (jobs.parameters #>> '{dlq,error_code}') IN ('400','500')
+func constructParameterPathQuery(alias string, filter ParameterPathFilterT) []string {
+	value := fmt.Sprintf(`(%s.parameters #>> %s)`, alias, pq.QuoteLiteral("{"+strings.Join(filter.Path, ",")+"}"))
+	var conditions []string
+	if len(filter.Values) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`%s IN (%s)`, value, strings.Join(lo.Map(filter.Values, func(v string, _ int) string {
+			return pq.QuoteLiteral(v)
+		}), ",")))
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`%s::timestamptz >= %s`, value, pq.QuoteLiteral(filter.From.Format(time.RFC3339Nano))))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(`%s::timestamptz < %s`, value, pq.QuoteLiteral(filter.To.Format(time.RFC3339Nano))))
+	}
+	return conditions
+}
+
 // statTags is a struct to hold tags for stats
 type statTags struct {
 	CustomValFilters []string
@@ -535,6 +535,10 @@ func (jd *Handle) migrateJobsInTx(ctx context.Context, tx *Tx, srcDS, destDS dat
 	if err = rows.Err(); err != nil {
 		return 0, fmt.Errorf("rows.Err() on column types: %w", err)
 	}
+	if columnTypeMap[srcDS.JobTable] == string(BYTEA) && columnTypeMap[destDS.JobTable] != string(BYTEA) {
+		// bytea payloads might be compressed, thus they need to be decoded before being converted
+		return jd.migrateDecodedJobsInTx(ctx, tx, srcDS, destDS)
+	}
 	payloadLiteral, err := getColumnConversion(columnTypeMap[srcDS.JobTable], columnTypeMap[destDS.JobTable])
 	if err != nil {
 		return 0, err
@@ -575,6 +579,96 @@ func (jd *Handle) migrateJobsInTx(ctx context.Context, tx *Tx, srcDS, destDS dat
 	return numJobsMigrated, nil
 }
 
+// migrateDecodedJobsInTx migrates jobs from a dataset with a bytea payload column to a dataset with a different payload column type,
+// decoding any compressed payloads along the way. Jobs are migrated in batches, since decoding cannot happen in the database.
+func (jd *Handle) migrateDecodedJobsInTx(ctx context.Context, tx *Tx, srcDS, destDS dataSetT) (int, error) {
+	batchSize := jd.config.GetIntVar(10000, 1, jd.configKeys("migration.decodeBatchSize")...)
+	selectJobsQuery := fmt.Sprintf(
+		`select j.job_id, j.workspace_id, j.uuid, j.user_id, j.custom_val, j.parameters, j.event_payload, j.event_count, j.created_at, j.expire_at
+		from %[2]q j left join "v_last_%[1]s" js on js.job_id = j.job_id
+		where (js.job_id is null or js.job_state = ANY('{%[3]s}')) and j.job_id > $1 order by j.job_id limit $2`,
+		srcDS.JobStatusTable,
+		srcDS.JobTable,
+		strings.Join(validNonTerminalStates, ","),
+	)
+	type migratedJob struct {
+		jobID                                int64
+		workspaceID, uuid, userID, customVal string
+		parameters, payload                  []byte
+		eventCount                           int
+		createdAt, expireAt                  time.Time
+	}
+	selectJobs := func(afterJobID int64) ([]migratedJob, error) {
+		rows, err := tx.QueryContext(ctx, selectJobsQuery, afterJobID, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("select jobs: %w", err)
+		}
+		defer func() { _ = rows.Close() }()
+		var jobs []migratedJob
+		for rows.Next() {
+			var j migratedJob
+			if err := rows.Scan(&j.jobID, &j.workspaceID, &j.uuid, &j.userID, &j.customVal, &j.parameters, &j.payload, &j.eventCount, &j.createdAt, &j.expireAt); err != nil {
+				return nil, fmt.Errorf("scan job: %w", err)
+			}
+			if j.payload, err = decodePayload(j.payload); err != nil {
+				return nil, fmt.Errorf("decoding payload of job %d: %w", j.jobID, err)
+			}
+			jobs = append(jobs, j)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("rows.Err() on jobs: %w", err)
+		}
+		return jobs, nil
+	}
+	insertJobs := func(jobs []migratedJob) error {
+		stmt, err := tx.PrepareContext(ctx, pq.CopyIn(destDS.JobTable, "job_id", "workspace_id", "uuid", "user_id", "custom_val", "parameters", "event_payload", "event_count", "created_at", "expire_at"))
+		if err != nil {
+			return fmt.Errorf("prepare copy in: %w", err)
+		}
+		defer func() { _ = stmt.Close() }()
+		for _, j := range jobs {
+			if _, err := stmt.ExecContext(ctx, j.jobID, j.workspaceID, j.uuid, j.userID, j.customVal, string(j.parameters), string(j.payload), j.eventCount, j.createdAt, j.expireAt); err != nil {
+				return fmt.Errorf("copy in job %d: %w", j.jobID, err)
+			}
+		}
+		if _, err := stmt.ExecContext(ctx); err != nil {
+			return fmt.Errorf("copy in: %w", err)
+		}
+		return nil
+	}
+
+	var numJobsMigrated int
+	var lastJobID int64
+	for {
+		jobs, err := selectJobs(lastJobID)
+		if err != nil {
+			return 0, err
+		}
+		if len(jobs) == 0 {
+			break
+		}
+		if err := insertJobs(jobs); err != nil {
+			return 0, err
+		}
+		numJobsMigrated += len(jobs)
+		lastJobID = jobs[len(jobs)-1].jobID
+	}
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+		`insert into %[2]q (job_id, job_state, attempt, exec_time, retry_time, error_code, error_response, parameters)
+		(select job_id, job_state, attempt, exec_time, retry_time, error_code, error_response, parameters from "v_last_%[1]s" where job_state = ANY('{%[3]s}'))`,
+		srcDS.JobStatusTable,
+		destDS.JobStatusTable,
+		strings.Join(validNonTerminalStates, ","),
+	)); err != nil {
+		return 0, fmt.Errorf("migrate job statuses: %w", err)
+	}
+	if _, err := tx.Exec(fmt.Sprintf(`ANALYZE %q, %q`, destDS.JobTable, destDS.JobStatusTable)); err != nil {
+		return 0, err
+	}
+	return numJobsMigrated, nil
+}
+
 func (jd *Handle) computeNewIdxForIntraNodeMigration(l lock.LockToken, insertBeforeDS dataSetT) (string, error) { // Within the node
 	jd.logger.Debugf("computeNewIdxForIntraNodeMigration, insertBeforeDS : %v", insertBeforeDS)
 	dList, err := jd.doRefreshDSList(l)
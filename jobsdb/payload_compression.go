@@ -0,0 +1,45 @@
+package jobsdb
+
+import (
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/jobsdb/internal/compression"
+)
+
+// initPayloadCodec sets up the codec used for compressing job payloads, configured through JobsDB.<tablePrefix>.payloadCompression.
+// Compression is only supported for BYTEA payload columns; for any other column type it is disabled.
+func (jd *Handle) initPayloadCodec() {
+	name := jd.config.GetStringVar(compression.None, jd.configKeys("payloadCompression")...)
+	codec, err := compression.Get(name)
+	if err != nil {
+		jd.logger.Errorn("invalid payload compression codec, payloads will be stored uncompressed",
+			logger.NewStringField("codec", name),
+			obskit.Error(err),
+		)
+		return
+	}
+	if codec != nil && jd.conf.payloadColumnType != BYTEA {
+		jd.logger.Warnn("payload compression requires a bytea payload column type, payloads will be stored uncompressed",
+			logger.NewStringField("codec", name),
+			logger.NewStringField("payloadColumnType", string(jd.conf.payloadColumnType)),
+		)
+		return
+	}
+	jd.conf.payloadCodec = codec
+}
+
+// encodePayload returns the value to be stored in the event_payload column for the provided payload,
+// compressing it if a payload codec is configured
+func (jd *Handle) encodePayload(payload []byte) (any, error) {
+	if jd.conf.payloadCodec == nil {
+		return string(payload), nil
+	}
+	return jd.conf.payloadCodec.Encode(payload)
+}
+
+// decodePayload decompresses a payload read from the event_payload column. Since compressed payloads are self-describing,
+// payloads are decoded regardless of the codec currently configured, while uncompressed payloads are returned as-is.
+func decodePayload(payload []byte) ([]byte, error) {
+	return compression.Decode(payload)
+}
@@ -0,0 +1,113 @@
+package jobsdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+
+	"github.com/rudderlabs/rudder-server/jobsdb/internal/compression"
+	"github.com/rudderlabs/rudder-server/utils/tx"
+)
+
+func TestPayloadCompression(t *testing.T) {
+	pg := startPostgres(t)
+	ctx := context.Background()
+
+	newJobsDB := func(t *testing.T, c *config.Config, tablePrefix string) *Handle {
+		jd := &Handle{config: c}
+		require.NoError(t, jd.Setup(ReadWrite, true, tablePrefix))
+		t.Cleanup(jd.TearDown)
+		return jd
+	}
+	storedPayload := func(t *testing.T, tablePrefix string) []byte {
+		var payload []byte
+		require.NoError(t, pg.DB.QueryRowContext(ctx, fmt.Sprintf(`SELECT event_payload FROM %q LIMIT 1`, tablePrefix+"_jobs_1")).Scan(&payload))
+		return payload
+	}
+
+	for _, codec := range []string{compression.Zstd, compression.LZ4, compression.Snappy} {
+		t.Run(codec, func(t *testing.T) {
+			tablePrefix := "compressed_" + codec
+			c := config.New()
+			c.Set("JobsDB."+tablePrefix+".payloadColumnType", string(BYTEA))
+			c.Set("JobsDB."+tablePrefix+".payloadCompression", codec)
+			jd := newJobsDB(t, c, tablePrefix)
+			require.NotNil(t, jd.conf.payloadCodec)
+
+			jobs := genJobs("wsid", "cv", 10, 1)
+			require.NoError(t, jd.Store(ctx, jobs))
+
+			stored := storedPayload(t, tablePrefix)
+			require.Equal(t, codec, compression.Detect(stored).Name(), "payload should be stored compressed")
+
+			res, err := jd.GetUnprocessed(ctx, GetQueryParams{JobsLimit: 100, IgnoreCustomValFiltersInQuery: true})
+			require.NoError(t, err)
+			require.Len(t, res.Jobs, len(jobs))
+			for i := range res.Jobs {
+				require.JSONEq(t, string(jobs[i].EventPayload), string(res.Jobs[i].EventPayload))
+			}
+			require.JSONEq(t, string(jobs[len(jobs)-1].EventPayload), string(jd.GetLastJob(ctx).EventPayload))
+		})
+	}
+
+	t.Run("compression is disabled for non-bytea payload columns", func(t *testing.T) {
+		c := config.New()
+		c.Set("JobsDB.payloadCompression", compression.Zstd)
+		jd := newJobsDB(t, c, "compressed_text")
+		require.Nil(t, jd.conf.payloadCodec)
+
+		require.NoError(t, jd.Store(ctx, genJobs("wsid", "cv", 1, 1)))
+		require.Nil(t, compression.Detect(storedPayload(t, "compressed_text")))
+	})
+
+	t.Run("invalid codec", func(t *testing.T) {
+		c := config.New()
+		c.Set("JobsDB.payloadColumnType", string(BYTEA))
+		c.Set("JobsDB.payloadCompression", "invalid")
+		jd := newJobsDB(t, c, "compressed_invalid")
+		require.Nil(t, jd.conf.payloadCodec)
+	})
+
+	t.Run("migration decodes compressed payloads", func(t *testing.T) {
+		for _, destType := range []payloadColumnType{TEXT, JSONB, BYTEA} {
+			t.Run(string(destType), func(t *testing.T) {
+				srcPrefix := "migrate_src_" + string(destType)
+				srcConf := config.New()
+				srcConf.Set("JobsDB.payloadColumnType", string(BYTEA))
+				srcConf.Set("JobsDB.payloadCompression", compression.Zstd)
+				srcJD := newJobsDB(t, srcConf, srcPrefix)
+
+				destPrefix := "migrate_dest_" + string(destType)
+				destConf := config.New()
+				destConf.Set("JobsDB.payloadColumnType", string(destType))
+				destJD := newJobsDB(t, destConf, destPrefix)
+
+				jobs := genJobs("wsid", "cv", 5, 1)
+				require.NoError(t, srcJD.Store(ctx, jobs))
+
+				txn, err := pg.DB.Begin()
+				require.NoError(t, err)
+				migrated, err := destJD.migrateJobsInTx(
+					ctx,
+					&tx.Tx{Tx: txn},
+					dataSetT{JobTable: srcPrefix + "_jobs_1", JobStatusTable: srcPrefix + "_job_status_1", Index: "1"},
+					dataSetT{JobTable: destPrefix + "_jobs_1", JobStatusTable: destPrefix + "_job_status_1", Index: "1"},
+				)
+				require.NoError(t, err)
+				require.Equal(t, len(jobs), migrated)
+				require.NoError(t, txn.Commit())
+
+				res, err := destJD.GetUnprocessed(ctx, GetQueryParams{JobsLimit: 100, IgnoreCustomValFiltersInQuery: true})
+				require.NoError(t, err)
+				require.Len(t, res.Jobs, len(jobs))
+				for i := range res.Jobs {
+					require.JSONEq(t, string(jobs[i].EventPayload), string(res.Jobs[i].EventPayload))
+				}
+			})
+		}
+	})
+}
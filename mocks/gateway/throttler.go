@@ -54,3 +54,19 @@ func (mr *MockThrottlerMockRecorder) CheckLimitReached(context, workspaceId, eve
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckLimitReached", reflect.TypeOf((*MockThrottler)(nil).CheckLimitReached), context, workspaceId, eventCount)
 }
+
+// CheckSourceAndUserLimitsReached mocks base method.
+func (m *MockThrottler) CheckSourceAndUserLimitsReached(ctx context.Context, sourceId string, userEventCounts map[string]int64) (string, func(context.Context) error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSourceAndUserLimitsReached", ctx, sourceId, userEventCounts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(func(context.Context) error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CheckSourceAndUserLimitsReached indicates an expected call of CheckSourceAndUserLimitsReached.
+func (mr *MockThrottlerMockRecorder) CheckSourceAndUserLimitsReached(ctx, sourceId, userEventCounts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSourceAndUserLimitsReached", reflect.TypeOf((*MockThrottler)(nil).CheckSourceAndUserLimitsReached), ctx, sourceId, userEventCounts)
+}
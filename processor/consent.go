@@ -1,27 +1,89 @@
 package processor
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/samber/lo"
 
 	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
 
 	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/processor/internal/tcf"
 	"github.com/rudderlabs/rudder-server/processor/types"
 	"github.com/rudderlabs/rudder-server/utils/misc"
+	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
+)
+
+const (
+	// iabConsentProvider is the provider of IAB TCF v2 consents, carried by the event as a TC string.
+	// Its consents are the ids of the TCF purposes and are always resolved as opt-in.
+	iabConsentProvider = "iab"
+
+	// consentModeOptIn requires the user to have allowed the consents configured in the destination,
+	// whereas in the default opt-out mode the destination only requires them not to be denied
+	consentModeOptIn = "opt-in"
+)
+
+// Consent rules removing a destination from an event's destinations
+const (
+	// consentRuleDenied removes a destination when the user has denied its consents
+	consentRuleDenied = "denied_consent"
+	// consentRuleNotAllowed removes an opt-in destination when the user has not allowed its consents
+	consentRuleNotAllowed = "missing_allowed_consent"
+	// consentRuleMissing removes an opt-in destination when the event carries no consents of its providers
+	consentRuleMissing = "missing_consent"
+	// consentRuleIABVendor removes a destination when the user has not consented to its IAB vendor
+	consentRuleIABVendor = "iab_vendor_consent"
 )
 
 type ConsentManagementInfo struct {
-	DeniedConsentIDs   []string    `json:"deniedConsentIds"`
-	AllowedConsentIDs  interface{} `json:"allowedConsentIds"` // Not used currently but added for future use
+	DeniedConsentIDs []string `json:"deniedConsentIds"`
+	// AllowedConsentIDs are the consents the user has opted in to, either as a list of ids or as an object keyed by id
+	AllowedConsentIDs  interface{} `json:"allowedConsentIds"`
 	Provider           string      `json:"provider"`
 	ResolutionStrategy string      `json:"resolutionStrategy"`
+	// TCString is the IAB TCF v2 consent string of the iab provider
+	TCString string `json:"tcString"`
+}
+
+// allowedConsentIDs returns the ids of the consents the user has opted in to
+func (info ConsentManagementInfo) allowedConsentIDs() []string {
+	var ids []string
+	switch allowed := info.AllowedConsentIDs.(type) {
+	case []interface{}:
+		for _, id := range allowed {
+			switch id := id.(type) {
+			case string:
+				ids = append(ids, id)
+			case float64:
+				ids = append(ids, strconv.FormatFloat(id, 'f', -1, 64))
+			}
+		}
+	case map[string]interface{}:
+		// e.g. OneTrust sends the allowed categories as an object of category ids to names
+		ids = lo.Keys(allowed)
+	}
+	return lo.Filter(ids, func(id string, _ int) bool { return id != "" })
 }
 
 type GenericConsentManagementProviderData struct {
 	ResolutionStrategy string
 	Consents           []string
+	ConsentMode        string
+	VendorID           int
+}
+
+// optIn returns true if the destination requires the user to have allowed its consents
+func (d GenericConsentManagementProviderData) optIn(provider ConsentProviderKey) bool {
+	return provider == iabConsentProvider || d.ConsentMode == consentModeOptIn
 }
 
 type GenericConsentsConfig struct {
@@ -32,6 +94,16 @@ type GenericConsentManagementProviderConfig struct {
 	Provider           string                  `json:"provider"`
 	ResolutionStrategy string                  `json:"resolutionStrategy"`
 	Consents           []GenericConsentsConfig `json:"consents"`
+	ConsentMode        string                  `json:"consentMode"`
+	VendorID           int                     `json:"vendorId"`
+}
+
+// consentDrop is a destination removed from an event's destinations by a consent rule
+type consentDrop struct {
+	destination backendconfig.DestinationT
+	provider    string
+	rule        string
+	reason      string
 }
 
 /*
@@ -41,56 +113,200 @@ Supports legacy and generic consent management.
 For GCM based filtering, uses source and destination IDs to fetch the appropriate GCM data from the config.
 */
 func (proc *Handle) getConsentFilteredDestinations(event types.SingularEventT, sourceID string, destinations []backendconfig.DestinationT) []backendconfig.DestinationT {
-	// If the event does not have denied consent IDs, do not filter any destinations
+	filtered, _ := proc.filterDestinationsByConsent(event, sourceID, destinations)
+	return filtered
+}
+
+// filterDestinationsByConsent splits the destinations into the ones the event can be sent to, according to the user consents present in the event,
+// and the ones removed by a consent rule along with the reason of their removal
+func (proc *Handle) filterDestinationsByConsent(event types.SingularEventT, sourceID string, destinations []backendconfig.DestinationT) ([]backendconfig.DestinationT, []consentDrop) {
 	consentManagementInfo, err := getConsentManagementInfo(event)
 	if err != nil {
 		// Log the error for debugging purposes
 		proc.logger.Errorw("failed to get consent management info", "error", err.Error())
 	}
 
-	if len(consentManagementInfo.DeniedConsentIDs) == 0 {
-		return destinations
+	// If the event does not have denied consent IDs, only opt-in destinations can be filtered
+	if len(consentManagementInfo.DeniedConsentIDs) == 0 && !proc.hasOptInConsents(sourceID) {
+		return destinations, nil
 	}
 
-	return lo.Filter(destinations, func(dest backendconfig.DestinationT, _ int) bool {
-		// Generic consent management
-		if cmpData := proc.getGCMData(sourceID, dest.ID, consentManagementInfo.Provider); len(cmpData.Consents) > 0 {
+	// The consents the user has given, lazily resolved since they are only needed by opt-in destinations
+	var (
+		allowedResolved bool
+		allowed         []string
+		tcfConsent      *tcf.Consent
+		tcfErr          error
+	)
+	resolveAllowed := func() {
+		if allowedResolved {
+			return
+		}
+		allowedResolved = true
+		if consentManagementInfo.Provider != iabConsentProvider {
+			allowed = lo.Without(consentManagementInfo.allowedConsentIDs(), consentManagementInfo.DeniedConsentIDs...)
+			return
+		}
+		if consentManagementInfo.TCString == "" {
+			tcfErr = errors.New("no tc string")
+			return
+		}
+		if tcfConsent, tcfErr = tcf.Decode(consentManagementInfo.TCString); tcfErr == nil {
+			allowed = lo.Map(tcfConsent.Purposes, func(purpose, _ int) string { return strconv.Itoa(purpose) })
+		}
+	}
+
+	var drops []consentDrop
+	drop := func(dest backendconfig.DestinationT, rule, reason string) bool {
+		drops = append(drops, consentDrop{destination: dest, provider: consentManagementInfo.Provider, rule: rule, reason: reason})
+		return false
+	}
+	filtered := lo.Filter(destinations, func(dest backendconfig.DestinationT, _ int) bool {
+		providers := proc.getGCMProviders(sourceID, dest.ID)
 
+		// Generic consent management
+		if cmpData, ok := providers[ConsentProviderKey(consentManagementInfo.Provider)]; ok {
 			finalResolutionStrategy := consentManagementInfo.ResolutionStrategy
 
-			// For custom provider, the resolution strategy is to be picked from the destination config
-			if consentManagementInfo.Provider == "custom" {
+			// For custom and iab providers, the resolution strategy is to be picked from the destination config
+			if consentManagementInfo.Provider == "custom" || consentManagementInfo.Provider == iabConsentProvider {
 				finalResolutionStrategy = cmpData.ResolutionStrategy
 			}
 
-			switch finalResolutionStrategy {
-			// The user must consent to at least one of the configured consents in the destination
-			case "or":
-				return !lo.Every(consentManagementInfo.DeniedConsentIDs, cmpData.Consents)
+			if cmpData.optIn(ConsentProviderKey(consentManagementInfo.Provider)) {
+				resolveAllowed()
+				if tcfErr != nil {
+					return drop(dest, consentRuleMissing, fmt.Sprintf("invalid tc string: %v", tcfErr))
+				}
+				if missing := lo.Without(cmpData.Consents, allowed...); len(cmpData.Consents) > 0 && !resolveConsents(finalResolutionStrategy, cmpData.Consents, missing) {
+					return drop(dest, consentRuleNotAllowed, fmt.Sprintf("consents %v were not allowed", missing))
+				}
+				if tcfConsent != nil && cmpData.VendorID > 0 && !tcfConsent.VendorConsent(cmpData.VendorID) {
+					return drop(dest, consentRuleIABVendor, fmt.Sprintf("vendor %d was not consented to", cmpData.VendorID))
+				}
+				return true
+			}
 
-			// The user must consent to all of the configured consents in the destination
-			default: // "and"
-				return len(lo.Intersect(cmpData.Consents, consentManagementInfo.DeniedConsentIDs)) == 0
+			if denied := lo.Intersect(cmpData.Consents, consentManagementInfo.DeniedConsentIDs); len(cmpData.Consents) > 0 && !resolveConsents(finalResolutionStrategy, cmpData.Consents, denied) {
+				return drop(dest, consentRuleDenied, fmt.Sprintf("consents %v were denied", denied))
 			}
+			return true
+		}
+
+		// Opt-in destinations require the consents of one of their providers
+		if optInProviders := lo.Filter(lo.Keys(providers), func(provider ConsentProviderKey, _ int) bool {
+			return providers[provider].optIn(provider)
+		}); len(optInProviders) > 0 {
+			slices.Sort(optInProviders)
+			return drop(dest, consentRuleMissing, fmt.Sprintf("no consents of the opt-in providers %v", optInProviders))
 		}
 
 		// Legacy consent management
 		if consentManagementInfo.Provider == "" || consentManagementInfo.Provider == "oneTrust" {
 			// If the destination has oneTrustCookieCategories, returns false if any of the oneTrustCategories are present in deniedCategories
 			if oneTrustCategories := proc.getOneTrustConsentData(dest.ID); len(oneTrustCategories) > 0 {
-				return len(lo.Intersect(oneTrustCategories, consentManagementInfo.DeniedConsentIDs)) == 0
+				if denied := lo.Intersect(oneTrustCategories, consentManagementInfo.DeniedConsentIDs); len(denied) > 0 {
+					return drop(dest, consentRuleDenied, fmt.Sprintf("oneTrust categories %v were denied", denied))
+				}
+				return true
 			}
 		}
 
 		if consentManagementInfo.Provider == "" || consentManagementInfo.Provider == "ketch" {
 			// If the destination has ketchConsentPurposes, returns false if all ketchPurposes are present in deniedCategories
 			if ketchPurposes := proc.getKetchConsentData(dest.ID); len(ketchPurposes) > 0 {
-				return !lo.Every(consentManagementInfo.DeniedConsentIDs, ketchPurposes)
+				if lo.Every(consentManagementInfo.DeniedConsentIDs, ketchPurposes) {
+					return drop(dest, consentRuleDenied, fmt.Sprintf("ketch purposes %v were denied", ketchPurposes))
+				}
+				return true
 			}
 		}
 
 		return true
 	})
+	return filtered, drops
+}
+
+// recordConsentDrops counts the destinations removed from an event by consent rules and adds them to the reporting maps as events filtered
+// by the consent filter, with a status detail for every consent rule. If auditing is enabled, the ids of the dropped messages are reported too,
+// so that every drop is recorded in the error index along with its destination and rule.
+func (proc *Handle) recordConsentDrops(
+	connectionDetailsMap map[string]*reportingtypes.ConnectionDetails,
+	statusDetailsMap map[string]map[string]*reportingtypes.StatusDetail,
+	metadata *types.Metadata,
+	receivedAt time.Time,
+	drops []consentDrop,
+) {
+	for _, d := range drops {
+		proc.statsFactory.NewTaggedStat("processor_consent_filtered_events", stats.CountType, stats.Tags{
+			"workspaceId":   metadata.WorkspaceID,
+			"sourceId":      metadata.SourceID,
+			"destinationId": d.destination.ID,
+			"provider":      d.provider,
+			"rule":          d.rule,
+		}).Increment()
+		proc.logger.Debugn("Dropping destination due to consent",
+			obskit.SourceID(metadata.SourceID),
+			obskit.DestinationID(d.destination.ID),
+			logger.NewStringField("rule", d.rule),
+			logger.NewStringField("reason", d.reason),
+		)
+
+		if !proc.isReportingEnabled() {
+			continue
+		}
+		dropMetadata := *metadata
+		dropMetadata.DestinationID = d.destination.ID
+		dropMetadata.DestinationName = d.destination.Name
+		dropMetadata.DestinationType = d.destination.DestinationDefinition.Name
+		dropMetadata.DestinationDefinitionID = d.destination.DestinationDefinition.ID
+		key := proc.addConnectionDetails(connectionDetailsMap, &dropMetadata, jobsdb.Filtered.State, reportingtypes.FilterEventCode)
+		if _, ok := statusDetailsMap[key]; !ok {
+			statusDetailsMap[key] = make(map[string]*reportingtypes.StatusDetail)
+		}
+		sdkey := d.rule + ":" + metadata.EventName + ":" + metadata.EventType
+		sd, ok := statusDetailsMap[key][sdkey]
+		if !ok {
+			sd = &reportingtypes.StatusDetail{
+				Status:         jobsdb.Filtered.State,
+				StatusCode:     reportingtypes.FilterEventCode,
+				SampleResponse: d.reason,
+				EventName:      metadata.EventName,
+				EventType:      metadata.EventType,
+				ErrorType:      d.rule,
+			}
+			statusDetailsMap[key][sdkey] = sd
+		}
+		sd.Count++
+		if proc.config.auditConsentDroppedEvents.Load() {
+			sd.FailedMessages = append(sd.FailedMessages, &reportingtypes.FailedMessage{MessageID: metadata.MessageID, ReceivedAt: receivedAt})
+		}
+	}
+}
+
+// resolveConsents returns true if the consents required by a destination are resolved given the ones the user has not given:
+// with the "or" resolution strategy the user must have given at least one of them, otherwise all of them
+func resolveConsents(resolutionStrategy string, required, notGiven []string) bool {
+	switch resolutionStrategy {
+	case "or":
+		return len(lo.Uniq(notGiven)) < len(lo.Uniq(required))
+	default: // "and"
+		return len(notGiven) == 0
+	}
+}
+
+// hasOptInConsents returns true if any destination of the source requires opt-in consents
+func (proc *Handle) hasOptInConsents(sourceID string) bool {
+	proc.config.configSubscriberLock.RLock()
+	defer proc.config.configSubscriberLock.RUnlock()
+	for _, providers := range proc.config.genericConsentManagementMap[SourceID(sourceID)] {
+		for provider, data := range providers {
+			if data.optIn(provider) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (proc *Handle) getOneTrustConsentData(destinationID string) []string {
@@ -105,22 +321,10 @@ func (proc *Handle) getKetchConsentData(destinationID string) []string {
 	return proc.config.ketchConsentCategoriesMap[destinationID]
 }
 
-func (proc *Handle) getGCMData(sourceID, destinationID, provider string) GenericConsentManagementProviderData {
+func (proc *Handle) getGCMProviders(sourceID, destinationID string) ConsentProviderMap {
 	proc.config.configSubscriberLock.RLock()
 	defer proc.config.configSubscriberLock.RUnlock()
-
-	defRetVal := GenericConsentManagementProviderData{}
-	destinationData, ok := proc.config.genericConsentManagementMap[SourceID(sourceID)][DestinationID(destinationID)]
-	if !ok {
-		return defRetVal
-	}
-
-	providerData, ok := destinationData[ConsentProviderKey(provider)]
-	if !ok {
-		return defRetVal
-	}
-
-	return providerData
+	return proc.config.genericConsentManagementMap[SourceID(sourceID)][DestinationID(destinationID)]
 }
 
 func getOneTrustConsentCategories(dest *backendconfig.DestinationT) []string {
@@ -183,21 +387,28 @@ func getGenericConsentManagementData(dest *backendconfig.DestinationT) (ConsentP
 	}
 
 	for _, providerConfig := range consentManagementConfig {
-		consentsConfig := providerConfig.Consents
-
-		if len(consentsConfig) > 0 && providerConfig.Provider != "" {
-			consentIDs := lo.FilterMap(
-				consentsConfig,
-				func(consentsObj GenericConsentsConfig, _ int) (string, bool) {
-					return consentsObj.Consent, consentsObj.Consent != ""
-				},
-			)
-
-			if len(consentIDs) > 0 {
-				genericConsentManagementData[ConsentProviderKey(providerConfig.Provider)] = GenericConsentManagementProviderData{
-					ResolutionStrategy: providerConfig.ResolutionStrategy,
-					Consents:           consentIDs,
-				}
+		if providerConfig.Provider == "" {
+			continue
+		}
+		consentIDs := lo.FilterMap(
+			providerConfig.Consents,
+			func(consentsObj GenericConsentsConfig, _ int) (string, bool) {
+				return consentsObj.Consent, consentsObj.Consent != ""
+			},
+		)
+
+		// IAB destinations can require the consent of their vendor only
+		vendorID := 0
+		if providerConfig.Provider == iabConsentProvider {
+			vendorID = providerConfig.VendorID
+		}
+
+		if len(consentIDs) > 0 || vendorID > 0 {
+			genericConsentManagementData[ConsentProviderKey(providerConfig.Provider)] = GenericConsentManagementProviderData{
+				ResolutionStrategy: providerConfig.ResolutionStrategy,
+				Consents:           consentIDs,
+				ConsentMode:        providerConfig.ConsentMode,
+				VendorID:           vendorID,
 			}
 		}
 	}
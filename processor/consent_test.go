@@ -2,13 +2,20 @@ package processor
 
 import (
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rudderlabs/rudder-go-kit/config"
 	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
 	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	mockreportingtypes "github.com/rudderlabs/rudder-server/mocks/utils/types"
 	"github.com/rudderlabs/rudder-server/processor/types"
+	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
 )
 
 type ConnectionInfo struct {
@@ -1794,3 +1801,255 @@ func TestGetGenericConsentManagementData(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterDestinationsByConsent(t *testing.T) {
+	// consents to purposes 1 and 2 and vendors 1 to 5
+	const tcString = "CP3wTQAP3wTQAAHADBENCWEAAMAAAAAAAAAAAFPg"
+
+	gcmDestination := func(id string, providerConfigs ...map[string]interface{}) backendconfig.DestinationT {
+		return backendconfig.DestinationT{
+			ID: id,
+			Config: map[string]interface{}{
+				"consentManagement": lo.Map(providerConfigs, func(c map[string]interface{}, _ int) interface{} { return c }),
+			},
+		}
+	}
+	consents := func(ids ...string) []interface{} {
+		return lo.Map(ids, func(id string, _ int) interface{} { return map[string]interface{}{"consent": id} })
+	}
+	event := func(consentManagement map[string]interface{}) types.SingularEventT {
+		return types.SingularEventT{"context": map[string]interface{}{"consentManagement": consentManagement}}
+	}
+
+	destinations := []backendconfig.DestinationT{
+		gcmDestination("opt-in-and", map[string]interface{}{
+			"provider": "oneTrust", "consentMode": "opt-in", "consents": consents("analytics", "ads"),
+		}),
+		gcmDestination("opt-in-or", map[string]interface{}{
+			"provider": "custom", "consentMode": "opt-in", "resolutionStrategy": "or", "consents": consents("analytics", "ads"),
+		}),
+		gcmDestination("opt-out", map[string]interface{}{
+			"provider": "oneTrust", "consents": consents("ads"),
+		}),
+		gcmDestination("iab", map[string]interface{}{
+			"provider": "iab", "consents": consents("1", "2"), "vendorId": 5,
+		}),
+		gcmDestination("iab-purpose", map[string]interface{}{
+			"provider": "iab", "consents": consents("1", "3"),
+		}),
+		gcmDestination("iab-vendor", map[string]interface{}{
+			"provider": "iab", "vendorId": 6,
+		}),
+		{ID: "no-consents"},
+	}
+
+	type drop struct {
+		destinationID, rule string
+	}
+	testCases := []struct {
+		description   string
+		event         types.SingularEventT
+		expectedDests []string
+		expectedDrops []drop
+	}{
+		{
+			description:   "opt-in destinations are dropped without consents",
+			event:         types.SingularEventT{},
+			expectedDests: []string{"opt-out", "no-consents"},
+			expectedDrops: []drop{
+				{"opt-in-and", consentRuleMissing},
+				{"opt-in-or", consentRuleMissing},
+				{"iab", consentRuleMissing},
+				{"iab-purpose", consentRuleMissing},
+				{"iab-vendor", consentRuleMissing},
+			},
+		},
+		{
+			description: "allowed consents as a list",
+			event: event(map[string]interface{}{
+				"provider":          "oneTrust",
+				"allowedConsentIds": []interface{}{"analytics", "ads"},
+			}),
+			expectedDests: []string{"opt-in-and", "opt-out", "no-consents"},
+			expectedDrops: []drop{
+				{"opt-in-or", consentRuleMissing},
+				{"iab", consentRuleMissing},
+				{"iab-purpose", consentRuleMissing},
+				{"iab-vendor", consentRuleMissing},
+			},
+		},
+		{
+			description: "allowed consents as an object",
+			event: event(map[string]interface{}{
+				"provider":          "oneTrust",
+				"allowedConsentIds": map[string]interface{}{"analytics": "Analytics"},
+				"deniedConsentIds":  []interface{}{"ads"},
+			}),
+			expectedDests: []string{"no-consents"},
+			expectedDrops: []drop{
+				{"opt-in-and", consentRuleNotAllowed},
+				{"opt-in-or", consentRuleMissing},
+				{"opt-out", consentRuleDenied},
+				{"iab", consentRuleMissing},
+				{"iab-purpose", consentRuleMissing},
+				{"iab-vendor", consentRuleMissing},
+			},
+		},
+		{
+			description: "denied consents take precedence over allowed ones",
+			event: event(map[string]interface{}{
+				"provider":          "custom",
+				"allowedConsentIds": []interface{}{"analytics", "ads"},
+				"deniedConsentIds":  []interface{}{"analytics", "ads"},
+			}),
+			expectedDests: []string{"opt-out", "no-consents"},
+			expectedDrops: []drop{
+				{"opt-in-and", consentRuleMissing},
+				{"opt-in-or", consentRuleNotAllowed},
+				{"iab", consentRuleMissing},
+				{"iab-purpose", consentRuleMissing},
+				{"iab-vendor", consentRuleMissing},
+			},
+		},
+		{
+			description: "or resolution strategy requires one allowed consent",
+			event: event(map[string]interface{}{
+				"provider":          "custom",
+				"allowedConsentIds": []interface{}{"ads"},
+			}),
+			expectedDests: []string{"opt-in-or", "opt-out", "no-consents"},
+			expectedDrops: []drop{
+				{"opt-in-and", consentRuleMissing},
+				{"iab", consentRuleMissing},
+				{"iab-purpose", consentRuleMissing},
+				{"iab-vendor", consentRuleMissing},
+			},
+		},
+		{
+			description: "iab tc string",
+			event: event(map[string]interface{}{
+				"provider": "iab",
+				"tcString": tcString,
+			}),
+			expectedDests: []string{"opt-out", "iab", "no-consents"},
+			expectedDrops: []drop{
+				{"opt-in-and", consentRuleMissing},
+				{"opt-in-or", consentRuleMissing},
+				{"iab-purpose", consentRuleNotAllowed},
+				{"iab-vendor", consentRuleIABVendor},
+			},
+		},
+		{
+			description: "invalid iab tc string",
+			event: event(map[string]interface{}{
+				"provider": "iab",
+				"tcString": "invalid",
+			}),
+			expectedDests: []string{"opt-out", "no-consents"},
+			expectedDrops: []drop{
+				{"opt-in-and", consentRuleMissing},
+				{"opt-in-or", consentRuleMissing},
+				{"iab", consentRuleMissing},
+				{"iab-purpose", consentRuleMissing},
+				{"iab-vendor", consentRuleMissing},
+			},
+		},
+	}
+
+	proc := &Handle{}
+	proc.logger = logger.NOP
+	proc.config.genericConsentManagementMap = SourceConsentMap{"sourceID": make(DestConsentMap)}
+	for _, dest := range destinations {
+		proc.config.genericConsentManagementMap["sourceID"][DestinationID(dest.ID)], _ = getGenericConsentManagementData(&dest)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			filtered, drops := proc.filterDestinationsByConsent(tc.event, "sourceID", destinations)
+			require.Equal(t, tc.expectedDests, lo.Map(filtered, func(dest backendconfig.DestinationT, _ int) string { return dest.ID }))
+			require.Equal(t, tc.expectedDrops, lo.Map(drops, func(d consentDrop, _ int) drop { return drop{d.destination.ID, d.rule} }))
+			for _, d := range drops {
+				require.NotEmpty(t, d.reason)
+			}
+		})
+	}
+
+	t.Run("opt-out destinations are not filtered without denied consents", func(t *testing.T) {
+		proc := &Handle{}
+		proc.logger = logger.NOP
+		proc.config.genericConsentManagementMap = SourceConsentMap{"sourceID": make(DestConsentMap)}
+		dest := destinations[2]
+		proc.config.genericConsentManagementMap["sourceID"][DestinationID(dest.ID)], _ = getGenericConsentManagementData(&dest)
+
+		filtered, drops := proc.filterDestinationsByConsent(types.SingularEventT{}, "sourceID", []backendconfig.DestinationT{dest})
+		require.Len(t, filtered, 1)
+		require.Empty(t, drops)
+	})
+}
+
+func TestAllowedConsentIDs(t *testing.T) {
+	require.Equal(t, []string{"a", "1"}, ConsentManagementInfo{AllowedConsentIDs: []interface{}{"a", "", 1.0, true}}.allowedConsentIDs())
+	require.ElementsMatch(t, []string{"a", "b"}, ConsentManagementInfo{AllowedConsentIDs: map[string]interface{}{"a": "A", "b": "B"}}.allowedConsentIDs())
+	require.Empty(t, ConsentManagementInfo{AllowedConsentIDs: "a"}.allowedConsentIDs())
+	require.Empty(t, ConsentManagementInfo{}.allowedConsentIDs())
+}
+
+func TestRecordConsentDrops(t *testing.T) {
+	statsStore, err := memstats.New()
+	require.NoError(t, err)
+	c := config.New()
+	c.Set("Processor.consentManagement.auditDroppedEvents", true)
+	proc := NewHandle(c, nil)
+	proc.logger = logger.NOP
+	proc.statsFactory = statsStore
+	proc.reportingEnabled = true
+	proc.reporting = &mockreportingtypes.MockReporting{}
+
+	receivedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	destination := backendconfig.DestinationT{ID: "destID", Name: "dest", DestinationDefinition: backendconfig.DestinationDefinitionT{ID: "destDefID", Name: "DEST"}}
+	metadata := &types.Metadata{WorkspaceID: "workspaceID", SourceID: "sourceID", MessageID: "messageID", EventName: "Order Completed", EventType: "track"}
+
+	connectionDetailsMap := make(map[string]*reportingtypes.ConnectionDetails)
+	statusDetailsMap := make(map[string]map[string]*reportingtypes.StatusDetail)
+	proc.recordConsentDrops(connectionDetailsMap, statusDetailsMap, metadata, receivedAt, []consentDrop{
+		{destination: destination, provider: "oneTrust", rule: consentRuleDenied, reason: "consents [ads] were denied"},
+	})
+	metadata.MessageID = "messageID-2"
+	proc.recordConsentDrops(connectionDetailsMap, statusDetailsMap, metadata, receivedAt, []consentDrop{
+		{destination: destination, provider: "oneTrust", rule: consentRuleDenied, reason: "consents [analytics] were denied"},
+		{destination: destination, provider: "oneTrust", rule: consentRuleNotAllowed, reason: "consents [ads] were not allowed"},
+	})
+
+	require.Len(t, connectionDetailsMap, 1)
+	for key, cd := range connectionDetailsMap {
+		require.Equal(t, "sourceID", cd.SourceID)
+		require.Equal(t, "destID", cd.DestinationID)
+		require.Equal(t, "destDefID", cd.DestinationDefinitionID)
+
+		sds := statusDetailsMap[key]
+		require.Len(t, sds, 2)
+		denied := sds[consentRuleDenied+":Order Completed:track"]
+		require.Equal(t, &reportingtypes.StatusDetail{
+			Status:         jobsdb.Filtered.State,
+			StatusCode:     reportingtypes.FilterEventCode,
+			Count:          2,
+			SampleResponse: "consents [ads] were denied",
+			EventName:      "Order Completed",
+			EventType:      "track",
+			ErrorType:      consentRuleDenied,
+			FailedMessages: []*reportingtypes.FailedMessage{
+				{MessageID: "messageID", ReceivedAt: receivedAt},
+				{MessageID: "messageID-2", ReceivedAt: receivedAt},
+			},
+		}, denied)
+		require.EqualValues(t, 1, sds[consentRuleNotAllowed+":Order Completed:track"].Count)
+	}
+
+	require.EqualValues(t, 2, statsStore.Get("processor_consent_filtered_events", stats.Tags{
+		"workspaceId":   "workspaceID",
+		"sourceId":      "sourceID",
+		"destinationId": "destID",
+		"provider":      "oneTrust",
+		"rule":          consentRuleDenied,
+	}).LastValue())
+}
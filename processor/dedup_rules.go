@@ -0,0 +1,71 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+)
+
+// dedupRuleKey is the content-based deduplication key computed by a source's dedup rule for an event
+type dedupRuleKey struct {
+	rule string
+	key  string
+}
+
+// dedupRuleKeys computes the content-based deduplication keys of an event for all dedup rules of its source.
+// Rules with an invalid window, or for which the event is missing any of the key paths, are skipped.
+//
+// Keys are scoped to the source and rule, and include a window bucket: every rule yields a key for the bucket the event
+// was received in and one for the previous bucket, so that duplicates received within a window of each other are
+// detected even across a bucket boundary. Duplicates received up to twice the window apart may be detected as well.
+func dedupRuleKeys(source *backendconfig.SourceT, payload []byte, receivedAt time.Time) []dedupRuleKey {
+	if len(source.Dedup.Rules) == 0 || len(payload) == 0 {
+		return nil
+	}
+	var keys []dedupRuleKey
+	for _, rule := range source.Dedup.Rules {
+		if rule.Name == "" || len(rule.KeyPaths) == 0 {
+			continue
+		}
+		window, err := time.ParseDuration(rule.Window)
+		if err != nil || window <= 0 {
+			continue
+		}
+		values := gjson.GetManyBytes(payload, rule.KeyPaths...)
+		h := sha256.New()
+		missing := false
+		for _, value := range values {
+			if !value.Exists() || value.Type == gjson.Null || value.Raw == `""` {
+				missing = true
+				break
+			}
+			// using the raw value, so that e.g. the number 1 and the string "1" are not considered equal
+			_, _ = h.Write([]byte(value.Raw))
+			_, _ = h.Write([]byte{0})
+		}
+		if missing {
+			continue
+		}
+		hash := hex.EncodeToString(h.Sum(nil))
+		bucket := receivedAt.UnixNano() / window.Nanoseconds()
+		for _, b := range []int64{bucket, bucket - 1} {
+			keys = append(keys, dedupRuleKey{
+				rule: rule.Name,
+				key: strings.Join([]string{
+					"rule",
+					source.ID,
+					rule.Name,
+					strconv.FormatInt(b, 10),
+					hash,
+				}, ":"),
+			})
+		}
+	}
+	return keys
+}
@@ -0,0 +1,89 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+)
+
+func TestDedupRuleKeys(t *testing.T) {
+	receivedAt := time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC)
+	newSource := func(rules ...backendconfig.DedupRuleT) *backendconfig.SourceT {
+		return &backendconfig.SourceT{ID: "source-1", Dedup: backendconfig.DedupConfigT{Rules: rules}}
+	}
+	orderRule := backendconfig.DedupRuleT{Name: "order", KeyPaths: []string{"event", "properties.order_id"}, Window: "10m"}
+	keysOf := func(keys []dedupRuleKey) []string {
+		return lo.Map(keys, func(k dedupRuleKey, _ int) string { return k.key })
+	}
+
+	t.Run("no rules", func(t *testing.T) {
+		require.Empty(t, dedupRuleKeys(newSource(), []byte(`{"event":"a"}`), receivedAt))
+	})
+
+	t.Run("same content within the same window", func(t *testing.T) {
+		source := newSource(orderRule)
+		keys1 := dedupRuleKeys(source, []byte(`{"messageId":"1","event":"Order Completed","properties":{"order_id":"o-1"}}`), receivedAt)
+		keys2 := dedupRuleKeys(source, []byte(`{"messageId":"2","event":"Order Completed","properties":{"order_id":"o-1","total":5}}`), receivedAt.Add(5*time.Minute))
+		require.Len(t, keys1, 2)
+		require.Equal(t, "order", keys1[0].rule)
+		require.Regexp(t, `^rule:source-1:order:\d+:[0-9a-f]{64}$`, keys1[0].key)
+		require.Equal(t, keysOf(keys1), keysOf(keys2))
+	})
+
+	t.Run("same content across a window boundary", func(t *testing.T) {
+		source := newSource(orderRule)
+		payload := []byte(`{"event":"Order Completed","properties":{"order_id":"o-1"}}`)
+		boundary := receivedAt.Truncate(10 * time.Minute).Add(10 * time.Minute)
+		keys1 := keysOf(dedupRuleKeys(source, payload, boundary.Add(-time.Second)))
+		keys2 := keysOf(dedupRuleKeys(source, payload, boundary.Add(time.Second)))
+		require.NotEqual(t, keys1[0], keys2[0])
+		require.Equal(t, keys1[0], keys2[1], "the previous bucket's key of the later event should match the earlier event's key")
+	})
+
+	t.Run("different content", func(t *testing.T) {
+		source := newSource(orderRule)
+		keys1 := dedupRuleKeys(source, []byte(`{"event":"Order Completed","properties":{"order_id":"o-1"}}`), receivedAt)
+		keys2 := dedupRuleKeys(source, []byte(`{"event":"Order Completed","properties":{"order_id":"o-2"}}`), receivedAt)
+		keys3 := dedupRuleKeys(source, []byte(`{"event":"Order Completed","properties":{"order_id":1}}`), receivedAt)
+		keys4 := dedupRuleKeys(source, []byte(`{"event":"Order Completed","properties":{"order_id":"1"}}`), receivedAt)
+		require.NotEqual(t, keysOf(keys1), keysOf(keys2))
+		require.NotEqual(t, keysOf(keys3), keysOf(keys4))
+	})
+
+	t.Run("different windows", func(t *testing.T) {
+		source := newSource(orderRule)
+		payload := []byte(`{"event":"Order Completed","properties":{"order_id":"o-1"}}`)
+		require.Empty(t, lo.Intersect(keysOf(dedupRuleKeys(source, payload, receivedAt)), keysOf(dedupRuleKeys(source, payload, receivedAt.Add(20*time.Minute)))))
+	})
+
+	t.Run("different sources", func(t *testing.T) {
+		payload := []byte(`{"event":"Order Completed","properties":{"order_id":"o-1"}}`)
+		other := newSource(orderRule)
+		other.ID = "source-2"
+		require.NotEqual(t, keysOf(dedupRuleKeys(newSource(orderRule), payload, receivedAt)), keysOf(dedupRuleKeys(other, payload, receivedAt)))
+	})
+
+	t.Run("missing or empty key paths", func(t *testing.T) {
+		source := newSource(orderRule)
+		require.Empty(t, dedupRuleKeys(source, []byte(`{"event":"Order Completed"}`), receivedAt))
+		require.Empty(t, dedupRuleKeys(source, []byte(`{"event":"Order Completed","properties":{"order_id":null}}`), receivedAt))
+		require.Empty(t, dedupRuleKeys(source, []byte(`{"event":"Order Completed","properties":{"order_id":""}}`), receivedAt))
+	})
+
+	t.Run("invalid rules are skipped", func(t *testing.T) {
+		source := newSource(
+			backendconfig.DedupRuleT{Name: "no-window", KeyPaths: []string{"event"}},
+			backendconfig.DedupRuleT{Name: "bad-window", KeyPaths: []string{"event"}, Window: "ten minutes"},
+			backendconfig.DedupRuleT{Name: "no-paths", Window: "10m"},
+			backendconfig.DedupRuleT{KeyPaths: []string{"event"}, Window: "10m"},
+			backendconfig.DedupRuleT{Name: "event", KeyPaths: []string{"event"}, Window: "1h"},
+		)
+		keys := dedupRuleKeys(source, []byte(`{"event":"Order Completed"}`), receivedAt)
+		require.Len(t, keys, 2)
+		require.Equal(t, []string{"event"}, lo.Uniq(lo.Map(keys, func(k dedupRuleKey, _ int) string { return k.rule })))
+	})
+}
@@ -0,0 +1,204 @@
+// Package sampling reduces the volume of events sent to a destination, according to the sampling rules of its config.
+//
+// A rule either keeps a percentage of the events it matches, deterministically on the hash of their user so that
+// the same users are always kept, or sheds the events above a maximum rate, or both. Only the first rule matching
+// an event applies to it, e.g.
+//
+//	{
+//	  "eventSampling": {
+//	    "enabled": true,
+//	    "rules": [
+//	      {"eventName": "Product Viewed", "percentage": 10},
+//	      {"eventType": "page", "maxEventsPerSecond": 100}
+//	    ]
+//	  }
+//	}
+//
+// The maximum rate applies to the time events were received at, not to how fast they are processed: events are counted
+// in fixed windows of their receivedAt, so that a backlog of events received at a low rate is not shed once processed.
+// Rates are enforced by every processor node on its own, thus a destination receives up to the maximum rate per node.
+package sampling
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+)
+
+// ConfigKey is the key of the sampling configuration in a destination's config
+const ConfigKey = "eventSampling"
+
+// Reasons of the events dropped by a sampler
+const (
+	// SampledOutReason is the reason of events dropped for not being part of the kept percentage
+	SampledOutReason = "sampled_out"
+	// VolumeShedReason is the reason of events dropped for exceeding the maximum rate
+	VolumeShedReason = "volume_shed"
+)
+
+var ErrInvalidConfig = errors.New("invalid event sampling config")
+
+// Rule is a sampling rule, matching the events of its event name and type, or all of them if empty
+type Rule struct {
+	EventName string `json:"eventName"`
+	EventType string `json:"eventType"`
+	// Percentage of the events kept, all of them if nil
+	Percentage *float64 `json:"percentage"`
+	// MaxEventsPerSecond is the rate above which events are shed, unlimited if zero.
+	// It is enforced per processor node.
+	MaxEventsPerSecond float64 `json:"maxEventsPerSecond"`
+}
+
+func (r Rule) equal(other Rule) bool {
+	if (r.Percentage == nil) != (other.Percentage == nil) || (r.Percentage != nil && *r.Percentage != *other.Percentage) {
+		return false
+	}
+	return r.EventName == other.EventName && r.EventType == other.EventType && r.MaxEventsPerSecond == other.MaxEventsPerSecond
+}
+
+// Config is the sampling configuration of a destination
+type Config struct {
+	Enabled bool   `json:"enabled"`
+	Rules   []Rule `json:"rules"`
+}
+
+// ParseConfig parses the sampling configuration of a destination's config, returning false if sampling is not enabled
+func ParseConfig(destConfig map[string]any) (Config, bool, error) {
+	raw, ok := destConfig[ConfigKey]
+	if !ok {
+		return Config{}, false, nil
+	}
+	data, err := jsonrs.Marshal(raw)
+	if err != nil {
+		return Config{}, false, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	var conf Config
+	if err := jsonrs.Unmarshal(data, &conf); err != nil {
+		return Config{}, false, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if !conf.Enabled || len(conf.Rules) == 0 {
+		return Config{}, false, nil
+	}
+	for i, r := range conf.Rules {
+		if r.Percentage == nil && r.MaxEventsPerSecond == 0 {
+			return Config{}, false, fmt.Errorf("%w: rule %d has neither a percentage nor a maximum rate", ErrInvalidConfig, i)
+		}
+		if r.Percentage != nil && (*r.Percentage < 0 || *r.Percentage > 100) {
+			return Config{}, false, fmt.Errorf("%w: rule %d has an invalid percentage: %v", ErrInvalidConfig, i, *r.Percentage)
+		}
+		if r.MaxEventsPerSecond < 0 {
+			return Config{}, false, fmt.Errorf("%w: rule %d has an invalid maximum rate: %v", ErrInvalidConfig, i, r.MaxEventsPerSecond)
+		}
+	}
+	return conf, true, nil
+}
+
+// Sampler samples the events of a destination. It is safe for concurrent use.
+type Sampler struct {
+	rules []rule
+}
+
+type rule struct {
+	Rule
+	// limiter sheds the events above the maximum rate, nil if unlimited
+	limiter *limiter
+}
+
+// New returns a sampler of the configured rules. The rules of the previous sampler of the destination, if any,
+// keep counting the events of their current windows if unchanged, so that config updates don't reset their rates.
+func New(conf Config, previous *Sampler) *Sampler {
+	s := &Sampler{rules: make([]rule, len(conf.Rules))}
+	for i, r := range conf.Rules {
+		s.rules[i].Rule = r
+		if r.MaxEventsPerSecond <= 0 {
+			continue
+		}
+		if previous != nil {
+			for _, pr := range previous.rules {
+				if pr.limiter != nil && pr.equal(r) {
+					s.rules[i].limiter = pr.limiter
+					break
+				}
+			}
+		}
+		if s.rules[i].limiter == nil {
+			s.rules[i].limiter = newLimiter(r.MaxEventsPerSecond)
+		}
+	}
+	return s
+}
+
+// Sample returns true if the event of the user, received at receivedAt, is to be kept, otherwise the reason it is dropped
+func (s *Sampler) Sample(eventName, eventType, userID string, receivedAt time.Time) (bool, string) {
+	for i := range s.rules {
+		r := &s.rules[i]
+		if (r.EventName != "" && r.EventName != eventName) || (r.EventType != "" && r.EventType != eventType) {
+			continue
+		}
+		if r.Percentage != nil && !keep(userID, *r.Percentage) {
+			return false, SampledOutReason
+		}
+		if r.limiter != nil && !r.limiter.allow(receivedAt) {
+			return false, VolumeShedReason
+		}
+		return true, ""
+	}
+	return true, ""
+}
+
+// retainedWindows is the number of windows before the latest one whose counts are kept, for events received out of order
+const retainedWindows = 60
+
+// limiter keeps up to a limit of events per fixed window of their receivedAt.
+// Windows last a second, or longer for rates below one event per second, their limit being the rate over the window rounded down.
+type limiter struct {
+	window time.Duration
+	limit  int
+
+	mu     sync.Mutex
+	counts map[int64]int // kept events per window index
+	latest int64         // index of the latest window
+}
+
+func newLimiter(maxEventsPerSecond float64) *limiter {
+	window := time.Second
+	if maxEventsPerSecond < 1 {
+		window = time.Duration(float64(time.Second) / maxEventsPerSecond)
+	}
+	return &limiter{
+		window: window,
+		limit:  max(1, int(maxEventsPerSecond*window.Seconds())),
+		counts: make(map[int64]int),
+	}
+}
+
+func (l *limiter) allow(receivedAt time.Time) bool {
+	index := receivedAt.UnixNano() / int64(l.window)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if index > l.latest {
+		l.latest = index
+		for i := range l.counts {
+			if i < index-retainedWindows {
+				delete(l.counts, i)
+			}
+		}
+	}
+	if l.counts[index] >= l.limit {
+		return false
+	}
+	l.counts[index]++
+	return true
+}
+
+// keep returns true if the user is part of the percentage of kept users
+func keep(userID string, percentage float64) bool {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(userID))
+	return float64(h.Sum64()%10000) < percentage*100
+}
@@ -0,0 +1,149 @@
+package sampling
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	conf, ok, err := ParseConfig(map[string]any{ConfigKey: map[string]any{"enabled": true, "rules": []any{
+		map[string]any{"eventName": "Product Viewed", "percentage": 10},
+		map[string]any{"eventType": "page", "maxEventsPerSecond": 100},
+	}}})
+	require.NoError(t, err)
+	require.True(t, ok)
+	percentage := 10.0
+	require.Equal(t, Config{Enabled: true, Rules: []Rule{
+		{EventName: "Product Viewed", Percentage: &percentage},
+		{EventType: "page", MaxEventsPerSecond: 100},
+	}}, conf)
+
+	_, ok, err = ParseConfig(map[string]any{})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = ParseConfig(map[string]any{ConfigKey: map[string]any{"enabled": false, "rules": []any{map[string]any{"percentage": 10}}}})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = ParseConfig(map[string]any{ConfigKey: map[string]any{"enabled": true}})
+	require.NoError(t, err)
+	require.False(t, ok, "sampling without rules is not enabled")
+
+	for name, config := range map[string]any{
+		"malformed":           "enabled",
+		"no limit":            map[string]any{"enabled": true, "rules": []any{map[string]any{"eventType": "page"}}},
+		"negative percentage": map[string]any{"enabled": true, "rules": []any{map[string]any{"percentage": -1}}},
+		"invalid percentage":  map[string]any{"enabled": true, "rules": []any{map[string]any{"percentage": 101}}},
+		"negative rate":       map[string]any{"enabled": true, "rules": []any{map[string]any{"maxEventsPerSecond": -5}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := ParseConfig(map[string]any{ConfigKey: config})
+			require.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestSample(t *testing.T) {
+	percentage := func(p float64) *float64 { return &p }
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("percentage", func(t *testing.T) {
+		s := New(Config{Rules: []Rule{{EventName: "Product Viewed", Percentage: percentage(20)}}}, nil)
+		var kept int
+		for i := 0; i < 10000; i++ {
+			userID := "user-" + strconv.Itoa(i)
+			ok, reason := s.Sample("Product Viewed", "track", userID, now)
+			if ok {
+				kept++
+				require.Empty(t, reason)
+			} else {
+				require.Equal(t, SampledOutReason, reason)
+			}
+			again, _ := s.Sample("Product Viewed", "track", userID, now)
+			require.Equal(t, ok, again, "sampling is deterministic on the user")
+		}
+		require.InDelta(t, 2000, kept, 200)
+
+		ok, _ := s.Sample("Order Completed", "track", "user-1", now)
+		require.True(t, ok, "events not matching any rule are kept")
+	})
+
+	t.Run("all or nothing", func(t *testing.T) {
+		none := New(Config{Rules: []Rule{{Percentage: percentage(0)}}}, nil)
+		all := New(Config{Rules: []Rule{{Percentage: percentage(100)}}}, nil)
+		for i := 0; i < 100; i++ {
+			ok, _ := none.Sample("", "track", strconv.Itoa(i), now)
+			require.False(t, ok)
+			ok, _ = all.Sample("", "track", strconv.Itoa(i), now)
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("shedding", func(t *testing.T) {
+		s := New(Config{Rules: []Rule{{EventType: "page", MaxEventsPerSecond: 2}}}, nil)
+		ok, _ := s.Sample("Home", "page", "user-1", now)
+		require.True(t, ok)
+		ok, _ = s.Sample("Home", "page", "user-2", now)
+		require.True(t, ok)
+		ok, reason := s.Sample("Home", "page", "user-3", now)
+		require.False(t, ok)
+		require.Equal(t, VolumeShedReason, reason)
+
+		ok, _ = s.Sample("Order Completed", "track", "user-3", now)
+		require.True(t, ok, "events not matching any rule are not shed")
+	})
+
+	t.Run("shedding on receivedAt", func(t *testing.T) {
+		s := New(Config{Rules: []Rule{{MaxEventsPerSecond: 2}}}, nil)
+		kept := func(receivedAt time.Time) bool {
+			ok, _ := s.Sample("", "track", "user-1", receivedAt)
+			return ok
+		}
+		require.True(t, kept(now))
+		require.True(t, kept(now.Add(500*time.Millisecond)))
+		require.False(t, kept(now.Add(900*time.Millisecond)))
+		require.True(t, kept(now.Add(time.Second)), "events received in the next second are kept, however fast they are processed")
+		require.False(t, kept(now.Add(100*time.Millisecond)), "events received out of order count against their own window")
+	})
+
+	t.Run("shedding below one event per second", func(t *testing.T) {
+		s := New(Config{Rules: []Rule{{MaxEventsPerSecond: 0.5}}}, nil)
+		// windows of two seconds, now being the second one of its window
+		ok, _ := s.Sample("", "track", "user-1", now.Add(-time.Second))
+		require.True(t, ok)
+		ok, _ = s.Sample("", "track", "user-1", now)
+		require.False(t, ok)
+		ok, _ = s.Sample("", "track", "user-1", now.Add(time.Second))
+		require.True(t, ok)
+	})
+
+	t.Run("config updates", func(t *testing.T) {
+		conf := Config{Rules: []Rule{{EventType: "page", MaxEventsPerSecond: 1}, {EventType: "track", MaxEventsPerSecond: 1}}}
+		s := New(conf, nil)
+		ok, _ := s.Sample("", "page", "user-1", now)
+		require.True(t, ok)
+		ok, _ = s.Sample("", "track", "user-1", now)
+		require.True(t, ok)
+
+		updated := New(Config{Rules: []Rule{{EventType: "page", MaxEventsPerSecond: 1}, {EventType: "track", MaxEventsPerSecond: 2}}}, s)
+		ok, _ = updated.Sample("", "page", "user-2", now)
+		require.False(t, ok, "unchanged rules keep their rates")
+		ok, _ = updated.Sample("", "track", "user-2", now)
+		require.True(t, ok, "changed rules start over")
+	})
+
+	t.Run("first matching rule", func(t *testing.T) {
+		s := New(Config{Rules: []Rule{
+			{EventName: "Home", Percentage: percentage(100)},
+			{EventType: "page", Percentage: percentage(0)},
+		}}, nil)
+		ok, _ := s.Sample("Home", "page", "user-1", now)
+		require.True(t, ok)
+		ok, _ = s.Sample("Pricing", "page", "user-1", now)
+		require.False(t, ok)
+	})
+}
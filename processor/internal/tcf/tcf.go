@@ -0,0 +1,177 @@
+// Package tcf decodes IAB Transparency & Consent Framework v2 consent strings (TC strings).
+//
+// Only the core segment of a TC string is decoded, i.e. the purposes and vendors the user has consented to.
+// Disclosed vendors, allowed vendors and publisher segments are ignored, as are publisher restrictions.
+// See https://github.com/InteractiveAdvertisingBureau/GDPR-Transparency-and-Consent-Framework for the format specification.
+package tcf
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// Version is the only TC string version supported
+	Version = 2
+
+	// MaxPurposeID is the highest purpose id a TC string can carry consent for
+	MaxPurposeID = 24
+)
+
+var errShortString = errors.New("tc string is too short")
+
+// Consent is the decoded core segment of a TC string
+type Consent struct {
+	Version           int
+	Created           time.Time
+	LastUpdated       time.Time
+	CmpID             int
+	CmpVersion        int
+	ConsentLanguage   string
+	VendorListVersion int
+	PolicyVersion     int
+	// Purposes are the ids of the purposes the user has consented to, in ascending order
+	Purposes []int
+	// vendors are the ranges of vendor ids the user has consented to
+	vendors []vendorRange
+}
+
+type vendorRange struct {
+	start, end int
+}
+
+// PurposeConsent returns true if the user has consented to the purpose
+func (c *Consent) PurposeConsent(id int) bool {
+	for _, purpose := range c.Purposes {
+		if purpose == id {
+			return true
+		}
+	}
+	return false
+}
+
+// VendorConsent returns true if the user has consented to the vendor
+func (c *Consent) VendorConsent(id int) bool {
+	for _, r := range c.vendors {
+		if id >= r.start && id <= r.end {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode decodes the core segment of a TC string
+func Decode(tcString string) (*Consent, error) {
+	core, _, _ := strings.Cut(tcString, ".")
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(core, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decoding tc string: %w", err)
+	}
+	r := &bitReader{data: data}
+
+	c := &Consent{}
+	c.Version = r.int(6)
+	if r.err == nil && c.Version != Version {
+		return nil, fmt.Errorf("unsupported tc string version: %d", c.Version)
+	}
+	c.Created = r.time()
+	c.LastUpdated = r.time()
+	c.CmpID = r.int(12)
+	c.CmpVersion = r.int(12)
+	r.skip(6) // consent screen
+	c.ConsentLanguage = r.letters()
+	c.VendorListVersion = r.int(12)
+	c.PolicyVersion = r.int(6)
+	r.skip(1 + 1 + 12) // is service specific, use non standard texts, special feature opt ins
+	for id := 1; id <= MaxPurposeID; id++ {
+		if r.bool() {
+			c.Purposes = append(c.Purposes, id)
+		}
+	}
+	r.skip(24 + 1 + 12) // purposes legitimate interest transparency, purpose one treatment, publisher country code
+	c.vendors = r.vendors()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return c, nil
+}
+
+// bitReader reads big endian bit fields, remembering the first error encountered
+type bitReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *bitReader) int(bits int) int {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos+bits > len(r.data)*8 {
+		r.err = errShortString
+		return 0
+	}
+	var v int
+	for i := 0; i < bits; i++ {
+		bit := (r.data[r.pos/8] >> (7 - r.pos%8)) & 1
+		v = v<<1 | int(bit)
+		r.pos++
+	}
+	return v
+}
+
+func (r *bitReader) bool() bool {
+	return r.int(1) == 1
+}
+
+func (r *bitReader) skip(bits int) {
+	r.int(bits)
+}
+
+// time reads a timestamp encoded as deciseconds since the unix epoch
+func (r *bitReader) time() time.Time {
+	return time.UnixMilli(int64(r.int(36)) * 100).UTC()
+}
+
+// letters reads two letters encoded as their 6 bit offset from 'A'
+func (r *bitReader) letters() string {
+	first, second := r.int(6), r.int(6)
+	return string([]byte{byte('A' + first), byte('A' + second)})
+}
+
+// vendors reads a vendor section, either encoded as a bit field or as a list of ranges
+func (r *bitReader) vendors() []vendorRange {
+	maxVendorID := r.int(16)
+	var ranges []vendorRange
+	if !r.bool() { // bit field
+		for id := 1; id <= maxVendorID && r.err == nil; id++ {
+			if !r.bool() {
+				continue
+			}
+			if n := len(ranges); n > 0 && ranges[n-1].end == id-1 {
+				ranges[n-1].end = id
+				continue
+			}
+			ranges = append(ranges, vendorRange{start: id, end: id})
+		}
+		return ranges
+	}
+	entries := r.int(12)
+	for i := 0; i < entries && r.err == nil; i++ {
+		isRange := r.bool()
+		start := r.int(16)
+		end := start
+		if isRange {
+			end = r.int(16)
+		}
+		if end < start {
+			r.err = fmt.Errorf("invalid vendor range: %d-%d", start, end)
+			return nil
+		}
+		ranges = append(ranges, vendorRange{start: start, end: end})
+	}
+	return ranges
+}
@@ -0,0 +1,153 @@
+package tcf
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// bitWriter builds the bit fields of a tc string
+type bitWriter struct {
+	bits []bool
+}
+
+func (w *bitWriter) int(v, bits int) *bitWriter {
+	for i := bits - 1; i >= 0; i-- {
+		w.bits = append(w.bits, (v>>i)&1 == 1)
+	}
+	return w
+}
+
+func (w *bitWriter) bool(v bool) *bitWriter {
+	w.bits = append(w.bits, v)
+	return w
+}
+
+func (w *bitWriter) String() string {
+	data := make([]byte, (len(w.bits)+7)/8)
+	for i, bit := range w.bits {
+		if bit {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// coreSegment writes the fields of a core segment preceding the vendor consent section
+func coreSegment(version int, created time.Time, purposes ...int) *bitWriter {
+	w := &bitWriter{}
+	w.int(version, 6)
+	w.int(int(created.UnixMilli()/100), 36)
+	w.int(int(created.UnixMilli()/100), 36)
+	w.int(7, 12)                      // cmp id
+	w.int(3, 12)                      // cmp version
+	w.int(1, 6)                       // consent screen
+	w.int('E'-'A', 6).int('N'-'A', 6) // consent language
+	w.int(150, 12)                    // vendor list version
+	w.int(4, 6)                       // policy version
+	w.bool(false).bool(false).int(0, 12)
+	for id := 1; id <= MaxPurposeID; id++ {
+		w.bool(contains(purposes, id))
+	}
+	w.int(0, 24).bool(false).int(0, 12)
+	return w
+}
+
+func contains(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDecode(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.UTC)
+
+	t.Run("bit field vendors", func(t *testing.T) {
+		w := coreSegment(2, created, 1, 3, 24)
+		w.int(6, 16).bool(false)
+		for _, consent := range []bool{true, true, false, false, true, false} {
+			w.bool(consent)
+		}
+		c, err := Decode(w.String())
+		require.NoError(t, err)
+
+		require.Equal(t, 2, c.Version)
+		require.Equal(t, created, c.Created)
+		require.Equal(t, created, c.LastUpdated)
+		require.Equal(t, 7, c.CmpID)
+		require.Equal(t, 3, c.CmpVersion)
+		require.Equal(t, "EN", c.ConsentLanguage)
+		require.Equal(t, 150, c.VendorListVersion)
+		require.Equal(t, 4, c.PolicyVersion)
+		require.Equal(t, []int{1, 3, 24}, c.Purposes)
+		require.True(t, c.PurposeConsent(3))
+		require.False(t, c.PurposeConsent(2))
+
+		require.Equal(t, []vendorRange{{1, 2}, {5, 5}}, c.vendors)
+		require.True(t, c.VendorConsent(2))
+		require.False(t, c.VendorConsent(3))
+		require.True(t, c.VendorConsent(5))
+		require.False(t, c.VendorConsent(7))
+	})
+
+	t.Run("range vendors", func(t *testing.T) {
+		w := coreSegment(2, created, 2)
+		w.int(1000, 16).bool(true).int(2, 12)
+		w.bool(false).int(10, 16)
+		w.bool(true).int(100, 16).int(1000, 16)
+		c, err := Decode(w.String() + ".YAAAAAAAAAAA")
+		require.NoError(t, err)
+
+		require.Equal(t, []int{2}, c.Purposes)
+		require.True(t, c.VendorConsent(10))
+		require.False(t, c.VendorConsent(11))
+		require.True(t, c.VendorConsent(755))
+		require.False(t, c.VendorConsent(1001))
+	})
+
+	t.Run("padded", func(t *testing.T) {
+		w := coreSegment(2, created, 1)
+		w.int(0, 16).bool(false)
+		s := w.String()
+		for len(s)%4 != 0 {
+			s += "="
+		}
+		c, err := Decode(s)
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, c.Purposes)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		w := coreSegment(1, created)
+		w.int(0, 16).bool(false)
+		_, err := Decode(w.String())
+		require.ErrorContains(t, err, "unsupported tc string version")
+	})
+
+	t.Run("invalid vendor range", func(t *testing.T) {
+		w := coreSegment(2, created)
+		w.int(10, 16).bool(true).int(1, 12).bool(true).int(10, 16).int(5, 16)
+		_, err := Decode(w.String())
+		require.ErrorContains(t, err, "invalid vendor range")
+	})
+
+	t.Run("short", func(t *testing.T) {
+		w := coreSegment(2, created)
+		w.int(100, 16).bool(false).int(0, 5)
+		_, err := Decode(w.String())
+		require.ErrorIs(t, err, errShortString)
+
+		_, err = Decode("")
+		require.ErrorIs(t, err, errShortString)
+	})
+
+	t.Run("invalid base64", func(t *testing.T) {
+		_, err := Decode("not a tc string!")
+		require.Error(t, err)
+	})
+}
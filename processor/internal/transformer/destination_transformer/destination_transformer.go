@@ -32,6 +32,7 @@ import (
 	transformerutils "github.com/rudderlabs/rudder-server/processor/internal/transformer"
 	"github.com/rudderlabs/rudder-server/processor/internal/transformer/destination_transformer/embedded/kafka"
 	"github.com/rudderlabs/rudder-server/processor/internal/transformer/destination_transformer/embedded/pubsub"
+	"github.com/rudderlabs/rudder-server/processor/internal/transformer/destination_transformer/embedded/stream"
 	"github.com/rudderlabs/rudder-server/processor/types"
 	"github.com/rudderlabs/rudder-server/utils/httputil"
 	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
@@ -390,6 +391,12 @@ var embeddedTransformerImpls = map[string]transformer{
 	"KAFKA":        kafka.Transform,
 }
 
+// embeddedOnlyTransformerImpls are the transformations of destinations which aren't supported by the transformer service
+var embeddedOnlyTransformerImpls = map[string]transformer{
+	"PULSAR":         stream.NewTransform("Pulsar", "PULSAR", "pulsar", "Pulsar"),
+	"NATS_JETSTREAM": stream.NewTransform("NATS JetStream", "NATS_JETSTREAM", "nats_jetstream", "NatsJetStream"),
+}
+
 func (c *Client) Transform(ctx context.Context, clientEvents []types.TransformerEvent) types.Response {
 	if len(clientEvents) == 0 {
 		return types.Response{}
@@ -405,6 +412,10 @@ func (c *Client) Transform(ctx context.Context, clientEvents []types.Transformer
 		return c.warehouseClient.Transform(ctx, clientEvents)
 	}
 
+	if impl, ok := embeddedOnlyTransformerImpls[destType]; ok {
+		return impl(ctx, clientEvents)
+	}
+
 	impl, ok := embeddedTransformerImpls[destType]
 	if !ok {
 		return c.transform(ctx, clientEvents)
@@ -0,0 +1,296 @@
+// Package pii detects personally identifiable information in the user id, ip addresses, traits and properties of events and masks it
+// according to the configuration of their destination, so that destinations can be kept from receiving raw PII.
+//
+// Only string values are scanned and a value is considered PII when it is an email address, a phone number,
+// a credit card number or an IP address as a whole. Values merely containing PII, e.g. free text, are left untouched.
+package pii
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+)
+
+// ConfigKey is the key of the pii masking configuration in a destination's config
+const ConfigKey = "piiMasking"
+
+// Type is a type of personally identifiable information
+type Type string
+
+const (
+	EmailType      Type = "email"
+	PhoneType      Type = "phone"
+	CreditCardType Type = "credit_card"
+	IPType         Type = "ip"
+)
+
+// Types are all the supported pii types, in the order they are detected
+var Types = []Type{EmailType, CreditCardType, IPType, PhoneType}
+
+// Action is what is done with the pii found in an event
+type Action string
+
+const (
+	// HashAction replaces the value with its hex encoded SHA-256 hash, salted with the workspace's salt
+	HashAction Action = "hash"
+	// RedactAction replaces the value with RedactedValue
+	RedactAction Action = "redact"
+	// DropAction removes the field, or the element in case of arrays
+	DropAction Action = "drop"
+)
+
+// RedactedValue is the value of redacted fields
+const RedactedValue = "[REDACTED]"
+
+// Paths are the paths of the event fields scanned for pii, either objects scanned recursively or single values
+var Paths = [][]string{{"userId"}, {"request_ip"}, {"context", "ip"}, {"traits"}, {"context", "traits"}, {"properties"}}
+
+var ErrInvalidConfig = errors.New("invalid pii masking config")
+
+var (
+	emailRegex      = regexp.MustCompile(`^[a-zA-Z0-9._%+'-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	phoneRegex      = regexp.MustCompile(`^\+?\(?[0-9][0-9 ()-]*[0-9]$`)
+	creditCardRegex = regexp.MustCompile(`^[0-9][0-9 -]*[0-9]$`)
+)
+
+// Config is the pii masking configuration of a destination
+type Config struct {
+	Enabled bool   `json:"enabled"`
+	Action  Action `json:"action"`
+	// Types are the types of pii to mask, all of them if empty
+	Types []Type `json:"types"`
+}
+
+// ParseConfig parses the pii masking configuration of a destination's config, returning false if masking is not enabled
+func ParseConfig(destConfig map[string]any) (Config, bool, error) {
+	raw, ok := destConfig[ConfigKey]
+	if !ok {
+		return Config{}, false, nil
+	}
+	data, err := jsonrs.Marshal(raw)
+	if err != nil {
+		return Config{}, false, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	var conf Config
+	if err := jsonrs.Unmarshal(data, &conf); err != nil {
+		return Config{}, false, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if !conf.Enabled {
+		return Config{}, false, nil
+	}
+	switch conf.Action {
+	case "":
+		conf.Action = HashAction
+	case HashAction, RedactAction, DropAction:
+	default:
+		return Config{}, false, fmt.Errorf("%w: unsupported action %q", ErrInvalidConfig, conf.Action)
+	}
+	for _, t := range conf.Types {
+		if !slices.Contains(Types, t) {
+			return Config{}, false, fmt.Errorf("%w: unsupported type %q", ErrInvalidConfig, t)
+		}
+	}
+	return conf, true, nil
+}
+
+// Masker masks the pii of events according to a destination's configuration
+type Masker struct {
+	action Action
+	types  []Type
+	salt   string
+}
+
+// New returns a masker of the configured pii types, hashing values with the salt if the action is hash
+func New(conf Config, salt string) *Masker {
+	types := Types
+	if len(conf.Types) > 0 {
+		// keeping the order of detection
+		types = slices.DeleteFunc(slices.Clone(Types), func(t Type) bool {
+			return !slices.Contains(conf.Types, t)
+		})
+	}
+	return &Masker{action: conf.Action, types: types, salt: salt}
+}
+
+// Mask returns the message with the pii found at its Paths masked, along with the number of masked fields per pii type.
+// The message itself is never modified: the objects and arrays containing masked fields are copied instead,
+// since the same message is shared by all the destinations of an event.
+func (m *Masker) Mask(message map[string]any) (map[string]any, map[Type]int) {
+	counts := make(map[Type]int)
+	masked := message
+	for _, path := range Paths {
+		masked, _ = m.maskPath(masked, path, counts)
+	}
+	return masked, counts
+}
+
+// maskPath masks the value found at the path of the object, returning a copy of the object if anything was masked
+func (m *Masker) maskPath(object map[string]any, path []string, counts map[Type]int) (map[string]any, bool) {
+	value, ok := object[path[0]]
+	if !ok {
+		return object, false
+	}
+	var (
+		masked        any
+		drop, changed bool
+	)
+	if len(path) > 1 {
+		child, ok := value.(map[string]any)
+		if !ok {
+			return object, false
+		}
+		masked, changed = m.maskPath(child, path[1:], counts)
+	} else {
+		masked, drop, changed = m.mask(value, counts)
+	}
+	if !changed {
+		return object, false
+	}
+	object = maps.Clone(object)
+	if drop {
+		delete(object, path[0])
+	} else {
+		object[path[0]] = masked
+	}
+	return object, true
+}
+
+// mask masks the value, returning whether it is to be dropped and whether it was changed at all.
+// Objects and arrays are scanned recursively and copied if any of their values were changed.
+func (m *Masker) mask(value any, counts map[Type]int) (masked any, drop, changed bool) {
+	switch v := value.(type) {
+	case string:
+		t, ok := m.detect(v)
+		if !ok {
+			return v, false, false
+		}
+		counts[t]++
+		switch m.action {
+		case DropAction:
+			return nil, true, true
+		case RedactAction:
+			return RedactedValue, false, true
+		default:
+			return m.hash(t, v), false, true
+		}
+	case map[string]any:
+		var out map[string]any
+		for key, item := range v {
+			maskedItem, drop, changed := m.mask(item, counts)
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = maps.Clone(v)
+			}
+			if drop {
+				delete(out, key)
+			} else {
+				out[key] = maskedItem
+			}
+		}
+		if out == nil {
+			return v, false, false
+		}
+		return out, false, true
+	case []any:
+		var out []any
+		for i, item := range v {
+			maskedItem, drop, changed := m.mask(item, counts)
+			if changed && out == nil {
+				out = make([]any, i, len(v))
+				copy(out, v[:i])
+			}
+			if out == nil || drop {
+				continue
+			}
+			out = append(out, maskedItem)
+		}
+		if out == nil {
+			return v, false, false
+		}
+		return out, false, true
+	}
+	return value, false, false
+}
+
+// detect returns the type of pii the value is, if any
+func (m *Masker) detect(value string) (Type, bool) {
+	value = strings.TrimSpace(value)
+	for _, t := range m.types {
+		if is(t, value) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+func is(t Type, value string) bool {
+	switch t {
+	case EmailType:
+		return emailRegex.MatchString(value)
+	case CreditCardType:
+		if !creditCardRegex.MatchString(value) {
+			return false
+		}
+		d := digits(value)
+		return len(d) >= 13 && len(d) <= 19 && luhn(d)
+	case IPType:
+		return strings.ContainsAny(value, ".:") && net.ParseIP(value) != nil
+	case PhoneType:
+		if !phoneRegex.MatchString(value) {
+			return false
+		}
+		d := digits(value)
+		if strings.HasPrefix(value, "+") {
+			return len(d) >= 8 && len(d) <= 15
+		}
+		// plain numbers are more likely to be ids than phone numbers and short ones to be dates,
+		// thus numbers not in international format need to contain separators and at least 10 digits
+		return strings.ContainsAny(value, " ()-") && len(d) >= 10 && len(d) <= 15
+	}
+	return false
+}
+
+func (m *Masker) hash(t Type, value string) string {
+	value = strings.TrimSpace(value)
+	if t == EmailType {
+		// emails are case insensitive, hashing them in lower case lets hashed emails be matched across sources
+		value = strings.ToLower(value)
+	}
+	sum := sha256.Sum256([]byte(m.salt + value))
+	return hex.EncodeToString(sum[:])
+}
+
+func digits(value string) []byte {
+	d := make([]byte, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		if value[i] >= '0' && value[i] <= '9' {
+			d = append(d, value[i])
+		}
+	}
+	return d
+}
+
+// luhn returns true if the digits have a valid luhn checksum
+func luhn(digits []byte) bool {
+	var sum int
+	for i := range digits {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
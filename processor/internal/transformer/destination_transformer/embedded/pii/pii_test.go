@@ -0,0 +1,179 @@
+package pii
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sha(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParseConfig(t *testing.T) {
+	conf, ok, err := ParseConfig(map[string]any{ConfigKey: map[string]any{"enabled": true, "types": []any{"email", "ip"}}})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Config{Enabled: true, Action: HashAction, Types: []Type{EmailType, IPType}}, conf)
+
+	conf, ok, err = ParseConfig(map[string]any{ConfigKey: map[string]any{"enabled": true, "action": "drop"}})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Config{Enabled: true, Action: DropAction}, conf)
+
+	_, ok, err = ParseConfig(map[string]any{})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = ParseConfig(map[string]any{ConfigKey: map[string]any{"enabled": false, "action": "drop"}})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for name, config := range map[string]any{
+		"malformed":          "enabled",
+		"unsupported action": map[string]any{"enabled": true, "action": "encrypt"},
+		"unsupported type":   map[string]any{"enabled": true, "types": []any{"ssn"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := ParseConfig(map[string]any{ConfigKey: config})
+			require.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	m := New(Config{}, "")
+	for value, expected := range map[string]Type{
+		"john.doe+test@example.co.uk": EmailType,
+		" John@Example.com ":          EmailType,
+		"4111 1111 1111 1111":         CreditCardType,
+		"4111-1111-1111-1111":         CreditCardType,
+		"378282246310005":             CreditCardType,
+		"192.168.0.1":                 IPType,
+		"2001:db8::1":                 IPType,
+		"+14155552671":                PhoneType,
+		"+44 20 7946 0958":            PhoneType,
+		"(415) 555-2671":              PhoneType,
+		"415-555-2671":                PhoneType,
+	} {
+		t.Run(value, func(t *testing.T) {
+			typ, ok := m.detect(value)
+			require.True(t, ok)
+			require.Equal(t, expected, typ)
+		})
+	}
+
+	for _, value := range []string{
+		"",
+		"john",
+		"john@example",
+		"4111 1111 1111 1112", // invalid checksum
+		"4111111111111111111111",
+		"4155552671", // plain number
+		"2024-01-02",
+		"3.14159265358979",
+		"1.2.3",
+		"order-1234567890",
+		"Call me at +14155552671",
+	} {
+		t.Run(value, func(t *testing.T) {
+			_, ok := m.detect(value)
+			require.False(t, ok)
+		})
+	}
+
+	t.Run("configured types", func(t *testing.T) {
+		m := New(Config{Types: []Type{PhoneType, EmailType}}, "")
+		require.Equal(t, []Type{EmailType, PhoneType}, m.types)
+		_, ok := m.detect("192.168.0.1")
+		require.False(t, ok)
+	})
+}
+
+func TestMask(t *testing.T) {
+	message := func() map[string]any {
+		return map[string]any{
+			"type":       "identify",
+			"userId":     "john@example.com",
+			"request_ip": "2001:db8::1",
+			"context": map[string]any{
+				"ip": "192.168.0.1",
+				"traits": map[string]any{
+					"email": "John@Example.com",
+					"name":  "John",
+				},
+			},
+			"traits": map[string]any{
+				"phone":   "+14155552671",
+				"age":     42.0,
+				"address": map[string]any{"city": "Berlin", "ip": "10.0.0.1"},
+				"cards":   []any{"4111 1111 1111 1111", "none", "378282246310005"},
+			},
+			"properties": map[string]any{"plan": "pro"},
+		}
+	}
+
+	t.Run("hash", func(t *testing.T) {
+		original := message()
+		masked, counts := New(Config{Action: HashAction}, "salt").Mask(original)
+
+		require.Equal(t, map[Type]int{EmailType: 2, PhoneType: 1, IPType: 3, CreditCardType: 2}, counts)
+		require.Equal(t, map[string]any{
+			"type":       "identify",
+			"userId":     sha("saltjohn@example.com"),
+			"request_ip": sha("salt2001:db8::1"),
+			"context": map[string]any{
+				"ip": sha("salt192.168.0.1"),
+				"traits": map[string]any{
+					"email": sha("saltjohn@example.com"),
+					"name":  "John",
+				},
+			},
+			"traits": map[string]any{
+				"phone":   sha("salt+14155552671"),
+				"age":     42.0,
+				"address": map[string]any{"city": "Berlin", "ip": sha("salt10.0.0.1")},
+				"cards":   []any{sha("salt4111 1111 1111 1111"), "none", sha("salt378282246310005")},
+			},
+			"properties": map[string]any{"plan": "pro"},
+		}, masked)
+		require.Equal(t, message(), original, "the original message is left untouched")
+	})
+
+	t.Run("redact", func(t *testing.T) {
+		masked, counts := New(Config{Action: RedactAction, Types: []Type{EmailType, CreditCardType}}, "").Mask(message())
+
+		require.Equal(t, map[Type]int{EmailType: 2, CreditCardType: 2}, counts)
+		require.Equal(t, RedactedValue, masked["userId"])
+		require.Equal(t, "2001:db8::1", masked["request_ip"])
+		require.Equal(t, RedactedValue, masked["context"].(map[string]any)["traits"].(map[string]any)["email"])
+		require.Equal(t, []any{RedactedValue, "none", RedactedValue}, masked["traits"].(map[string]any)["cards"])
+		require.Equal(t, "+14155552671", masked["traits"].(map[string]any)["phone"])
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		original := message()
+		masked, counts := New(Config{Action: DropAction}, "").Mask(original)
+
+		require.Equal(t, map[Type]int{EmailType: 2, PhoneType: 1, IPType: 3, CreditCardType: 2}, counts)
+		require.NotContains(t, masked, "userId")
+		require.NotContains(t, masked, "request_ip")
+		require.Equal(t, map[string]any{"traits": map[string]any{"name": "John"}}, masked["context"])
+		require.Equal(t, map[string]any{
+			"age":     42.0,
+			"address": map[string]any{"city": "Berlin"},
+			"cards":   []any{"none"},
+		}, masked["traits"])
+		require.Equal(t, message(), original, "the original message is left untouched")
+	})
+
+	t.Run("nothing to mask", func(t *testing.T) {
+		original := map[string]any{"type": "track", "properties": map[string]any{"plan": "pro"}}
+		masked, counts := New(Config{Action: HashAction}, "").Mask(original)
+		require.Empty(t, counts)
+		require.Equal(t, original, masked)
+	})
+}
@@ -0,0 +1,97 @@
+// Package stream implements the transformation of stream destinations that publish the event as is to a topic
+// (or subject) mapped from the event, e.g. Pulsar and NATS JetStream.
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	utils "github.com/rudderlabs/rudder-server/processor/internal/transformer/destination_transformer/embedded"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+// NewTransform returns the transformation of a topic based stream destination. The canonical names are the
+// keys under which the destination's options (e.g. topic) can be provided in the event's integrations object.
+//
+// The topic of an event is resolved from
+//  1. the integrations object's topic,
+//  2. the destination's eventTypeToTopicMap & eventToTopicMap, if enableMultiTopic is true, and
+//  3. the destination's topic.
+func NewTransform(displayName string, canonicalNames ...string) func(context.Context, []types.TransformerEvent) types.Response {
+	return func(_ context.Context, events []types.TransformerEvent) types.Response {
+		response := types.Response{}
+		destination := events[0].Destination
+		eventTypeToTopicMap := utils.GetTopicMap(destination, "eventTypeToTopicMap", true)
+		eventToTopicMap := utils.GetTopicMap(destination, "eventToTopicMap", false)
+		enableMultiTopic := destination.Config["enableMultiTopic"] == true
+		defaultTopic, _ := destination.Config["topic"].(string)
+
+		for _, event := range events {
+			if event.Destination.ID != destination.ID {
+				panic("all events must have the same destination")
+			}
+
+			event.Message = utils.UpdateTimestampFieldForRETLEvent(event.Message)
+			var integrationsObj map[string]interface{}
+			for _, canonicalName := range canonicalNames {
+				if inObj, ok := misc.MapLookup(event.Message, "integrations", canonicalName).(map[string]interface{}); ok {
+					integrationsObj = inObj
+					break
+				}
+			}
+
+			topic, _ := integrationsObj["topic"].(string)
+			if topic == "" && enableMultiTopic {
+				topic = multiTopic(event.Message, eventTypeToTopicMap, eventToTopicMap)
+			}
+			if topic == "" {
+				topic = defaultTopic
+			}
+			if topic == "" {
+				response.FailedEvents = append(response.FailedEvents, types.TransformerResponse{
+					Error:      fmt.Sprintf("topic is required for %s destination", displayName),
+					Metadata:   event.Metadata,
+					StatusCode: http.StatusBadRequest,
+					StatTags:   utils.GetValidationErrorStatTags(event.Destination),
+				})
+				continue
+			}
+
+			var userID string
+			if id, ok := event.Message["userId"].(string); ok && id != "" {
+				userID = id
+			} else if id, ok := event.Message["anonymousId"].(string); ok {
+				userID = id
+			}
+
+			// events of different topics don't need to be delivered in order
+			event.Metadata.RudderID = fmt.Sprintf("%s<<>>%s", event.Metadata.RudderID, topic)
+
+			response.Events = append(response.Events, types.TransformerResponse{
+				Output: map[string]interface{}{
+					"message": utils.GetMessageAsMap(event.Message),
+					"userId":  userID,
+					"topic":   topic,
+				},
+				Metadata:   event.Metadata,
+				StatusCode: http.StatusOK,
+			})
+		}
+		return response
+	}
+}
+
+func multiTopic(message types.SingularEventT, eventTypeToTopicMap, eventToTopicMap map[string]string) string {
+	messageType, _ := message["type"].(string)
+	switch messageType {
+	case "identify", "screen", "page", "group", "alias":
+		return eventTypeToTopicMap[messageType]
+	case "track":
+		if eventName, ok := message["event"].(string); ok && eventName != "" {
+			return eventToTopicMap[eventName]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/types"
+)
+
+func TestTransform(t *testing.T) {
+	transform := NewTransform("Pulsar", "PULSAR", "pulsar", "Pulsar")
+	destination := backendconfig.DestinationT{
+		ID:          "destination-id-123",
+		WorkspaceID: "workspace-id-123",
+		DestinationDefinition: backendconfig.DestinationDefinitionT{
+			Name: "PULSAR",
+		},
+		Config: map[string]interface{}{
+			"topic":            "default-topic",
+			"enableMultiTopic": true,
+			"eventTypeToTopicMap": []interface{}{
+				map[string]interface{}{"from": "Identify", "to": "identify-topic"},
+				map[string]interface{}{"from": "", "to": "empty-topic"},
+			},
+			"eventToTopicMap": []interface{}{
+				map[string]interface{}{"from": "Order Completed", "to": "orders-topic"},
+			},
+		},
+	}
+	newEvent := func(message types.SingularEventT) types.TransformerEvent {
+		return types.TransformerEvent{
+			Message:     message,
+			Metadata:    types.Metadata{RudderID: "rudder-id"},
+			Destination: destination,
+		}
+	}
+
+	t.Run("topic resolution", func(t *testing.T) {
+		response := transform(context.Background(), []types.TransformerEvent{
+			newEvent(types.SingularEventT{"type": "identify", "userId": "user-1"}),
+			newEvent(types.SingularEventT{"type": "track", "event": "Order Completed", "anonymousId": "anon-1"}),
+			newEvent(types.SingularEventT{"type": "track", "event": "Product Viewed", "userId": "user-1"}),
+			newEvent(types.SingularEventT{"type": "track", "event": "Order Completed", "userId": "user-1", "integrations": map[string]interface{}{"pulsar": map[string]interface{}{"topic": "override-topic"}}}),
+		})
+		require.Empty(t, response.FailedEvents)
+		require.Len(t, response.Events, 4)
+
+		expected := []struct{ topic, userID string }{
+			{"identify-topic", "user-1"},
+			{"orders-topic", "anon-1"},
+			{"default-topic", "user-1"},
+			{"override-topic", "user-1"},
+		}
+		for i, e := range expected {
+			event := response.Events[i]
+			require.Equal(t, http.StatusOK, event.StatusCode)
+			require.Equal(t, e.topic, event.Output["topic"])
+			require.Equal(t, e.userID, event.Output["userId"])
+			require.Equal(t, "rudder-id<<>>"+e.topic, event.Metadata.RudderID)
+		}
+	})
+
+	t.Run("multi topic disabled", func(t *testing.T) {
+		destination := destination
+		destination.Config = map[string]interface{}{
+			"topic":               "default-topic",
+			"eventTypeToTopicMap": destination.Config["eventTypeToTopicMap"],
+		}
+		response := transform(context.Background(), []types.TransformerEvent{
+			{Message: types.SingularEventT{"type": "identify", "userId": "user-1"}, Destination: destination},
+		})
+		require.Len(t, response.Events, 1)
+		require.Equal(t, "default-topic", response.Events[0].Output["topic"])
+	})
+
+	t.Run("missing topic", func(t *testing.T) {
+		destination := destination
+		destination.Config = map[string]interface{}{}
+		response := transform(context.Background(), []types.TransformerEvent{
+			{Message: types.SingularEventT{"type": "identify", "userId": "user-1"}, Destination: destination},
+		})
+		require.Empty(t, response.Events)
+		require.Len(t, response.FailedEvents, 1)
+		require.Equal(t, http.StatusBadRequest, response.FailedEvents[0].StatusCode)
+		require.Equal(t, "topic is required for Pulsar destination", response.FailedEvents[0].Error)
+		require.Equal(t, "PULSAR", response.FailedEvents[0].StatTags["destType"])
+	})
+
+	t.Run("panics if destination ids are different", func(t *testing.T) {
+		other := newEvent(types.SingularEventT{"type": "identify"})
+		other.Destination.ID = "other-destination-id"
+		require.Panics(t, func() {
+			transform(context.Background(), []types.TransformerEvent{newEvent(types.SingularEventT{"type": "identify"}), other})
+		})
+	})
+}
@@ -0,0 +1,574 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+)
+
+// expression is a compiled expression, evaluated against an event
+type expression interface {
+	eval(event map[string]any) (any, error)
+}
+
+// functions are the functions expressions can call, along with their number of arguments, -1 if variadic
+var functions = map[string]struct {
+	args int
+	call func(args []any) (any, error)
+}{
+	"lower":    {1, stringFunc(strings.ToLower)},
+	"upper":    {1, stringFunc(strings.ToUpper)},
+	"trim":     {1, stringFunc(strings.TrimSpace)},
+	"string":   {1, func(args []any) (any, error) { return toString(args[0]), nil }},
+	"number":   {1, numberFunc},
+	"concat":   {-1, concatFunc},
+	"coalesce": {-1, coalesceFunc},
+}
+
+// parseExpression compiles the expression
+func parseExpression(input string) (expression, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators are sorted so that the longest ones are matched first
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "+", "-", "*", "/", "%", "!", "(", ")", ",", "."}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := rune(input[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(input) && (input[i] >= '0' && input[i] <= '9' || input[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: input[start:i], pos: start})
+		case c == '\'' || c == '"':
+			start := i
+			var sb strings.Builder
+			for i++; i < len(input) && rune(input[i]) != c; i++ {
+				if input[i] == '\\' && i+1 < len(input) {
+					i++
+				}
+				sb.WriteByte(input[i])
+			}
+			if i == len(input) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start})
+		case c == '_' || c == '$' || unicode.IsLetter(c):
+			start := i
+			for i < len(input) && (input[i] == '_' || input[i] == '$' || unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: input[start:i], pos: start})
+		default:
+			var op string
+			for _, candidate := range operators {
+				if strings.HasPrefix(input[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of expression", pos: len(input)}), nil
+}
+
+// parser is a recursive descent parser, with a method per precedence level
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the operators
+func (p *parser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		t := p.peek()
+		return fmt.Errorf("expected %q at position %d, got %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+// binary parses a left associative sequence of operands separated by the operators
+func (p *parser) binary(operand func() (expression, error), ops ...string) (expression, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) or() (expression, error) { return p.binary(p.and, "||") }
+
+func (p *parser) and() (expression, error) { return p.binary(p.equality, "&&") }
+
+func (p *parser) equality() (expression, error) { return p.binary(p.comparison, "==", "!=") }
+
+func (p *parser) comparison() (expression, error) {
+	return p.binary(p.additive, "<=", ">=", "<", ">")
+}
+
+func (p *parser) additive() (expression, error) { return p.binary(p.multiplicative, "+", "-") }
+
+func (p *parser) multiplicative() (expression, error) { return p.binary(p.unary, "*", "/", "%") }
+
+func (p *parser) unary() (expression, error) {
+	if op, ok := p.accept("!", "-"); ok {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: op, operand: operand}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (expression, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &literal{value: n}, nil
+	case tokenString:
+		return &literal{value: t.text}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literal{value: true}, nil
+		case "false":
+			return &literal{value: false}, nil
+		case "null":
+			return &literal{value: nil}, nil
+		}
+		if _, ok := p.accept("("); ok {
+			return p.call(t)
+		}
+		path := []string{t.text}
+		for {
+			if _, ok := p.accept("."); !ok {
+				return &pathExpr{path: path}, nil
+			}
+			field := p.next()
+			if field.kind != tokenIdent {
+				return nil, fmt.Errorf("expected a field name at position %d, got %q", field.pos, field.text)
+			}
+			path = append(path, field.text)
+		}
+	case tokenOperator:
+		if t.text == "(" {
+			expr, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			return expr, p.expect(")")
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+func (p *parser) call(name token) (expression, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	var args []expression
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if fn.args >= 0 && len(args) != fn.args {
+		return nil, fmt.Errorf("function %q expects %d arguments, got %d", name.text, fn.args, len(args))
+	}
+	return &callExpr{name: name.text, args: args}, nil
+}
+
+type literal struct {
+	value any
+}
+
+func (l *literal) eval(map[string]any) (any, error) {
+	return l.value, nil
+}
+
+// pathExpr is the value of a field of the event, null if it doesn't exist
+type pathExpr struct {
+	path []string
+}
+
+func (p *pathExpr) eval(event map[string]any) (any, error) {
+	value, _ := lookup(event, p.path)
+	return value, nil
+}
+
+type unaryExpr struct {
+	op      string
+	operand expression
+}
+
+func (u *unaryExpr) eval(event map[string]any) (any, error) {
+	v, err := u.operand.eval(event)
+	if err != nil {
+		return nil, err
+	}
+	if u.op == "!" {
+		return !truthy(v), nil
+	}
+	if v == nil {
+		return nil, nil
+	}
+	n, ok := toNumber(v)
+	if !ok {
+		return nil, fmt.Errorf("cannot negate %s", typeName(v))
+	}
+	return -n, nil
+}
+
+type binaryExpr struct {
+	op          string
+	left, right expression
+}
+
+func (b *binaryExpr) eval(event map[string]any) (any, error) {
+	l, err := b.left.eval(event)
+	if err != nil {
+		return nil, err
+	}
+	switch b.op { // short circuiting
+	case "&&":
+		if !truthy(l) {
+			return false, nil
+		}
+	case "||":
+		if truthy(l) {
+			return true, nil
+		}
+	}
+	r, err := b.right.eval(event)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b.op {
+	case "&&", "||":
+		return truthy(r), nil
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	case "<", "<=", ">", ">=":
+		return compare(b.op, l, r)
+	}
+
+	// arithmetic operators propagate nulls
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	if ls, ok := l.(string); ok && b.op == "+" {
+		if rs, ok := r.(string); ok {
+			return ls + rs, nil
+		}
+	}
+	ln, lok := toNumber(l)
+	rn, rok := toNumber(r)
+	if !lok || !rok {
+		return nil, fmt.Errorf("invalid operands for %q: %s and %s", b.op, typeName(l), typeName(r))
+	}
+	switch b.op {
+	case "+":
+		return ln + rn, nil
+	case "-":
+		return ln - rn, nil
+	case "*":
+		return ln * rn, nil
+	case "/":
+		if rn == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return ln / rn, nil
+	default: // "%"
+		if rn == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return math.Mod(ln, rn), nil
+	}
+}
+
+type callExpr struct {
+	name string
+	args []expression
+}
+
+func (c *callExpr) eval(event map[string]any) (any, error) {
+	args := make([]any, len(c.args))
+	for i, arg := range c.args {
+		v, err := arg.eval(event)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := functions[c.name].call(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.name, err)
+	}
+	return v, nil
+}
+
+func stringFunc(fn func(string) string) func(args []any) (any, error) {
+	return func(args []any) (any, error) {
+		switch v := args[0].(type) {
+		case nil:
+			return nil, nil
+		case string:
+			return fn(v), nil
+		default:
+			return nil, fmt.Errorf("expected a string, got %s", typeName(v))
+		}
+	}
+}
+
+func numberFunc(args []any) (any, error) {
+	if args[0] == nil {
+		return nil, nil
+	}
+	if n, ok := toNumber(args[0]); ok {
+		return n, nil
+	}
+	if s, ok := args[0].(string); ok {
+		if n, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot convert %v to a number", args[0])
+}
+
+func concatFunc(args []any) (any, error) {
+	var sb strings.Builder
+	for _, arg := range args {
+		if arg != nil {
+			sb.WriteString(toString(arg))
+		}
+	}
+	return sb.String(), nil
+}
+
+func coalesceFunc(args []any) (any, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
+
+// truthy returns false for null, false, zero and empty strings, true for anything else
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if n, ok := toNumber(v); ok {
+		return n != 0
+	}
+	return true
+}
+
+func equal(l, r any) bool {
+	ln, lok := toNumber(l)
+	rn, rok := toNumber(r)
+	if lok && rok {
+		return ln == rn
+	}
+	return reflect.DeepEqual(l, r)
+}
+
+// compare compares numbers or strings, comparisons with null being always false
+func compare(op string, l, r any) (bool, error) {
+	if l == nil || r == nil {
+		return false, nil
+	}
+	var c int
+	ln, lok := toNumber(l)
+	rn, rok := toNumber(r)
+	ls, lsok := l.(string)
+	rs, rsok := r.(string)
+	switch {
+	case lok && rok:
+		c = cmpFloat(ln, rn)
+	case lsok && rsok:
+		c = strings.Compare(ls, rs)
+	default:
+		return false, fmt.Errorf("cannot compare %s and %s", typeName(l), typeName(r))
+	}
+	switch op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default: // ">="
+		return c >= 0, nil
+	}
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func toNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	if n, ok := toNumber(v); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	data, _ := jsonrs.Marshal(v)
+	return string(data)
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+	if _, ok := toNumber(v); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression(t *testing.T) {
+	event := map[string]any{
+		"event": "Order Completed",
+		"type":  "track",
+		"properties": map[string]any{
+			"price":    10.5,
+			"quantity": 2.0,
+			"coupon":   "",
+			"email":    "  John@Example.com ",
+			"tags":     []any{"a"},
+		},
+	}
+
+	for expr, expected := range map[string]any{
+		"properties.price * properties.quantity":          21.0,
+		"properties.price + properties.quantity * 2":      14.5,
+		"(properties.price + 1.5) / 4":                    3.0,
+		"-properties.quantity % 3":                        -2.0,
+		"properties.missing * 2":                          nil,
+		"event + ' (' + type + ')'":                       "Order Completed (track)",
+		"event == 'Order Completed' && type != \"x\"":     true,
+		"properties.quantity == 2":                        true,
+		"properties.price >= 10 && properties.price < 11": true,
+		"'b' > 'a'":                     true,
+		"properties.missing > 1":        false,
+		"properties.coupon || 'none'":   true,
+		"!properties.coupon":            true,
+		"properties.missing == null":    true,
+		"properties.tags == null":       false,
+		"lower(trim(properties.email))": "john@example.com",
+		"upper(event)":                  "ORDER COMPLETED",
+		"concat(type, ':', properties.quantity, properties.missing)": "track:2",
+		"coalesce(properties.missing, properties.coupon, 'x')":       "",
+		"number('3.5') + 1":         4.5,
+		"string(properties.price)":  "10.5",
+		"lower(properties.missing)": nil,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			e, err := parseExpression(expr)
+			require.NoError(t, err)
+			v, err := e.eval(event)
+			require.NoError(t, err)
+			require.Equal(t, expected, v)
+		})
+	}
+
+	for expr, expected := range map[string]string{
+		"":                     `unexpected "end of expression" at position 0`,
+		"1 +":                  `unexpected "end of expression" at position 3`,
+		"(1 + 2":               `expected ")" at position 6`,
+		"'abc":                 "unterminated string at position 0",
+		"1 # 2":                `unexpected character '#' at position 2`,
+		"properties.":          "expected a field name at position 11",
+		"unknown(1)":           `unknown function "unknown"`,
+		"lower(1, 2)":          `function "lower" expects 1 arguments, got 2`,
+		"1.2.3":                `invalid number "1.2.3"`,
+		"event type":           `unexpected "type" at position 6`,
+		"properties.price 2 +": `unexpected "2"`,
+	} {
+		t.Run("invalid "+expr, func(t *testing.T) {
+			_, err := parseExpression(expr)
+			require.ErrorContains(t, err, expected)
+		})
+	}
+
+	for expr, expected := range map[string]string{
+		"event * 2":           `invalid operands for "*": string and number`,
+		"properties.tags + 1": `invalid operands for "+": array and number`,
+		"1 / 0":               "division by zero",
+		"event > 1":           "cannot compare string and number",
+		"-event":              "cannot negate string",
+		"upper(1)":            "upper: expected a string, got number",
+		"number('abc')":       "number: cannot convert abc to a number",
+	} {
+		t.Run("failing "+expr, func(t *testing.T) {
+			e, err := parseExpression(expr)
+			require.NoError(t, err)
+			_, err = e.eval(event)
+			require.ErrorContains(t, err, expected)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Package rules runs declarative user transformations in process, without a round trip to the transformer.
+//
+// A rules transformation is a list of rules applied in order to every event, each of them optionally guarded by a
+// condition, e.g.
+//
+//	{
+//	  "language": "rules",
+//	  "rules": [
+//	    {"action": "filter", "when": "event == 'Debug'"},
+//	    {"action": "rename", "path": "properties.revenue", "to": "properties.value"},
+//	    {"action": "remove", "path": "context.ip"},
+//	    {"action": "set", "path": "properties.currency", "value": "EUR", "when": "properties.currency == null"},
+//	    {"action": "set", "path": "properties.total", "expression": "properties.price * properties.quantity"}
+//	  ]
+//	}
+//
+// Expressions support field paths (null if missing), string, number, boolean and null literals, the arithmetic
+// (+ - * / %), comparison (== != < <= > >=) and logical (&& || !) operators, as well as the lower, upper, trim,
+// string, number, concat and coalesce functions. Arithmetic on null yields null.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+)
+
+// Language is the language of rules transformations, in the transformation's config
+const Language = "rules"
+
+// Action is what a rule does to an event
+type Action string
+
+const (
+	// SetAction sets the field at the path to the value, or to the result of the expression
+	SetAction Action = "set"
+	// RenameAction moves the field at the path to another one
+	RenameAction Action = "rename"
+	// RemoveAction removes the field at the path
+	RemoveAction Action = "remove"
+	// FilterAction filters out the event
+	FilterAction Action = "filter"
+)
+
+var ErrInvalidRules = errors.New("invalid rules transformation")
+
+// Rule is a step of a rules transformation
+type Rule struct {
+	Action Action `json:"action"`
+	// Path is the dot separated path of the field the rule applies to
+	Path string `json:"path"`
+	// To is the path the field is renamed to
+	To string `json:"to"`
+	// Value is the value set by the rule, unless it has an expression
+	Value any `json:"value"`
+	// Expression computes the value set by the rule
+	Expression string `json:"expression"`
+	// When is the condition of the rule, which always applies if empty
+	When string `json:"when"`
+}
+
+type transformationConfig struct {
+	Language string `json:"language"`
+	Rules    []Rule `json:"rules"`
+}
+
+// IsRules returns true if the transformation's config is a rules transformation
+func IsRules(config map[string]any) bool {
+	language, _ := config["language"].(string)
+	return strings.EqualFold(language, Language)
+}
+
+// Program is a compiled rules transformation
+type Program struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	path, to   []string
+	expression expression
+	when       expression
+}
+
+// Compile compiles the rules transformation of the transformation's config
+func Compile(config map[string]any) (*Program, error) {
+	data, err := jsonrs.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+	var conf transformationConfig
+	if err := jsonrs.Unmarshal(data, &conf); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+	p := &Program{rules: make([]compiledRule, 0, len(conf.Rules))}
+	for i, rule := range conf.Rules {
+		compiled, err := compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %d: %v", ErrInvalidRules, i, err)
+		}
+		p.rules = append(p.rules, compiled)
+	}
+	return p, nil
+}
+
+func compile(rule Rule) (compiledRule, error) {
+	c := compiledRule{Rule: rule}
+	var err error
+	if rule.When != "" {
+		if c.when, err = parseExpression(rule.When); err != nil {
+			return c, fmt.Errorf("condition: %w", err)
+		}
+	}
+	switch rule.Action {
+	case FilterAction:
+		if c.when == nil {
+			return c, errors.New("filter without a condition")
+		}
+		return c, nil
+	case SetAction, RenameAction, RemoveAction:
+	default:
+		return c, fmt.Errorf("unsupported action %q", rule.Action)
+	}
+	if c.path, err = parsePath(rule.Path); err != nil {
+		return c, err
+	}
+	switch rule.Action {
+	case RenameAction:
+		if c.to, err = parsePath(rule.To); err != nil {
+			return c, err
+		}
+	case SetAction:
+		if rule.Expression != "" {
+			if c.expression, err = parseExpression(rule.Expression); err != nil {
+				return c, fmt.Errorf("expression: %w", err)
+			}
+		}
+	}
+	return c, nil
+}
+
+func parsePath(path string) ([]string, error) {
+	fields := strings.Split(path, ".")
+	for _, field := range fields {
+		if strings.TrimSpace(field) == "" {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	return fields, nil
+}
+
+// Apply applies the rules to the event, returning false if the event is filtered out.
+// The event itself is never modified, a copy of it is transformed instead.
+func (p *Program) Apply(event map[string]any) (map[string]any, bool, error) {
+	event, _ = deepCopy(event).(map[string]any)
+	if event == nil {
+		event = make(map[string]any)
+	}
+	for i := range p.rules {
+		rule := &p.rules[i]
+		if rule.when != nil {
+			v, err := rule.when.eval(event)
+			if err != nil {
+				return nil, false, fmt.Errorf("rule %d: condition: %w", i, err)
+			}
+			if !truthy(v) {
+				continue
+			}
+		}
+		switch rule.Action {
+		case FilterAction:
+			return nil, false, nil
+		case SetAction:
+			value := deepCopy(rule.Value)
+			if rule.expression != nil {
+				var err error
+				if value, err = rule.expression.eval(event); err != nil {
+					return nil, false, fmt.Errorf("rule %d: expression: %w", i, err)
+				}
+			}
+			if err := set(event, rule.path, value); err != nil {
+				return nil, false, fmt.Errorf("rule %d: %w", i, err)
+			}
+		case RenameAction:
+			value, ok := lookup(event, rule.path)
+			if !ok {
+				continue
+			}
+			remove(event, rule.path)
+			if err := set(event, rule.to, value); err != nil {
+				return nil, false, fmt.Errorf("rule %d: %w", i, err)
+			}
+		case RemoveAction:
+			remove(event, rule.path)
+		}
+	}
+	return event, true, nil
+}
+
+// lookup returns the value at the path of the object, if any
+func lookup(object map[string]any, path []string) (any, bool) {
+	for _, field := range path[:len(path)-1] {
+		child, ok := object[field].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		object = child
+	}
+	value, ok := object[path[len(path)-1]]
+	return value, ok
+}
+
+// set sets the value at the path of the object, creating missing intermediate objects
+func set(object map[string]any, path []string, value any) error {
+	for i, field := range path[:len(path)-1] {
+		child, ok := object[field]
+		if !ok || child == nil {
+			child = make(map[string]any)
+			object[field] = child
+		}
+		if object, ok = child.(map[string]any); !ok {
+			return fmt.Errorf("cannot set %q: %q is not an object", strings.Join(path, "."), strings.Join(path[:i+1], "."))
+		}
+	}
+	object[path[len(path)-1]] = value
+	return nil
+}
+
+func remove(object map[string]any, path []string) {
+	for _, field := range path[:len(path)-1] {
+		child, ok := object[field].(map[string]any)
+		if !ok {
+			return
+		}
+		object = child
+	}
+	delete(object, path[len(path)-1])
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := maps.Clone(v)
+		for key, item := range out {
+			out[key] = deepCopy(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = deepCopy(item)
+		}
+		return out
+	}
+	return value
+}
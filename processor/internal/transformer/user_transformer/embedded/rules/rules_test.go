@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRules(t *testing.T) {
+	require.True(t, IsRules(map[string]any{"language": "rules"}))
+	require.True(t, IsRules(map[string]any{"language": "Rules"}))
+	require.False(t, IsRules(map[string]any{"language": "javascript"}))
+	require.False(t, IsRules(map[string]any{}))
+	require.False(t, IsRules(nil))
+}
+
+func TestCompile(t *testing.T) {
+	p, err := Compile(map[string]any{"language": "rules", "rules": []any{
+		map[string]any{"action": "filter", "when": "type == 'page'"},
+		map[string]any{"action": "set", "path": "properties.total", "expression": "properties.price * 2"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, p.rules, 2)
+
+	p, err = Compile(map[string]any{"language": "rules"})
+	require.NoError(t, err)
+	require.Empty(t, p.rules)
+
+	for name, rule := range map[string]map[string]any{
+		"unsupported action":       {"action": "encrypt", "path": "properties.email"},
+		"filter without condition": {"action": "filter"},
+		"missing path":             {"action": "remove"},
+		"invalid path":             {"action": "remove", "path": "properties..email"},
+		"rename without target":    {"action": "rename", "path": "properties.email"},
+		"invalid condition":        {"action": "remove", "path": "properties.email", "when": "type =="},
+		"invalid expression":       {"action": "set", "path": "properties.total", "expression": "2 *"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile(map[string]any{"language": "rules", "rules": []any{rule}})
+			require.ErrorIs(t, err, ErrInvalidRules)
+		})
+	}
+
+	_, err = Compile(map[string]any{"language": "rules", "rules": "remove everything"})
+	require.ErrorIs(t, err, ErrInvalidRules)
+}
+
+func TestApply(t *testing.T) {
+	p, err := Compile(map[string]any{"language": "rules", "rules": []any{
+		map[string]any{"action": "filter", "when": "event == 'Debug'"},
+		map[string]any{"action": "rename", "path": "properties.revenue", "to": "properties.value"},
+		map[string]any{"action": "rename", "path": "properties.missing", "to": "properties.other"},
+		map[string]any{"action": "remove", "path": "context.ip"},
+		map[string]any{"action": "set", "path": "properties.currency", "value": "EUR", "when": "properties.currency == null"},
+		map[string]any{"action": "set", "path": "properties.total", "expression": "properties.price * properties.quantity"},
+		map[string]any{"action": "set", "path": "context.traits.plan", "value": map[string]any{"name": "pro"}},
+	}})
+	require.NoError(t, err)
+
+	event := func() map[string]any {
+		return map[string]any{
+			"event":   "Order Completed",
+			"context": map[string]any{"ip": "192.168.0.1", "library": "js"},
+			"properties": map[string]any{
+				"revenue":  20.0,
+				"price":    10.0,
+				"quantity": 3.0,
+			},
+		}
+	}
+
+	t.Run("transformed", func(t *testing.T) {
+		original := event()
+		transformed, ok, err := p.Apply(original)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, map[string]any{
+			"event": "Order Completed",
+			"context": map[string]any{
+				"library": "js",
+				"traits":  map[string]any{"plan": map[string]any{"name": "pro"}},
+			},
+			"properties": map[string]any{
+				"value":    20.0,
+				"price":    10.0,
+				"quantity": 3.0,
+				"currency": "EUR",
+				"total":    30.0,
+			},
+		}, transformed)
+		require.Equal(t, event(), original, "the original event is left untouched")
+
+		transformed["context"].(map[string]any)["traits"].(map[string]any)["plan"].(map[string]any)["name"] = "free"
+		transformed, _, err = p.Apply(original)
+		require.NoError(t, err)
+		require.Equal(t, "pro", transformed["context"].(map[string]any)["traits"].(map[string]any)["plan"].(map[string]any)["name"], "set values are not shared across events")
+	})
+
+	t.Run("condition not met", func(t *testing.T) {
+		e := event()
+		e["properties"].(map[string]any)["currency"] = "USD"
+		transformed, ok, err := p.Apply(e)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "USD", transformed["properties"].(map[string]any)["currency"])
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		e := event()
+		e["event"] = "Debug"
+		_, ok, err := p.Apply(e)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		e := event()
+		e["properties"].(map[string]any)["price"] = "ten"
+		_, _, err := p.Apply(e)
+		require.ErrorContains(t, err, `rule 5: expression: invalid operands for "*": string and number`)
+
+		e = event()
+		e["context"].(map[string]any)["traits"] = "none"
+		_, _, err = p.Apply(e)
+		require.ErrorContains(t, err, `rule 6: cannot set "context.traits.plan": "context.traits" is not an object`)
+	})
+}
@@ -20,9 +20,11 @@ import (
 	"github.com/rudderlabs/rudder-go-kit/logger"
 	"github.com/rudderlabs/rudder-go-kit/stats"
 	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
 	transformerclient "github.com/rudderlabs/rudder-server/internal/transformer-client"
 	"github.com/rudderlabs/rudder-server/processor/integrations"
 	transformerutils "github.com/rudderlabs/rudder-server/processor/internal/transformer"
+	"github.com/rudderlabs/rudder-server/processor/internal/transformer/user_transformer/embedded/rules"
 	"github.com/rudderlabs/rudder-server/processor/types"
 	"github.com/rudderlabs/rudder-server/utils/httputil"
 	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
@@ -52,6 +54,7 @@ func New(conf *config.Config, log logger.Logger, stat stats.Stats, opts ...Opt)
 	handle.config.maxRetryBackoffInterval = conf.GetReloadableDurationVar(30, time.Second, "Processor.UserTransformer.maxRetryBackoffInterval", "Processor.maxRetryBackoffInterval")
 	handle.config.collectInstanceLevelStats = conf.GetBool("Processor.collectInstanceLevelStats", false)
 	handle.config.batchSize = conf.GetReloadableIntVar(200, 1, "Processor.UserTransformer.batchSize", "Processor.userTransformBatchSize")
+	handle.config.embeddedRulesEnabled = conf.GetReloadableBoolVar(true, "Processor.UserTransformer.Embedded.rules.enabled")
 
 	for _, opt := range opts {
 		opt(handle)
@@ -75,11 +78,23 @@ type Client struct {
 		timeoutDuration            time.Duration
 		collectInstanceLevelStats  bool
 		batchSize                  config.ValueLoader[int]
+		embeddedRulesEnabled       config.ValueLoader[bool]
 	}
 	conf   *config.Config
 	log    logger.Logger
 	stat   stats.Stats
 	client transformerclient.Client
+
+	// embeddedPrograms are the compiled rules transformations, keyed by transformation id
+	embeddedProgramsMu sync.Mutex
+	embeddedPrograms   map[string]*embeddedProgram
+}
+
+// embeddedProgram is a rules transformation compiled for a version of the transformation
+type embeddedProgram struct {
+	versionID string
+	program   *rules.Program
+	err       error
 }
 
 func (u *Client) Transform(ctx context.Context, clientEvents []types.TransformerEvent) types.Response {
@@ -105,6 +120,11 @@ func (u *Client) Transform(ctx context.Context, clientEvents []types.Transformer
 		Mirroring:        u.config.forMirroring,
 	}
 
+	// rules transformations are run in process, whereas code transformations are sent to the transformer
+	if u.runsEmbedded(clientEvents[0].Destination.Transformations) {
+		return u.transformEmbedded(clientEvents, labels)
+	}
+
 	var trackWg sync.WaitGroup
 	defer trackWg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
@@ -169,6 +189,69 @@ func (u *Client) Transform(ctx context.Context, clientEvents []types.Transformer
 	}
 }
 
+func (u *Client) runsEmbedded(transformations []backendconfig.TransformationT) bool {
+	if u.config.forMirroring || !u.config.embeddedRulesEnabled.Load() || len(transformations) == 0 {
+		return false
+	}
+	return rules.IsRules(transformations[0].Config)
+}
+
+// transformEmbedded applies the rules transformation of the events' destination to them.
+// Events failing to be transformed are returned with a bad request status code, as the transformer does for failing code.
+func (u *Client) transformEmbedded(clientEvents []types.TransformerEvent, labels types.TransformerMetricLabels) types.Response {
+	start := time.Now()
+	labels.Endpoint = "embedded"
+
+	var response types.Response
+	program, compileErr := u.embeddedProgram(clientEvents[0].Destination.ID, clientEvents[0].Destination.Transformations[0])
+	for i := range clientEvents {
+		event := &clientEvents[i]
+		if compileErr != nil {
+			response.FailedEvents = append(response.FailedEvents, types.TransformerResponse{StatusCode: http.StatusBadRequest, Error: compileErr.Error(), Metadata: event.Metadata})
+			continue
+		}
+		output, ok, err := program.Apply(event.Message)
+		switch {
+		case err != nil:
+			response.FailedEvents = append(response.FailedEvents, types.TransformerResponse{StatusCode: http.StatusBadRequest, Error: err.Error(), Metadata: event.Metadata})
+		case !ok:
+			response.FailedEvents = append(response.FailedEvents, types.TransformerResponse{StatusCode: reportingtypes.FilterEventCode, Metadata: event.Metadata})
+		default:
+			response.Events = append(response.Events, types.TransformerResponse{Output: output, StatusCode: http.StatusOK, Metadata: event.Metadata})
+		}
+	}
+
+	tags := labels.ToStatsTag()
+	u.stat.NewTaggedStat("embedded_user_transformer_total_time", stats.TimerType, tags).SendTiming(time.Since(start))
+	u.stat.NewTaggedStat("embedded_user_transformer_sent", stats.CountType, tags).Count(len(clientEvents))
+	u.stat.NewTaggedStat("embedded_user_transformer_received", stats.CountType, tags).Count(len(response.Events))
+	return response
+}
+
+// embeddedProgram returns the compiled rules transformation, compiling it only once per version of the transformation
+func (u *Client) embeddedProgram(destinationID string, transformation backendconfig.TransformationT) (*rules.Program, error) {
+	u.embeddedProgramsMu.Lock()
+	defer u.embeddedProgramsMu.Unlock()
+	if p, ok := u.embeddedPrograms[transformation.ID]; ok && p.versionID == transformation.VersionID {
+		return p.program, p.err
+	}
+
+	program, err := rules.Compile(transformation.Config)
+	if err != nil {
+		u.log.Warnn("Invalid rules transformation",
+			obskit.DestinationID(destinationID),
+			logger.NewStringField("transformationID", transformation.ID),
+			logger.NewStringField("transformationVersionID", transformation.VersionID),
+			obskit.Error(err),
+		)
+	}
+	if u.embeddedPrograms == nil {
+		u.embeddedPrograms = make(map[string]*embeddedProgram)
+	}
+	u.embeddedPrograms[transformation.ID] = &embeddedProgram{versionID: transformation.VersionID, program: program, err: err}
+	return program, err
+}
+
 func (u *Client) sendBatch(ctx context.Context, url string, labels types.TransformerMetricLabels, clientEvents []types.TransformerEvent) []types.TransformerResponse {
 	if len(clientEvents) == 0 {
 		return nil
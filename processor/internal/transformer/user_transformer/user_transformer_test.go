@@ -700,6 +700,138 @@ func TestUserTransformer(t *testing.T) {
 	}
 }
 
+func TestEmbeddedRulesTransformation(t *testing.T) {
+	newVersionEvents := func(versionID string, transformationConfig map[string]any) []types.TransformerEvent {
+		destination := backendconfig.DestinationT{
+			ID:                    "destination-id",
+			DestinationDefinition: backendconfig.DestinationDefinitionT{Name: "test-destination"},
+			Transformations: []backendconfig.TransformationT{
+				{ID: "transformation-id", VersionID: versionID, Config: transformationConfig},
+			},
+		}
+		return []types.TransformerEvent{
+			{
+				Metadata:    types.Metadata{MessageID: "message-1"},
+				Message:     map[string]any{"event": "Order Completed", "properties": map[string]any{"price": 2.0}, "forceStatusCode": 200.0},
+				Destination: destination,
+			},
+			{
+				Metadata:    types.Metadata{MessageID: "message-2"},
+				Message:     map[string]any{"event": "Debug", "forceStatusCode": 200.0},
+				Destination: destination,
+			},
+			{
+				Metadata:    types.Metadata{MessageID: "message-3"},
+				Message:     map[string]any{"event": "Order Completed", "properties": map[string]any{"price": "two"}, "forceStatusCode": 200.0},
+				Destination: destination,
+			},
+		}
+	}
+	newEvents := func(transformationConfig map[string]any) []types.TransformerEvent {
+		return newVersionEvents("version-id", transformationConfig)
+	}
+	rulesConfig := map[string]any{"language": "rules", "rules": []any{
+		map[string]any{"action": "filter", "when": "event == 'Debug'"},
+		map[string]any{"action": "remove", "path": "forceStatusCode"},
+		map[string]any{"action": "set", "path": "properties.total", "expression": "properties.price * 2"},
+	}}
+	newStatsClient := func(t *testing.T, conf *config.Config, stat stats.Stats, opts ...user_transformer.Opt) (*user_transformer.Client, *fakeTransformer) {
+		ft := &fakeTransformer{t: t}
+		srv := httptest.NewServer(ft)
+		t.Cleanup(srv.Close)
+		conf.Set("USER_TRANSFORM_URL", srv.URL)
+		conf.Set("USER_TRANSFORM_MIRROR_URL", srv.URL)
+		opts = append(opts, user_transformer.WithClient(srv.Client()))
+		return user_transformer.New(conf, logger.NOP, stat, opts...), ft
+	}
+	newClient := func(t *testing.T, conf *config.Config, opts ...user_transformer.Opt) (*user_transformer.Client, *fakeTransformer) {
+		return newStatsClient(t, conf, stats.NOP, opts...)
+	}
+
+	t.Run("rules transformation", func(t *testing.T) {
+		statsStore, err := memstats.New()
+		require.NoError(t, err)
+		c, ft := newStatsClient(t, config.New(), statsStore)
+		events := newEvents(rulesConfig)
+		rsp := c.Transform(context.Background(), events)
+
+		require.Empty(t, ft.requests, "rules transformations are not sent to the transformer")
+		require.Equal(t, []types.TransformerResponse{
+			{
+				Output:     map[string]any{"event": "Order Completed", "properties": map[string]any{"price": 2.0, "total": 4.0}},
+				Metadata:   types.Metadata{MessageID: "message-1"},
+				StatusCode: http.StatusOK,
+			},
+		}, rsp.Events)
+		require.Len(t, rsp.FailedEvents, 2)
+		require.Equal(t, reportingtypes.FilterEventCode, rsp.FailedEvents[0].StatusCode)
+		require.Equal(t, "message-2", rsp.FailedEvents[0].Metadata.MessageID)
+		require.Equal(t, http.StatusBadRequest, rsp.FailedEvents[1].StatusCode)
+		require.Equal(t, "message-3", rsp.FailedEvents[1].Metadata.MessageID)
+		require.Contains(t, rsp.FailedEvents[1].Error, `invalid operands for "*"`)
+		require.Contains(t, events[0].Message, "forceStatusCode", "input events are left untouched")
+
+		sent := statsStore.GetByName("embedded_user_transformer_sent")
+		require.Len(t, sent, 1)
+		require.EqualValues(t, 3, sent[0].Value)
+		require.Equal(t, "embedded", sent[0].Tags["endpoint"])
+		received := statsStore.GetByName("embedded_user_transformer_received")
+		require.Len(t, received, 1)
+		require.EqualValues(t, 1, received[0].Value)
+		require.Empty(t, statsStore.GetByName("processor_transformer_sent"), "embedded transformations are not counted as sent to the transformer")
+	})
+
+	t.Run("compiled once per version", func(t *testing.T) {
+		c, _ := newClient(t, config.New())
+		require.Len(t, c.Transform(context.Background(), newEvents(rulesConfig)).Events, 1)
+
+		invalidConfig := map[string]any{"language": "rules", "rules": []any{map[string]any{"action": "encrypt"}}}
+		rsp := c.Transform(context.Background(), newEvents(invalidConfig))
+		require.Len(t, rsp.Events, 1, "the transformation compiled for the version is reused")
+
+		rsp = c.Transform(context.Background(), newVersionEvents("new-version-id", invalidConfig))
+		require.Empty(t, rsp.Events, "a new version of the transformation is compiled again")
+		require.Len(t, rsp.FailedEvents, 3)
+	})
+
+	t.Run("invalid rules transformation", func(t *testing.T) {
+		c, ft := newClient(t, config.New())
+		rsp := c.Transform(context.Background(), newEvents(map[string]any{"language": "rules", "rules": []any{
+			map[string]any{"action": "encrypt"},
+		}}))
+
+		require.Empty(t, ft.requests)
+		require.Empty(t, rsp.Events)
+		require.Len(t, rsp.FailedEvents, 3)
+		for _, event := range rsp.FailedEvents {
+			require.Equal(t, http.StatusBadRequest, event.StatusCode)
+			require.Contains(t, event.Error, "invalid rules transformation")
+		}
+	})
+
+	t.Run("code transformation", func(t *testing.T) {
+		c, ft := newClient(t, config.New())
+		rsp := c.Transform(context.Background(), newEvents(map[string]any{"language": "javascript"}))
+		require.Len(t, ft.requests, 1)
+		require.Len(t, rsp.Events, 3)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		conf := config.New()
+		conf.Set("Processor.UserTransformer.Embedded.rules.enabled", false)
+		c, ft := newClient(t, conf)
+		rsp := c.Transform(context.Background(), newEvents(rulesConfig))
+		require.Len(t, ft.requests, 1)
+		require.Len(t, rsp.Events, 3)
+	})
+
+	t.Run("mirroring", func(t *testing.T) {
+		c, ft := newClient(t, config.New(), user_transformer.ForMirroring())
+		c.Transform(context.Background(), newEvents(rulesConfig))
+		require.Len(t, ft.requests, 1, "rules transformations are still mirrored to the transformer")
+	})
+}
+
 func TestLongRunningTransformation(t *testing.T) {
 	fileName := t.TempDir() + "out.log"
 	f, err := os.Create(fileName)
@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"net/http"
+
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/internal/transformer/destination_transformer/embedded/pii"
+	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
+)
+
+// newPIIMasker returns the pii masker of the destination, or nil if pii masking is not enabled for it
+func (proc *Handle) newPIIMasker(dest *backendconfig.DestinationT, salt string) *pii.Masker {
+	conf, ok, err := pii.ParseConfig(dest.Config)
+	if err != nil {
+		proc.logger.Errorn("Invalid pii masking config, pii will not be masked",
+			obskit.DestinationID(dest.ID),
+			obskit.Error(err),
+		)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	if conf.Action == pii.HashAction && salt == "" {
+		proc.logger.Warnn("Hashing pii without a workspace salt",
+			obskit.WorkspaceID(dest.WorkspaceID),
+			obskit.DestinationID(dest.ID),
+		)
+	}
+	return pii.New(conf, salt)
+}
+
+func (proc *Handle) getPIIMasker(destinationID string) *pii.Masker {
+	proc.config.configSubscriberLock.RLock()
+	defer proc.config.configSubscriberLock.RUnlock()
+	return proc.config.piiMaskersMap[destinationID]
+}
+
+// maskPII masks the pii of the events to be transformed for the destination, if enabled for it,
+// reporting the number of events with masked fields per event name, event type and pii type
+func (proc *Handle) maskPII(data *userTransformAndFilterOutput) {
+	if !proc.config.piiMaskingEnabled.Load() {
+		return
+	}
+	masker := proc.getPIIMasker(data.commonMetaData.DestinationID)
+	if masker == nil {
+		return
+	}
+
+	fieldCounts := make(map[pii.Type]int)
+	eventCounts := make(map[pii.Type]int)
+	connectionDetailsMap := make(map[string]*reportingtypes.ConnectionDetails)
+	statusDetailsMap := make(map[string]map[string]*reportingtypes.StatusDetail)
+	for i := range data.eventsToTransform {
+		event := &data.eventsToTransform[i]
+		masked, counts := masker.Mask(event.Message)
+		if len(counts) == 0 {
+			continue
+		}
+		// the events to transform are built from the events of the response, one for each and in the same order
+		event.Message = masked
+		data.response.Events[i].Output = masked
+
+		for t, count := range counts {
+			fieldCounts[t] += count
+			eventCounts[t]++
+		}
+		if !proc.isReportingEnabled() {
+			continue
+		}
+		metadata := &event.Metadata
+		key := proc.addConnectionDetails(connectionDetailsMap, metadata, reportingtypes.PIIMaskedStatus, http.StatusOK)
+		if _, ok := statusDetailsMap[key]; !ok {
+			statusDetailsMap[key] = make(map[string]*reportingtypes.StatusDetail)
+		}
+		for t := range counts {
+			sdkey := string(t) + ":" + metadata.EventName + ":" + metadata.EventType
+			sd, ok := statusDetailsMap[key][sdkey]
+			if !ok {
+				sd = &reportingtypes.StatusDetail{
+					Status:     reportingtypes.PIIMaskedStatus,
+					StatusCode: http.StatusOK,
+					EventName:  metadata.EventName,
+					EventType:  metadata.EventType,
+					ErrorType:  string(t),
+				}
+				statusDetailsMap[key][sdkey] = sd
+			}
+			sd.Count++
+		}
+	}
+	if len(eventCounts) == 0 {
+		return
+	}
+
+	for t, count := range eventCounts {
+		tags := stats.Tags{
+			"workspaceId":   data.commonMetaData.WorkspaceID,
+			"sourceId":      data.commonMetaData.SourceID,
+			"destinationId": data.commonMetaData.DestinationID,
+			"destType":      data.commonMetaData.DestinationType,
+			"piiType":       string(t),
+		}
+		proc.statsFactory.NewTaggedStat("processor_pii_masked_events", stats.CountType, tags).Count(count)
+		proc.statsFactory.NewTaggedStat("processor_pii_masked_fields", stats.CountType, tags).Count(fieldCounts[t])
+	}
+
+	for k, sds := range statusDetailsMap {
+		for _, sd := range sds {
+			data.reportMetrics = append(data.reportMetrics, &reportingtypes.PUReportedMetric{
+				ConnectionDetails: *connectionDetailsMap[k],
+				PUDetails:         *reportingtypes.CreatePUDetails("", reportingtypes.PII_MASKING, false, false),
+				StatusDetail:      sd,
+			})
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package processor
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	mockreportingtypes "github.com/rudderlabs/rudder-server/mocks/utils/types"
+	"github.com/rudderlabs/rudder-server/processor/internal/transformer/destination_transformer/embedded/pii"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
+)
+
+func TestNewPIIMasker(t *testing.T) {
+	proc := NewHandle(config.New(), nil)
+	proc.logger = logger.NOP
+
+	require.NotNil(t, proc.newPIIMasker(&backendconfig.DestinationT{ID: "destID", Config: map[string]any{
+		pii.ConfigKey: map[string]any{"enabled": true, "action": "redact"},
+	}}, ""))
+	require.Nil(t, proc.newPIIMasker(&backendconfig.DestinationT{ID: "destID", Config: map[string]any{}}, "salt"))
+	require.Nil(t, proc.newPIIMasker(&backendconfig.DestinationT{ID: "destID", Config: map[string]any{
+		pii.ConfigKey: map[string]any{"enabled": true, "action": "encrypt"},
+	}}, "salt"), "invalid configs are ignored")
+}
+
+func TestMaskPII(t *testing.T) {
+	newData := func(message types.SingularEventT) *userTransformAndFilterOutput {
+		metadata := types.Metadata{
+			WorkspaceID:     "workspaceID",
+			SourceID:        "sourceID",
+			DestinationID:   "marketingDestID",
+			DestinationType: "FACEBOOK_PIXEL",
+			EventName:       "Order Completed",
+			EventType:       "track",
+		}
+		return &userTransformAndFilterOutput{
+			eventsToTransform: []types.TransformerEvent{{Message: message, Metadata: metadata}},
+			commonMetaData:    &metadata,
+			response:          types.Response{Events: []types.TransformerResponse{{Output: message, Metadata: metadata}}},
+		}
+	}
+	message := func() types.SingularEventT {
+		return types.SingularEventT{
+			"event": "Order Completed",
+			"properties": map[string]any{
+				"email":          "john@example.com",
+				"referrer_email": "jane@example.com",
+				"phone":          "+14155552671",
+				"total":          42.0,
+			},
+		}
+	}
+	newHandle := func(enabled bool) (*Handle, *memstats.Store) {
+		statsStore, err := memstats.New()
+		require.NoError(t, err)
+		c := config.New()
+		c.Set("Processor.piiMasking.enabled", enabled)
+		proc := NewHandle(c, nil)
+		proc.logger = logger.NOP
+		proc.statsFactory = statsStore
+		proc.reportingEnabled = true
+		proc.reporting = &mockreportingtypes.MockReporting{}
+		proc.config.piiMaskersMap = map[string]*pii.Masker{
+			"marketingDestID": pii.New(pii.Config{Enabled: true, Action: pii.RedactAction}, ""),
+		}
+		return proc, statsStore
+	}
+
+	t.Run("masked", func(t *testing.T) {
+		proc, statsStore := newHandle(true)
+		original := message()
+		data := newData(original)
+		proc.maskPII(data)
+
+		expected := map[string]any{"email": pii.RedactedValue, "referrer_email": pii.RedactedValue, "phone": pii.RedactedValue, "total": 42.0}
+		require.Equal(t, expected, data.eventsToTransform[0].Message["properties"])
+		require.Equal(t, expected, data.response.Events[0].Output["properties"])
+		require.Equal(t, message(), original, "the event shared with other destinations is left untouched")
+
+		require.Len(t, data.reportMetrics, 2)
+		for _, metric := range data.reportMetrics {
+			require.Equal(t, reportingtypes.PII_MASKING, metric.PUDetails.PU)
+			require.Equal(t, "marketingDestID", metric.ConnectionDetails.DestinationID)
+			require.Equal(t, reportingtypes.PIIMaskedStatus, metric.StatusDetail.Status)
+			require.Equal(t, http.StatusOK, metric.StatusDetail.StatusCode)
+			require.EqualValues(t, 1, metric.StatusDetail.Count, "events are counted once per pii type")
+			require.Contains(t, []string{string(pii.EmailType), string(pii.PhoneType)}, metric.StatusDetail.ErrorType)
+		}
+		tags := stats.Tags{
+			"workspaceId":   "workspaceID",
+			"sourceId":      "sourceID",
+			"destinationId": "marketingDestID",
+			"destType":      "FACEBOOK_PIXEL",
+			"piiType":       string(pii.EmailType),
+		}
+		require.EqualValues(t, 1, statsStore.Get("processor_pii_masked_events", tags).LastValue())
+		require.EqualValues(t, 2, statsStore.Get("processor_pii_masked_fields", tags).LastValue())
+	})
+
+	t.Run("destination without masking", func(t *testing.T) {
+		proc, _ := newHandle(true)
+		data := newData(message())
+		data.commonMetaData.DestinationID = "warehouseDestID"
+		proc.maskPII(data)
+
+		require.Equal(t, message(), data.eventsToTransform[0].Message)
+		require.Empty(t, data.reportMetrics)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		proc, _ := newHandle(false)
+		data := newData(message())
+		proc.maskPII(data)
+
+		require.Equal(t, message(), data.eventsToTransform[0].Message)
+		require.Empty(t, data.reportMetrics)
+	})
+}
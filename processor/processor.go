@@ -38,11 +38,14 @@ import (
 	"github.com/rudderlabs/rudder-server/processor/delayed"
 	"github.com/rudderlabs/rudder-server/processor/eventfilter"
 	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/processor/internal/sampling"
+	"github.com/rudderlabs/rudder-server/processor/internal/transformer/destination_transformer/embedded/pii"
 	"github.com/rudderlabs/rudder-server/processor/isolation"
 	"github.com/rudderlabs/rudder-server/processor/stash"
 	"github.com/rudderlabs/rudder-server/processor/transformer"
 	"github.com/rudderlabs/rudder-server/processor/types"
 	"github.com/rudderlabs/rudder-server/router/batchrouter"
+	routertransformer "github.com/rudderlabs/rudder-server/router/transformer"
 	"github.com/rudderlabs/rudder-server/rruntime"
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
 	transformationdebugger "github.com/rudderlabs/rudder-server/services/debugger/transformation"
@@ -160,6 +163,8 @@ type Handle struct {
 		connectionConfigMap                       map[connection]backendconfig.Connection
 		ketchConsentCategoriesMap                 map[string][]string
 		genericConsentManagementMap               SourceConsentMap
+		piiMaskersMap                             map[string]*pii.Masker
+		eventSamplersMap                          map[string]*sampling.Sampler
 		batchDestinations                         []string
 		configSubscriberLock                      sync.RWMutex
 		enableDedup                               bool
@@ -178,6 +183,9 @@ type Handle struct {
 		userTransformationMirroringFireAndForget  config.ValueLoader[bool]
 		storeSamplerEnabled                       config.ValueLoader[bool]
 		enableOptimizedConnectionDetailsKey       config.ValueLoader[bool]
+		auditConsentDroppedEvents                 config.ValueLoader[bool]
+		piiMaskingEnabled                         config.ValueLoader[bool]
+		eventSamplingEnabled                      config.ValueLoader[bool]
 	}
 
 	drainConfig struct {
@@ -568,7 +576,7 @@ func (proc *Handle) Setup(
 
 	if proc.config.enableDedup {
 		var err error
-		proc.dedup, err = dedup.New(proc.conf, proc.statsFactory, proc.logger)
+		proc.dedup, err = dedup.New(proc.conf, proc.statsFactory, proc.logger, dedup.WithDB(proc.gatewayDB))
 		if err != nil {
 			return err
 		}
@@ -779,6 +787,9 @@ func (proc *Handle) loadReloadableConfig(defaultPayloadLimit int64, defaultMaxEv
 	proc.config.userTransformationMirroringFireAndForget = proc.conf.GetReloadableBoolVar(false, "Processor.userTransformationMirroring.fireAndForget")
 	proc.config.storeSamplerEnabled = proc.conf.GetReloadableBoolVar(false, "Processor.storeSamplerEnabled")
 	proc.config.enableOptimizedConnectionDetailsKey = proc.conf.GetReloadableBoolVar(false, "Processor.enableOptimizedConnectionDetailsKey")
+	proc.config.auditConsentDroppedEvents = proc.conf.GetReloadableBoolVar(false, "Processor.consentManagement.auditDroppedEvents")
+	proc.config.piiMaskingEnabled = proc.conf.GetReloadableBoolVar(true, "Processor.piiMasking.enabled")
+	proc.config.eventSamplingEnabled = proc.conf.GetReloadableBoolVar(true, "Processor.eventSampling.enabled")
 }
 
 type connection struct {
@@ -794,6 +805,8 @@ func (proc *Handle) backendConfigSubscriber(ctx context.Context) {
 			oneTrustConsentCategoriesMap = make(map[string][]string)
 			ketchConsentCategoriesMap    = make(map[string][]string)
 			genericConsentManagementMap  = make(SourceConsentMap)
+			piiMaskersMap                = make(map[string]*pii.Masker)
+			eventSamplersMap             = make(map[string]*sampling.Sampler)
 			workspaceLibrariesMap        = make(map[string]backendconfig.LibrariesT, len(config))
 			sourceIdDestinationMap       = make(map[string][]backendconfig.DestinationT)
 			sourceIdSourceMap            = make(map[string]backendconfig.SourceT)
@@ -822,6 +835,12 @@ func (proc *Handle) backendConfigSubscriber(ctx context.Context) {
 						if err != nil {
 							proc.logger.Error(err)
 						}
+						if masker := proc.newPIIMasker(destination, wConfig.Settings.PIIMasking.Salt); masker != nil {
+							piiMaskersMap[destination.ID] = masker
+						}
+						if sampler := proc.newEventSampler(destination, proc.getEventSampler(destination.ID)); sampler != nil {
+							eventSamplersMap[destination.ID] = sampler
+						}
 					}
 				}
 				if source.SourceDefinition.Category != "" && !strings.EqualFold(source.SourceDefinition.Category, sourceCategoryWebhook) {
@@ -844,6 +863,8 @@ func (proc *Handle) backendConfigSubscriber(ctx context.Context) {
 		proc.config.oneTrustConsentCategoriesMap = oneTrustConsentCategoriesMap
 		proc.config.ketchConsentCategoriesMap = ketchConsentCategoriesMap
 		proc.config.genericConsentManagementMap = genericConsentManagementMap
+		proc.config.piiMaskersMap = piiMaskersMap
+		proc.config.eventSamplersMap = eventSamplersMap
 		proc.config.workspaceLibrariesMap = workspaceLibrariesMap
 		proc.config.sourceIdDestinationMap = sourceIdDestinationMap
 		proc.config.sourceIdSourceMap = sourceIdSourceMap
@@ -1232,42 +1253,7 @@ func (proc *Handle) updateMetricMaps(
 		}
 	}
 
-	key := event.Metadata.SourceID + ":" +
-		event.Metadata.DestinationID + ":" +
-		event.Metadata.SourceJobRunID + ":" +
-		event.Metadata.TransformationID + ":" +
-		event.Metadata.TransformationVersionID + ":" +
-		event.Metadata.TrackingPlanID + ":" +
-		strconv.Itoa(event.Metadata.TrackingPlanVersion) + ":" +
-		status + ":" + strconv.Itoa(event.StatusCode) + ":" +
-		eventName + ":" + eventType
-
-	if proc.config.enableOptimizedConnectionDetailsKey.Load() {
-		key = event.Metadata.SourceID + ":" +
-			event.Metadata.DestinationID + ":" +
-			event.Metadata.SourceJobRunID + ":" +
-			event.Metadata.TransformationID + ":" +
-			event.Metadata.TransformationVersionID + ":" +
-			event.Metadata.TrackingPlanID + ":" +
-			strconv.Itoa(event.Metadata.TrackingPlanVersion)
-	}
-
-	if _, ok := connectionDetailsMap[key]; !ok {
-		connectionDetailsMap[key] = &reportingtypes.ConnectionDetails{
-			SourceID:                event.Metadata.SourceID,
-			SourceTaskRunID:         event.Metadata.SourceTaskRunID,
-			SourceJobID:             event.Metadata.SourceJobID,
-			SourceJobRunID:          event.Metadata.SourceJobRunID,
-			SourceDefinitionID:      event.Metadata.SourceDefinitionID,
-			SourceCategory:          event.Metadata.SourceCategory,
-			DestinationID:           event.Metadata.DestinationID,
-			DestinationDefinitionID: event.Metadata.DestinationDefinitionID,
-			TransformationID:        event.Metadata.TransformationID,
-			TransformationVersionID: event.Metadata.TransformationVersionID,
-			TrackingPlanID:          event.Metadata.TrackingPlanID,
-			TrackingPlanVersion:     event.Metadata.TrackingPlanVersion,
-		}
-	}
+	key := proc.addConnectionDetails(connectionDetailsMap, &event.Metadata, status, event.StatusCode)
 
 	if _, ok := statusDetailsMap[key]; !ok {
 		statusDetailsMap[key] = make(map[string]*reportingtypes.StatusDetail)
@@ -1334,6 +1320,51 @@ func (proc *Handle) updateMetricMaps(
 	sd.ViolationCount += int64(veCount)
 }
 
+// addConnectionDetails adds the connection details of the event's metadata to the map, if missing, returning their key
+func (proc *Handle) addConnectionDetails(
+	connectionDetailsMap map[string]*reportingtypes.ConnectionDetails,
+	metadata *types.Metadata,
+	status string, statusCode int,
+) string {
+	key := metadata.SourceID + ":" +
+		metadata.DestinationID + ":" +
+		metadata.SourceJobRunID + ":" +
+		metadata.TransformationID + ":" +
+		metadata.TransformationVersionID + ":" +
+		metadata.TrackingPlanID + ":" +
+		strconv.Itoa(metadata.TrackingPlanVersion) + ":" +
+		status + ":" + strconv.Itoa(statusCode) + ":" +
+		metadata.EventName + ":" + metadata.EventType
+
+	if proc.config.enableOptimizedConnectionDetailsKey.Load() {
+		key = metadata.SourceID + ":" +
+			metadata.DestinationID + ":" +
+			metadata.SourceJobRunID + ":" +
+			metadata.TransformationID + ":" +
+			metadata.TransformationVersionID + ":" +
+			metadata.TrackingPlanID + ":" +
+			strconv.Itoa(metadata.TrackingPlanVersion)
+	}
+
+	if _, ok := connectionDetailsMap[key]; !ok {
+		connectionDetailsMap[key] = &reportingtypes.ConnectionDetails{
+			SourceID:                metadata.SourceID,
+			SourceTaskRunID:         metadata.SourceTaskRunID,
+			SourceJobID:             metadata.SourceJobID,
+			SourceJobRunID:          metadata.SourceJobRunID,
+			SourceDefinitionID:      metadata.SourceDefinitionID,
+			SourceCategory:          metadata.SourceCategory,
+			DestinationID:           metadata.DestinationID,
+			DestinationDefinitionID: metadata.DestinationDefinitionID,
+			TransformationID:        metadata.TransformationID,
+			TransformationVersionID: metadata.TransformationVersionID,
+			TrackingPlanID:          metadata.TrackingPlanID,
+			TrackingPlanVersion:     metadata.TrackingPlanVersion,
+		}
+	}
+	return key
+}
+
 func (proc *Handle) getNonSuccessfulMetrics(
 	response types.Response,
 	commonMetaData *types.Metadata,
@@ -1646,6 +1677,8 @@ func getDiffMetrics(
 
 type dupStatKey struct {
 	sourceID string
+	// rule is the name of the source's dedup rule which detected the duplicate, empty for message id duplicates
+	rule string
 }
 
 func (proc *Handle) eventAuditEnabled(workspaceID string) bool {
@@ -1665,6 +1698,7 @@ type preTransformationMessage struct {
 	botManagementStatusDetailsMap map[string]map[string]*reportingtypes.StatusDetail
 	eventBlockingStatusDetailsMap map[string]map[string]*reportingtypes.StatusDetail
 	destFilterStatusDetailMap     map[string]map[string]*reportingtypes.StatusDetail
+	consentStatusDetailsMap       map[string]map[string]*reportingtypes.StatusDetail
 	reportMetrics                 []*reportingtypes.PUReportedMetric
 	inCountMetadataMap            map[string]MetricMetadata
 	inCountMap                    map[string]int64
@@ -1734,6 +1768,7 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 	enricherStatusDetailsMap := make(map[string]map[string]*reportingtypes.StatusDetail)
 	botManagementStatusDetailsMap := make(map[string]map[string]*reportingtypes.StatusDetail)
 	eventBlockingStatusDetailsMap := make(map[string]map[string]*reportingtypes.StatusDetail)
+	consentStatusDetailsMap := make(map[string]map[string]*reportingtypes.StatusDetail)
 	// map of jobID to destinationID: for messages that needs to be delivered to a specific destinations only
 	jobIDToSpecificDestMapOnly := make(map[int64]string)
 
@@ -1744,6 +1779,11 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 		}
 	}()
 
+	type ruleDedupBatchKey struct {
+		rule string
+		dedup.BatchKey
+	}
+
 	type jobWithMetaData struct {
 		jobID         int64
 		workspaceID   string
@@ -1752,6 +1792,7 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 		userId        string
 		eventParams   types.EventParams
 		dedupKey      dedup.BatchKey
+		ruleDedupKeys []ruleDedupBatchKey
 		requestIP     string
 		recievedAt    time.Time
 		parameters    json.RawMessage
@@ -1847,6 +1888,19 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 				Key:   messageId + eventParams.SourceJobRunId,
 			}
 			dedupBatchKeysIdx++
+			dedupBatchKeys = append(dedupBatchKeys, dedupBatchKey)
+			var ruleDedupKeys []ruleDedupBatchKey
+			if proc.config.enableDedup {
+				for _, ruleKey := range dedupRuleKeys(source, payloadFunc(), receivedAt) {
+					ruleDedupKey := ruleDedupBatchKey{
+						rule:     ruleKey.rule,
+						BatchKey: dedup.BatchKey{Index: dedupBatchKeysIdx, Key: ruleKey.key},
+					}
+					dedupBatchKeysIdx++
+					ruleDedupKeys = append(ruleDedupKeys, ruleDedupKey)
+					dedupBatchKeys = append(dedupBatchKeys, ruleDedupKey.BatchKey)
+				}
+			}
 			jobsWithMetaData = append(jobsWithMetaData, jobWithMetaData{
 				jobID:         batchEvent.JobID,
 				userId:        batchEvent.UserID,
@@ -1855,6 +1909,7 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 				messageID:     messageId,
 				eventParams:   eventParams,
 				dedupKey:      dedupBatchKey,
+				ruleDedupKeys: ruleDedupKeys,
 				requestIP:     requestIP,
 				recievedAt:    receivedAt,
 				parameters:    parameters,
@@ -1864,7 +1919,6 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 				customVal:     batchEvent.CustomVal,
 				payloadFunc:   payloadFunc,
 			})
-			dedupBatchKeys = append(dedupBatchKeys, dedupBatchKey)
 		}
 	}
 
@@ -1959,12 +2013,35 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 		}
 
 		if proc.config.enableDedup {
-			if !allowedBatchKeys[event.dedupKey] {
-				proc.logger.Debugn("Dropping event with duplicate key %s", logger.NewStringField("key", event.dedupKey.Key))
-				sourceDupStats[dupStatKey{sourceID: event.eventParams.SourceId}] += 1
+			duplicate := !allowedBatchKeys[event.dedupKey]
+			var duplicateRule string
+			if duplicate {
+				proc.logger.Debugn("Dropping event with duplicate key", logger.NewStringField("key", event.dedupKey.Key))
+			} else {
+				for _, ruleKey := range event.ruleDedupKeys {
+					if !allowedBatchKeys[ruleKey.BatchKey] {
+						proc.logger.Debugn("Dropping event with duplicate content",
+							logger.NewStringField("rule", ruleKey.rule),
+							logger.NewStringField("key", ruleKey.Key),
+						)
+						duplicate, duplicateRule = true, ruleKey.rule
+						break
+					}
+				}
+			}
+			// allowed keys are committed even for duplicate events, since the deduplication service expects all allowed keys to be committed
+			if allowedBatchKeys[event.dedupKey] {
+				dedupKeys[event.dedupKey.Key] = struct{}{}
+			}
+			for _, ruleKey := range event.ruleDedupKeys {
+				if allowedBatchKeys[ruleKey.BatchKey] {
+					dedupKeys[ruleKey.Key] = struct{}{}
+				}
+			}
+			if duplicate {
+				sourceDupStats[dupStatKey{sourceID: sourceId, rule: duplicateRule}] += 1
 				continue
 			}
-			dedupKeys[event.dedupKey.Key] = struct{}{}
 		}
 
 		proc.updateSourceEventStatsDetailed(event.singularEvent, sourceId)
@@ -2063,7 +2140,10 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 		// Event will be dropped if no valid destination is present
 		// if empty destinationID is passed in this fn all the destinations for the source are validated
 		// else only passed destinationID will be validated
-		if !proc.isDestinationAvailable(event.singularEvent, sourceId, event.eventParams.DestinationID) {
+		// Destinations removed by consent rules are only recorded here if the event is dropped altogether,
+		// otherwise they are recorded when the event is routed to the remaining destinations
+		if destinations, consentDrops := proc.availableDestinations(event.singularEvent, sourceId, event.eventParams.DestinationID); len(destinations) == 0 {
+			proc.recordConsentDrops(connectionDetailsMap, consentStatusDetailsMap, commonMetadataFromSingularEvent, event.recievedAt, consentDrops)
 			continue
 		}
 
@@ -2116,6 +2196,7 @@ func (proc *Handle) preprocessStage(partition string, subJobs subJob) (*preTrans
 		eventBlockingStatusDetailsMap: eventBlockingStatusDetailsMap,
 		reportMetrics:                 reportMetrics,
 		destFilterStatusDetailMap:     destFilterStatusDetailMap,
+		consentStatusDetailsMap:       consentStatusDetailsMap,
 		inCountMetadataMap:            inCountMetadataMap,
 		inCountMap:                    inCountMap,
 		outCountMap:                   outCountMap,
@@ -2313,7 +2394,7 @@ func (proc *Handle) pretransformStage(partition string, preTrans *preTransformat
 
 			for i := range enabledDestTypes {
 				destType := &enabledDestTypes[i]
-				enabledDestinationsList := proc.getConsentFilteredDestinations(
+				enabledDestinationsList, consentDrops := proc.filterDestinationsByConsent(
 					singularEvent,
 					sourceId,
 					lo.Filter(proc.getEnabledDestinations(sourceId, *destType), func(item backendconfig.DestinationT, index int) bool {
@@ -2324,6 +2405,7 @@ func (proc *Handle) pretransformStage(partition string, preTrans *preTransformat
 						return destId == ""
 					}),
 				)
+				proc.recordConsentDrops(preTrans.connectionDetailsMap, preTrans.consentStatusDetailsMap, &event.Metadata, preTrans.eventsByMessageID[event.Metadata.MessageID].ReceivedAt, consentDrops)
 
 				// Adding a singular event multiple times if there are multiple destinations of same type
 				for idx := range enabledDestinationsList {
@@ -2366,6 +2448,22 @@ func (proc *Handle) pretransformStage(partition string, preTrans *preTransformat
 			}
 		}
 	}
+
+	// REPORTING - CONSENT_FILTER metrics - START
+	if proc.isReportingEnabled() {
+		reportingtypes.AssertKeysSubset(preTrans.connectionDetailsMap, preTrans.consentStatusDetailsMap)
+		for k, sds := range preTrans.consentStatusDetailsMap {
+			for _, sd := range sds {
+				preTrans.reportMetrics = append(preTrans.reportMetrics, &reportingtypes.PUReportedMetric{
+					ConnectionDetails: *preTrans.connectionDetailsMap[k],
+					PUDetails:         *reportingtypes.CreatePUDetails("", reportingtypes.CONSENT_FILTER, false, false),
+					StatusDetail:      sd,
+				})
+			}
+		}
+	}
+	// REPORTING - CONSENT_FILTER metrics - END
+
 	trackedUsersReportGenStart := time.Now()
 	trackedUsersReports := proc.trackedUsersReporter.GenerateReportsFromJobs(preTrans.jobList, proc.getNonEventStreamSources())
 	proc.stats.trackedUsersReportGeneration(preTrans.partition).SendTiming(time.Since(trackedUsersReportGenStart))
@@ -3270,6 +3368,10 @@ func (proc *Handle) userTransformAndFilter(
 	if val, ok := destination.DestinationDefinition.Config["transformAtV1"].(string); ok {
 		transformAt = val
 	}
+	if routertransformer.IsEmbedded(destination.DestinationDefinition.Name) {
+		// natively transformed destinations are only transformed by the router
+		transformAt = "router"
+	}
 	// Check for overrides through env
 	transformAtOverrideFound := proc.conf.IsSet("Processor." + destination.DestinationDefinition.Name + ".transformAt")
 	if transformAtOverrideFound {
@@ -3279,6 +3381,9 @@ func (proc *Handle) userTransformAndFilter(
 	s := time.Now()
 	eventFilterInCount := len(eventsToTransform)
 	proc.logger.Debug("Supported messages filtering input size", eventFilterInCount)
+	// events dropped by the destination's sampling rules are reported as filtered by the event filter
+	var sampledEvents map[string][]types.TransformerResponse
+	eventsToTransform, sampledEvents = proc.sampleEvents(eventsToTransform, destination)
 	response = ConvertToFilteredTransformerResponse(
 		eventsToTransform,
 		transformAt != "none",
@@ -3301,6 +3406,7 @@ func (proc *Handle) userTransformAndFilter(
 	var successCountMap map[string]int64
 	var successCountMetadataMap map[string]MetricMetadata
 	nonSuccessMetrics := proc.getNonSuccessfulMetrics(response, commonMetaData, eventsByMessageID, inPU, reportingtypes.EVENT_FILTER)
+	proc.addSampledMetrics(nonSuccessMetrics, sampledEvents, commonMetaData, eventsByMessageID, inPU)
 	droppedJobs = append(droppedJobs, append(proc.getDroppedJobs(response, eventsToTransform), append(nonSuccessMetrics.failedJobs, nonSuccessMetrics.filteredJobs...)...)...)
 	if _, ok := procErrorJobsByDestID[destID]; !ok {
 		procErrorJobsByDestID[destID] = make([]*jobsdb.JobT, 0)
@@ -3371,6 +3477,9 @@ func (proc *Handle) destTransform(
 		}
 	}
 
+	// PII is masked before the destination transformation, so that neither the transformer nor the destination receives raw PII
+	proc.maskPII(&data)
+
 	response := data.response
 	eventsByMessageID := data.eventsByMessageID
 	sourceID := data.commonMetaData.SourceID
@@ -3384,7 +3493,8 @@ func (proc *Handle) destTransform(
 	destJobs := make([]*jobsdb.JobT, 0)
 	batchDestJobs := make([]*jobsdb.JobT, 0)
 	routerDestIDs := make(map[string]struct{})
-	transformAtFromFeaturesFile := proc.transformerFeaturesService.RouterTransform(destination.DestinationDefinition.Name)
+	transformAtFromFeaturesFile := proc.transformerFeaturesService.RouterTransform(destination.DestinationDefinition.Name) ||
+		routertransformer.IsEmbedded(destination.DestinationDefinition.Name)
 
 	// Destination transformation - START
 	// Send to transformer only if is
@@ -3866,6 +3976,12 @@ func (proc *Handle) updateSourceStats(sourceStats map[dupStatKey]int, bucket str
 		}
 		sourceStatsD := proc.statsFactory.NewTaggedStat(bucket, stats.CountType, tags)
 		sourceStatsD.Count(count)
+		if dupStat.rule != "" {
+			proc.statsFactory.NewTaggedStat("processor_dedup_rule_dropped_events", stats.CountType, stats.Tags{
+				"sourceId": dupStat.sourceID,
+				"rule":     dupStat.rule,
+			}).Count(count)
+		}
 	}
 }
 
@@ -3900,34 +4016,43 @@ func (*Handle) getLimiterPriority(partition string) kitsync.LimiterPriorityValue
 //
 // event will be dropped if no destination is found
 func (proc *Handle) isDestinationAvailable(event types.SingularEventT, sourceId, destinationID string) bool {
+	destinations, _ := proc.availableDestinations(event, sourceId, destinationID)
+	return len(destinations) > 0
+}
+
+// availableDestinations returns the destinations the event can be sent to, along with the ones removed by consent rules.
+// If a destinationID is passed, only the destination with this ID is considered.
+func (proc *Handle) availableDestinations(event types.SingularEventT, sourceId, destinationID string) ([]backendconfig.DestinationT, []consentDrop) {
 	enabledDestTypes := integrations.FilterClientIntegrations(
 		event,
 		proc.getBackendEnabledDestinationTypes(sourceId),
 	)
 	if len(enabledDestTypes) == 0 {
 		proc.logger.Debug("No enabled destination types")
-		return false
+		return nil, nil
 	}
 
-	if enabledDestinationsList := lo.Filter(proc.getConsentFilteredDestinations(
+	enabledDestinationsList, consentDrops := proc.filterDestinationsByConsent(
 		event,
 		sourceId,
-		lo.Flatten(
-			lo.Map(
-				enabledDestTypes,
-				func(destType string, _ int) []backendconfig.DestinationT {
-					return proc.getEnabledDestinations(sourceId, destType)
-				},
+		lo.Filter(
+			lo.Flatten(
+				lo.Map(
+					enabledDestTypes,
+					func(destType string, _ int) []backendconfig.DestinationT {
+						return proc.getEnabledDestinations(sourceId, destType)
+					},
+				),
 			),
+			func(dest backendconfig.DestinationT, index int) bool {
+				return len(destinationID) == 0 || dest.ID == destinationID
+			},
 		),
-	), func(dest backendconfig.DestinationT, index int) bool {
-		return len(destinationID) == 0 || dest.ID == destinationID
-	}); len(enabledDestinationsList) == 0 {
+	)
+	if len(enabledDestinationsList) == 0 {
 		proc.logger.Debug("No destination to route this event to")
-		return false
 	}
-
-	return true
+	return enabledDestinationsList, consentDrops
 }
 
 // pipelineDelayStats reports the delay of the pipeline as a range:
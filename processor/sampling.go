@@ -0,0 +1,132 @@
+package processor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/processor/internal/sampling"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
+)
+
+// newEventSampler returns the event sampler of the destination, or nil if sampling is not enabled for it.
+// Samplers are recreated on every config update, the unchanged shedding rules of the previous sampler keeping their rates.
+func (proc *Handle) newEventSampler(dest *backendconfig.DestinationT, previous *sampling.Sampler) *sampling.Sampler {
+	conf, ok, err := sampling.ParseConfig(dest.Config)
+	if err != nil {
+		proc.logger.Errorn("Invalid event sampling config, events will not be sampled",
+			obskit.DestinationID(dest.ID),
+			obskit.Error(err),
+		)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	return sampling.New(conf, previous)
+}
+
+func (proc *Handle) getEventSampler(destinationID string) *sampling.Sampler {
+	proc.config.configSubscriberLock.RLock()
+	defer proc.config.configSubscriberLock.RUnlock()
+	return proc.config.eventSamplersMap[destinationID]
+}
+
+// sampleEvents returns the events kept by the sampler of the destination, if enabled for it,
+// along with the dropped ones as filtered events, grouped by the reason they were dropped
+func (proc *Handle) sampleEvents(events []types.TransformerEvent, destination *backendconfig.DestinationT) ([]types.TransformerEvent, map[string][]types.TransformerResponse) {
+	if !proc.config.eventSamplingEnabled.Load() {
+		return events, nil
+	}
+	sampler := proc.getEventSampler(destination.ID)
+	if sampler == nil {
+		return events, nil
+	}
+	kept := make([]types.TransformerEvent, 0, len(events))
+	dropped := make(map[string][]types.TransformerResponse)
+	for i := range events {
+		event := &events[i]
+		ok, reason := sampler.Sample(event.Metadata.EventName, event.Metadata.EventType, samplingUserID(event), samplingReceivedAt(event))
+		if ok {
+			kept = append(kept, *event)
+			continue
+		}
+		dropped[reason] = append(dropped[reason], types.TransformerResponse{
+			Output:     event.Message,
+			StatusCode: reportingtypes.FilterEventCode,
+			Metadata:   event.Metadata,
+			Error:      "Event dropped by the destination's sampling rules: " + reason,
+		})
+	}
+
+	for reason, events := range dropped {
+		proc.statsFactory.NewTaggedStat("processor_sampled_events", stats.CountType, stats.Tags{
+			"workspaceId":   destination.WorkspaceID,
+			"destinationId": destination.ID,
+			"destType":      destination.DestinationDefinition.Name,
+			"reason":        reason,
+		}).Count(len(events))
+	}
+	return kept, dropped
+}
+
+// addSampledMetrics adds the jobs and metrics of the sampled events to the filtered ones of the event filter,
+// reporting the reason they were dropped as their error type
+func (proc *Handle) addSampledMetrics(
+	m *NonSuccessfulTransformationMetrics,
+	sampled map[string][]types.TransformerResponse,
+	commonMetaData *types.Metadata,
+	eventsByMessageID map[string]types.SingularEventWithReceivedAt,
+	inPU string,
+) {
+	for _, reason := range []string{sampling.SampledOutReason, sampling.VolumeShedReason} {
+		if len(sampled[reason]) == 0 {
+			continue
+		}
+		jobs, metrics, countMap := proc.getTransformationMetrics(
+			sampled[reason],
+			jobsdb.Filtered.State,
+			commonMetaData,
+			eventsByMessageID,
+			inPU,
+			reportingtypes.EVENT_FILTER,
+		)
+		for _, metric := range metrics {
+			metric.StatusDetail.ErrorType = reason
+		}
+		m.filteredJobs = append(m.filteredJobs, jobs...)
+		m.filteredMetrics = append(m.filteredMetrics, metrics...)
+		if m.filteredCountMap == nil {
+			m.filteredCountMap = make(map[string]int64)
+		}
+		for k, count := range countMap {
+			m.filteredCountMap[k] += count
+		}
+	}
+}
+
+// samplingUserID returns the id of the event's user, which sampling is deterministic on.
+// Anonymous events are sampled on their anonymous id, events without any on their message id.
+func samplingUserID(event *types.TransformerEvent) string {
+	for _, key := range []string{"userId", "anonymousId"} {
+		if v, ok := event.Message[key]; ok && v != nil && v != "" {
+			return fmt.Sprint(v)
+		}
+	}
+	return event.Metadata.MessageID
+}
+
+// samplingReceivedAt returns the time the event was received at, which shedding rates apply to
+func samplingReceivedAt(event *types.TransformerEvent) time.Time {
+	receivedAt, err := time.Parse(misc.RFC3339Milli, event.Metadata.ReceivedAt)
+	if err != nil {
+		return time.Now()
+	}
+	return receivedAt
+}
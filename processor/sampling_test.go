@@ -0,0 +1,123 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	mockreportingtypes "github.com/rudderlabs/rudder-server/mocks/utils/types"
+	"github.com/rudderlabs/rudder-server/processor/internal/sampling"
+	"github.com/rudderlabs/rudder-server/processor/types"
+	"github.com/rudderlabs/rudder-server/services/transientsource"
+	reportingtypes "github.com/rudderlabs/rudder-server/utils/types"
+)
+
+func TestSampleEvents(t *testing.T) {
+	destination := &backendconfig.DestinationT{
+		ID:                    "destID",
+		WorkspaceID:           "workspaceID",
+		DestinationDefinition: backendconfig.DestinationDefinitionT{Name: "FACEBOOK_PIXEL"},
+		Config: map[string]any{sampling.ConfigKey: map[string]any{"enabled": true, "rules": []any{
+			map[string]any{"eventName": "Product Viewed", "percentage": 0},
+			map[string]any{"eventType": "page", "maxEventsPerSecond": 1},
+		}}},
+	}
+	newEvent := func(messageID, eventName, eventType string) types.TransformerEvent {
+		metadata := types.Metadata{
+			WorkspaceID:   "workspaceID",
+			SourceID:      "sourceID",
+			DestinationID: "destID",
+			MessageID:     messageID,
+			MessageIDs:    []string{messageID},
+			EventName:     eventName,
+			EventType:     eventType,
+			ReceivedAt:    "2024-01-02T03:04:05.000Z",
+		}
+		return types.TransformerEvent{
+			Message:  types.SingularEventT{"messageId": messageID, "userId": "user-" + messageID, "event": eventName, "type": eventType},
+			Metadata: metadata,
+		}
+	}
+	events := []types.TransformerEvent{
+		newEvent("1", "Product Viewed", "track"),
+		newEvent("2", "Order Completed", "track"),
+		newEvent("3", "Home", "page"),
+		newEvent("4", "Home", "page"),
+	}
+	eventsByMessageID := make(map[string]types.SingularEventWithReceivedAt)
+	for _, event := range events {
+		eventsByMessageID[event.Metadata.MessageID] = types.SingularEventWithReceivedAt{SingularEvent: event.Message}
+	}
+	newHandle := func(enabled bool) (*Handle, *memstats.Store) {
+		statsStore, err := memstats.New()
+		require.NoError(t, err)
+		c := config.New()
+		c.Set("Processor.eventSampling.enabled", enabled)
+		proc := NewHandle(c, nil)
+		proc.logger = logger.NOP
+		proc.statsFactory = statsStore
+		proc.reportingEnabled = true
+		proc.reporting = &mockreportingtypes.MockReporting{}
+		proc.transientSources = transientsource.NewEmptyService()
+		proc.config.eventSamplersMap = map[string]*sampling.Sampler{"destID": proc.newEventSampler(destination, nil)}
+		return proc, statsStore
+	}
+
+	t.Run("sampled", func(t *testing.T) {
+		proc, statsStore := newHandle(true)
+		kept, sampled := proc.sampleEvents(events, destination)
+
+		require.Equal(t, []types.TransformerEvent{events[1], events[2]}, kept)
+		require.Len(t, sampled[sampling.SampledOutReason], 1)
+		require.Equal(t, "1", sampled[sampling.SampledOutReason][0].Metadata.MessageID)
+		require.Len(t, sampled[sampling.VolumeShedReason], 1)
+		require.Equal(t, "4", sampled[sampling.VolumeShedReason][0].Metadata.MessageID)
+		require.Equal(t, reportingtypes.FilterEventCode, sampled[sampling.VolumeShedReason][0].StatusCode)
+		require.Len(t, events, 4, "the events are left untouched")
+		require.EqualValues(t, 1, statsStore.Get("processor_sampled_events", stats.Tags{
+			"workspaceId":   "workspaceID",
+			"destinationId": "destID",
+			"destType":      "FACEBOOK_PIXEL",
+			"reason":        sampling.VolumeShedReason,
+		}).LastValue())
+
+		m := &NonSuccessfulTransformationMetrics{filteredCountMap: map[string]int64{"other": 1}}
+		proc.addSampledMetrics(m, sampled, &events[0].Metadata, eventsByMessageID, reportingtypes.DESTINATION_FILTER)
+		require.Len(t, m.filteredJobs, 2)
+		require.Len(t, m.filteredMetrics, 2)
+		reasons := make(map[string]string)
+		for _, metric := range m.filteredMetrics {
+			require.Equal(t, jobsdb.Filtered.State, metric.StatusDetail.Status)
+			require.Equal(t, reportingtypes.EVENT_FILTER, metric.PUDetails.PU)
+			require.Equal(t, reportingtypes.DESTINATION_FILTER, metric.PUDetails.InPU)
+			require.EqualValues(t, 1, metric.StatusDetail.Count)
+			reasons[metric.StatusDetail.EventName] = metric.StatusDetail.ErrorType
+		}
+		require.Equal(t, map[string]string{"Product Viewed": sampling.SampledOutReason, "Home": sampling.VolumeShedReason}, reasons)
+		var filteredCount int64
+		for _, count := range m.filteredCountMap {
+			filteredCount += count
+		}
+		require.EqualValues(t, 3, filteredCount)
+	})
+
+	t.Run("destination without sampling", func(t *testing.T) {
+		proc, _ := newHandle(true)
+		kept, sampled := proc.sampleEvents(events, &backendconfig.DestinationT{ID: "otherDestID"})
+		require.Equal(t, events, kept)
+		require.Empty(t, sampled)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		proc, _ := newHandle(false)
+		kept, sampled := proc.sampleEvents(events, destination)
+		require.Equal(t, events, kept)
+		require.Empty(t, sampled)
+	})
+}
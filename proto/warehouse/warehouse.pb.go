@@ -617,6 +617,480 @@ func (x *TriggerWhUploadsResponse) GetStatusCode() int32 {
 	return 0
 }
 
+type PlanWHUploadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorkspaceId   string `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	UploadId      int64  `protobuf:"varint,2,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	SourceId      string `protobuf:"bytes,3,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	DestinationId string `protobuf:"bytes,4,opt,name=destination_id,json=destinationId,proto3" json:"destination_id,omitempty"`
+}
+
+func (x *PlanWHUploadRequest) Reset() {
+	*x = PlanWHUploadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanWHUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanWHUploadRequest) ProtoMessage() {}
+
+func (x *PlanWHUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanWHUploadRequest.ProtoReflect.Descriptor instead.
+func (*PlanWHUploadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PlanWHUploadRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *PlanWHUploadRequest) GetUploadId() int64 {
+	if x != nil {
+		return x.UploadId
+	}
+	return 0
+}
+
+func (x *PlanWHUploadRequest) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *PlanWHUploadRequest) GetDestinationId() string {
+	if x != nil {
+		return x.DestinationId
+	}
+	return ""
+}
+
+type PlanWHUploadResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId        int64          `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	SourceId        string         `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	DestinationId   string         `protobuf:"bytes,3,opt,name=destination_id,json=destinationId,proto3" json:"destination_id,omitempty"`
+	DestinationType string         `protobuf:"bytes,4,opt,name=destination_type,json=destinationType,proto3" json:"destination_type,omitempty"`
+	Namespace       string         `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	StagingFiles    int64          `protobuf:"varint,6,opt,name=staging_files,json=stagingFiles,proto3" json:"staging_files,omitempty"`
+	Tables          []*WHTablePlan `protobuf:"bytes,7,rep,name=tables,proto3" json:"tables,omitempty"`
+}
+
+func (x *PlanWHUploadResponse) Reset() {
+	*x = PlanWHUploadResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanWHUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanWHUploadResponse) ProtoMessage() {}
+
+func (x *PlanWHUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanWHUploadResponse.ProtoReflect.Descriptor instead.
+func (*PlanWHUploadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PlanWHUploadResponse) GetUploadId() int64 {
+	if x != nil {
+		return x.UploadId
+	}
+	return 0
+}
+
+func (x *PlanWHUploadResponse) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *PlanWHUploadResponse) GetDestinationId() string {
+	if x != nil {
+		return x.DestinationId
+	}
+	return ""
+}
+
+func (x *PlanWHUploadResponse) GetDestinationType() string {
+	if x != nil {
+		return x.DestinationType
+	}
+	return ""
+}
+
+func (x *PlanWHUploadResponse) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PlanWHUploadResponse) GetStagingFiles() int64 {
+	if x != nil {
+		return x.StagingFiles
+	}
+	return 0
+}
+
+func (x *PlanWHUploadResponse) GetTables() []*WHTablePlan {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type WHTablePlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ToBeCreated    bool              `protobuf:"varint,2,opt,name=to_be_created,json=toBeCreated,proto3" json:"to_be_created,omitempty"`
+	AddedColumns   map[string]string `protobuf:"bytes,3,rep,name=added_columns,json=addedColumns,proto3" json:"added_columns,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AlteredColumns map[string]string `protobuf:"bytes,4,rep,name=altered_columns,json=alteredColumns,proto3" json:"altered_columns,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Rows           int64             `protobuf:"varint,5,opt,name=rows,proto3" json:"rows,omitempty"`
+	Columns        []*WHColumnPlan   `protobuf:"bytes,6,rep,name=columns,proto3" json:"columns,omitempty"`
+}
+
+func (x *WHTablePlan) Reset() {
+	*x = WHTablePlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WHTablePlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WHTablePlan) ProtoMessage() {}
+
+func (x *WHTablePlan) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WHTablePlan.ProtoReflect.Descriptor instead.
+func (*WHTablePlan) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WHTablePlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WHTablePlan) GetToBeCreated() bool {
+	if x != nil {
+		return x.ToBeCreated
+	}
+	return false
+}
+
+func (x *WHTablePlan) GetAddedColumns() map[string]string {
+	if x != nil {
+		return x.AddedColumns
+	}
+	return nil
+}
+
+func (x *WHTablePlan) GetAlteredColumns() map[string]string {
+	if x != nil {
+		return x.AlteredColumns
+	}
+	return nil
+}
+
+func (x *WHTablePlan) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *WHTablePlan) GetColumns() []*WHColumnPlan {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+type WHColumnPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name                 string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Discards             int64  `protobuf:"varint,2,opt,name=discards,proto3" json:"discards,omitempty"`
+	ConstraintViolations int64  `protobuf:"varint,3,opt,name=constraint_violations,json=constraintViolations,proto3" json:"constraint_violations,omitempty"`
+}
+
+func (x *WHColumnPlan) Reset() {
+	*x = WHColumnPlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WHColumnPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WHColumnPlan) ProtoMessage() {}
+
+func (x *WHColumnPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WHColumnPlan.ProtoReflect.Descriptor instead.
+func (*WHColumnPlan) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WHColumnPlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WHColumnPlan) GetDiscards() int64 {
+	if x != nil {
+		return x.Discards
+	}
+	return 0
+}
+
+func (x *WHColumnPlan) GetConstraintViolations() int64 {
+	if x != nil {
+		return x.ConstraintViolations
+	}
+	return 0
+}
+
+type WHDataQualityChecksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId int64                 `protobuf:"varint,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Checks   []*WHDataQualityCheck `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+}
+
+func (x *WHDataQualityChecksResponse) Reset() {
+	*x = WHDataQualityChecksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WHDataQualityChecksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WHDataQualityChecksResponse) ProtoMessage() {}
+
+func (x *WHDataQualityChecksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WHDataQualityChecksResponse.ProtoReflect.Descriptor instead.
+func (*WHDataQualityChecksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WHDataQualityChecksResponse) GetUploadId() int64 {
+	if x != nil {
+		return x.UploadId
+	}
+	return 0
+}
+
+func (x *WHDataQualityChecksResponse) GetChecks() []*WHDataQualityCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type WHDataQualityCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TableName  string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	CheckType  string                 `protobuf:"bytes,2,opt,name=check_type,json=checkType,proto3" json:"check_type,omitempty"`
+	ColumnName string                 `protobuf:"bytes,3,opt,name=column_name,json=columnName,proto3" json:"column_name,omitempty"`
+	Status     string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Observed   float64                `protobuf:"fixed64,5,opt,name=observed,proto3" json:"observed,omitempty"`
+	Threshold  float64                `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Message    string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *WHDataQualityCheck) Reset() {
+	*x = WHDataQualityCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WHDataQualityCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WHDataQualityCheck) ProtoMessage() {}
+
+func (x *WHDataQualityCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WHDataQualityCheck.ProtoReflect.Descriptor instead.
+func (*WHDataQualityCheck) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WHDataQualityCheck) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *WHDataQualityCheck) GetCheckType() string {
+	if x != nil {
+		return x.CheckType
+	}
+	return ""
+}
+
+func (x *WHDataQualityCheck) GetColumnName() string {
+	if x != nil {
+		return x.ColumnName
+	}
+	return ""
+}
+
+func (x *WHDataQualityCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WHDataQualityCheck) GetObserved() float64 {
+	if x != nil {
+		return x.Observed
+	}
+	return 0
+}
+
+func (x *WHDataQualityCheck) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *WHDataQualityCheck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WHDataQualityCheck) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 type WHValidationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -631,7 +1105,7 @@ type WHValidationRequest struct {
 func (x *WHValidationRequest) Reset() {
 	*x = WHValidationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[7]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -644,7 +1118,7 @@ func (x *WHValidationRequest) String() string {
 func (*WHValidationRequest) ProtoMessage() {}
 
 func (x *WHValidationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[7]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,7 +1131,7 @@ func (x *WHValidationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WHValidationRequest.ProtoReflect.Descriptor instead.
 func (*WHValidationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{7}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *WHValidationRequest) GetRole() string {
@@ -700,7 +1174,7 @@ type WHValidationResponse struct {
 func (x *WHValidationResponse) Reset() {
 	*x = WHValidationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[8]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -713,7 +1187,7 @@ func (x *WHValidationResponse) String() string {
 func (*WHValidationResponse) ProtoMessage() {}
 
 func (x *WHValidationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[8]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -726,7 +1200,7 @@ func (x *WHValidationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WHValidationResponse.ProtoReflect.Descriptor instead.
 func (*WHValidationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{8}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *WHValidationResponse) GetError() string {
@@ -760,7 +1234,7 @@ type RetryWHUploadsRequest struct {
 func (x *RetryWHUploadsRequest) Reset() {
 	*x = RetryWHUploadsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[9]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -773,7 +1247,7 @@ func (x *RetryWHUploadsRequest) String() string {
 func (*RetryWHUploadsRequest) ProtoMessage() {}
 
 func (x *RetryWHUploadsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[9]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -786,7 +1260,7 @@ func (x *RetryWHUploadsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetryWHUploadsRequest.ProtoReflect.Descriptor instead.
 func (*RetryWHUploadsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{9}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *RetryWHUploadsRequest) GetWorkspaceId() string {
@@ -851,7 +1325,7 @@ type RetryWHUploadsResponse struct {
 func (x *RetryWHUploadsResponse) Reset() {
 	*x = RetryWHUploadsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[10]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -864,7 +1338,7 @@ func (x *RetryWHUploadsResponse) String() string {
 func (*RetryWHUploadsResponse) ProtoMessage() {}
 
 func (x *RetryWHUploadsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[10]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,7 +1351,7 @@ func (x *RetryWHUploadsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetryWHUploadsResponse.ProtoReflect.Descriptor instead.
 func (*RetryWHUploadsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{10}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RetryWHUploadsResponse) GetMessage() string {
@@ -913,7 +1387,7 @@ type ValidateObjectStorageRequest struct {
 func (x *ValidateObjectStorageRequest) Reset() {
 	*x = ValidateObjectStorageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[11]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -926,7 +1400,7 @@ func (x *ValidateObjectStorageRequest) String() string {
 func (*ValidateObjectStorageRequest) ProtoMessage() {}
 
 func (x *ValidateObjectStorageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[11]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,7 +1413,7 @@ func (x *ValidateObjectStorageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateObjectStorageRequest.ProtoReflect.Descriptor instead.
 func (*ValidateObjectStorageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{11}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ValidateObjectStorageRequest) GetType() string {
@@ -968,7 +1442,7 @@ type ValidateObjectStorageResponse struct {
 func (x *ValidateObjectStorageResponse) Reset() {
 	*x = ValidateObjectStorageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[12]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -981,7 +1455,7 @@ func (x *ValidateObjectStorageResponse) String() string {
 func (*ValidateObjectStorageResponse) ProtoMessage() {}
 
 func (x *ValidateObjectStorageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[12]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -994,7 +1468,7 @@ func (x *ValidateObjectStorageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateObjectStorageResponse.ProtoReflect.Descriptor instead.
 func (*ValidateObjectStorageResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{12}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ValidateObjectStorageResponse) GetIsValid() bool {
@@ -1029,7 +1503,7 @@ type FailedBatchInfo struct {
 func (x *FailedBatchInfo) Reset() {
 	*x = FailedBatchInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[13]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1042,7 +1516,7 @@ func (x *FailedBatchInfo) String() string {
 func (*FailedBatchInfo) ProtoMessage() {}
 
 func (x *FailedBatchInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[13]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,7 +1529,7 @@ func (x *FailedBatchInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FailedBatchInfo.ProtoReflect.Descriptor instead.
 func (*FailedBatchInfo) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{13}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *FailedBatchInfo) GetError() string {
@@ -1128,7 +1602,7 @@ type RetrieveFailedBatchesRequest struct {
 func (x *RetrieveFailedBatchesRequest) Reset() {
 	*x = RetrieveFailedBatchesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[14]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1141,7 +1615,7 @@ func (x *RetrieveFailedBatchesRequest) String() string {
 func (*RetrieveFailedBatchesRequest) ProtoMessage() {}
 
 func (x *RetrieveFailedBatchesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[14]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1154,7 +1628,7 @@ func (x *RetrieveFailedBatchesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetrieveFailedBatchesRequest.ProtoReflect.Descriptor instead.
 func (*RetrieveFailedBatchesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{14}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RetrieveFailedBatchesRequest) GetWorkspaceID() string {
@@ -1196,7 +1670,7 @@ type RetrieveFailedBatchesResponse struct {
 func (x *RetrieveFailedBatchesResponse) Reset() {
 	*x = RetrieveFailedBatchesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[15]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1209,7 +1683,7 @@ func (x *RetrieveFailedBatchesResponse) String() string {
 func (*RetrieveFailedBatchesResponse) ProtoMessage() {}
 
 func (x *RetrieveFailedBatchesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[15]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1222,7 +1696,7 @@ func (x *RetrieveFailedBatchesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetrieveFailedBatchesResponse.ProtoReflect.Descriptor instead.
 func (*RetrieveFailedBatchesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{15}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RetrieveFailedBatchesResponse) GetFailedBatches() []*FailedBatchInfo {
@@ -1249,7 +1723,7 @@ type RetryFailedBatchesRequest struct {
 func (x *RetryFailedBatchesRequest) Reset() {
 	*x = RetryFailedBatchesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[16]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1262,7 +1736,7 @@ func (x *RetryFailedBatchesRequest) String() string {
 func (*RetryFailedBatchesRequest) ProtoMessage() {}
 
 func (x *RetryFailedBatchesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[16]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1275,7 +1749,7 @@ func (x *RetryFailedBatchesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetryFailedBatchesRequest.ProtoReflect.Descriptor instead.
 func (*RetryFailedBatchesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{16}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RetryFailedBatchesRequest) GetWorkspaceID() string {
@@ -1338,7 +1812,7 @@ type RetryFailedBatchesResponse struct {
 func (x *RetryFailedBatchesResponse) Reset() {
 	*x = RetryFailedBatchesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[17]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1351,7 +1825,7 @@ func (x *RetryFailedBatchesResponse) String() string {
 func (*RetryFailedBatchesResponse) ProtoMessage() {}
 
 func (x *RetryFailedBatchesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[17]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,7 +1838,7 @@ func (x *RetryFailedBatchesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RetryFailedBatchesResponse.ProtoReflect.Descriptor instead.
 func (*RetryFailedBatchesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{17}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *RetryFailedBatchesResponse) GetRetriedSyncsCount() int64 {
@@ -1386,7 +1860,7 @@ type FirstAbortedUploadInContinuousAbortsByDestinationRequest struct {
 func (x *FirstAbortedUploadInContinuousAbortsByDestinationRequest) Reset() {
 	*x = FirstAbortedUploadInContinuousAbortsByDestinationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[18]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1399,7 +1873,7 @@ func (x *FirstAbortedUploadInContinuousAbortsByDestinationRequest) String() stri
 func (*FirstAbortedUploadInContinuousAbortsByDestinationRequest) ProtoMessage() {}
 
 func (x *FirstAbortedUploadInContinuousAbortsByDestinationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[18]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1412,7 +1886,7 @@ func (x *FirstAbortedUploadInContinuousAbortsByDestinationRequest) ProtoReflect(
 
 // Deprecated: Use FirstAbortedUploadInContinuousAbortsByDestinationRequest.ProtoReflect.Descriptor instead.
 func (*FirstAbortedUploadInContinuousAbortsByDestinationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{18}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *FirstAbortedUploadInContinuousAbortsByDestinationRequest) GetWorkspaceId() string {
@@ -1440,7 +1914,7 @@ type SyncWHSchemaRequest struct {
 func (x *SyncWHSchemaRequest) Reset() {
 	*x = SyncWHSchemaRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[19]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1453,7 +1927,7 @@ func (x *SyncWHSchemaRequest) String() string {
 func (*SyncWHSchemaRequest) ProtoMessage() {}
 
 func (x *SyncWHSchemaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[19]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1466,7 +1940,7 @@ func (x *SyncWHSchemaRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncWHSchemaRequest.ProtoReflect.Descriptor instead.
 func (*SyncWHSchemaRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{19}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SyncWHSchemaRequest) GetDestinationId() string {
@@ -1492,7 +1966,7 @@ type FirstAbortedUploadResponse struct {
 func (x *FirstAbortedUploadResponse) Reset() {
 	*x = FirstAbortedUploadResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[20]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1505,7 +1979,7 @@ func (x *FirstAbortedUploadResponse) String() string {
 func (*FirstAbortedUploadResponse) ProtoMessage() {}
 
 func (x *FirstAbortedUploadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[20]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1518,7 +1992,7 @@ func (x *FirstAbortedUploadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FirstAbortedUploadResponse.ProtoReflect.Descriptor instead.
 func (*FirstAbortedUploadResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{20}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *FirstAbortedUploadResponse) GetId() int64 {
@@ -1574,7 +2048,7 @@ type FirstAbortedUploadInContinuousAbortsByDestinationResponse struct {
 func (x *FirstAbortedUploadInContinuousAbortsByDestinationResponse) Reset() {
 	*x = FirstAbortedUploadInContinuousAbortsByDestinationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[21]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1587,7 +2061,7 @@ func (x *FirstAbortedUploadInContinuousAbortsByDestinationResponse) String() str
 func (*FirstAbortedUploadInContinuousAbortsByDestinationResponse) ProtoMessage() {}
 
 func (x *FirstAbortedUploadInContinuousAbortsByDestinationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[21]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1600,7 +2074,7 @@ func (x *FirstAbortedUploadInContinuousAbortsByDestinationResponse) ProtoReflect
 
 // Deprecated: Use FirstAbortedUploadInContinuousAbortsByDestinationResponse.ProtoReflect.Descriptor instead.
 func (*FirstAbortedUploadInContinuousAbortsByDestinationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{21}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *FirstAbortedUploadInContinuousAbortsByDestinationResponse) GetUploads() []*FirstAbortedUploadResponse {
@@ -1625,7 +2099,7 @@ type SyncLatencyRequest struct {
 func (x *SyncLatencyRequest) Reset() {
 	*x = SyncLatencyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[22]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1638,7 +2112,7 @@ func (x *SyncLatencyRequest) String() string {
 func (*SyncLatencyRequest) ProtoMessage() {}
 
 func (x *SyncLatencyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[22]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1651,7 +2125,7 @@ func (x *SyncLatencyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncLatencyRequest.ProtoReflect.Descriptor instead.
 func (*SyncLatencyRequest) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{22}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SyncLatencyRequest) GetDestinationId() string {
@@ -1700,7 +2174,7 @@ type SyncLatencyResponse struct {
 func (x *SyncLatencyResponse) Reset() {
 	*x = SyncLatencyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[23]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1713,7 +2187,7 @@ func (x *SyncLatencyResponse) String() string {
 func (*SyncLatencyResponse) ProtoMessage() {}
 
 func (x *SyncLatencyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[23]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1726,7 +2200,7 @@ func (x *SyncLatencyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncLatencyResponse.ProtoReflect.Descriptor instead.
 func (*SyncLatencyResponse) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{23}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *SyncLatencyResponse) GetTimeSeriesDataPoints() []*LatencyTimeSeriesDataPoint {
@@ -1748,7 +2222,7 @@ type LatencyTimeSeriesDataPoint struct {
 func (x *LatencyTimeSeriesDataPoint) Reset() {
 	*x = LatencyTimeSeriesDataPoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_warehouse_warehouse_proto_msgTypes[24]
+		mi := &file_proto_warehouse_warehouse_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1761,7 +2235,7 @@ func (x *LatencyTimeSeriesDataPoint) String() string {
 func (*LatencyTimeSeriesDataPoint) ProtoMessage() {}
 
 func (x *LatencyTimeSeriesDataPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_warehouse_warehouse_proto_msgTypes[24]
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1774,7 +2248,7 @@ func (x *LatencyTimeSeriesDataPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LatencyTimeSeriesDataPoint.ProtoReflect.Descriptor instead.
 func (*LatencyTimeSeriesDataPoint) Descriptor() ([]byte, []int) {
-	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{24}
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *LatencyTimeSeriesDataPoint) GetTimestampMillis() *wrapperspb.DoubleValue {
@@ -1899,254 +2373,350 @@ var file_proto_warehouse_warehouse_proto_rawDesc = []byte{
 	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
 	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x65, 0x0a, 0x13,
-	0x57, 0x48, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73,
-	0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12,
-	0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62,
-	0x6f, 0x64, 0x79, 0x22, 0x40, 0x0a, 0x14, 0x57, 0x48, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x8d, 0x02, 0x0a, 0x15, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57,
-	0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x20, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49,
-	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a,
-	0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65,
-	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a,
-	0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
-	0x49, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x49, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x49, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x52, 0x65,
-	0x74, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x63, 0x65,
-	0x52, 0x65, 0x74, 0x72, 0x79, 0x22, 0x69, 0x0a, 0x16, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65,
-	0x22, 0x63, 0x0a, 0x1c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65,
-	0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x4f, 0x0a, 0x1d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xdd, 0x02, 0x0a, 0x0f, 0x46, 0x61, 0x69, 0x6c, 0x65,
-	0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x99, 0x01, 0x0a,
+	0x13, 0x50, 0x6c, 0x61, 0x6e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x91, 0x02, 0x0a, 0x14, 0x50, 0x6c, 0x61,
+	0x6e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x12, 0x2a, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x50, 0x6c, 0x61, 0x6e, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0xa8, 0x03, 0x0a,
+	0x0b, 0x57, 0x48, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x22, 0x0a, 0x0d, 0x74, 0x6f, 0x5f, 0x62, 0x65, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x74, 0x6f, 0x42, 0x65, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x49, 0x0a, 0x0d, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x2e,
+	0x41, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0c, 0x61, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12,
+	0x4f, 0x0a, 0x0f, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x57, 0x48, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x2e, 0x41, 0x6c, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0e, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x72, 0x6f, 0x77, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x73, 0x0a, 0x0c, 0x57, 0x48, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x74, 0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x74, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6d, 0x0a, 0x1b,
+	0x57, 0x48, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x57, 0x48, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x52, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x22, 0x9a, 0x02, 0x0a, 0x12,
+	0x57, 0x48, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x65, 0x0a, 0x13, 0x57, 0x48, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x62,
+	0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22,
+	0x40, 0x0a, 0x14, 0x57, 0x48, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x8d, 0x02, 0x0a, 0x15, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x28, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x49, 0x6e, 0x48, 0x6f,
+	0x75, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x22, 0x69, 0x0a, 0x16, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x63, 0x0a, 0x1c,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x2f, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x22, 0x4f, 0x0a, 0x1d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0xdd, 0x02, 0x0a, 0x0f, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x24, 0x0a, 0x0d,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f,
+	0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x12, 0x2c,
+	0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x10,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x79,
+	0x6e, 0x63, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0d, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x1c, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x65, 0x6e, 0x64, 0x22, 0x5d, 0x0a, 0x1d, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x22, 0xe5, 0x01, 0x0a, 0x19, 0x52, 0x65, 0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64,
 	0x12, 0x24, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x61,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x61,
 	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x49, 0x44, 0x12, 0x2c, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x66,
-	0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x2a, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x66, 0x61, 0x69, 0x6c,
-	0x65, 0x64, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0c,
-	0x6c, 0x61, 0x73, 0x74, 0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c,
-	0x6c, 0x61, 0x73, 0x74, 0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0d,
-	0x66, 0x69, 0x72, 0x73, 0x74, 0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x48, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x1c, 0x52, 0x65, 0x74, 0x72, 0x69,
-	0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f,
-	0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x73,
-	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x5d, 0x0a, 0x1d, 0x52, 0x65, 0x74, 0x72, 0x69,
-	0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x66, 0x61, 0x69, 0x6c,
-	0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0xe5, 0x01, 0x0a, 0x19, 0x52, 0x65, 0x74, 0x72, 0x79,
-	0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x65, 0x6e, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x61, 0x74,
-	0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4a,
-	0x0a, 0x1a, 0x52, 0x65, 0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74,
-	0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x11,
-	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x64, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x64,
-	0x53, 0x79, 0x6e, 0x63, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x73, 0x0a, 0x38, 0x46, 0x69,
+	0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4a, 0x0a, 0x1a, 0x52, 0x65,
+	0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x64, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x64, 0x53, 0x79, 0x6e, 0x63,
+	0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x73, 0x0a, 0x38, 0x46, 0x69, 0x72, 0x73, 0x74, 0x41,
+	0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x43, 0x6f,
+	0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x79,
+	0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x3c, 0x0a, 0x13, 0x53,
+	0x79, 0x6e, 0x63, 0x57, 0x48, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xad, 0x02, 0x0a, 0x1a, 0x46, 0x69,
 	0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x49, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x41, 0x62, 0x6f, 0x72,
-	0x74, 0x73, 0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f,
-	0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22,
-	0x3c, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63, 0x57, 0x48, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xad, 0x02,
-	0x0a, 0x1a, 0x46, 0x69, 0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73,
-	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
-	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x66,
-	0x69, 0x72, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x12, 0x3e, 0x0a,
-	0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x22, 0x78, 0x0a,
-	0x39, 0x46, 0x69, 0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x49, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x41,
-	0x62, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07,
-	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x22, 0xcb, 0x01, 0x0a, 0x12, 0x53, 0x79, 0x6e, 0x63,
-	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
-	0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65,
-	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0x60, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63, 0x4c, 0x61, 0x74,
-	0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x17,
-	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x64, 0x61, 0x74, 0x61,
-	0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x69, 0x6d,
-	0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74,
-	0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x22, 0x9c, 0x01, 0x0a, 0x1a, 0x4c, 0x61, 0x74, 0x65,
-	0x6e, 0x63, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x44, 0x61, 0x74,
-	0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06,
-	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x3e, 0x0a, 0x0f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6c,
-	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x32, 0xca, 0x09, 0x0a, 0x09, 0x57, 0x61, 0x72, 0x65, 0x68,
-	0x6f, 0x75, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74,
-	0x68, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x41, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x57, 0x48, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x57,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x12, 0x3e, 0x0a, 0x0d, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61,
+	0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x22, 0x78, 0x0a, 0x39, 0x46, 0x69, 0x72,
+	0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49,
+	0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x41, 0x62, 0x6f, 0x72, 0x74,
+	0x73, 0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x69, 0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x22, 0xcb, 0x01, 0x0a, 0x12, 0x53, 0x79, 0x6e, 0x63, 0x4c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x12, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6e,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x64, 0x22, 0x60, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x17, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x22, 0x9c, 0x01, 0x0a, 0x1a, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x54,
+	0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x44, 0x61, 0x74, 0x61, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f,
+	0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x3e, 0x0a, 0x0f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x6f,
+	0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x32, 0xef, 0x0a, 0x0a, 0x09, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65,
+	0x12, 0x3f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x41, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x73, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x57, 0x48, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x57,
 	0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
 	0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x54, 0x72, 0x69, 0x67,
-	0x67, 0x65, 0x72, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x72, 0x69, 0x67,
-	0x67, 0x65, 0x72, 0x57, 0x68, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x10, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x57,
-	0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65,
-	0x72, 0x57, 0x68, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x57, 0x48, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x52, 0x65, 0x74, 0x72, 0x79,
-	0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x15, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x57,
-	0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x54, 0x6f, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12,
-	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x20,
-	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61,
-	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x15, 0x52,
-	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74,
-	0x63, 0x68, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x59, 0x0a, 0x12, 0x52, 0x65, 0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65,
-	0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x52, 0x65, 0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0xb9, 0x01, 0x0a, 0x34, 0x47,
-	0x65, 0x74, 0x46, 0x69, 0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73,
-	0x41, 0x62, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x72, 0x73,
-	0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e,
-	0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x73,
-	0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x40, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x72,
+	0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x57,
+	0x68, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x47, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x6e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x57, 0x48, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x1c, 0x47, 0x65, 0x74,
+	0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x44, 0x61, 0x74, 0x61,
+	0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x10, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67,
+	0x65, 0x72, 0x57, 0x68, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x48, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x15, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x57, 0x48, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x54, 0x6f, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x57, 0x48, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a,
+	0x20, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x15,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x59, 0x0a, 0x12, 0x52, 0x65, 0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0xb9, 0x01, 0x0a, 0x34,
+	0x47, 0x65, 0x74, 0x46, 0x69, 0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75,
+	0x73, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x72,
 	0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49,
 	0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x41, 0x62, 0x6f, 0x72, 0x74,
 	0x73, 0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6e,
-	0x63, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x79, 0x6e, 0x63,
-	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x42, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x57, 0x48, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12,
-	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x57, 0x48, 0x53, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x40, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69,
+	0x72, 0x73, 0x74, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x49, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x6f, 0x75, 0x73, 0x41, 0x62, 0x6f, 0x72,
+	0x74, 0x73, 0x42, 0x79, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x79,
+	0x6e, 0x63, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x79, 0x6e,
+	0x63, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x42, 0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x57, 0x48, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x57, 0x48, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2161,7 +2731,7 @@ func file_proto_warehouse_warehouse_proto_rawDescGZIP() []byte {
 	return file_proto_warehouse_warehouse_proto_rawDescData
 }
 
-var file_proto_warehouse_warehouse_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_proto_warehouse_warehouse_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_proto_warehouse_warehouse_proto_goTypes = []interface{}{
 	(*Pagination)(nil),                                                // 0: proto.Pagination
 	(*WHTable)(nil),                                                   // 1: proto.WHTable
@@ -2170,84 +2740,102 @@ var file_proto_warehouse_warehouse_proto_goTypes = []interface{}{
 	(*WHUploadRequest)(nil),                                           // 4: proto.WHUploadRequest
 	(*WHUploadResponse)(nil),                                          // 5: proto.WHUploadResponse
 	(*TriggerWhUploadsResponse)(nil),                                  // 6: proto.TriggerWhUploadsResponse
-	(*WHValidationRequest)(nil),                                       // 7: proto.WHValidationRequest
-	(*WHValidationResponse)(nil),                                      // 8: proto.WHValidationResponse
-	(*RetryWHUploadsRequest)(nil),                                     // 9: proto.RetryWHUploadsRequest
-	(*RetryWHUploadsResponse)(nil),                                    // 10: proto.RetryWHUploadsResponse
-	(*ValidateObjectStorageRequest)(nil),                              // 11: proto.ValidateObjectStorageRequest
-	(*ValidateObjectStorageResponse)(nil),                             // 12: proto.ValidateObjectStorageResponse
-	(*FailedBatchInfo)(nil),                                           // 13: proto.FailedBatchInfo
-	(*RetrieveFailedBatchesRequest)(nil),                              // 14: proto.RetrieveFailedBatchesRequest
-	(*RetrieveFailedBatchesResponse)(nil),                             // 15: proto.RetrieveFailedBatchesResponse
-	(*RetryFailedBatchesRequest)(nil),                                 // 16: proto.RetryFailedBatchesRequest
-	(*RetryFailedBatchesResponse)(nil),                                // 17: proto.RetryFailedBatchesResponse
-	(*FirstAbortedUploadInContinuousAbortsByDestinationRequest)(nil),  // 18: proto.FirstAbortedUploadInContinuousAbortsByDestinationRequest
-	(*SyncWHSchemaRequest)(nil),                                       // 19: proto.SyncWHSchemaRequest
-	(*FirstAbortedUploadResponse)(nil),                                // 20: proto.FirstAbortedUploadResponse
-	(*FirstAbortedUploadInContinuousAbortsByDestinationResponse)(nil), // 21: proto.FirstAbortedUploadInContinuousAbortsByDestinationResponse
-	(*SyncLatencyRequest)(nil),                                        // 22: proto.SyncLatencyRequest
-	(*SyncLatencyResponse)(nil),                                       // 23: proto.SyncLatencyResponse
-	(*LatencyTimeSeriesDataPoint)(nil),                                // 24: proto.LatencyTimeSeriesDataPoint
-	(*timestamppb.Timestamp)(nil),                                     // 25: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),                                           // 26: google.protobuf.Struct
-	(*wrapperspb.DoubleValue)(nil),                                    // 27: google.protobuf.DoubleValue
-	(*emptypb.Empty)(nil),                                             // 28: google.protobuf.Empty
-	(*wrapperspb.BoolValue)(nil),                                      // 29: google.protobuf.BoolValue
+	(*PlanWHUploadRequest)(nil),                                       // 7: proto.PlanWHUploadRequest
+	(*PlanWHUploadResponse)(nil),                                      // 8: proto.PlanWHUploadResponse
+	(*WHTablePlan)(nil),                                               // 9: proto.WHTablePlan
+	(*WHColumnPlan)(nil),                                              // 10: proto.WHColumnPlan
+	(*WHDataQualityChecksResponse)(nil),                               // 11: proto.WHDataQualityChecksResponse
+	(*WHDataQualityCheck)(nil),                                        // 12: proto.WHDataQualityCheck
+	(*WHValidationRequest)(nil),                                       // 13: proto.WHValidationRequest
+	(*WHValidationResponse)(nil),                                      // 14: proto.WHValidationResponse
+	(*RetryWHUploadsRequest)(nil),                                     // 15: proto.RetryWHUploadsRequest
+	(*RetryWHUploadsResponse)(nil),                                    // 16: proto.RetryWHUploadsResponse
+	(*ValidateObjectStorageRequest)(nil),                              // 17: proto.ValidateObjectStorageRequest
+	(*ValidateObjectStorageResponse)(nil),                             // 18: proto.ValidateObjectStorageResponse
+	(*FailedBatchInfo)(nil),                                           // 19: proto.FailedBatchInfo
+	(*RetrieveFailedBatchesRequest)(nil),                              // 20: proto.RetrieveFailedBatchesRequest
+	(*RetrieveFailedBatchesResponse)(nil),                             // 21: proto.RetrieveFailedBatchesResponse
+	(*RetryFailedBatchesRequest)(nil),                                 // 22: proto.RetryFailedBatchesRequest
+	(*RetryFailedBatchesResponse)(nil),                                // 23: proto.RetryFailedBatchesResponse
+	(*FirstAbortedUploadInContinuousAbortsByDestinationRequest)(nil),  // 24: proto.FirstAbortedUploadInContinuousAbortsByDestinationRequest
+	(*SyncWHSchemaRequest)(nil),                                       // 25: proto.SyncWHSchemaRequest
+	(*FirstAbortedUploadResponse)(nil),                                // 26: proto.FirstAbortedUploadResponse
+	(*FirstAbortedUploadInContinuousAbortsByDestinationResponse)(nil), // 27: proto.FirstAbortedUploadInContinuousAbortsByDestinationResponse
+	(*SyncLatencyRequest)(nil),                                        // 28: proto.SyncLatencyRequest
+	(*SyncLatencyResponse)(nil),                                       // 29: proto.SyncLatencyResponse
+	(*LatencyTimeSeriesDataPoint)(nil),                                // 30: proto.LatencyTimeSeriesDataPoint
+	nil,                                                               // 31: proto.WHTablePlan.AddedColumnsEntry
+	nil,                                                               // 32: proto.WHTablePlan.AlteredColumnsEntry
+	(*timestamppb.Timestamp)(nil),                                     // 33: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                                           // 34: google.protobuf.Struct
+	(*wrapperspb.DoubleValue)(nil),                                    // 35: google.protobuf.DoubleValue
+	(*emptypb.Empty)(nil),                                             // 36: google.protobuf.Empty
+	(*wrapperspb.BoolValue)(nil),                                      // 37: google.protobuf.BoolValue
 }
 var file_proto_warehouse_warehouse_proto_depIdxs = []int32{
-	25, // 0: proto.WHTable.last_exec_at:type_name -> google.protobuf.Timestamp
+	33, // 0: proto.WHTable.last_exec_at:type_name -> google.protobuf.Timestamp
 	5,  // 1: proto.WHUploadsResponse.uploads:type_name -> proto.WHUploadResponse
 	0,  // 2: proto.WHUploadsResponse.pagination:type_name -> proto.Pagination
-	25, // 3: proto.WHUploadResponse.created_at:type_name -> google.protobuf.Timestamp
-	25, // 4: proto.WHUploadResponse.first_event_at:type_name -> google.protobuf.Timestamp
-	25, // 5: proto.WHUploadResponse.last_event_at:type_name -> google.protobuf.Timestamp
-	25, // 6: proto.WHUploadResponse.last_exec_at:type_name -> google.protobuf.Timestamp
-	25, // 7: proto.WHUploadResponse.next_retry_time:type_name -> google.protobuf.Timestamp
+	33, // 3: proto.WHUploadResponse.created_at:type_name -> google.protobuf.Timestamp
+	33, // 4: proto.WHUploadResponse.first_event_at:type_name -> google.protobuf.Timestamp
+	33, // 5: proto.WHUploadResponse.last_event_at:type_name -> google.protobuf.Timestamp
+	33, // 6: proto.WHUploadResponse.last_exec_at:type_name -> google.protobuf.Timestamp
+	33, // 7: proto.WHUploadResponse.next_retry_time:type_name -> google.protobuf.Timestamp
 	1,  // 8: proto.WHUploadResponse.tables:type_name -> proto.WHTable
-	26, // 9: proto.ValidateObjectStorageRequest.config:type_name -> google.protobuf.Struct
-	25, // 10: proto.FailedBatchInfo.lastHappened:type_name -> google.protobuf.Timestamp
-	25, // 11: proto.FailedBatchInfo.firstHappened:type_name -> google.protobuf.Timestamp
-	13, // 12: proto.RetrieveFailedBatchesResponse.failedBatches:type_name -> proto.FailedBatchInfo
-	25, // 13: proto.FirstAbortedUploadResponse.created_at:type_name -> google.protobuf.Timestamp
-	25, // 14: proto.FirstAbortedUploadResponse.first_event_at:type_name -> google.protobuf.Timestamp
-	25, // 15: proto.FirstAbortedUploadResponse.last_event_at:type_name -> google.protobuf.Timestamp
-	20, // 16: proto.FirstAbortedUploadInContinuousAbortsByDestinationResponse.uploads:type_name -> proto.FirstAbortedUploadResponse
-	24, // 17: proto.SyncLatencyResponse.time_series_data_points:type_name -> proto.LatencyTimeSeriesDataPoint
-	27, // 18: proto.LatencyTimeSeriesDataPoint.timestamp_millis:type_name -> google.protobuf.DoubleValue
-	27, // 19: proto.LatencyTimeSeriesDataPoint.latency_seconds:type_name -> google.protobuf.DoubleValue
-	28, // 20: proto.Warehouse.GetHealth:input_type -> google.protobuf.Empty
-	2,  // 21: proto.Warehouse.GetWHUploads:input_type -> proto.WHUploadsRequest
-	4,  // 22: proto.Warehouse.GetWHUpload:input_type -> proto.WHUploadRequest
-	4,  // 23: proto.Warehouse.TriggerWHUpload:input_type -> proto.WHUploadRequest
-	2,  // 24: proto.Warehouse.TriggerWHUploads:input_type -> proto.WHUploadsRequest
-	7,  // 25: proto.Warehouse.Validate:input_type -> proto.WHValidationRequest
-	9,  // 26: proto.Warehouse.RetryWHUploads:input_type -> proto.RetryWHUploadsRequest
-	9,  // 27: proto.Warehouse.CountWHUploadsToRetry:input_type -> proto.RetryWHUploadsRequest
-	11, // 28: proto.Warehouse.ValidateObjectStorageDestination:input_type -> proto.ValidateObjectStorageRequest
-	14, // 29: proto.Warehouse.RetrieveFailedBatches:input_type -> proto.RetrieveFailedBatchesRequest
-	16, // 30: proto.Warehouse.RetryFailedBatches:input_type -> proto.RetryFailedBatchesRequest
-	18, // 31: proto.Warehouse.GetFirstAbortedUploadInContinuousAbortsByDestination:input_type -> proto.FirstAbortedUploadInContinuousAbortsByDestinationRequest
-	22, // 32: proto.Warehouse.GetSyncLatency:input_type -> proto.SyncLatencyRequest
-	19, // 33: proto.Warehouse.SyncWHSchema:input_type -> proto.SyncWHSchemaRequest
-	29, // 34: proto.Warehouse.GetHealth:output_type -> google.protobuf.BoolValue
-	3,  // 35: proto.Warehouse.GetWHUploads:output_type -> proto.WHUploadsResponse
-	5,  // 36: proto.Warehouse.GetWHUpload:output_type -> proto.WHUploadResponse
-	6,  // 37: proto.Warehouse.TriggerWHUpload:output_type -> proto.TriggerWhUploadsResponse
-	6,  // 38: proto.Warehouse.TriggerWHUploads:output_type -> proto.TriggerWhUploadsResponse
-	8,  // 39: proto.Warehouse.Validate:output_type -> proto.WHValidationResponse
-	10, // 40: proto.Warehouse.RetryWHUploads:output_type -> proto.RetryWHUploadsResponse
-	10, // 41: proto.Warehouse.CountWHUploadsToRetry:output_type -> proto.RetryWHUploadsResponse
-	12, // 42: proto.Warehouse.ValidateObjectStorageDestination:output_type -> proto.ValidateObjectStorageResponse
-	15, // 43: proto.Warehouse.RetrieveFailedBatches:output_type -> proto.RetrieveFailedBatchesResponse
-	17, // 44: proto.Warehouse.RetryFailedBatches:output_type -> proto.RetryFailedBatchesResponse
-	21, // 45: proto.Warehouse.GetFirstAbortedUploadInContinuousAbortsByDestination:output_type -> proto.FirstAbortedUploadInContinuousAbortsByDestinationResponse
-	23, // 46: proto.Warehouse.GetSyncLatency:output_type -> proto.SyncLatencyResponse
-	28, // 47: proto.Warehouse.SyncWHSchema:output_type -> google.protobuf.Empty
-	34, // [34:48] is the sub-list for method output_type
-	20, // [20:34] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	9,  // 9: proto.PlanWHUploadResponse.tables:type_name -> proto.WHTablePlan
+	31, // 10: proto.WHTablePlan.added_columns:type_name -> proto.WHTablePlan.AddedColumnsEntry
+	32, // 11: proto.WHTablePlan.altered_columns:type_name -> proto.WHTablePlan.AlteredColumnsEntry
+	10, // 12: proto.WHTablePlan.columns:type_name -> proto.WHColumnPlan
+	12, // 13: proto.WHDataQualityChecksResponse.checks:type_name -> proto.WHDataQualityCheck
+	33, // 14: proto.WHDataQualityCheck.created_at:type_name -> google.protobuf.Timestamp
+	34, // 15: proto.ValidateObjectStorageRequest.config:type_name -> google.protobuf.Struct
+	33, // 16: proto.FailedBatchInfo.lastHappened:type_name -> google.protobuf.Timestamp
+	33, // 17: proto.FailedBatchInfo.firstHappened:type_name -> google.protobuf.Timestamp
+	19, // 18: proto.RetrieveFailedBatchesResponse.failedBatches:type_name -> proto.FailedBatchInfo
+	33, // 19: proto.FirstAbortedUploadResponse.created_at:type_name -> google.protobuf.Timestamp
+	33, // 20: proto.FirstAbortedUploadResponse.first_event_at:type_name -> google.protobuf.Timestamp
+	33, // 21: proto.FirstAbortedUploadResponse.last_event_at:type_name -> google.protobuf.Timestamp
+	26, // 22: proto.FirstAbortedUploadInContinuousAbortsByDestinationResponse.uploads:type_name -> proto.FirstAbortedUploadResponse
+	30, // 23: proto.SyncLatencyResponse.time_series_data_points:type_name -> proto.LatencyTimeSeriesDataPoint
+	35, // 24: proto.LatencyTimeSeriesDataPoint.timestamp_millis:type_name -> google.protobuf.DoubleValue
+	35, // 25: proto.LatencyTimeSeriesDataPoint.latency_seconds:type_name -> google.protobuf.DoubleValue
+	36, // 26: proto.Warehouse.GetHealth:input_type -> google.protobuf.Empty
+	2,  // 27: proto.Warehouse.GetWHUploads:input_type -> proto.WHUploadsRequest
+	4,  // 28: proto.Warehouse.GetWHUpload:input_type -> proto.WHUploadRequest
+	4,  // 29: proto.Warehouse.TriggerWHUpload:input_type -> proto.WHUploadRequest
+	7,  // 30: proto.Warehouse.PlanWHUpload:input_type -> proto.PlanWHUploadRequest
+	4,  // 31: proto.Warehouse.GetWHUploadDataQualityChecks:input_type -> proto.WHUploadRequest
+	2,  // 32: proto.Warehouse.TriggerWHUploads:input_type -> proto.WHUploadsRequest
+	13, // 33: proto.Warehouse.Validate:input_type -> proto.WHValidationRequest
+	15, // 34: proto.Warehouse.RetryWHUploads:input_type -> proto.RetryWHUploadsRequest
+	15, // 35: proto.Warehouse.CountWHUploadsToRetry:input_type -> proto.RetryWHUploadsRequest
+	17, // 36: proto.Warehouse.ValidateObjectStorageDestination:input_type -> proto.ValidateObjectStorageRequest
+	20, // 37: proto.Warehouse.RetrieveFailedBatches:input_type -> proto.RetrieveFailedBatchesRequest
+	22, // 38: proto.Warehouse.RetryFailedBatches:input_type -> proto.RetryFailedBatchesRequest
+	24, // 39: proto.Warehouse.GetFirstAbortedUploadInContinuousAbortsByDestination:input_type -> proto.FirstAbortedUploadInContinuousAbortsByDestinationRequest
+	28, // 40: proto.Warehouse.GetSyncLatency:input_type -> proto.SyncLatencyRequest
+	25, // 41: proto.Warehouse.SyncWHSchema:input_type -> proto.SyncWHSchemaRequest
+	37, // 42: proto.Warehouse.GetHealth:output_type -> google.protobuf.BoolValue
+	3,  // 43: proto.Warehouse.GetWHUploads:output_type -> proto.WHUploadsResponse
+	5,  // 44: proto.Warehouse.GetWHUpload:output_type -> proto.WHUploadResponse
+	6,  // 45: proto.Warehouse.TriggerWHUpload:output_type -> proto.TriggerWhUploadsResponse
+	8,  // 46: proto.Warehouse.PlanWHUpload:output_type -> proto.PlanWHUploadResponse
+	11, // 47: proto.Warehouse.GetWHUploadDataQualityChecks:output_type -> proto.WHDataQualityChecksResponse
+	6,  // 48: proto.Warehouse.TriggerWHUploads:output_type -> proto.TriggerWhUploadsResponse
+	14, // 49: proto.Warehouse.Validate:output_type -> proto.WHValidationResponse
+	16, // 50: proto.Warehouse.RetryWHUploads:output_type -> proto.RetryWHUploadsResponse
+	16, // 51: proto.Warehouse.CountWHUploadsToRetry:output_type -> proto.RetryWHUploadsResponse
+	18, // 52: proto.Warehouse.ValidateObjectStorageDestination:output_type -> proto.ValidateObjectStorageResponse
+	21, // 53: proto.Warehouse.RetrieveFailedBatches:output_type -> proto.RetrieveFailedBatchesResponse
+	23, // 54: proto.Warehouse.RetryFailedBatches:output_type -> proto.RetryFailedBatchesResponse
+	27, // 55: proto.Warehouse.GetFirstAbortedUploadInContinuousAbortsByDestination:output_type -> proto.FirstAbortedUploadInContinuousAbortsByDestinationResponse
+	29, // 56: proto.Warehouse.GetSyncLatency:output_type -> proto.SyncLatencyResponse
+	36, // 57: proto.Warehouse.SyncWHSchema:output_type -> google.protobuf.Empty
+	42, // [42:58] is the sub-list for method output_type
+	26, // [26:42] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_proto_warehouse_warehouse_proto_init() }
@@ -2341,7 +2929,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WHValidationRequest); i {
+			switch v := v.(*PlanWHUploadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2353,7 +2941,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WHValidationResponse); i {
+			switch v := v.(*PlanWHUploadResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2365,7 +2953,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetryWHUploadsRequest); i {
+			switch v := v.(*WHTablePlan); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2377,7 +2965,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetryWHUploadsResponse); i {
+			switch v := v.(*WHColumnPlan); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2389,7 +2977,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidateObjectStorageRequest); i {
+			switch v := v.(*WHDataQualityChecksResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2401,7 +2989,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValidateObjectStorageResponse); i {
+			switch v := v.(*WHDataQualityCheck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2413,7 +3001,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FailedBatchInfo); i {
+			switch v := v.(*WHValidationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2425,7 +3013,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetrieveFailedBatchesRequest); i {
+			switch v := v.(*WHValidationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2437,7 +3025,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetrieveFailedBatchesResponse); i {
+			switch v := v.(*RetryWHUploadsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2449,7 +3037,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetryFailedBatchesRequest); i {
+			switch v := v.(*RetryWHUploadsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2461,7 +3049,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RetryFailedBatchesResponse); i {
+			switch v := v.(*ValidateObjectStorageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2473,7 +3061,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirstAbortedUploadInContinuousAbortsByDestinationRequest); i {
+			switch v := v.(*ValidateObjectStorageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2485,7 +3073,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncWHSchemaRequest); i {
+			switch v := v.(*FailedBatchInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2497,7 +3085,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirstAbortedUploadResponse); i {
+			switch v := v.(*RetrieveFailedBatchesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2509,7 +3097,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirstAbortedUploadInContinuousAbortsByDestinationResponse); i {
+			switch v := v.(*RetrieveFailedBatchesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2521,7 +3109,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncLatencyRequest); i {
+			switch v := v.(*RetryFailedBatchesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2533,7 +3121,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncLatencyResponse); i {
+			switch v := v.(*RetryFailedBatchesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2545,6 +3133,78 @@ func file_proto_warehouse_warehouse_proto_init() {
 			}
 		}
 		file_proto_warehouse_warehouse_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirstAbortedUploadInContinuousAbortsByDestinationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_warehouse_warehouse_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncWHSchemaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_warehouse_warehouse_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirstAbortedUploadResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_warehouse_warehouse_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirstAbortedUploadInContinuousAbortsByDestinationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_warehouse_warehouse_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncLatencyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_warehouse_warehouse_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncLatencyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_warehouse_warehouse_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LatencyTimeSeriesDataPoint); i {
 			case 0:
 				return &v.state
@@ -2563,7 +3223,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_warehouse_warehouse_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,6 +25,8 @@ const (
 	Warehouse_GetWHUploads_FullMethodName                                         = "/proto.Warehouse/GetWHUploads"
 	Warehouse_GetWHUpload_FullMethodName                                          = "/proto.Warehouse/GetWHUpload"
 	Warehouse_TriggerWHUpload_FullMethodName                                      = "/proto.Warehouse/TriggerWHUpload"
+	Warehouse_PlanWHUpload_FullMethodName                                         = "/proto.Warehouse/PlanWHUpload"
+	Warehouse_GetWHUploadDataQualityChecks_FullMethodName                         = "/proto.Warehouse/GetWHUploadDataQualityChecks"
 	Warehouse_TriggerWHUploads_FullMethodName                                     = "/proto.Warehouse/TriggerWHUploads"
 	Warehouse_Validate_FullMethodName                                             = "/proto.Warehouse/Validate"
 	Warehouse_RetryWHUploads_FullMethodName                                       = "/proto.Warehouse/RetryWHUploads"
@@ -45,6 +47,8 @@ type WarehouseClient interface {
 	GetWHUploads(ctx context.Context, in *WHUploadsRequest, opts ...grpc.CallOption) (*WHUploadsResponse, error)
 	GetWHUpload(ctx context.Context, in *WHUploadRequest, opts ...grpc.CallOption) (*WHUploadResponse, error)
 	TriggerWHUpload(ctx context.Context, in *WHUploadRequest, opts ...grpc.CallOption) (*TriggerWhUploadsResponse, error)
+	PlanWHUpload(ctx context.Context, in *PlanWHUploadRequest, opts ...grpc.CallOption) (*PlanWHUploadResponse, error)
+	GetWHUploadDataQualityChecks(ctx context.Context, in *WHUploadRequest, opts ...grpc.CallOption) (*WHDataQualityChecksResponse, error)
 	TriggerWHUploads(ctx context.Context, in *WHUploadsRequest, opts ...grpc.CallOption) (*TriggerWhUploadsResponse, error)
 	Validate(ctx context.Context, in *WHValidationRequest, opts ...grpc.CallOption) (*WHValidationResponse, error)
 	RetryWHUploads(ctx context.Context, in *RetryWHUploadsRequest, opts ...grpc.CallOption) (*RetryWHUploadsResponse, error)
@@ -101,6 +105,24 @@ func (c *warehouseClient) TriggerWHUpload(ctx context.Context, in *WHUploadReque
 	return out, nil
 }
 
+func (c *warehouseClient) PlanWHUpload(ctx context.Context, in *PlanWHUploadRequest, opts ...grpc.CallOption) (*PlanWHUploadResponse, error) {
+	out := new(PlanWHUploadResponse)
+	err := c.cc.Invoke(ctx, Warehouse_PlanWHUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseClient) GetWHUploadDataQualityChecks(ctx context.Context, in *WHUploadRequest, opts ...grpc.CallOption) (*WHDataQualityChecksResponse, error) {
+	out := new(WHDataQualityChecksResponse)
+	err := c.cc.Invoke(ctx, Warehouse_GetWHUploadDataQualityChecks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *warehouseClient) TriggerWHUploads(ctx context.Context, in *WHUploadsRequest, opts ...grpc.CallOption) (*TriggerWhUploadsResponse, error) {
 	out := new(TriggerWhUploadsResponse)
 	err := c.cc.Invoke(ctx, Warehouse_TriggerWHUploads_FullMethodName, in, out, opts...)
@@ -199,6 +221,8 @@ type WarehouseServer interface {
 	GetWHUploads(context.Context, *WHUploadsRequest) (*WHUploadsResponse, error)
 	GetWHUpload(context.Context, *WHUploadRequest) (*WHUploadResponse, error)
 	TriggerWHUpload(context.Context, *WHUploadRequest) (*TriggerWhUploadsResponse, error)
+	PlanWHUpload(context.Context, *PlanWHUploadRequest) (*PlanWHUploadResponse, error)
+	GetWHUploadDataQualityChecks(context.Context, *WHUploadRequest) (*WHDataQualityChecksResponse, error)
 	TriggerWHUploads(context.Context, *WHUploadsRequest) (*TriggerWhUploadsResponse, error)
 	Validate(context.Context, *WHValidationRequest) (*WHValidationResponse, error)
 	RetryWHUploads(context.Context, *RetryWHUploadsRequest) (*RetryWHUploadsResponse, error)
@@ -228,6 +252,12 @@ func (UnimplementedWarehouseServer) GetWHUpload(context.Context, *WHUploadReques
 func (UnimplementedWarehouseServer) TriggerWHUpload(context.Context, *WHUploadRequest) (*TriggerWhUploadsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TriggerWHUpload not implemented")
 }
+func (UnimplementedWarehouseServer) PlanWHUpload(context.Context, *PlanWHUploadRequest) (*PlanWHUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanWHUpload not implemented")
+}
+func (UnimplementedWarehouseServer) GetWHUploadDataQualityChecks(context.Context, *WHUploadRequest) (*WHDataQualityChecksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWHUploadDataQualityChecks not implemented")
+}
 func (UnimplementedWarehouseServer) TriggerWHUploads(context.Context, *WHUploadsRequest) (*TriggerWhUploadsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TriggerWHUploads not implemented")
 }
@@ -343,6 +373,42 @@ func _Warehouse_TriggerWHUpload_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Warehouse_PlanWHUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanWHUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServer).PlanWHUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Warehouse_PlanWHUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServer).PlanWHUpload(ctx, req.(*PlanWHUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Warehouse_GetWHUploadDataQualityChecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WHUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServer).GetWHUploadDataQualityChecks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Warehouse_GetWHUploadDataQualityChecks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServer).GetWHUploadDataQualityChecks(ctx, req.(*WHUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Warehouse_TriggerWHUploads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WHUploadsRequest)
 	if err := dec(in); err != nil {
@@ -546,6 +612,14 @@ var Warehouse_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TriggerWHUpload",
 			Handler:    _Warehouse_TriggerWHUpload_Handler,
 		},
+		{
+			MethodName: "PlanWHUpload",
+			Handler:    _Warehouse_PlanWHUpload_Handler,
+		},
+		{
+			MethodName: "GetWHUploadDataQualityChecks",
+			Handler:    _Warehouse_GetWHUploadDataQualityChecks_Handler,
+		},
 		{
 			MethodName: "TriggerWHUploads",
 			Handler:    _Warehouse_TriggerWHUploads_Handler,
@@ -16,6 +16,7 @@ type Factory struct {
 	BackendConfig         backendconfig.BackendConfig
 	RouterDB              jobsdb.JobsDB
 	ProcErrorDB           jobsdb.JobsDB
+	DLQDB                 jobsdb.JobsDB // optional, aborted jobs are copied to the dead-letter queue if provided
 	TransientSources      transientsource.Service
 	RsourcesService       rsources.JobService
 	Debugger              destinationdebugger.DestinationDebugger
@@ -26,6 +27,7 @@ type Factory struct {
 func (f *Factory) New(destType string) *Handle {
 	r := &Handle{
 		adaptiveLimit: f.AdaptiveLimit,
+		dlqDB:         f.DLQDB,
 	}
 
 	r.Setup(
@@ -38,6 +38,7 @@ import (
 	routerutils "github.com/rudderlabs/rudder-server/router/utils"
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
 	"github.com/rudderlabs/rudder-server/services/diagnostics"
+	"github.com/rudderlabs/rudder-server/services/dlq"
 	"github.com/rudderlabs/rudder-server/services/rmetrics"
 	"github.com/rudderlabs/rudder-server/services/rsources"
 	"github.com/rudderlabs/rudder-server/services/transientsource"
@@ -60,6 +61,7 @@ type Handle struct {
 	netHandle             *http.Client
 	jobsDB                jobsdb.JobsDB
 	errorDB               jobsdb.JobsDB
+	dlqDB                 jobsdb.JobsDB
 	reporting             types.Reporting
 	backendConfig         backendconfig.BackendConfig
 	fileManagerFactory    filemanager.Factory
@@ -777,6 +779,7 @@ func (brt *Handle) updateJobStatus(batchJobs *BatchedJobs, isWarehouse bool, err
 			brt.logger.Errorf("abortedEvents: %v", abortedEvents)
 			panic(err)
 		}
+		brt.storeDLQJobs(abortedEvents, statusList)
 	}
 
 	// REPORTING - START
@@ -835,6 +838,23 @@ func (brt *Handle) updateJobStatus(batchJobs *BatchedJobs, isWarehouse bool, err
 	sendDestStatusStats(batchJobs.Connection, jobStateCounts, brt.destType, isWarehouse)
 }
 
+// storeDLQJobs copies aborted jobs to the dead-letter queue, if it is enabled
+func (brt *Handle) storeDLQJobs(abortedJobs []*jobsdb.JobT, statusList []*jobsdb.JobStatusT) {
+	if brt.dlqDB == nil {
+		return
+	}
+	dlqJobs := dlq.NewJobs(brt.jobsDB.Identifier(), abortedJobs, statusList)
+	if len(dlqJobs) == 0 {
+		return
+	}
+	err := misc.RetryWithNotify(context.Background(), brt.jobsDBCommandTimeout.Load(), brt.jobdDBMaxRetries.Load(), func(ctx context.Context) error {
+		return brt.dlqDB.Store(ctx, dlqJobs)
+	}, brt.sendRetryStoreStats)
+	if err != nil {
+		panic(fmt.Errorf("storing %s jobs into DLQ: %w", brt.destType, err))
+	}
+}
+
 // uploadInterval calculates the upload interval for the destination
 func (brt *Handle) uploadInterval(destinationConfig map[string]interface{}) time.Duration {
 	uploadInterval, ok := destinationConfig["uploadInterval"]
@@ -263,6 +263,7 @@ func (brt *Handle) updatePollStatusToDB(
 					brt.logger.Errorf("[Batch Router] Failed to store aborted jobs for Dest Type %v with error %v", brt.destType, err)
 					panic(fmt.Errorf("storing jobs into ErrorDB: %w", err))
 				}
+				brt.storeDLQJobs(abortedJobs, statusList)
 			}
 			if err := brt.updateJobStatuses(ctx, destinationID, importingList, completedJobsList, statusList); err != nil {
 				brt.logger.Errorf("[Batch Router] Failed to update job status for Dest Type %v with error %v", brt.destType, err)
@@ -184,6 +184,7 @@ func (w *worker) scheduleJobs(destinationJobs *DestinationJobs) {
 		if err != nil {
 			panic(fmt.Errorf("storing %s jobs into ErrorDB: %w", brt.destType, err))
 		}
+		brt.storeDLQJobs(drainJobList, drainList)
 		reportMetrics := brt.getReportMetrics(getReportMetricsParams{
 			StatusList:    drainList,
 			ParametersMap: brt.getParamertsFromJobs(drainJobList),
@@ -23,6 +23,7 @@ type Factory struct {
 	BackendConfig              backendconfig.BackendConfig
 	RouterDB                   jobsdb.JobsDB
 	ProcErrorDB                jobsdb.JobsDB
+	DLQDB                      jobsdb.JobsDB // optional, aborted jobs are copied to the dead-letter queue if provided
 	TransientSources           transientsource.Service
 	RsourcesService            rsources.JobService
 	TransformerFeaturesService transformerFeaturesService.FeaturesService
@@ -36,6 +37,7 @@ func (f *Factory) New(destination *backendconfig.DestinationT) *Handle {
 	r := &Handle{
 		Reporting:     f.Reporting,
 		adaptiveLimit: f.AdaptiveLimit,
+		dlqDB:         f.DLQDB,
 	}
 	r.Setup(
 		destination.DestinationDefinition,
@@ -35,6 +35,7 @@ import (
 	routerutils "github.com/rudderlabs/rudder-server/router/utils"
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
 	"github.com/rudderlabs/rudder-server/services/diagnostics"
+	"github.com/rudderlabs/rudder-server/services/dlq"
 	"github.com/rudderlabs/rudder-server/services/oauth"
 	"github.com/rudderlabs/rudder-server/services/rmetrics"
 	"github.com/rudderlabs/rudder-server/services/rsources"
@@ -51,6 +52,7 @@ type Handle struct {
 	// external dependencies
 	jobsDB                     jobsdb.JobsDB
 	errorDB                    jobsdb.JobsDB
+	dlqDB                      jobsdb.JobsDB
 	throttlerFactory           rtThrottler.Factory
 	backendConfig              backendconfig.BackendConfig
 	Reporting                  reporter
@@ -479,6 +481,7 @@ func (rt *Handle) commitStatusList(workerJobStatuses *[]workerJobStatus) {
 			if err != nil {
 				panic(fmt.Errorf("storing jobs into ErrorDB: %w", err))
 			}
+			rt.storeDLQJobs(routerAbortedJobs, statusList)
 		}
 		// Update the status
 		err := misc.RetryWithNotify(context.Background(), rt.reloadableConfig.jobsDBCommandTimeout.Load(), rt.reloadableConfig.jobdDBMaxRetries.Load(), func(ctx context.Context) error {
@@ -536,6 +539,23 @@ func (rt *Handle) commitStatusList(workerJobStatuses *[]workerJobStatus) {
 	}
 }
 
+// storeDLQJobs copies aborted jobs to the dead-letter queue, if it is enabled
+func (rt *Handle) storeDLQJobs(abortedJobs []*jobsdb.JobT, statusList []*jobsdb.JobStatusT) {
+	if rt.dlqDB == nil {
+		return
+	}
+	dlqJobs := dlq.NewJobs(rt.jobsDB.Identifier(), abortedJobs, statusList)
+	if len(dlqJobs) == 0 {
+		return
+	}
+	err := misc.RetryWithNotify(context.Background(), rt.reloadableConfig.jobsDBCommandTimeout.Load(), rt.reloadableConfig.jobdDBMaxRetries.Load(), func(ctx context.Context) error {
+		return rt.dlqDB.Store(ctx, dlqJobs)
+	}, rt.sendRetryStoreStats)
+	if err != nil {
+		panic(fmt.Errorf("storing jobs into DLQ: %w", err))
+	}
+}
+
 func (rt *Handle) getJobsFn(parentContext context.Context) func(context.Context, jobsdb.GetQueryParams, jobsdb.MoreToken) (*jobsdb.MoreJobsResult, error) {
 	return func(ctx context.Context, params jobsdb.GetQueryParams, resumeFrom jobsdb.MoreToken) (*jobsdb.MoreJobsResult, error) {
 		jobs, err := misc.QueryWithRetriesAndNotify(parentContext, rt.reloadableConfig.jobsDBCommandTimeout.Load(), rt.reloadableConfig.jobdDBMaxRetries.Load(), func(ctx context.Context) (*jobsdb.MoreJobsResult, error) {
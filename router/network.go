@@ -146,6 +146,16 @@ func (network *netHandle) SendPost(ctx context.Context, structData integrations.
 					}
 				}
 				payload = strings.NewReader(strValue)
+			case "RAW":
+				// the payload is sent as is, e.g. for requests whose signature is computed over the exact body
+				strValue, ok := bodyValue["payload"].(string)
+				if !ok {
+					return &utils.SendPostResponse{
+						StatusCode:   400,
+						ResponseBody: []byte("400 Unable to construct raw payload. Unexpected transformer response"),
+					}
+				}
+				payload = strings.NewReader(strValue)
 			case "FORM":
 				formValues := url.Values{}
 				for key, val := range bodyValue {
@@ -473,6 +473,48 @@ func TestSendPost(t *testing.T) {
 		require.Equal(t, http.StatusOK, resp.StatusCode)
 		require.Equal(t, "OK", string(resp.ResponseBody))
 	})
+
+	t.Run("should handle RAW body", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mockHTTPClient := mocksSysUtils.NewMockHTTPClientI(mockCtrl)
+		network := &netHandle{
+			logger:               logger.NewLogger().Child("network"),
+			blockPrivateIPsCIDRs: netutil.DefaultPrivateCidrRanges,
+			httpClient:           mockHTTPClient,
+		}
+
+		structData := integrations.PostParametersT{
+			Type:          "REST",
+			RequestMethod: "PUT",
+			URL:           "https://example.com",
+			Headers:       map[string]interface{}{"Content-Type": "application/json"},
+			Body: map[string]interface{}{
+				"RAW": map[string]interface{}{
+					"payload": `[{"b":1, "a":2}]`,
+				},
+			},
+		}
+
+		mockHTTPClient.EXPECT().Do(gomock.Any()).Times(1).Do(func(req *http.Request) {
+			require.Equal(t, "PUT", req.Method)
+			require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.Equal(t, `[{"b":1, "a":2}]`, string(body))
+		}).Return(&http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewReader([]byte("OK"))),
+		}, nil)
+
+		resp := network.SendPost(context.Background(), structData)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		structData.Body = map[string]interface{}{"RAW": map[string]interface{}{"payload": []interface{}{}}}
+		resp = network.SendPost(context.Background(), structData)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Equal(t, "400 Unable to construct raw payload. Unexpected transformer response", string(resp.ResponseBody))
+	})
 }
 
 func TestResponseContentType(t *testing.T) {
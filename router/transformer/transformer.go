@@ -28,6 +28,7 @@ import (
 	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
 	transformerclient "github.com/rudderlabs/rudder-server/internal/transformer-client"
 	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/router/transformer/webhook"
 	"github.com/rudderlabs/rudder-server/router/types"
 	oauthv2 "github.com/rudderlabs/rudder-server/services/oauth/v2"
 	"github.com/rudderlabs/rudder-server/services/oauth/v2/common"
@@ -166,8 +167,24 @@ func (t transformerMetricLabels) ToStatsTag() stats.Tags {
 	return tags
 }
 
+// embeddedTransformerImpls are the router transformations of destinations implemented natively, without the transformer service
+var embeddedTransformerImpls = map[string]func(transformMessage *types.TransformMessageT) []types.DestinationJobT{
+	webhook.DestType: webhook.Transform,
+}
+
+// IsEmbedded returns true if the router transformation of the destination type is implemented natively
+func IsEmbedded(destType string) bool {
+	_, ok := embeddedTransformerImpls[destType]
+	return ok
+}
+
 // Transform transforms router jobs to destination jobs
 func (trans *handle) Transform(transformType string, transformMessage *types.TransformMessageT) []types.DestinationJobT {
+	if len(transformMessage.Data) > 0 {
+		if impl, ok := embeddedTransformerImpls[transformMessage.Data[0].Destination.DestinationDefinition.Name]; ok {
+			return impl(transformMessage)
+		}
+	}
 	start := time.Now()
 	var destinationJobs types.DestinationJobs
 	transformMessageCopy, preservedData := transformMessage.Dehydrate()
@@ -0,0 +1,272 @@
+// Package webhook implements the router transformation of the HTTP webhook destination natively,
+// without a round-trip to the transformer service.
+//
+// Events are delivered to the configured url, either one per request or, if maxBatchSize is greater than 1,
+// in batches of up to maxBatchSize events as a JSON array. The body of every event is the event itself,
+// or the output of the destination's bodyTemplate (a Go template, executed with the event as data) which must be valid JSON.
+// If a signingSecret is configured, requests are signed with HMAC-SHA256, see [Sign].
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/router/types"
+)
+
+const (
+	// DestType is the name of the destination definition
+	DestType = "HTTP_WEBHOOK"
+
+	// TimestampHeader is the header carrying the unix timestamp (in seconds) the request was signed at
+	TimestampHeader = "X-Rudder-Timestamp"
+	// SignatureHeader is the header carrying the request's signature
+	SignatureHeader = "X-Rudder-Signature"
+
+	// BodyFormat is the body format of the delivered requests, sending the payload as is
+	BodyFormat = "RAW"
+)
+
+var now = time.Now
+
+// config is the configuration of an HTTP webhook destination
+type config struct {
+	URL           string
+	Method        string
+	Headers       map[string]string
+	SigningSecret string
+	BodyTemplate  *template.Template
+	MaxBatchSize  int
+}
+
+func parseConfig(destination *backendconfig.DestinationT) (*config, error) {
+	c := &config{
+		Method:       http.MethodPost,
+		Headers:      map[string]string{},
+		MaxBatchSize: 1,
+	}
+	c.URL, _ = destination.Config["webhookUrl"].(string)
+	if c.URL == "" {
+		return nil, fmt.Errorf("webhookUrl is required")
+	}
+	if method, _ := destination.Config["webhookMethod"].(string); method != "" {
+		c.Method = strings.ToUpper(method)
+	}
+	switch c.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return nil, fmt.Errorf("unsupported webhookMethod: %q", c.Method)
+	}
+	if headers, ok := destination.Config["headers"].([]interface{}); ok {
+		for _, header := range headers {
+			h, ok := header.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			key, _ := h["from"].(string)
+			value, _ := h["to"].(string)
+			if key = strings.TrimSpace(key); key != "" {
+				c.Headers[key] = value
+			}
+		}
+	}
+	c.SigningSecret, _ = destination.Config["signingSecret"].(string)
+	if bodyTemplate, _ := destination.Config["bodyTemplate"].(string); strings.TrimSpace(bodyTemplate) != "" {
+		tmpl, err := template.New("body").Option("missingkey=zero").Funcs(templateFuncs).Parse(bodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bodyTemplate: %w", err)
+		}
+		c.BodyTemplate = tmpl
+	}
+	if maxBatchSize, err := toInt(destination.Config["maxBatchSize"]); err != nil {
+		return nil, fmt.Errorf("invalid maxBatchSize: %w", err)
+	} else if maxBatchSize > 1 {
+		c.MaxBatchSize = maxBatchSize
+	}
+	return c, nil
+}
+
+var templateFuncs = template.FuncMap{
+	// toJSON renders a value as JSON, e.g. {"name": {{ toJSON .traits.name }}}
+	"toJSON": func(v interface{}) (string, error) {
+		b, err := jsonrs.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Transform transforms router jobs of HTTP webhook destinations to requests, batching jobs of the same destination
+// if configured. Jobs which are flagged as not to be batched are always delivered in a request of their own.
+func Transform(transformMessage *types.TransformMessageT) []types.DestinationJobT {
+	destinationJobs := make([]types.DestinationJobT, 0, len(transformMessage.Data))
+	configs := make(map[string]*config)
+	configErrors := make(map[string]error)
+	pending := make(map[string]*batch) // the batch of every destination which is still being filled
+	var batches []*batch               // all batches, in order of their first job
+
+	for i := range transformMessage.Data {
+		routerJob := &transformMessage.Data[i]
+		destinationID := routerJob.Destination.ID
+		c, ok := configs[destinationID]
+		if !ok && configErrors[destinationID] == nil {
+			var err error
+			if c, err = parseConfig(&routerJob.Destination); err != nil {
+				configErrors[destinationID] = err
+			} else {
+				configs[destinationID] = c
+			}
+		}
+		if err := configErrors[destinationID]; err != nil {
+			destinationJobs = append(destinationJobs, failedJob(routerJob, http.StatusBadRequest, fmt.Sprintf("invalid destination configuration: %v", err)))
+			continue
+		}
+		body, err := c.render(routerJob.Message)
+		if err != nil {
+			destinationJobs = append(destinationJobs, failedJob(routerJob, http.StatusBadRequest, err.Error()))
+			continue
+		}
+		b, ok := pending[destinationID]
+		if !ok || routerJob.JobMetadata.DontBatch {
+			b = &batch{routerJob: routerJob, config: c}
+			batches = append(batches, b)
+		}
+		b.bodies = append(b.bodies, body)
+		b.metadata = append(b.metadata, routerJob.JobMetadata)
+		switch {
+		case routerJob.JobMetadata.DontBatch:
+		case len(b.bodies) >= c.MaxBatchSize:
+			delete(pending, destinationID)
+		default:
+			pending[destinationID] = b
+		}
+	}
+	for _, b := range batches {
+		destinationJobs = append(destinationJobs, b.destinationJob())
+	}
+	return destinationJobs
+}
+
+// render returns the body of an event
+func (c *config) render(message json.RawMessage) ([]byte, error) {
+	if c.BodyTemplate == nil {
+		if !json.Valid(message) {
+			return nil, fmt.Errorf("event is not valid JSON")
+		}
+		return message, nil
+	}
+	var event map[string]interface{}
+	if err := jsonrs.Unmarshal(message, &event); err != nil {
+		return nil, fmt.Errorf("unmarshalling event: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := c.BodyTemplate.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("executing bodyTemplate: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("bodyTemplate output is not valid JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// batch is a group of events of the same destination delivered in a single request
+type batch struct {
+	routerJob *types.RouterJobT // the first job of the batch
+	config    *config
+	bodies    [][]byte
+	metadata  []types.JobMetadataT
+}
+
+func (b *batch) destinationJob() types.DestinationJobT {
+	c := b.config
+	var body []byte
+	if c.MaxBatchSize == 1 {
+		body = b.bodies[0]
+	} else {
+		body = append([]byte("["), bytes.Join(b.bodies, []byte(","))...)
+		body = append(body, ']')
+	}
+	headers := make(map[string]interface{}, len(c.Headers)+3)
+	for k, v := range c.Headers {
+		headers[k] = v
+	}
+	headers["Content-Type"] = "application/json"
+	if c.SigningSecret != "" {
+		timestamp := strconv.FormatInt(now().Unix(), 10)
+		headers[TimestampHeader] = timestamp
+		headers[SignatureHeader] = Sign(c.SigningSecret, timestamp, body)
+	}
+	request, err := jsonrs.Marshal(integrations.PostParametersT{
+		Type:          "REST",
+		URL:           c.URL,
+		RequestMethod: c.Method,
+		Headers:       headers,
+		QueryParams:   map[string]interface{}{},
+		Body:          map[string]interface{}{BodyFormat: map[string]interface{}{"payload": string(body)}},
+		Files:         map[string]interface{}{},
+	})
+	if err != nil {
+		panic(fmt.Errorf("marshalling webhook request: %w", err))
+	}
+	return types.DestinationJobT{
+		Message:          request,
+		JobMetadataArray: b.metadata,
+		Destination:      b.routerJob.Destination,
+		Connection:       b.routerJob.Connection,
+		Batched:          len(b.metadata) > 1,
+		StatusCode:       http.StatusOK,
+	}
+}
+
+// Sign returns the signature of a request body signed at the given timestamp, i.e.
+// v1=<hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the signing secret>
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func failedJob(routerJob *types.RouterJobT, statusCode int, errorMessage string) types.DestinationJobT {
+	return types.DestinationJobT{
+		Message:          routerJob.Message,
+		JobMetadataArray: []types.JobMetadataT{routerJob.JobMetadata},
+		Destination:      routerJob.Destination,
+		Connection:       routerJob.Connection,
+		StatusCode:       statusCode,
+		Error:            errorMessage,
+		StatTags: map[string]string{
+			"errorCategory": "dataValidation",
+			"errorType":     "configuration",
+		},
+	}
+}
+
+func toInt(v interface{}) (int, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int(v), nil
+	case int:
+		return v, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("unsupported type %T", v)
+	}
+}
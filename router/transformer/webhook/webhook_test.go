@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/router/types"
+)
+
+func TestTransform(t *testing.T) {
+	now = func() time.Time { return time.Unix(1700000000, 0) }
+	t.Cleanup(func() { now = time.Now })
+
+	newDestination := func(id string, config map[string]interface{}) backendconfig.DestinationT {
+		return backendconfig.DestinationT{
+			ID:                    id,
+			Config:                config,
+			DestinationDefinition: backendconfig.DestinationDefinitionT{Name: DestType},
+		}
+	}
+	newJob := func(jobID int64, destination backendconfig.DestinationT, message string) types.RouterJobT {
+		return types.RouterJobT{
+			Message:     []byte(message),
+			JobMetadata: types.JobMetadataT{JobID: jobID, DestinationID: destination.ID},
+			Destination: destination,
+		}
+	}
+	requestOf := func(t *testing.T, job types.DestinationJobT) (integrations.PostParametersT, string) {
+		t.Helper()
+		var request integrations.PostParametersT
+		require.NoError(t, jsonrs.Unmarshal(job.Message, &request))
+		body, ok := request.Body[BodyFormat].(map[string]interface{})
+		require.True(t, ok)
+		payload, ok := body["payload"].(string)
+		require.True(t, ok)
+		return request, payload
+	}
+	jobIDsOf := func(job types.DestinationJobT) []int64 {
+		return lo.Map(job.JobMetadataArray, func(m types.JobMetadataT, _ int) int64 { return m.JobID })
+	}
+
+	t.Run("single event per request", func(t *testing.T) {
+		destination := newDestination("dest-1", map[string]interface{}{
+			"webhookUrl": "https://example.com/hook",
+			"headers": []interface{}{
+				map[string]interface{}{"from": "X-Api-Key", "to": "key"},
+				map[string]interface{}{"from": " ", "to": "ignored"},
+			},
+		})
+		destinationJobs := Transform(&types.TransformMessageT{Data: []types.RouterJobT{
+			newJob(1, destination, `{"event":"a"}`),
+			newJob(2, destination, `{"event":"b"}`),
+		}})
+		require.Len(t, destinationJobs, 2)
+		for i, job := range destinationJobs {
+			require.Equal(t, http.StatusOK, job.StatusCode)
+			require.False(t, job.Batched)
+			require.Equal(t, []int64{int64(i + 1)}, jobIDsOf(job))
+			request, payload := requestOf(t, job)
+			require.Equal(t, "REST", request.Type)
+			require.Equal(t, "https://example.com/hook", request.URL)
+			require.Equal(t, http.MethodPost, request.RequestMethod)
+			require.Equal(t, map[string]interface{}{"X-Api-Key": "key", "Content-Type": "application/json"}, request.Headers)
+			require.JSONEq(t, []string{`{"event":"a"}`, `{"event":"b"}`}[i], payload)
+		}
+	})
+
+	t.Run("batching", func(t *testing.T) {
+		destination1 := newDestination("dest-1", map[string]interface{}{"webhookUrl": "https://example.com/1", "maxBatchSize": float64(2), "webhookMethod": "put"})
+		destination2 := newDestination("dest-2", map[string]interface{}{"webhookUrl": "https://example.com/2", "maxBatchSize": "10"})
+		dontBatchJob := newJob(4, destination1, `{"event":"d"}`)
+		dontBatchJob.JobMetadata.DontBatch = true
+		destinationJobs := Transform(&types.TransformMessageT{Data: []types.RouterJobT{
+			newJob(1, destination1, `{"event":"a"}`),
+			newJob(2, destination2, `{"event":"b"}`),
+			newJob(3, destination1, `{"event":"c"}`),
+			dontBatchJob,
+			newJob(5, destination1, `{"event":"e"}`),
+			newJob(6, destination2, `{"event":"f"}`),
+		}})
+		require.Len(t, destinationJobs, 4)
+
+		require.Equal(t, []int64{1, 3}, jobIDsOf(destinationJobs[0]))
+		require.True(t, destinationJobs[0].Batched)
+		request, payload := requestOf(t, destinationJobs[0])
+		require.Equal(t, http.MethodPut, request.RequestMethod)
+		require.JSONEq(t, `[{"event":"a"},{"event":"c"}]`, payload)
+
+		require.Equal(t, []int64{2, 6}, jobIDsOf(destinationJobs[1]))
+		request, payload = requestOf(t, destinationJobs[1])
+		require.Equal(t, "https://example.com/2", request.URL)
+		require.JSONEq(t, `[{"event":"b"},{"event":"f"}]`, payload)
+
+		require.Equal(t, []int64{4}, jobIDsOf(destinationJobs[2]))
+		require.False(t, destinationJobs[2].Batched)
+		_, payload = requestOf(t, destinationJobs[2])
+		require.JSONEq(t, `[{"event":"d"}]`, payload)
+
+		require.Equal(t, []int64{5}, jobIDsOf(destinationJobs[3]))
+		_, payload = requestOf(t, destinationJobs[3])
+		require.JSONEq(t, `[{"event":"e"}]`, payload)
+	})
+
+	t.Run("signing", func(t *testing.T) {
+		destination := newDestination("dest-1", map[string]interface{}{"webhookUrl": "https://example.com/hook", "signingSecret": "secret"})
+		destinationJobs := Transform(&types.TransformMessageT{Data: []types.RouterJobT{newJob(1, destination, `{"event":"a"}`)}})
+		require.Len(t, destinationJobs, 1)
+		request, payload := requestOf(t, destinationJobs[0])
+		require.Equal(t, "1700000000", request.Headers[TimestampHeader])
+		require.Equal(t, Sign("secret", "1700000000", []byte(payload)), request.Headers[SignatureHeader])
+		// echo -n '1700000000.{"event":"a"}' | openssl dgst -sha256 -hmac secret
+		require.Equal(t, "v1=7339f474631e4d1d0a03c997dcaf2bcfe0fc533271ada848d6fc3aaa0fdf57e6", Sign("secret", "1700000000", []byte(`{"event":"a"}`)))
+	})
+
+	t.Run("body template", func(t *testing.T) {
+		destination := newDestination("dest-1", map[string]interface{}{
+			"webhookUrl":   "https://example.com/hook",
+			"bodyTemplate": `{"name": {{ toJSON .event }}, "userId": {{ toJSON .userId }}}`,
+		})
+		invalidDestination := newDestination("dest-2", map[string]interface{}{
+			"webhookUrl":   "https://example.com/hook",
+			"bodyTemplate": `{{ .event }}`,
+		})
+		destinationJobs := Transform(&types.TransformMessageT{Data: []types.RouterJobT{
+			newJob(1, destination, `{"event":"a","userId":"user-1"}`),
+			newJob(2, destination, `{"event":"b"}`),
+			newJob(3, invalidDestination, `{"event":"c"}`),
+		}})
+		require.Len(t, destinationJobs, 3)
+		// failed jobs are returned before the requests
+		require.Equal(t, http.StatusBadRequest, destinationJobs[0].StatusCode)
+		require.Equal(t, "bodyTemplate output is not valid JSON: c", destinationJobs[0].Error)
+		require.Equal(t, []int64{3}, jobIDsOf(destinationJobs[0]))
+		_, payload := requestOf(t, destinationJobs[1])
+		require.JSONEq(t, `{"name":"a","userId":"user-1"}`, payload)
+		_, payload = requestOf(t, destinationJobs[2])
+		require.JSONEq(t, `{"name":"b","userId":null}`, payload)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		for name, config := range map[string]map[string]interface{}{
+			"missing url":          {},
+			"unsupported method":   {"webhookUrl": "https://example.com/hook", "webhookMethod": "DELETE"},
+			"invalid template":     {"webhookUrl": "https://example.com/hook", "bodyTemplate": "{{ .event "},
+			"invalid maxBatchSize": {"webhookUrl": "https://example.com/hook", "maxBatchSize": "many"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				destination := newDestination("dest-1", config)
+				destinationJobs := Transform(&types.TransformMessageT{Data: []types.RouterJobT{
+					newJob(1, destination, `{"event":"a"}`),
+					newJob(2, destination, `{"event":"b"}`),
+				}})
+				require.Len(t, destinationJobs, 2)
+				for _, job := range destinationJobs {
+					require.Equal(t, http.StatusBadRequest, job.StatusCode)
+					require.Contains(t, job.Error, "invalid destination configuration")
+				}
+			})
+		}
+	})
+}
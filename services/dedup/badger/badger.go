@@ -288,6 +288,40 @@ func (d *Dedup) Commit(keys []string) error {
 	return nil
 }
 
+// ScanKeys scans all non-expired keys in batches
+func (d *Dedup) ScanKeys(ctx context.Context, batchSize int, fn func(keys []string) error) error {
+	if err := d.badgerDB.init(); err != nil {
+		return fmt.Errorf("initializing badger db: %w", err)
+	}
+	return d.badgerDB.badgerDB.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		keys := make([]string, 0, batchSize)
+		for it.Rewind(); it.Valid(); it.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			item := it.Item()
+			if item.IsDeletedOrExpired() {
+				continue
+			}
+			keys = append(keys, string(item.KeyCopy(nil)))
+			if len(keys) == batchSize {
+				if err := fn(keys); err != nil {
+					return err
+				}
+				keys = make([]string, 0, batchSize)
+			}
+		}
+		if len(keys) > 0 {
+			return fn(keys)
+		}
+		return nil
+	})
+}
+
 func (d *Dedup) Close() {
 	d.badgerDB.Close()
 }
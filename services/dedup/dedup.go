@@ -3,12 +3,19 @@
 package dedup
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
 	"github.com/rudderlabs/rudder-go-kit/config"
 	"github.com/rudderlabs/rudder-go-kit/logger"
 	"github.com/rudderlabs/rudder-go-kit/stats"
 	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
 	"github.com/rudderlabs/rudder-server/services/dedup/badger"
 	"github.com/rudderlabs/rudder-server/services/dedup/keydb"
+	"github.com/rudderlabs/rudder-server/services/dedup/postgres"
+	"github.com/rudderlabs/rudder-server/services/dedup/redis"
 	"github.com/rudderlabs/rudder-server/services/dedup/types"
 )
 
@@ -19,19 +26,144 @@ func SingleKey(key string) BatchKey {
 	return types.BatchKey{Key: key}
 }
 
-// New creates a new deduplication service. The service needs to be closed after use.
-func New(conf *config.Config, stats stats.Stats, log logger.Logger) (Dedup, error) {
-	badgerDedup := badger.NewBadgerDB(conf, stats, badger.DefaultPath())
+const (
+	// BackendBadger keeps keys in a local badger database (default)
+	BackendBadger = "badger"
+	// BackendKeyDB keeps keys in keydb
+	BackendKeyDB = "keydb"
+	// BackendPostgres keeps keys in a postgres table, using the database provided through [WithDB]
+	BackendPostgres = "postgres"
+	// BackendRedis keeps keys in redis
+	BackendRedis = "redis"
+)
+
+// Option is a functional option for the deduplication service
+type Option func(*Options)
+
+// Options are the dependencies available to deduplication backends
+type Options struct {
+	// DB is the database used by the postgres backend
+	DB postgres.TxRunner
+}
+
+// WithDB provides the database used by the postgres backend, e.g. a jobsdb
+func WithDB(db postgres.TxRunner) Option {
+	return func(o *Options) {
+		o.DB = db
+	}
+}
+
+// BackendFactory creates a deduplication backend
+type BackendFactory func(conf *config.Config, stats stats.Stats, log logger.Logger, opts Options) (Dedup, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]BackendFactory{
+		BackendBadger: func(conf *config.Config, stats stats.Stats, _ logger.Logger, _ Options) (Dedup, error) {
+			return badger.NewBadgerDB(conf, stats, badger.DefaultPath()), nil
+		},
+		BackendKeyDB: func(conf *config.Config, stats stats.Stats, log logger.Logger, _ Options) (Dedup, error) {
+			return keydb.NewKeyDB(conf, stats, log)
+		},
+		BackendPostgres: func(conf *config.Config, stats stats.Stats, log logger.Logger, opts Options) (Dedup, error) {
+			if opts.DB == nil {
+				return nil, fmt.Errorf("postgres dedup: no database provided")
+			}
+			return postgres.NewPostgres(opts.DB, conf, stats, log), nil
+		},
+		BackendRedis: func(conf *config.Config, stats stats.Stats, log logger.Logger, _ Options) (Dedup, error) {
+			return redis.NewRedis(conf, stats, log)
+		},
+	}
+)
+
+// RegisterBackend makes a deduplication backend available for selection through the Dedup.backend configuration.
+// Registering a backend with the name of an existing one replaces it.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = factory
+}
 
-	if conf.GetBool("KeyDB.Dedup.Mirror.Enabled", false) {
+// Backends returns the names of all available deduplication backends
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewBackend creates a new deduplication service using the backend with the provided name. The service needs to be closed after use.
+func NewBackend(name string, conf *config.Config, stats stats.Stats, log logger.Logger, opts ...Option) (Dedup, error) {
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown dedup backend: %q", name)
+	}
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return factory(conf, stats, log, o)
+}
+
+// New creates a new deduplication service using the backend configured through Dedup.backend. The service needs to be closed after use.
+func New(conf *config.Config, stats stats.Stats, log logger.Logger, opts ...Option) (Dedup, error) {
+	backend := conf.GetString("Dedup.backend", BackendBadger)
+	d, err := NewBackend(backend, conf, stats, log, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// mirroring to keydb is pointless if keydb is already the backend
+	if backend != BackendKeyDB && conf.GetBool("KeyDB.Dedup.Mirror.Enabled", false) {
 		keydbDedup, err := keydb.NewKeyDB(conf, stats, log)
 		if err == nil {
-			return newMirror(badgerDedup, keydbDedup, conf, stats, log), nil
+			return newMirror(d, keydbDedup, conf, stats, log), nil
 		}
 		log.Errorn("Failed to create keydb dedup", obskit.Error(err))
 	}
 
-	return badgerDedup, nil
+	return d, nil
+}
+
+// Migrate copies all keys of the source deduplication service to the destination one, returning the number of keys copied.
+// The source needs to be able to scan its keys, e.g. badger, postgres or redis. Copied keys expire according to the
+// destination's deduplication window, regardless of the time they have left in the source.
+func Migrate(ctx context.Context, src, dest Dedup, batchSize int) (int, error) {
+	scanner, ok := src.(types.KeyScanner)
+	if !ok {
+		return 0, fmt.Errorf("source dedup backend does not support scanning keys")
+	}
+	var copied int
+	err := scanner.ScanKeys(ctx, batchSize, func(keys []string) error {
+		batchKeys := make([]BatchKey, len(keys))
+		for i, key := range keys {
+			batchKeys[i] = BatchKey{Index: i, Key: key}
+		}
+		allowed, err := dest.Allowed(batchKeys...)
+		if err != nil {
+			return fmt.Errorf("checking keys in destination: %w", err)
+		}
+		if len(allowed) == 0 {
+			return nil
+		}
+		newKeys := make([]string, 0, len(allowed))
+		for batchKey := range allowed {
+			newKeys = append(newKeys, batchKey.Key)
+		}
+		if err := dest.Commit(newKeys); err != nil {
+			return fmt.Errorf("committing keys in destination: %w", err)
+		}
+		copied += len(newKeys)
+		return nil
+	})
+	return copied, err
 }
 
 // Dedup is the interface for deduplication service
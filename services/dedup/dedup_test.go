@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ory/dockertest/v3"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
@@ -26,10 +27,15 @@ import (
 	"github.com/rudderlabs/rudder-go-kit/logger"
 	"github.com/rudderlabs/rudder-go-kit/stats"
 	"github.com/rudderlabs/rudder-go-kit/testhelper"
+	"github.com/rudderlabs/rudder-go-kit/testhelper/docker/resource/postgres"
+	"github.com/rudderlabs/rudder-go-kit/testhelper/docker/resource/redis"
 	"github.com/rudderlabs/rudder-go-kit/testhelper/rand"
 	"github.com/rudderlabs/rudder-server/services/dedup"
+	"github.com/rudderlabs/rudder-server/services/dedup/badger"
 	dkdb "github.com/rudderlabs/rudder-server/services/dedup/keydb"
+	dpostgres "github.com/rudderlabs/rudder-server/services/dedup/postgres"
 	"github.com/rudderlabs/rudder-server/services/dedup/types"
+	migrator "github.com/rudderlabs/rudder-server/services/sql-migrator"
 	"github.com/rudderlabs/rudder-server/utils/misc"
 )
 
@@ -452,3 +458,126 @@ func (m *mockedCloudStorage) UploadReader(_ context.Context, _ string, _ io.Read
 func (m *mockedCloudStorage) ListFilesWithPrefix(_ context.Context, _, _ string, _ int64) filemanager.ListSession {
 	return &mockedFilemanagerSession{}
 }
+
+func Test_Backends(t *testing.T) {
+	testBackend := func(t *testing.T, d dedup.Dedup) {
+		t.Helper()
+		keyA, keyB := dedup.SingleKey("a"), dedup.SingleKey("b")
+		found, err := d.Allowed(keyA, keyB)
+		require.NoError(t, err)
+		require.True(t, found[keyA])
+		require.True(t, found[keyB])
+
+		found, err = d.Allowed(keyA)
+		require.NoError(t, err)
+		require.False(t, found[keyA], "uncommitted key should not be allowed twice")
+
+		require.NoError(t, d.Commit([]string{"a"}))
+		require.Error(t, d.Commit([]string{"c"}), "committing a key which has not been allowed")
+
+		kvs := []types.BatchKey{
+			{Index: 0, Key: "a"},
+			{Index: 1, Key: "d"},
+			{Index: 2, Key: "d"},
+		}
+		found, err = d.Allowed(kvs...)
+		require.NoError(t, err)
+		require.False(t, found[kvs[0]])
+		require.True(t, found[kvs[1]])
+		require.False(t, found[kvs[2]])
+	}
+
+	t.Run("postgres", func(t *testing.T) {
+		pool, err := dockertest.NewPool("")
+		require.NoError(t, err)
+		postgresContainer, err := postgres.Setup(pool, t)
+		require.NoError(t, err)
+		require.NoError(t, (&migrator.Migrator{
+			Handle:          postgresContainer.DB,
+			MigrationsTable: "node_migrations",
+		}).Migrate("node"))
+
+		conf := config.New()
+		conf.Set("Dedup.backend", dedup.BackendPostgres)
+		d, err := dedup.New(conf, stats.NOP, logger.NOP, dedup.WithDB(dpostgres.NewTxRunner(postgresContainer.DB)))
+		require.NoError(t, err)
+		defer d.Close()
+		testBackend(t, d)
+
+		t.Run("expired keys", func(t *testing.T) {
+			conf := config.New()
+			conf.Set("Dedup.dedupWindow", "1s")
+			d := dpostgres.NewPostgres(dpostgres.NewTxRunner(postgresContainer.DB), conf, stats.NOP, logger.NOP)
+			defer d.Close()
+			key := dedup.SingleKey("expiring")
+			found, err := d.Allowed(key)
+			require.NoError(t, err)
+			require.True(t, found[key])
+			require.NoError(t, d.Commit([]string{key.Key}))
+			require.Eventually(t, func() bool {
+				found, err := d.Allowed(key)
+				require.NoError(t, err)
+				return found[key]
+			}, 5*time.Second, 100*time.Millisecond)
+		})
+
+		t.Run("no database", func(t *testing.T) {
+			_, err := dedup.New(conf, stats.NOP, logger.NOP)
+			require.Error(t, err)
+		})
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		pool, err := dockertest.NewPool("")
+		require.NoError(t, err)
+		redisContainer, err := redis.Setup(context.Background(), pool, t)
+		require.NoError(t, err)
+
+		conf := config.New()
+		conf.Set("Dedup.backend", dedup.BackendRedis)
+		conf.Set("Redis.Dedup.Addresses", redisContainer.Addr)
+		d, err := dedup.New(conf, stats.NOP, logger.NOP)
+		require.NoError(t, err)
+		defer d.Close()
+		testBackend(t, d)
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		conf := config.New()
+		conf.Set("Dedup.backend", "unknown")
+		_, err := dedup.New(conf, stats.NOP, logger.NOP)
+		require.Error(t, err)
+	})
+}
+
+func Test_Migrate(t *testing.T) {
+	misc.Init()
+	conf := config.New()
+	src := badger.NewBadgerDB(conf, stats.NOP, t.TempDir())
+	defer src.Close()
+	dest := badger.NewBadgerDB(conf, stats.NOP, t.TempDir())
+	defer dest.Close()
+
+	keys := lo.RepeatBy(25, func(i int) string { return "key" + strconv.Itoa(i) })
+	allowed, err := src.Allowed(lo.Map(keys, func(k string, i int) dedup.BatchKey { return dedup.BatchKey{Index: i, Key: k} })...)
+	require.NoError(t, err)
+	require.Len(t, allowed, len(keys))
+	require.NoError(t, src.Commit(keys))
+
+	// a key already present in the destination is not copied again
+	existing := dedup.SingleKey(keys[0])
+	_, err = dest.Allowed(existing)
+	require.NoError(t, err)
+	require.NoError(t, dest.Commit([]string{existing.Key}))
+
+	copied, err := dedup.Migrate(context.Background(), src, dest, 10)
+	require.NoError(t, err)
+	require.Equal(t, len(keys)-1, copied)
+
+	allowed, err = dest.Allowed(lo.Map(keys, func(k string, i int) dedup.BatchKey { return dedup.BatchKey{Index: i, Key: k} })...)
+	require.NoError(t, err)
+	require.Empty(t, allowed, "all keys should be present in the destination")
+
+	_, err = dedup.Migrate(context.Background(), &dkdb.Dedup{}, dest, 10)
+	require.Error(t, err, "keydb does not support scanning keys")
+}
@@ -0,0 +1,113 @@
+// Package keystore provides a deduplication service on top of any key store supporting expiring keys.
+package keystore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/rudder-server/services/dedup/types"
+)
+
+// Store is a store of keys which expire after the deduplication window
+type Store interface {
+	// Get returns a map containing all keys which are present in the store
+	Get(keys []string) (map[string]bool, error)
+	// Set adds the keys to the store
+	Set(keys []string) error
+	// Close closes the store
+	Close()
+}
+
+// New creates a new deduplication service backed by the provided store
+func New(name string, store Store) *Dedup {
+	return &Dedup{
+		name:        name,
+		store:       store,
+		uncommitted: make(map[string]struct{}),
+	}
+}
+
+// Dedup is a deduplication service backed by a [Store].
+// Allowed keys are kept in memory until they get committed, so that they are not allowed twice by the same service.
+type Dedup struct {
+	name          string
+	store         Store
+	uncommittedMu sync.RWMutex
+	uncommitted   map[string]struct{}
+}
+
+func (d *Dedup) Allowed(batchKeys ...types.BatchKey) (map[types.BatchKey]bool, error) {
+	result := make(map[types.BatchKey]bool, len(batchKeys))  // keys encountered for the first time
+	seenInBatch := make(map[string]struct{}, len(batchKeys)) // keys already seen in the batch while iterating
+
+	// figure out which keys need to be checked against the store
+	batchKeysToCheck := make([]types.BatchKey, 0, len(batchKeys))
+	d.uncommittedMu.RLock()
+	for _, batchKey := range batchKeys {
+		if _, seen := seenInBatch[batchKey.Key]; seen {
+			continue
+		}
+		seenInBatch[batchKey.Key] = struct{}{}
+		if _, uncommitted := d.uncommitted[batchKey.Key]; uncommitted {
+			continue
+		}
+		batchKeysToCheck = append(batchKeysToCheck, batchKey)
+	}
+	d.uncommittedMu.RUnlock()
+
+	if len(batchKeysToCheck) > 0 {
+		seenInStore, err := d.store.Get(lo.Map(batchKeysToCheck, func(bk types.BatchKey, _ int) string { return bk.Key }))
+		if err != nil {
+			return nil, fmt.Errorf("getting keys from %s: %w", d.name, err)
+		}
+		d.uncommittedMu.Lock()
+		defer d.uncommittedMu.Unlock()
+		for _, batchKey := range batchKeysToCheck {
+			if !seenInStore[batchKey.Key] {
+				if _, race := d.uncommitted[batchKey.Key]; !race { // if another goroutine managed to set this key, we should skip it
+					result[batchKey] = true
+					d.uncommitted[batchKey.Key] = struct{}{}
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (d *Dedup) Commit(keys []string) error {
+	d.uncommittedMu.RLock()
+	for _, key := range keys {
+		if _, ok := d.uncommitted[key]; !ok {
+			d.uncommittedMu.RUnlock()
+			return fmt.Errorf("key %v has not been previously set", key)
+		}
+	}
+	d.uncommittedMu.RUnlock()
+
+	if err := d.store.Set(keys); err != nil {
+		return fmt.Errorf("setting keys in %s: %w", d.name, err)
+	}
+
+	d.uncommittedMu.Lock()
+	defer d.uncommittedMu.Unlock()
+	for _, key := range keys {
+		delete(d.uncommitted, key)
+	}
+	return nil
+}
+
+// ScanKeys scans the keys of the underlying store, if it is a [types.KeyScanner]
+func (d *Dedup) ScanKeys(ctx context.Context, batchSize int, fn func(keys []string) error) error {
+	scanner, ok := d.store.(types.KeyScanner)
+	if !ok {
+		return fmt.Errorf("%s does not support scanning keys", d.name)
+	}
+	return scanner.ScanKeys(ctx, batchSize, fn)
+}
+
+func (d *Dedup) Close() {
+	d.store.Close()
+}
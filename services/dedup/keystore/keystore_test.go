@@ -0,0 +1,92 @@
+package keystore_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/services/dedup/keystore"
+	"github.com/rudderlabs/rudder-server/services/dedup/types"
+)
+
+type memoryStore struct {
+	mu     sync.Mutex
+	keys   map[string]struct{}
+	getErr error
+}
+
+func (s *memoryStore) Get(keys []string) (map[string]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	res := make(map[string]bool)
+	for _, key := range keys {
+		if _, ok := s.keys[key]; ok {
+			res[key] = true
+		}
+	}
+	return res, nil
+}
+
+func (s *memoryStore) Set(keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		s.keys[key] = struct{}{}
+	}
+	return nil
+}
+
+func (s *memoryStore) Close() {}
+
+func TestKeyStoreDedup(t *testing.T) {
+	store := &memoryStore{keys: map[string]struct{}{"committed": {}}}
+	d := keystore.New("memory", store)
+	defer d.Close()
+
+	t.Run("allowed", func(t *testing.T) {
+		kvs := []types.BatchKey{
+			{Index: 0, Key: "a"},
+			{Index: 1, Key: "a"},
+			{Index: 2, Key: "committed"},
+			{Index: 3, Key: "b"},
+		}
+		allowed, err := d.Allowed(kvs...)
+		require.NoError(t, err)
+		require.Equal(t, map[types.BatchKey]bool{kvs[0]: true, kvs[3]: true}, allowed)
+
+		allowed, err = d.Allowed(kvs[0])
+		require.NoError(t, err)
+		require.Empty(t, allowed, "uncommitted keys should not be allowed twice")
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		require.NoError(t, d.Commit([]string{"a", "b"}))
+		require.Contains(t, store.keys, "a")
+		require.Contains(t, store.keys, "b")
+
+		allowed, err := d.Allowed(types.BatchKey{Key: "a"})
+		require.NoError(t, err)
+		require.Empty(t, allowed)
+	})
+
+	t.Run("committing a key which has not been allowed", func(t *testing.T) {
+		require.Error(t, d.Commit([]string{"c"}))
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		store.getErr = errors.New("store error")
+		defer func() { store.getErr = nil }()
+		_, err := d.Allowed(types.BatchKey{Key: "d"})
+		require.ErrorContains(t, err, "getting keys from memory")
+	})
+
+	t.Run("scanning keys is not supported by the store", func(t *testing.T) {
+		require.Error(t, d.ScanKeys(context.Background(), 10, func([]string) error { return nil }))
+	})
+}
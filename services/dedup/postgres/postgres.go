@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/rruntime"
+	"github.com/rudderlabs/rudder-server/services/dedup/keystore"
+	"github.com/rudderlabs/rudder-server/utils/tx"
+)
+
+const tableName = "dedup_keys"
+
+// TxRunner runs functions inside database transactions, e.g. a jobsdb
+type TxRunner interface {
+	WithTx(func(tx *tx.Tx) error) error
+}
+
+// Postgres is a key store keeping deduplication keys in a postgres table, so that they can be shared among replicas.
+// Its table is created by the node migrations.
+type Postgres struct {
+	db     TxRunner
+	window config.ValueLoader[time.Duration]
+	logger logger.Logger
+	once   sync.Once
+
+	cleanupInterval config.ValueLoader[time.Duration]
+	wg              sync.WaitGroup
+	bgCtx           context.Context
+	cancel          context.CancelFunc
+
+	stats struct {
+		getTimer     stats.Timer
+		setTimer     stats.Timer
+		expiredCount stats.Counter
+	}
+}
+
+// NewPostgres creates a new postgres backed deduplication service, using the database of the provided transaction runner
+func NewPostgres(db TxRunner, conf *config.Config, stat stats.Stats, log logger.Logger) *keystore.Dedup {
+	bgCtx, cancel := context.WithCancel(context.Background())
+	p := &Postgres{
+		db:              db,
+		window:          conf.GetReloadableDurationVar(3600, time.Second, "Postgres.Dedup.dedupWindow", "Dedup.dedupWindow", "Dedup.dedupWindowInS"),
+		cleanupInterval: conf.GetReloadableDurationVar(5, time.Minute, "Postgres.Dedup.cleanupInterval"),
+		logger:          log.Child("postgres"),
+		bgCtx:           bgCtx,
+		cancel:          cancel,
+	}
+	p.stats.getTimer = stat.NewTaggedStat("dedup_get_duration_seconds", stats.TimerType, stats.Tags{"mode": "postgres"})
+	p.stats.setTimer = stat.NewTaggedStat("dedup_set_duration_seconds", stats.TimerType, stats.Tags{"mode": "postgres"})
+	p.stats.expiredCount = stat.NewTaggedStat("dedup_expired_keys_count", stats.CountType, stats.Tags{"mode": "postgres"})
+	return keystore.New("postgres", p)
+}
+
+// startCleanup starts the loop deleting expired keys, the first time the store is used
+func (p *Postgres) startCleanup() {
+	p.once.Do(func() {
+		p.wg.Add(1)
+		rruntime.Go(func() {
+			defer p.wg.Done()
+			p.cleanupLoop()
+		})
+	})
+}
+
+func (p *Postgres) Get(keys []string) (map[string]bool, error) {
+	p.startCleanup()
+	defer p.stats.getTimer.RecordDuration()()
+	results := make(map[string]bool, len(keys))
+	err := p.db.WithTx(func(tx *tx.Tx) error {
+		rows, err := tx.Query(`SELECT key FROM `+tableName+` WHERE key = ANY($1) AND expire_at > NOW()`, pq.Array(keys))
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rows.Close() }()
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				return err
+			}
+			results[key] = true
+		}
+		return rows.Err()
+	})
+	return results, err
+}
+
+func (p *Postgres) Set(keys []string) error {
+	p.startCleanup()
+	defer p.stats.setTimer.RecordDuration()()
+	return p.db.WithTx(func(tx *tx.Tx) error {
+		_, err := tx.Exec(`INSERT INTO `+tableName+` (key, expire_at)
+			SELECT DISTINCT unnest($1::TEXT[]), NOW() + $2::BIGINT * INTERVAL '1 millisecond'
+			ON CONFLICT (key) DO UPDATE SET expire_at = EXCLUDED.expire_at`,
+			pq.Array(keys), p.window.Load().Milliseconds())
+		return err
+	})
+}
+
+// ScanKeys scans all non-expired keys in batches
+func (p *Postgres) ScanKeys(ctx context.Context, batchSize int, fn func(keys []string) error) error {
+	p.startCleanup()
+	var lastKey string
+	for {
+		var keys []string
+		if err := p.db.WithTx(func(tx *tx.Tx) error {
+			rows, err := tx.QueryContext(ctx, `SELECT key FROM `+tableName+` WHERE key > $1 AND expire_at > NOW() ORDER BY key LIMIT $2`, lastKey, batchSize)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = rows.Close() }()
+			for rows.Next() {
+				var key string
+				if err := rows.Scan(&key); err != nil {
+					return err
+				}
+				keys = append(keys, key)
+			}
+			return rows.Err()
+		}); err != nil {
+			return fmt.Errorf("scanning keys: %w", err)
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := fn(keys); err != nil {
+			return err
+		}
+		lastKey = keys[len(keys)-1]
+	}
+}
+
+func (p *Postgres) Close() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+// cleanupLoop periodically deletes expired keys
+func (p *Postgres) cleanupLoop() {
+	for {
+		select {
+		case <-p.bgCtx.Done():
+			return
+		case <-time.After(p.cleanupInterval.Load()):
+		}
+		err := p.db.WithTx(func(tx *tx.Tx) error {
+			res, err := tx.ExecContext(p.bgCtx, `DELETE FROM `+tableName+` WHERE expire_at <= NOW()`)
+			if err != nil {
+				return err
+			}
+			deleted, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			p.stats.expiredCount.Count(int(deleted))
+			return nil
+		})
+		if err != nil && p.bgCtx.Err() == nil {
+			p.logger.Errorn("Error while deleting expired dedup keys", obskit.Error(err))
+		}
+	}
+}
+
+// NewTxRunner returns a [TxRunner] running transactions against the provided database, for when no jobsdb is available
+func NewTxRunner(db *sql.DB) TxRunner {
+	return &sqlTxRunner{db: db}
+}
+
+type sqlTxRunner struct {
+	db *sql.DB
+}
+
+func (r *sqlTxRunner) WithTx(f func(tx *tx.Tx) error) error {
+	sqlTx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	t := &tx.Tx{Tx: sqlTx}
+	if err := f(t); err != nil {
+		_ = t.Rollback()
+		return err
+	}
+	return t.Commit()
+}
@@ -0,0 +1,134 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+
+	"github.com/rudderlabs/rudder-server/services/dedup/keystore"
+)
+
+// Redis is a key store keeping deduplication keys in redis, relying on redis' key expiration for the deduplication window
+type Redis struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	window    config.ValueLoader[time.Duration]
+	timeout   config.ValueLoader[time.Duration]
+	logger    logger.Logger
+
+	stats struct {
+		getTimer stats.Timer
+		setTimer stats.Timer
+	}
+}
+
+// NewRedis creates a new redis backed deduplication service. Multiple comma separated addresses can be provided for redis cluster.
+func NewRedis(conf *config.Config, stat stats.Stats, log logger.Logger) (*keystore.Dedup, error) {
+	addresses := conf.GetString("Redis.Dedup.Addresses", "")
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("redis dedup: no addresses provided")
+	}
+	client := redis.NewUniversalClient(&redis.UniversalOptions{
+		Addrs:    strings.Split(addresses, ","),
+		Username: conf.GetString("Redis.Dedup.Username", ""),
+		Password: conf.GetString("Redis.Dedup.Password", ""),
+		DB:       conf.GetInt("Redis.Dedup.DB", 0),
+	})
+	r := &Redis{
+		client:    client,
+		keyPrefix: conf.GetString("Redis.Dedup.KeyPrefix", "dedup:"),
+		window:    conf.GetReloadableDurationVar(3600, time.Second, "Redis.Dedup.dedupWindow", "Dedup.dedupWindow", "Dedup.dedupWindowInS"),
+		timeout:   conf.GetReloadableDurationVar(10, time.Second, "Redis.Dedup.Timeout"),
+		logger:    log.Child("redis"),
+	}
+	r.stats.getTimer = stat.NewTaggedStat("dedup_get_duration_seconds", stats.TimerType, stats.Tags{"mode": "redis"})
+	r.stats.setTimer = stat.NewTaggedStat("dedup_set_duration_seconds", stats.TimerType, stats.Tags{"mode": "redis"})
+	return keystore.New("redis", r), nil
+}
+
+func (r *Redis) Get(keys []string) (map[string]bool, error) {
+	defer r.stats.getTimer.RecordDuration()()
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout.Load())
+	defer cancel()
+	// using a pipeline of EXISTS commands instead of MGET, since keys can belong to different slots in cluster mode
+	cmds := make([]*redis.IntCmd, len(keys))
+	if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Exists(ctx, r.keyPrefix+key)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	results := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		if cmds[i].Val() > 0 {
+			results[key] = true
+		}
+	}
+	return results, nil
+}
+
+func (r *Redis) Set(keys []string) error {
+	defer r.stats.setTimer.RecordDuration()()
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout.Load())
+	defer cancel()
+	window := r.window.Load()
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Set(ctx, r.keyPrefix+key, 1, window)
+		}
+		return nil
+	})
+	return err
+}
+
+// ScanKeys scans all keys with the configured prefix in batches
+func (r *Redis) ScanKeys(ctx context.Context, batchSize int, fn func(keys []string) error) error {
+	scan := func(ctx context.Context, client redis.Cmdable) error {
+		iter := client.Scan(ctx, 0, r.keyPrefix+"*", int64(batchSize)).Iterator()
+		keys := make([]string, 0, batchSize)
+		for iter.Next(ctx) {
+			keys = append(keys, strings.TrimPrefix(iter.Val(), r.keyPrefix))
+			if len(keys) == batchSize {
+				if err := fn(keys); err != nil {
+					return err
+				}
+				keys = make([]string, 0, batchSize)
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("scanning keys: %w", err)
+		}
+		if len(keys) > 0 {
+			return fn(keys)
+		}
+		return nil
+	}
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		// masters are scanned concurrently, thus fn calls need to be serialized
+		var fnMu sync.Mutex
+		serializedFn := fn
+		fn = func(keys []string) error {
+			fnMu.Lock()
+			defer fnMu.Unlock()
+			return serializedFn(keys)
+		}
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return scan(ctx, client)
+		})
+	}
+	return scan(ctx, r.client)
+}
+
+func (r *Redis) Close() {
+	_ = r.client.Close()
+}
@@ -1,5 +1,7 @@
 package types
 
+import "context"
+
 // BatchKey represents a key in a batch
 type BatchKey struct {
 	// Index is the index of the key in the batch (used for discriminating between keys with the same value)
@@ -7,3 +9,9 @@ type BatchKey struct {
 	// Key is the value of the key
 	Key string
 }
+
+// KeyScanner is implemented by deduplication backends which are able to list the keys they contain, e.g. for copying them to another backend
+type KeyScanner interface {
+	// ScanKeys calls fn with batches of at most batchSize keys, until all non-expired keys have been scanned
+	ScanKeys(ctx context.Context, batchSize int, fn func(keys []string) error) error
+}
@@ -0,0 +1,322 @@
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/services/rmetrics"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+// TablePrefix is the table prefix of the dead-letter queue's jobsdb
+const TablePrefix = "dlq"
+
+// parametersKey is the key of the job parameters holding the dead-letter queue's information about the aborted job
+const parametersKey = "dlq"
+
+// abortParameters are added to the parameters of router jobs when they get aborted, thus they are removed when requeueing
+var abortParameters = []string{parametersKey, "stage", "reason"}
+
+// ErrUnknownJobsDB is returned when a filter refers to a jobsdb which is not a requeue target
+var ErrUnknownJobsDB = errors.New("unknown jobsdb")
+
+// Enabled returns true if aborted router and batch router jobs should be copied to the dead-letter queue
+func Enabled(conf *config.Config) bool {
+	return conf.GetBoolVar(false, "DLQ.enabled")
+}
+
+// NewJobs creates dead-letter queue jobs for the aborted jobs of the jobsdb with the provided table prefix, e.g. rt or batch_rt.
+// The failure reason is taken from the status of each job, jobs without an aborted status are ignored.
+func NewJobs(tablePrefix string, jobs []*jobsdb.JobT, statusList []*jobsdb.JobStatusT) []*jobsdb.JobT {
+	statuses := make(map[int64]*jobsdb.JobStatusT, len(statusList))
+	for _, status := range statusList {
+		if status.JobState == jobsdb.Aborted.State {
+			statuses[status.JobID] = status
+		}
+	}
+	dlqJobs := make([]*jobsdb.JobT, 0, len(jobs))
+	for _, job := range jobs {
+		status, ok := statuses[job.JobID]
+		if !ok {
+			continue
+		}
+		errorResponse := status.ErrorResponse
+		if !gjson.ValidBytes(errorResponse) {
+			errorResponse = misc.UpdateJSONWithNewKeyVal([]byte(`{}`), "response", string(errorResponse))
+		}
+		jobParameters, err := sjson.SetBytes(job.Parameters, parametersKey, parameters{
+			JobsDB:        tablePrefix,
+			JobID:         job.JobID,
+			ErrorCode:     status.ErrorCode,
+			ErrorResponse: errorResponse,
+			AttemptNum:    status.AttemptNum,
+			AbortedAt:     status.ExecTime,
+		})
+		if err != nil {
+			jobParameters = job.Parameters
+		}
+		dlqJobs = append(dlqJobs, &jobsdb.JobT{
+			UUID:         uuid.New(),
+			UserID:       job.UserID,
+			CustomVal:    job.CustomVal,
+			EventCount:   job.EventCount,
+			EventPayload: job.EventPayload,
+			Parameters:   jobParameters,
+			WorkspaceId:  job.WorkspaceId,
+		})
+	}
+	return dlqJobs
+}
+
+// parameters are the dead-letter queue's information about an aborted job, stored along with its parameters
+type parameters struct {
+	JobsDB        string          `json:"jobsdb"`
+	JobID         int64           `json:"job_id"`
+	ErrorCode     string          `json:"error_code"`
+	ErrorResponse json.RawMessage `json:"error_response"`
+	AttemptNum    int             `json:"attempt_num"`
+	AbortedAt     time.Time       `json:"aborted_at"`
+}
+
+// Entry is a job in the dead-letter queue
+type Entry struct {
+	JobID         int64           `json:"jobId"`
+	JobsDB        string          `json:"jobsdb"`
+	OriginalJobID int64           `json:"originalJobId"`
+	WorkspaceID   string          `json:"workspaceId"`
+	SourceID      string          `json:"sourceId"`
+	DestinationID string          `json:"destinationId"`
+	DestType      string          `json:"destType"`
+	UserID        string          `json:"userId"`
+	ErrorCode     string          `json:"errorCode"`
+	ErrorResponse json.RawMessage `json:"errorResponse"`
+	AttemptNum    int             `json:"attemptNum"`
+	AbortedAt     time.Time       `json:"abortedAt"`
+	EventPayload  json.RawMessage `json:"eventPayload,omitempty"`
+}
+
+// Filter selects jobs of the dead-letter queue. Empty fields are ignored.
+type Filter struct {
+	JobIDs        []int64
+	JobsDB        string
+	WorkspaceID   string
+	DestinationID string
+	DestType      string
+	ErrorCodes    []string
+	// From and To limit the time range in which jobs were aborted, From is inclusive and To is exclusive
+	From, To time.Time
+	// AfterJobID only selects jobs with a greater job id, for paginating
+	AfterJobID int64
+}
+
+// Service lists the jobs of the dead-letter queue and requeues them back to the jobsdb they have been aborted in
+type Service struct {
+	db            jobsdb.JobsDB
+	targets       map[string]jobsdb.JobsDB
+	pendingEvents rmetrics.PendingEventsRegistry
+	log           logger.Logger
+
+	requeueMu sync.Mutex // prevents concurrent requeues of the same jobs
+
+	config struct {
+		scanBatchSize config.ValueLoader[int]
+		maxLimit      config.ValueLoader[int]
+	}
+}
+
+// NewService creates a new dead-letter queue service. Targets are the jobsdbs jobs can be requeued to, keyed by their table prefix.
+func NewService(db jobsdb.JobsDB, targets []jobsdb.JobsDB, pendingEvents rmetrics.PendingEventsRegistry, conf *config.Config, log logger.Logger) *Service {
+	s := &Service{
+		db: db,
+		targets: lo.SliceToMap(targets, func(target jobsdb.JobsDB) (string, jobsdb.JobsDB) {
+			return target.Identifier(), target
+		}),
+		pendingEvents: pendingEvents,
+		log:           log,
+	}
+	s.config.scanBatchSize = conf.GetReloadableIntVar(1000, 1, "DLQ.scanBatchSize")
+	s.config.maxLimit = conf.GetReloadableIntVar(1000, 1, "DLQ.maxLimit")
+	return s
+}
+
+// List returns up to limit jobs of the dead-letter queue matching the filter, ordered by job id
+func (s *Service) List(ctx context.Context, filter Filter, limit int) ([]Entry, error) {
+	var entries []Entry
+	err := s.scan(ctx, filter, limit, func(job *jobsdb.JobT, p *parameters) {
+		entries = append(entries, Entry{
+			JobID:         job.JobID,
+			JobsDB:        p.JobsDB,
+			OriginalJobID: p.JobID,
+			WorkspaceID:   job.WorkspaceId,
+			SourceID:      gjson.GetBytes(job.Parameters, "source_id").String(),
+			DestinationID: gjson.GetBytes(job.Parameters, "destination_id").String(),
+			DestType:      job.CustomVal,
+			UserID:        job.UserID,
+			ErrorCode:     p.ErrorCode,
+			ErrorResponse: p.ErrorResponse,
+			AttemptNum:    p.AttemptNum,
+			AbortedAt:     p.AbortedAt,
+			EventPayload:  job.EventPayload,
+		})
+	})
+	return entries, err
+}
+
+// Requeue stores up to limit jobs of the dead-letter queue matching the filter back to the jobsdb they have been aborted in,
+// and removes them from the dead-letter queue. It returns the number of jobs requeued.
+func (s *Service) Requeue(ctx context.Context, filter Filter, limit int) (int, error) {
+	if filter.JobsDB != "" {
+		if _, ok := s.targets[filter.JobsDB]; !ok {
+			return 0, fmt.Errorf("%w: %q", ErrUnknownJobsDB, filter.JobsDB)
+		}
+	}
+	s.requeueMu.Lock()
+	defer s.requeueMu.Unlock()
+
+	jobsByTarget := make(map[string][]*jobsdb.JobT)
+	var statusList []*jobsdb.JobStatusT
+	if err := s.scan(ctx, filter, limit, func(job *jobsdb.JobT, p *parameters) {
+		if _, ok := s.targets[p.JobsDB]; !ok {
+			s.log.Warnn("Skipping dead-letter queue job of unknown jobsdb",
+				logger.NewIntField("jobId", job.JobID),
+				logger.NewStringField("jobsdb", p.JobsDB),
+			)
+			return
+		}
+		jobParameters := job.Parameters
+		for _, key := range abortParameters {
+			jobParameters, _ = sjson.DeleteBytes(jobParameters, key)
+		}
+		jobsByTarget[p.JobsDB] = append(jobsByTarget[p.JobsDB], &jobsdb.JobT{
+			UUID:         uuid.New(),
+			UserID:       job.UserID,
+			CustomVal:    job.CustomVal,
+			EventCount:   job.EventCount,
+			EventPayload: job.EventPayload,
+			Parameters:   jobParameters,
+			WorkspaceId:  job.WorkspaceId,
+		})
+		statusList = append(statusList, &jobsdb.JobStatusT{
+			JobID:         job.JobID,
+			JobState:      jobsdb.Succeeded.State,
+			AttemptNum:    1,
+			ExecTime:      time.Now(),
+			RetryTime:     time.Now(),
+			ErrorCode:     "200",
+			ErrorResponse: []byte(`{"requeued":true}`),
+			Parameters:    []byte(`{}`),
+			JobParameters: job.Parameters,
+			WorkspaceId:   job.WorkspaceId,
+		})
+	}); err != nil {
+		return 0, err
+	}
+	if len(statusList) == 0 {
+		return 0, nil
+	}
+
+	// storing the requeued jobs and marking them as succeeded in the dead-letter queue atomically
+	if err := s.db.WithUpdateSafeTx(ctx, func(tx jobsdb.UpdateSafeTx) error {
+		for tablePrefix, jobs := range jobsByTarget {
+			target := s.targets[tablePrefix]
+			if err := target.WithStoreSafeTxFromTx(ctx, tx.Tx(), func(tx jobsdb.StoreSafeTx) error {
+				return target.StoreInTx(ctx, tx, jobs)
+			}); err != nil {
+				return fmt.Errorf("storing %d jobs to %s: %w", len(jobs), tablePrefix, err)
+			}
+		}
+		if err := s.db.UpdateJobStatusInTx(ctx, tx, statusList, nil, nil); err != nil {
+			return fmt.Errorf("updating dead-letter queue job statuses: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	if s.pendingEvents != nil {
+		for tablePrefix, jobs := range jobsByTarget {
+			for _, job := range jobs {
+				s.pendingEvents.IncreasePendingEvents(tablePrefix, job.WorkspaceId, job.CustomVal, 1)
+			}
+		}
+	}
+	return len(statusList), nil
+}
+
+// scan calls fn for up to limit jobs of the dead-letter queue matching the filter, ordered by job id
+func (s *Service) scan(ctx context.Context, filter Filter, limit int, fn func(*jobsdb.JobT, *parameters)) error {
+	limit = s.normalizeLimit(limit)
+	params := jobsdb.GetQueryParams{
+		WorkspaceID: filter.WorkspaceID,
+		JobIDs:      filter.JobIDs,
+	}
+	if filter.DestType != "" {
+		params.CustomValFilters = []string{filter.DestType}
+	}
+	if filter.DestinationID != "" {
+		params.ParameterFilters = []jobsdb.ParameterFilterT{{Name: "destination_id", Value: filter.DestinationID}}
+	}
+	if filter.JobsDB != "" {
+		params.ParameterPathFilters = append(params.ParameterPathFilters, jobsdb.ParameterPathFilterT{Path: []string{parametersKey, "jobsdb"}, Values: []string{filter.JobsDB}})
+	}
+	if len(filter.ErrorCodes) > 0 {
+		params.ParameterPathFilters = append(params.ParameterPathFilters, jobsdb.ParameterPathFilterT{Path: []string{parametersKey, "error_code"}, Values: filter.ErrorCodes})
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		params.ParameterPathFilters = append(params.ParameterPathFilters, jobsdb.ParameterPathFilterT{Path: []string{parametersKey, "aborted_at"}, From: filter.From, To: filter.To})
+	}
+
+	// starting right before the first job which can match, skipping the datasets of older jobs
+	afterJobID := filter.AfterJobID
+	if len(filter.JobIDs) > 0 {
+		afterJobID = max(afterJobID, slices.Min(filter.JobIDs)-1)
+	}
+	var more jobsdb.MoreToken
+	if afterJobID > 0 {
+		more = jobsdb.NewMoreToken(afterJobID)
+	}
+	var found int
+	for found < limit {
+		params.JobsLimit = min(s.config.scanBatchSize.Load(), limit-found)
+		res, err := s.db.GetToProcess(ctx, params, more)
+		if err != nil {
+			return fmt.Errorf("getting dead-letter queue jobs: %w", err)
+		}
+		if len(res.Jobs) == 0 {
+			return nil
+		}
+		for _, job := range res.Jobs {
+			var p parameters
+			if err := jsonrs.Unmarshal([]byte(gjson.GetBytes(job.Parameters, parametersKey).Raw), &p); err != nil {
+				s.log.Warnn("Skipping dead-letter queue job with invalid parameters", logger.NewIntField("jobId", job.JobID))
+				continue
+			}
+			fn(job, &p)
+			found++
+		}
+		more = res.More
+	}
+	return nil
+}
+
+// normalizeLimit caps the limit to DLQ.maxLimit, which is also used when no limit is provided
+func (s *Service) normalizeLimit(limit int) int {
+	if maxLimit := s.config.maxLimit.Load(); limit <= 0 || limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
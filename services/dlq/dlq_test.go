@@ -0,0 +1,241 @@
+package dlq_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/testhelper/docker/resource/postgres"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/services/dlq"
+)
+
+func TestNewJobs(t *testing.T) {
+	abortedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	jobs := []*jobsdb.JobT{
+		{JobID: 1, UserID: "user-1", CustomVal: "WEBHOOK", EventCount: 1, EventPayload: []byte(`{"event":"a"}`), Parameters: []byte(`{"destination_id":"dest-1","reason":"too many attempts"}`), WorkspaceId: "ws-1"},
+		{JobID: 2, UserID: "user-2", CustomVal: "WEBHOOK", EventCount: 1, EventPayload: []byte(`{"event":"b"}`), Parameters: []byte(`{"destination_id":"dest-1"}`), WorkspaceId: "ws-1"},
+	}
+	statusList := []*jobsdb.JobStatusT{
+		{JobID: 1, JobState: jobsdb.Aborted.State, AttemptNum: 3, ErrorCode: "400", ErrorResponse: []byte(`{"response":"bad request"}`), ExecTime: abortedAt},
+		{JobID: 2, JobState: jobsdb.Succeeded.State, AttemptNum: 1, ErrorCode: "200"},
+	}
+
+	dlqJobs := dlq.NewJobs("rt", jobs, statusList)
+	require.Len(t, dlqJobs, 1, "only aborted jobs should be copied")
+	job := dlqJobs[0]
+	require.NotEqual(t, uuid.Nil, job.UUID)
+	require.Equal(t, "user-1", job.UserID)
+	require.Equal(t, "WEBHOOK", job.CustomVal)
+	require.Equal(t, "ws-1", job.WorkspaceId)
+	require.JSONEq(t, `{"event":"a"}`, string(job.EventPayload))
+	require.Equal(t, "dest-1", gjson.GetBytes(job.Parameters, "destination_id").String())
+	require.Equal(t, "rt", gjson.GetBytes(job.Parameters, "dlq.jobsdb").String())
+	require.EqualValues(t, 1, gjson.GetBytes(job.Parameters, "dlq.job_id").Int())
+	require.Equal(t, "400", gjson.GetBytes(job.Parameters, "dlq.error_code").String())
+	require.JSONEq(t, `{"response":"bad request"}`, gjson.GetBytes(job.Parameters, "dlq.error_response").Raw)
+	require.EqualValues(t, 3, gjson.GetBytes(job.Parameters, "dlq.attempt_num").Int())
+	require.True(t, abortedAt.Equal(gjson.GetBytes(job.Parameters, "dlq.aborted_at").Time()))
+
+	t.Run("invalid error response", func(t *testing.T) {
+		statusList := []*jobsdb.JobStatusT{{JobID: 2, JobState: jobsdb.Aborted.State, ErrorResponse: []byte(`not json`)}}
+		dlqJobs := dlq.NewJobs("batch_rt", jobs, statusList)
+		require.Len(t, dlqJobs, 1)
+		require.Equal(t, "not json", gjson.GetBytes(dlqJobs[0].Parameters, "dlq.error_response.response").String())
+	})
+}
+
+func TestService(t *testing.T) {
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err)
+	postgresContainer, err := postgres.Setup(pool, t)
+	require.NoError(t, err)
+
+	c := config.New()
+	c.Set("DB.name", postgresContainer.Database)
+	c.Set("DB.host", postgresContainer.Host)
+	c.Set("DB.port", postgresContainer.Port)
+	c.Set("DB.user", postgresContainer.User)
+	c.Set("DB.password", postgresContainer.Password)
+	c.Set("DLQ.scanBatchSize", 2)
+
+	newJobsDB := func(prefix string) *jobsdb.Handle {
+		db := jobsdb.NewForReadWrite(prefix, jobsdb.WithConfig(c), jobsdb.WithStats(stats.NOP))
+		require.NoError(t, db.Start())
+		t.Cleanup(db.TearDown)
+		return db
+	}
+	dlqDB := newJobsDB(dlq.TablePrefix)
+	rtDB := newJobsDB("rt")
+	brtDB := newJobsDB("batch_rt")
+
+	ctx := context.Background()
+	abortedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	newAbortedJob := func(jobID int64, destinationID, errorCode string, abortedAt time.Time) (*jobsdb.JobT, *jobsdb.JobStatusT) {
+		return &jobsdb.JobT{
+			JobID:        jobID,
+			UUID:         uuid.New(),
+			UserID:       "user-" + strconv.FormatInt(jobID, 10),
+			CustomVal:    "WEBHOOK",
+			EventCount:   1,
+			EventPayload: []byte(`{"event":"test"}`),
+			Parameters:   []byte(`{"source_id":"source-1","destination_id":"` + destinationID + `","stage":"router","reason":"retry limit reached"}`),
+			WorkspaceId:  "ws-1",
+		}, &jobsdb.JobStatusT{
+			JobID:         jobID,
+			JobState:      jobsdb.Aborted.State,
+			AttemptNum:    3,
+			ErrorCode:     errorCode,
+			ErrorResponse: []byte(`{"response":"failed"}`),
+			ExecTime:      abortedAt,
+		}
+	}
+	var jobs []*jobsdb.JobT
+	var statusList []*jobsdb.JobStatusT
+	for i, tc := range []struct {
+		destinationID string
+		errorCode     string
+		abortedAt     time.Time
+	}{
+		{"dest-1", "400", abortedAt},
+		{"dest-1", "500", abortedAt.Add(time.Hour)},
+		{"dest-2", "400", abortedAt.Add(2 * time.Hour)},
+		{"dest-1", "400", abortedAt.Add(3 * time.Hour)},
+		{"dest-1", "400", abortedAt.Add(4 * time.Hour)},
+	} {
+		job, status := newAbortedJob(int64(100+i), tc.destinationID, tc.errorCode, tc.abortedAt)
+		jobs = append(jobs, job)
+		statusList = append(statusList, status)
+	}
+	require.NoError(t, dlqDB.Store(ctx, dlq.NewJobs("rt", jobs[:4], statusList)))
+	require.NoError(t, dlqDB.Store(ctx, dlq.NewJobs("batch_rt", jobs[4:], statusList)))
+
+	s := dlq.NewService(dlqDB, []jobsdb.JobsDB{rtDB, brtDB}, nil, c, logger.NOP)
+	originalJobIDs := func(entries []dlq.Entry) []int64 {
+		ids := make([]int64, len(entries))
+		for i := range entries {
+			ids[i] = entries[i].OriginalJobID
+		}
+		return ids
+	}
+
+	t.Run("list", func(t *testing.T) {
+		entries, err := s.List(ctx, dlq.Filter{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int64{100, 101, 102, 103, 104}, originalJobIDs(entries))
+		require.Equal(t, "rt", entries[0].JobsDB)
+		require.Equal(t, "source-1", entries[0].SourceID)
+		require.Equal(t, "dest-1", entries[0].DestinationID)
+		require.Equal(t, "WEBHOOK", entries[0].DestType)
+		require.Equal(t, "400", entries[0].ErrorCode)
+		require.Equal(t, 3, entries[0].AttemptNum)
+		require.True(t, abortedAt.Equal(entries[0].AbortedAt))
+
+		entries, err = s.List(ctx, dlq.Filter{DestinationID: "dest-1", ErrorCodes: []string{"400"}}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int64{100, 103, 104}, originalJobIDs(entries))
+
+		entries, err = s.List(ctx, dlq.Filter{From: abortedAt.Add(time.Hour), To: abortedAt.Add(3 * time.Hour)}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int64{101, 102}, originalJobIDs(entries))
+
+		entries, err = s.List(ctx, dlq.Filter{JobsDB: "batch_rt"}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int64{104}, originalJobIDs(entries))
+
+		page, err := s.List(ctx, dlq.Filter{}, 2)
+		require.NoError(t, err)
+		require.Equal(t, []int64{100, 101}, originalJobIDs(page))
+		page, err = s.List(ctx, dlq.Filter{AfterJobID: page[1].JobID}, 2)
+		require.NoError(t, err)
+		require.Equal(t, []int64{102, 103}, originalJobIDs(page))
+
+		all, err := s.List(ctx, dlq.Filter{}, 0)
+		require.NoError(t, err)
+		entries, err = s.List(ctx, dlq.Filter{JobIDs: []int64{all[1].JobID, all[3].JobID, all[4].JobID}, AfterJobID: all[1].JobID}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int64{103, 104}, originalJobIDs(entries))
+	})
+
+	t.Run("http", func(t *testing.T) {
+		handler := s.HttpHandler()
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/?destinationId=dest-1&errorCode=500&includePayload=true", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+		var listResponse dlq.ListResponse
+		require.NoError(t, jsonrs.Unmarshal(resp.Body.Bytes(), &listResponse))
+		require.Equal(t, []int64{101}, originalJobIDs(listResponse.Entries))
+		require.JSONEq(t, `{"event":"test"}`, string(listResponse.Entries[0].EventPayload))
+		require.Zero(t, listResponse.NextAfterJobID)
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/?limit=1", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+		var pageResponse dlq.ListResponse
+		require.NoError(t, jsonrs.Unmarshal(resp.Body.Bytes(), &pageResponse))
+		require.Len(t, pageResponse.Entries, 1)
+		require.Nil(t, pageResponse.Entries[0].EventPayload)
+		require.Equal(t, pageResponse.Entries[0].JobID, pageResponse.NextAfterJobID)
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/?from=yesterday", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/requeue", bytes.NewBufferString(`{}`)))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/requeue", bytes.NewBufferString(`{"destinationId":"dest-1","jobsdb":"gw"}`)))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("requeue", func(t *testing.T) {
+		entries, err := s.List(ctx, dlq.Filter{DestinationID: "dest-1", ErrorCodes: []string{"400"}}, 0)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		requeued, err := s.Requeue(ctx, dlq.Filter{JobIDs: []int64{entries[0].JobID, entries[2].JobID}}, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, requeued)
+
+		requeued, err = s.Requeue(ctx, dlq.Filter{JobIDs: []int64{entries[0].JobID}}, 0)
+		require.NoError(t, err)
+		require.Zero(t, requeued, "jobs should only be requeued once")
+
+		remaining, err := s.List(ctx, dlq.Filter{}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int64{101, 102, 103}, originalJobIDs(remaining))
+
+		rtJobs, err := rtDB.GetUnprocessed(ctx, jobsdb.GetQueryParams{JobsLimit: 10})
+		require.NoError(t, err)
+		require.Len(t, rtJobs.Jobs, 1)
+		require.Equal(t, "user-100", rtJobs.Jobs[0].UserID)
+		require.Equal(t, "WEBHOOK", rtJobs.Jobs[0].CustomVal)
+		require.JSONEq(t, `{"source_id":"source-1","destination_id":"dest-1"}`, string(rtJobs.Jobs[0].Parameters))
+
+		brtJobs, err := brtDB.GetUnprocessed(ctx, jobsdb.GetQueryParams{JobsLimit: 10})
+		require.NoError(t, err)
+		require.Len(t, brtJobs.Jobs, 1)
+		require.Equal(t, "user-104", brtJobs.Jobs[0].UserID)
+
+		resp := httptest.NewRecorder()
+		s.HttpHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/requeue", bytes.NewBufferString(`{"destinationId":"dest-2"}`)))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.JSONEq(t, `{"requeued":1}`, resp.Body.String())
+	})
+}
@@ -0,0 +1,155 @@
+package dlq
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+)
+
+// HttpHandler returns the handler of the dead-letter queue's API
+//
+//   - GET / - lists jobs, filtered by the query parameters jobsdb, workspaceId, destinationId, destType, errorCode (repeatable),
+//     from & to (RFC3339), afterJobId and limit. Event payloads are only included if includePayload is true.
+//   - POST /requeue - requeues jobs selected by a [RequeueRequest] back to the jobsdb they have been aborted in
+func (s *Service) HttpHandler() http.Handler {
+	srvMux := chi.NewRouter()
+	srvMux.Get("/", s.list)
+	srvMux.Post("/requeue", s.requeue)
+	return srvMux
+}
+
+// ListResponse is the response of the list endpoint
+type ListResponse struct {
+	Entries []Entry `json:"entries"`
+	// NextAfterJobID is the afterJobId to be used for requesting the next page, if there might be more jobs
+	NextAfterJobID int64 `json:"nextAfterJobId,omitempty"`
+}
+
+// RequeueRequest is the request of the requeue endpoint. Either job ids or a destination id needs to be provided.
+type RequeueRequest struct {
+	JobIDs        []int64   `json:"jobIds"`
+	JobsDB        string    `json:"jobsdb"`
+	WorkspaceID   string    `json:"workspaceId"`
+	DestinationID string    `json:"destinationId"`
+	DestType      string    `json:"destType"`
+	ErrorCodes    []string  `json:"errorCodes"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Limit         int       `json:"limit"`
+}
+
+// RequeueResponse is the response of the requeue endpoint
+type RequeueResponse struct {
+	Requeued int `json:"requeued"`
+}
+
+func (s *Service) list(w http.ResponseWriter, r *http.Request) {
+	filter, limit, err := parseListQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit = s.normalizeLimit(limit)
+	entries, err := s.List(r.Context(), filter, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	includePayload, _ := strconv.ParseBool(r.URL.Query().Get("includePayload"))
+	if !includePayload {
+		for i := range entries {
+			entries[i].EventPayload = nil
+		}
+	}
+	response := ListResponse{Entries: entries}
+	if response.Entries == nil {
+		response.Entries = []Entry{}
+	}
+	if len(entries) > 0 && len(entries) == limit {
+		response.NextAfterJobID = entries[len(entries)-1].JobID
+	}
+	s.writeResponse(w, response)
+}
+
+func (s *Service) requeue(w http.ResponseWriter, r *http.Request) {
+	var req RequeueRequest
+	if err := jsonrs.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(req.JobIDs) == 0 && req.DestinationID == "" {
+		http.Error(w, "either jobIds or destinationId is required", http.StatusBadRequest)
+		return
+	}
+	requeued, err := s.Requeue(r.Context(), Filter{
+		JobIDs:        req.JobIDs,
+		JobsDB:        req.JobsDB,
+		WorkspaceID:   req.WorkspaceID,
+		DestinationID: req.DestinationID,
+		DestType:      req.DestType,
+		ErrorCodes:    req.ErrorCodes,
+		From:          req.From,
+		To:            req.To,
+	}, req.Limit)
+	if err != nil {
+		httpStatus := http.StatusInternalServerError
+		if errors.Is(err, ErrUnknownJobsDB) {
+			httpStatus = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), httpStatus)
+		return
+	}
+	s.writeResponse(w, RequeueResponse{Requeued: requeued})
+}
+
+func parseListQuery(r *http.Request) (filter Filter, limit int, err error) {
+	query := r.URL.Query()
+	filter.JobsDB = query.Get("jobsdb")
+	filter.WorkspaceID = query.Get("workspaceId")
+	filter.DestinationID = query.Get("destinationId")
+	filter.DestType = query.Get("destType")
+	for _, errorCode := range query["errorCode"] {
+		filter.ErrorCodes = append(filter.ErrorCodes, strings.Split(errorCode, ",")...)
+	}
+	if v := query.Get("from"); v != "" {
+		if filter.From, err = time.Parse(time.RFC3339, v); err != nil {
+			return filter, 0, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if v := query.Get("to"); v != "" {
+		if filter.To, err = time.Parse(time.RFC3339, v); err != nil {
+			return filter, 0, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if v := query.Get("afterJobId"); v != "" {
+		if filter.AfterJobID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return filter, 0, fmt.Errorf("invalid afterJobId: %w", err)
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			return filter, 0, fmt.Errorf("invalid limit: %q", v)
+		}
+	}
+	return filter, limit, nil
+}
+
+func (s *Service) writeResponse(w http.ResponseWriter, response any) {
+	body, err := jsonrs.Marshal(response)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if _, err := w.Write(body); err != nil {
+		s.log.Warnn("Writing dead-letter queue response", obskit.Error(err))
+	}
+}
@@ -16,9 +16,57 @@ var (
 
 type maxmindDBReader struct {
 	*maxminddb.Reader
+
+	// asnReaders are the network databases, e.g. GeoLite2-ASN, whose information is merged in order
+	asnReaders  []*maxminddb.Reader
+	hostingASNs map[uint]struct{}
+}
+
+// Option is a functional option for the maxmind db reader
+type Option func(*maxmindDBReader) error
+
+// WithASNDatabases adds the network databases looked up for the [ASN] of ips, along with the city database.
+// Databases are looked up in order and the first non-empty value of each field wins.
+func WithASNDatabases(dbLocs ...string) Option {
+	return func(r *maxmindDBReader) error {
+		for _, dbLoc := range dbLocs {
+			reader, err := openMaxmindDB(dbLoc)
+			if err != nil {
+				return err
+			}
+			r.asnReaders = append(r.asnReaders, reader)
+		}
+		return nil
+	}
+}
+
+// WithHostingASNs flags the ips of the autonomous systems as hosting, e.g. the ones of cloud providers
+func WithHostingASNs(asns ...uint) Option {
+	return func(r *maxmindDBReader) error {
+		for _, asn := range asns {
+			r.hostingASNs[asn] = struct{}{}
+		}
+		return nil
+	}
+}
+
+func NewMaxmindDBReader(dbLoc string, opts ...Option) (*maxmindDBReader, error) {
+	reader, err := openMaxmindDB(dbLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &maxmindDBReader{Reader: reader, hostingASNs: make(map[uint]struct{})}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+	}
+	return r, nil
 }
 
-func NewMaxmindDBReader(dbLoc string) (*maxmindDBReader, error) {
+func openMaxmindDB(dbLoc string) (*maxminddb.Reader, error) {
 	reader, err := maxminddb.Open(dbLoc)
 	if err != nil {
 
@@ -31,8 +79,7 @@ func NewMaxmindDBReader(dbLoc string) (*maxmindDBReader, error) {
 
 		return nil, fmt.Errorf("opening maxmind reader from location: %w", err)
 	}
-
-	return &maxmindDBReader{reader}, nil
+	return reader, nil
 }
 
 func (f *maxmindDBReader) Locate(ip string) (GeoInfo, error) {
@@ -47,13 +94,47 @@ func (f *maxmindDBReader) Locate(ip string) (GeoInfo, error) {
 		return GeoInfo{}, fmt.Errorf("reading geolocation for ip: %w", err)
 	}
 
+	for _, reader := range f.asnReaders {
+		var asn ASN
+		if err := reader.Lookup(parsedIP, &asn); err != nil {
+			return GeoInfo{}, fmt.Errorf("reading asn for ip: %w", err)
+		}
+		info.ASN = mergeASN(info.ASN, asn)
+	}
+	if _, ok := f.hostingASNs[info.ASN.Number]; ok && info.ASN.Number != 0 {
+		info.ASN.IsHosting = true
+	}
+
 	return info, nil
 }
 
+func mergeASN(asn, other ASN) ASN {
+	if asn.Number == 0 {
+		asn.Number = other.Number
+	}
+	if asn.Organization == "" {
+		asn.Organization = other.Organization
+	}
+	if asn.ISP == "" {
+		asn.ISP = other.ISP
+	}
+	if asn.ConnectionType == "" {
+		asn.ConnectionType = other.ConnectionType
+	}
+	asn.IsHosting = asn.IsHosting || other.IsHosting
+	return asn
+}
+
 func (f *maxmindDBReader) Close() error {
+	var errs []error
 	if err := f.Reader.Close(); err != nil {
-		return fmt.Errorf("closing the underlying maxmind db reader: %w", err)
+		errs = append(errs, fmt.Errorf("closing the underlying maxmind db reader: %w", err))
+	}
+	for _, reader := range f.asnReaders {
+		if err := reader.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing the underlying maxmind asn db reader: %w", err))
+		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
@@ -51,3 +51,51 @@ func TestGeolocationFetcher(t *testing.T) {
 		require.Empty(t, emptyLookup)
 	})
 }
+
+func TestGeolocationFetcher_ASN(t *testing.T) {
+	// below databases are generated from asn_test_input.json and connection_type_test_input.json
+	f, err := geolocation.NewMaxmindDBReader(
+		"./testdata/city_test.mmdb",
+		geolocation.WithASNDatabases("./testdata/asn_test.mmdb", "./testdata/connection_type_test.mmdb"),
+		geolocation.WithHostingASNs(16509),
+	)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, f.Close()) }()
+
+	t.Run("fetcher merges the asn databases", func(t *testing.T) {
+		lookup, err := f.Locate(`2.125.160.216`)
+		require.NoError(t, err)
+		require.Equal(t, `OX1`, lookup.Postal.Code)
+		require.Equal(t, geolocation.ASN{Number: 5607, Organization: "Sky UK Limited", ConnectionType: "Cable/DSL"}, lookup.ASN)
+
+		lookup, err = f.Locate(`2600:6000::1`) // IPv6
+		require.NoError(t, err)
+		require.Equal(t, geolocation.ASN{Number: 237, Organization: "Merit Network Inc.", ConnectionType: "Cable/DSL"}, lookup.ASN)
+
+		lookup, err = f.Locate(`2a03:2880::1`) // IPv6, missing in the connection type database
+		require.NoError(t, err)
+		require.Equal(t, geolocation.ASN{Number: 32934, Organization: "Facebook, Inc."}, lookup.ASN)
+	})
+
+	t.Run("fetcher flags hosting asns", func(t *testing.T) {
+		lookup, err := f.Locate(`3.5.140.2`)
+		require.NoError(t, err)
+		require.Empty(t, lookup.City.Names, "missing in the city database")
+		require.Equal(t, geolocation.ASN{Number: 16509, Organization: "AMAZON-02", ConnectionType: "Corporate", IsHosting: true}, lookup.ASN)
+
+		lookup, err = f.Locate(`1.128.0.1`)
+		require.NoError(t, err)
+		require.False(t, lookup.ASN.IsHosting)
+	})
+
+	t.Run("fetcher returns empty asn for IP address not available in databases", func(t *testing.T) {
+		lookup, err := f.Locate(`8.8.8.8`)
+		require.NoError(t, err)
+		require.Empty(t, lookup)
+	})
+
+	t.Run("reader errors out when asn db is corrupted", func(t *testing.T) {
+		_, err := geolocation.NewMaxmindDBReader("./testdata/city_test.mmdb", geolocation.WithASNDatabases("./testdata/corrupted_city_test.mmdb"))
+		require.ErrorIs(t, err, geolocation.ErrInvalidDatabase)
+	})
+}
@@ -15,6 +15,8 @@ type GeoInfo struct {
 	Subdivisions []Subdivision `maxminddb:"subdivisions"`
 	Country      Country       `maxminddb:"country"`
 	Location     Location      `maxminddb:"location"`
+	// ASN is looked up in the network databases of the reader, if any
+	ASN ASN `maxminddb:"-"`
 }
 
 type City struct {
@@ -45,3 +47,15 @@ type Location struct {
 	Latitude  *float64 `maxminddb:"latitude"`
 	Longitude *float64 `maxminddb:"longitude"`
 }
+
+// ASN is the network information of an ip, as found in databases with a layout compatible with the GeoLite2-ASN,
+// GeoIP2-ISP, GeoIP2-Connection-Type and GeoIP2-Anonymous-IP ones, e.g. DB-IP's and IP2Location's MMDB databases.
+type ASN struct {
+	Number         uint   `maxminddb:"autonomous_system_number"`
+	Organization   string `maxminddb:"autonomous_system_organization"`
+	ISP            string `maxminddb:"isp"`
+	ConnectionType string `maxminddb:"connection_type"`
+	// IsHosting is true for ips of hosting providers, i.e. datacenter traffic, either flagged by the database or
+	// belonging to one of the hosting autonomous systems of the reader.
+	IsHosting bool `maxminddb:"is_hosting_provider"`
+}
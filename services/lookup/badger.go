@@ -0,0 +1,127 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+	badgeroptions "github.com/dgraph-io/badger/v4/options"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+)
+
+// keySeparator separates the table of a row from its key in badger keys, it cannot be part of table names
+const keySeparator = "\x00"
+
+// badgerStore keeps the rows of all tables in a badger database, keyed by "<table>\x00<key>".
+// Its rows are local to the node, so it is only suitable for single-node deployments.
+type badgerStore struct {
+	db    *badger.DB
+	stats struct {
+		getTimer stats.Timer
+	}
+}
+
+func newBadgerStore(conf *config.Config, stat stats.Stats, log logger.Logger) (*badgerStore, error) {
+	dbPath := conf.GetStringVar("", "LookupEnrichment.badger.path")
+	if dbPath == "" {
+		return nil, fmt.Errorf("badger lookup store: LookupEnrichment.badger.path is required")
+	}
+	opts := badger.
+		DefaultOptions(dbPath).
+		WithLogger(badgerLogger{log.Child("badger")}).
+		WithCompression(badgeroptions.None).
+		WithNumVersionsToKeep(1).
+		WithSyncWrites(conf.GetBoolVar(false, "LookupEnrichment.badger.syncWrites"))
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("opening lookup badger db: %w", err)
+	}
+	s := &badgerStore{db: db}
+	s.stats.getTimer = stat.NewTaggedStat("lookup_store_get_duration_seconds", stats.TimerType, stats.Tags{"backend": BackendBadger})
+	return s, nil
+}
+
+func (s *badgerStore) Get(_ context.Context, table string, keys []string) (map[string]Row, error) {
+	if err := validateTable(table); err != nil {
+		return nil, err
+	}
+	defer s.stats.getTimer.RecordDuration()()
+	rows := make(map[string]Row, len(keys))
+	err := s.db.View(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			item, err := txn.Get(rowKey(table, key))
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := item.Value(func(val []byte) error {
+				var row Row
+				if err := jsonrs.Unmarshal(val, &row); err != nil {
+					return fmt.Errorf("decoding row %q of table %q: %w", key, table, err)
+				}
+				rows[key] = row
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return rows, err
+}
+
+// Replace drops the rows of the table before writing the new ones, thus rows might be missing while the table is being replaced
+func (s *badgerStore) Replace(_ context.Context, table string, rows map[string]Row) error {
+	if err := validateTable(table); err != nil {
+		return err
+	}
+	if err := s.db.DropPrefix(tablePrefix(table)); err != nil {
+		return fmt.Errorf("dropping rows of table %q: %w", table, err)
+	}
+	wb := s.db.NewWriteBatch()
+	defer wb.Cancel()
+	for key, row := range rows {
+		value, err := jsonrs.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("encoding row %q of table %q: %w", key, table, err)
+		}
+		if err := wb.Set(rowKey(table, key), value); err != nil {
+			return fmt.Errorf("writing row %q of table %q: %w", key, table, err)
+		}
+	}
+	return wb.Flush()
+}
+
+func (s *badgerStore) Delete(_ context.Context, table string) error {
+	if err := validateTable(table); err != nil {
+		return err
+	}
+	return s.db.DropPrefix(tablePrefix(table))
+}
+
+func (s *badgerStore) Close() error {
+	return s.db.Close()
+}
+
+func tablePrefix(table string) []byte {
+	return []byte(table + keySeparator)
+}
+
+func rowKey(table, key string) []byte {
+	return []byte(table + keySeparator + key)
+}
+
+type badgerLogger struct {
+	logger.Logger
+}
+
+func (l badgerLogger) Warningf(fmt string, args ...interface{}) {
+	l.Warnf(fmt, args...)
+}
@@ -0,0 +1,133 @@
+package lookup
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rudderlabs/rudder-go-kit/bytesize"
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+)
+
+// UploadResponse is the response of the upload endpoint
+type UploadResponse struct {
+	Rows int `json:"rows"`
+}
+
+// HttpHandler returns the handler of the lookup tables' API
+//
+//   - PUT /{table} - replaces the rows of the table with the uploaded csv (Content-Type: text/csv) or json data,
+//     keyed by the column or field named by the key query parameter (default: key), see [ParseCSV] and [ParseJSON]
+//   - GET /{table}/{key} - returns the row of the table with the key, which may contain slashes (e.g. urls)
+//   - DELETE /{table} - deletes all the rows of the table
+func HttpHandler(store Store, conf *config.Config, log logger.Logger) http.Handler {
+	h := &httpHandler{
+		store:         store,
+		log:           log.Child("lookup"),
+		maxUploadSize: conf.GetInt64Var(100*bytesize.MB, 1, "LookupEnrichment.maxUploadSize"),
+	}
+	srvMux := chi.NewRouter()
+	srvMux.Put("/{table}", h.upload)
+	srvMux.Get("/{table}/*", h.get)
+	srvMux.Delete("/{table}", h.delete)
+	return srvMux
+}
+
+type httpHandler struct {
+	store         Store
+	log           logger.Logger
+	maxUploadSize int64
+}
+
+func (h *httpHandler) upload(w http.ResponseWriter, r *http.Request) {
+	table := chi.URLParam(r, "table")
+	if err := validateTable(table); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	keyField := r.URL.Query().Get("key")
+	if keyField == "" {
+		keyField = "key"
+	}
+	body := http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+	var (
+		rows map[string]Row
+		err  error
+	)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+		rows, err = ParseCSV(body, keyField)
+	} else {
+		rows, err = ParseJSON(body, keyField)
+	}
+	if err != nil {
+		httpStatus := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpStatus = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), httpStatus)
+		return
+	}
+	if err := h.store.Replace(r.Context(), table, rows); err != nil {
+		h.log.Errorn("Replacing lookup table", logger.NewStringField("table", table), obskit.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.log.Infon("Replaced lookup table", logger.NewStringField("table", table), logger.NewIntField("rows", int64(len(rows))))
+	h.writeResponse(w, UploadResponse{Rows: len(rows)})
+}
+
+func (h *httpHandler) get(w http.ResponseWriter, r *http.Request) {
+	table := chi.URLParam(r, "table")
+	key, err := url.PathUnescape(chi.URLParam(r, "*"))
+	if err != nil {
+		http.Error(w, "invalid key", http.StatusBadRequest)
+		return
+	}
+	rows, err := h.store.Get(r.Context(), table, []string{key})
+	if err != nil {
+		httpStatus := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidTable) {
+			httpStatus = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), httpStatus)
+		return
+	}
+	row, ok := rows[key]
+	if !ok {
+		http.Error(w, "row not found", http.StatusNotFound)
+		return
+	}
+	h.writeResponse(w, row)
+}
+
+func (h *httpHandler) delete(w http.ResponseWriter, r *http.Request) {
+	table := chi.URLParam(r, "table")
+	if err := h.store.Delete(r.Context(), table); err != nil {
+		httpStatus := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidTable) {
+			httpStatus = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), httpStatus)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *httpHandler) writeResponse(w http.ResponseWriter, response any) {
+	body, err := jsonrs.Marshal(response)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if _, err := w.Write(body); err != nil {
+		h.log.Warnn("Writing lookup response", obskit.Error(err))
+	}
+}
@@ -0,0 +1,90 @@
+// Package lookup keeps the reference data of lookup tables, e.g. account tiers keyed by user id or campaigns keyed by
+// page url, which are uploaded through its API and joined with the events of sources by the lookup pipeline enricher.
+package lookup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+)
+
+const (
+	// BackendBadger keeps lookup tables in a local badger database at LookupEnrichment.badger.path.
+	// Tables are not shared among replicas, so it is only suitable for single-node deployments.
+	BackendBadger = "badger"
+	// BackendPostgres keeps lookup tables in a postgres table, using the database provided through [WithDB] (default)
+	BackendPostgres = "postgres"
+)
+
+var (
+	// ErrInvalidTable is returned for table names which are not made of up to 64 letters, digits, underscores and dashes
+	ErrInvalidTable = errors.New("invalid lookup table name")
+
+	tableNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+)
+
+// Row is a row of a lookup table
+type Row = map[string]any
+
+// Store keeps the rows of lookup tables, keyed by the value of their key
+type Store interface {
+	// Get returns the rows of the table found for the keys, keyed by their key
+	Get(ctx context.Context, table string, keys []string) (map[string]Row, error)
+	// Replace replaces all the rows of the table, creating it if needed
+	Replace(ctx context.Context, table string, rows map[string]Row) error
+	// Delete deletes all the rows of the table
+	Delete(ctx context.Context, table string) error
+	Close() error
+}
+
+// Enabled returns true if the events of sources should be enriched from lookup tables
+func Enabled(conf *config.Config) bool {
+	return conf.GetBoolVar(false, "LookupEnrichment.enabled")
+}
+
+// Option is a functional option for the lookup tables' store
+type Option func(*options)
+
+type options struct {
+	db *sql.DB
+}
+
+// WithDB provides the database used by the postgres backend
+func WithDB(db *sql.DB) Option {
+	return func(o *options) {
+		o.db = db
+	}
+}
+
+// New creates the store of the lookup tables, using the backend configured by LookupEnrichment.backend.
+// The store needs to be closed after use.
+func New(conf *config.Config, stat stats.Stats, log logger.Logger, opts ...Option) (Store, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	switch backend := conf.GetStringVar(BackendPostgres, "LookupEnrichment.backend"); backend {
+	case BackendBadger:
+		return newBadgerStore(conf, stat, log)
+	case BackendPostgres:
+		if o.db == nil {
+			return nil, fmt.Errorf("postgres lookup store: no database provided, the database pool needs to be shared")
+		}
+		return newPostgresStore(o.db, stat), nil
+	default:
+		return nil, fmt.Errorf("unknown lookup store backend: %q", backend)
+	}
+}
+
+func validateTable(table string) error {
+	if !tableNameRegex.MatchString(table) {
+		return fmt.Errorf("%w: %q", ErrInvalidTable, table)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package lookup
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+)
+
+func newBadgerTestStore(t *testing.T) Store {
+	t.Helper()
+	conf := config.New()
+	conf.Set("LookupEnrichment.backend", BackendBadger)
+	conf.Set("LookupEnrichment.badger.path", t.TempDir())
+	store, err := New(conf, stats.NOP, logger.NOP)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	return store
+}
+
+func TestNew(t *testing.T) {
+	conf := config.New()
+	_, err := New(conf, stats.NOP, logger.NOP)
+	require.Error(t, err, "default postgres backend requires a database")
+
+	conf = config.New()
+	conf.Set("LookupEnrichment.backend", BackendBadger)
+	_, err = New(conf, stats.NOP, logger.NOP)
+	require.Error(t, err, "badger backend requires a path")
+
+	conf = config.New()
+	conf.Set("LookupEnrichment.backend", "unknown")
+	_, err = New(conf, stats.NOP, logger.NOP)
+	require.Error(t, err)
+}
+
+func TestBadgerStore(t *testing.T) {
+	ctx := context.Background()
+	store := newBadgerTestStore(t)
+
+	require.NoError(t, store.Replace(ctx, "tiers", map[string]Row{
+		"u1": {"tier": "gold"},
+		"u2": {"tier": "silver"},
+	}))
+	require.NoError(t, store.Replace(ctx, "tiers_2", map[string]Row{
+		"u1": {"tier": "bronze"},
+	}))
+	rows, err := store.Get(ctx, "tiers", []string{"u1", "u2", "u3"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]Row{"u1": {"tier": "gold"}, "u2": {"tier": "silver"}}, rows)
+
+	t.Run("replace drops previous rows", func(t *testing.T) {
+		require.NoError(t, store.Replace(ctx, "tiers", map[string]Row{"u2": {"tier": "platinum"}}))
+		rows, err := store.Get(ctx, "tiers", []string{"u1", "u2"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]Row{"u2": {"tier": "platinum"}}, rows)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, "tiers"))
+		rows, err := store.Get(ctx, "tiers", []string{"u1", "u2"})
+		require.NoError(t, err)
+		require.Empty(t, rows)
+
+		rows, err = store.Get(ctx, "tiers_2", []string{"u1"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]Row{"u1": {"tier": "bronze"}}, rows, "other tables are kept")
+	})
+
+	t.Run("invalid table", func(t *testing.T) {
+		_, err := store.Get(ctx, "tiers\x00", []string{"u1"})
+		require.ErrorIs(t, err, ErrInvalidTable)
+		require.ErrorIs(t, store.Replace(ctx, "", nil), ErrInvalidTable)
+	})
+}
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("userId,tier,region\nu1,gold,eu\nu2,silver,us\n,bronze,eu\n"), "userId")
+	require.NoError(t, err)
+	require.Equal(t, map[string]Row{
+		"u1": {"tier": "gold", "region": "eu"},
+		"u2": {"tier": "silver", "region": "us"},
+	}, rows)
+
+	_, err = ParseCSV(strings.NewReader("id,tier\nu1,gold\n"), "userId")
+	require.ErrorIs(t, err, ErrInvalidData)
+	_, err = ParseCSV(strings.NewReader("userId,tier\nu1\n"), "userId")
+	require.ErrorIs(t, err, ErrInvalidData)
+	_, err = ParseCSV(strings.NewReader(""), "userId")
+	require.ErrorIs(t, err, ErrInvalidData)
+}
+
+func TestParseJSON(t *testing.T) {
+	rows, err := ParseJSON(strings.NewReader(`[{"id":1,"tier":"gold"},{"id":"u2","tier":"silver"}]`), "id")
+	require.NoError(t, err)
+	require.Equal(t, map[string]Row{"1": {"tier": "gold"}, "u2": {"tier": "silver"}}, rows)
+
+	rows, err = ParseJSON(strings.NewReader(`{"u1":{"tier":"gold"}}`), "id")
+	require.NoError(t, err)
+	require.Equal(t, map[string]Row{"u1": {"tier": "gold"}}, rows)
+
+	for _, data := range []string{`[{"tier":"gold"}]`, `[1]`, `{"u1":"gold"}`, `"u1"`, `{`} {
+		_, err = ParseJSON(strings.NewReader(data), "id")
+		require.ErrorIs(t, err, ErrInvalidData, data)
+	}
+}
+
+func TestHttpHandler(t *testing.T) {
+	store := newBadgerTestStore(t)
+	conf := config.New()
+	conf.Set("LookupEnrichment.maxUploadSize", 64)
+	srv := httptest.NewServer(HttpHandler(store, conf, logger.NOP))
+	t.Cleanup(srv.Close)
+
+	do := func(method, path, contentType, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		respBody, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(respBody)
+	}
+
+	status, body := do(http.MethodPut, "/tiers?key=userId", "text/csv; charset=utf-8", "userId,tier\nu1,gold\nu2,silver\n")
+	require.Equal(t, http.StatusOK, status)
+	var uploadResponse UploadResponse
+	require.NoError(t, jsonrs.Unmarshal([]byte(body), &uploadResponse))
+	require.Equal(t, 2, uploadResponse.Rows)
+
+	status, body = do(http.MethodGet, "/tiers/u1", "", "")
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{"tier":"gold"}`, body)
+
+	status, _ = do(http.MethodPut, "/campaigns", "application/json", `[{"key":"https://example.com","campaign":"spring"}]`)
+	require.Equal(t, http.StatusOK, status)
+	status, body = do(http.MethodGet, "/campaigns/https:%2F%2Fexample.com", "", "")
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{"campaign":"spring"}`, body)
+
+	status, _ = do(http.MethodPut, "/tiers", "application/json", `[{"tier":"gold"}]`)
+	require.Equal(t, http.StatusBadRequest, status, "missing key")
+	status, _ = do(http.MethodPut, "/tiers", "application/json", `[`+strings.Repeat(`{"key":"u1"},`, 10)+`{"key":"u2"}]`)
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+	status, _ = do(http.MethodPut, "/tiers.csv", "text/csv", "key,tier\nu1,gold\n")
+	require.Equal(t, http.StatusBadRequest, status, "invalid table name")
+
+	status, _ = do(http.MethodDelete, "/tiers", "", "")
+	require.Equal(t, http.StatusNoContent, status)
+	status, _ = do(http.MethodGet, "/tiers/u1", "", "")
+	require.Equal(t, http.StatusNotFound, status)
+}
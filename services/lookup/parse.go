@@ -0,0 +1,112 @@
+package lookup
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+)
+
+// ErrInvalidData is returned for uploaded lookup table data which cannot be parsed
+var ErrInvalidData = errors.New("invalid lookup table data")
+
+// ParseCSV parses the rows of a lookup table from csv data, whose first record is the header naming the columns.
+// Rows are keyed by the value of their keyColumn, all values being strings. Later rows replace earlier rows with the same key.
+func ParseCSV(r io.Reader, keyColumn string) (map[string]Row, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading csv header: %w", ErrInvalidData, err)
+	}
+	columns := append([]string(nil), header...)
+	keyIndex := -1
+	for i, column := range columns {
+		if column == keyColumn {
+			keyIndex = i
+			break
+		}
+	}
+	if keyIndex == -1 {
+		return nil, fmt.Errorf("%w: key column %q not found in csv header", ErrInvalidData, keyColumn)
+	}
+	rows := make(map[string]Row)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading csv record: %w", ErrInvalidData, err)
+		}
+		if record[keyIndex] == "" {
+			continue
+		}
+		row := make(Row, len(columns)-1)
+		for i, column := range columns {
+			if i != keyIndex {
+				row[column] = record[i]
+			}
+		}
+		rows[record[keyIndex]] = row
+	}
+}
+
+// ParseJSON parses the rows of a lookup table from json data, being either an array of objects keyed by the value of their keyField,
+// or an object of objects keyed by their property names, in which case keyField is ignored.
+func ParseJSON(r io.Reader, keyField string) (map[string]Row, error) {
+	var data any
+	if err := jsonrs.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("%w: decoding json: %w", ErrInvalidData, err)
+	}
+	switch data := data.(type) {
+	case map[string]any:
+		rows := make(map[string]Row, len(data))
+		for key, value := range data {
+			row, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: row %q is not an object", ErrInvalidData, key)
+			}
+			rows[key] = row
+		}
+		return rows, nil
+	case []any:
+		rows := make(map[string]Row, len(data))
+		for i, value := range data {
+			row, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: row %d is not an object", ErrInvalidData, i)
+			}
+			key, ok := KeyOf(row[keyField])
+			if !ok {
+				return nil, fmt.Errorf("%w: row %d has no valid %q key", ErrInvalidData, i, keyField)
+			}
+			delete(row, keyField)
+			rows[key] = row
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("%w: expected an array or an object", ErrInvalidData)
+	}
+}
+
+// KeyOf returns the key of a lookup table row matching the value, which needs to be a non-empty string, a number or a boolean
+func KeyOf(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,104 @@
+package lookup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+)
+
+const tableName = "lookup_table_rows"
+
+// postgresStore keeps the rows of all tables in a postgres table, so that they can be shared among replicas.
+// Its table is created by the node migrations.
+type postgresStore struct {
+	db *sql.DB
+
+	stats struct {
+		getTimer stats.Timer
+	}
+}
+
+func newPostgresStore(db *sql.DB, stat stats.Stats) *postgresStore {
+	s := &postgresStore{db: db}
+	s.stats.getTimer = stat.NewTaggedStat("lookup_store_get_duration_seconds", stats.TimerType, stats.Tags{"backend": BackendPostgres})
+	return s
+}
+
+func (s *postgresStore) Get(ctx context.Context, table string, keys []string) (map[string]Row, error) {
+	if err := validateTable(table); err != nil {
+		return nil, err
+	}
+	defer s.stats.getTimer.RecordDuration()()
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM `+tableName+` WHERE table_name = $1 AND key = ANY($2)`, table, pq.Array(keys))
+	if err != nil {
+		return nil, fmt.Errorf("querying rows of table %q: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+	result := make(map[string]Row, len(keys))
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("scanning row of table %q: %w", table, err)
+		}
+		var row Row
+		if err := jsonrs.Unmarshal(value, &row); err != nil {
+			return nil, fmt.Errorf("decoding row %q of table %q: %w", key, table, err)
+		}
+		result[key] = row
+	}
+	return result, rows.Err()
+}
+
+// Replace replaces the rows of the table in a single transaction
+func (s *postgresStore) Replace(ctx context.Context, table string, rows map[string]Row) error {
+	if err := validateTable(table); err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+tableName+` WHERE table_name = $1`, table); err != nil {
+		return fmt.Errorf("deleting rows of table %q: %w", table, err)
+	}
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(tableName, "table_name", "key", "value"))
+	if err != nil {
+		return fmt.Errorf("preparing copy statement: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+	for key, row := range rows {
+		value, err := jsonrs.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("encoding row %q of table %q: %w", key, table, err)
+		}
+		if _, err := stmt.ExecContext(ctx, table, key, string(value)); err != nil {
+			return fmt.Errorf("copying row %q of table %q: %w", key, table, err)
+		}
+	}
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return fmt.Errorf("copying rows of table %q: %w", table, err)
+	}
+	return tx.Commit()
+}
+
+func (s *postgresStore) Delete(ctx context.Context, table string) error {
+	if err := validateTable(table); err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM `+tableName+` WHERE table_name = $1`, table); err != nil {
+		return fmt.Errorf("deleting rows of table %q: %w", table, err)
+	}
+	return nil
+}
+
+// Close doesn't close the database, which is owned by the caller
+func (s *postgresStore) Close() error {
+	return nil
+}
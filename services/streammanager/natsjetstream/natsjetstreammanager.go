@@ -0,0 +1,250 @@
+package natsjetstream
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/services/streammanager/common"
+)
+
+const (
+	authenticationNone         = "none"
+	authenticationToken        = "token"
+	authenticationUserPassword = "userPassword"
+	authenticationCredentials  = "credentials"
+
+	// KeyHeader is the header carrying the event's user id, which consumers can use for key-based processing
+	KeyHeader = "Rudder-Key"
+
+	defaultPublishTimeout = 10 * time.Second
+)
+
+// configuration is the config that is required to send data to NATS JetStream
+type configuration struct {
+	// ServerURL is a comma separated list of the servers of the cluster, e.g. nats://localhost:4222
+	ServerURL string `json:"serverUrl"`
+	// Topic is the default subject, used if the event doesn't specify one
+	Topic string `json:"topic"`
+
+	TLSEnabled bool `json:"tlsEnabled"`
+	// CACertificate is the PEM encoded certificate of the CA the server's certificate is signed with
+	CACertificate string `json:"caCertificate"`
+	// ClientCertificate & ClientKey are the PEM encoded certificate and key used for mutual TLS
+	ClientCertificate string `json:"clientCertificate"`
+	ClientKey         string `json:"clientKey"`
+
+	// AuthenticationType is one of none, token, userPassword or credentials
+	AuthenticationType string `json:"authenticationType"`
+	Token              string `json:"token"`
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+	// UserJWT & NKeySeed are the decentralized authentication credentials of the user
+	UserJWT  string `json:"userJwt"`
+	NKeySeed string `json:"nkeySeed"`
+}
+
+func (c *configuration) validate() error {
+	if c.ServerURL == "" {
+		return fmt.Errorf("server url cannot be empty")
+	}
+	switch c.AuthenticationType {
+	case "", authenticationNone:
+	case authenticationToken:
+		if c.Token == "" {
+			return fmt.Errorf("token cannot be empty")
+		}
+	case authenticationUserPassword:
+		if c.Username == "" {
+			return fmt.Errorf("username cannot be empty")
+		}
+	case authenticationCredentials:
+		if c.UserJWT == "" || c.NKeySeed == "" {
+			return fmt.Errorf("user JWT and nkey seed cannot be empty")
+		}
+	default:
+		return fmt.Errorf("unsupported authentication type: %q", c.AuthenticationType)
+	}
+	if (c.ClientCertificate == "") != (c.ClientKey == "") {
+		return fmt.Errorf("both client certificate and key need to be provided")
+	}
+	return nil
+}
+
+func (c *configuration) connectOptions(name string) ([]nats.Option, error) {
+	opts := []nats.Option{
+		nats.Name(name),
+		nats.Timeout(config.GetDurationVar(10, time.Second, "Router.NATS_JETSTREAM.dialTimeout")),
+	}
+	if c.TLSEnabled {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if c.CACertificate != "" {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM([]byte(c.CACertificate)) {
+				return nil, fmt.Errorf("invalid CA certificate")
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if c.ClientCertificate != "" {
+			cert, err := tls.X509KeyPair([]byte(c.ClientCertificate), []byte(c.ClientKey))
+			if err != nil {
+				return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+	switch c.AuthenticationType {
+	case authenticationToken:
+		opts = append(opts, nats.Token(c.Token))
+	case authenticationUserPassword:
+		opts = append(opts, nats.UserInfo(c.Username, c.Password))
+	case authenticationCredentials:
+		opts = append(opts, nats.UserJWTAndSeed(c.UserJWT, c.NKeySeed))
+	}
+	return opts, nil
+}
+
+type publisher interface {
+	PublishMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// NatsJetStreamProducer publishes events to NATS JetStream subjects. Every publish waits for the stream's
+// acknowledgement, so that events of the same user, which the router sends sequentially, are stored in order.
+type NatsJetStreamProducer struct {
+	conn         *nats.Conn
+	js           publisher
+	defaultTopic string
+	timeout      time.Duration
+}
+
+var pkgLogger logger.Logger
+
+func init() {
+	pkgLogger = logger.NewLogger().Child("streammanager").Child("natsjetstream")
+}
+
+// NewProducer creates a producer based on destination config
+func NewProducer(destination *backendconfig.DestinationT, o common.Opts) (*NatsJetStreamProducer, error) {
+	var destConfig configuration
+	jsonConfig, err := jsonrs.Marshal(destination.Config)
+	if err != nil {
+		return nil, fmt.Errorf("[NATS JetStream] Error while marshalling destination config: %w", err)
+	}
+	if err = jsonrs.Unmarshal(jsonConfig, &destConfig); err != nil {
+		return nil, fmt.Errorf("[NATS JetStream] Error while unmarshalling destination config: %w", err)
+	}
+	if err = destConfig.validate(); err != nil {
+		return nil, fmt.Errorf("[NATS JetStream] invalid configuration: %w", err)
+	}
+	opts, err := destConfig.connectOptions("rudder-server-" + destination.ID)
+	if err != nil {
+		return nil, fmt.Errorf("[NATS JetStream] invalid configuration: %w", err)
+	}
+	conn, err := nats.Connect(destConfig.ServerURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("[NATS JetStream] could not connect: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("[NATS JetStream] could not create JetStream context: %w", err)
+	}
+	timeout := o.Timeout
+	if timeout < 1 {
+		timeout = defaultPublishTimeout
+	}
+	return &NatsJetStreamProducer{
+		conn:         conn,
+		js:           js,
+		defaultTopic: destConfig.Topic,
+		timeout:      timeout,
+	}, nil
+}
+
+// Produce publishes the message of the transformed event to its subject.
+// The event's messageId is used as the JetStream message id, so that retried publishes are deduplicated by the stream.
+func (p *NatsJetStreamProducer) Produce(jsonData json.RawMessage, _ interface{}) (statusCode int, respStatus, responseMessage string) {
+	parsedJSON := gjson.ParseBytes(jsonData)
+	messageValue := parsedJSON.Get("message").Value()
+	if messageValue == nil {
+		return 400, "Failure", "[NATS JetStream] error :: message from payload not found"
+	}
+	value, err := jsonrs.Marshal(messageValue)
+	if err != nil {
+		return 400, "Failure", "[NATS JetStream] error :: " + err.Error()
+	}
+	subject := parsedJSON.Get("topic").String()
+	if subject == "" {
+		subject = p.defaultTopic
+	}
+	if subject == "" {
+		return 400, "Failure", "[NATS JetStream] error :: subject not found"
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data = value
+	if userID := parsedJSON.Get("userId").String(); userID != "" {
+		msg.Header.Set(KeyHeader, userID)
+	}
+	if messageID := parsedJSON.Get("message.messageId").String(); messageID != "" {
+		msg.Header.Set(jetstream.MsgIDHeader, messageID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	ack, err := p.js.PublishMsg(ctx, msg)
+	if err != nil {
+		return makeErrorResponse(fmt.Errorf("could not publish to %q: %w", subject, err))
+	}
+	responseMessage = fmt.Sprintf("Message delivered to subject: %s, stream: %s, sequence: %d", subject, ack.Stream, ack.Sequence)
+	return 200, "Success", responseMessage
+}
+
+// Close drains and closes the connection
+func (p *NatsJetStreamProducer) Close() error {
+	if p == nil || p.conn == nil {
+		return nil
+	}
+	if err := p.conn.Drain(); err != nil {
+		p.conn.Close()
+		return fmt.Errorf("draining connection: %w", err)
+	}
+	return nil
+}
+
+func makeErrorResponse(err error) (int, string, string) {
+	responseMessage := "[NATS JetStream] error :: " + err.Error()
+	pkgLogger.Errorn("Publishing to NATS JetStream", obskit.Error(err))
+	return getStatusCodeFromError(err), "Failure", responseMessage
+}
+
+// getStatusCodeFromError parses the error and returns the status so that the event gets retried or aborted
+func getStatusCodeFromError(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, nats.ErrTimeout):
+		return 504
+	case errors.Is(err, jetstream.ErrNoStreamResponse), errors.Is(err, nats.ErrNoResponders):
+		// no stream is capturing the subject (yet), or the stream is temporarily unavailable, e.g. during a leader election
+		return 503
+	case errors.Is(err, nats.ErrMaxPayload), errors.Is(err, nats.ErrBadSubject):
+		return 400
+	case errors.Is(err, nats.ErrAuthorization), errors.Is(err, nats.ErrPermissionViolation):
+		return 403
+	default:
+		return 500
+	}
+}
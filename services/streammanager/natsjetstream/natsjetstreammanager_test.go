@@ -0,0 +1,178 @@
+package natsjetstream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/require"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/services/streammanager/common"
+)
+
+func TestNewProducerInvalidConfiguration(t *testing.T) {
+	for name, destConfig := range map[string]map[string]interface{}{
+		"missing server url":     {"topic": "t"},
+		"missing token":          {"serverUrl": "nats://localhost:4222", "authenticationType": "token"},
+		"missing username":       {"serverUrl": "nats://localhost:4222", "authenticationType": "userPassword"},
+		"missing nkey seed":      {"serverUrl": "nats://localhost:4222", "authenticationType": "credentials", "userJwt": "jwt"},
+		"unsupported auth":       {"serverUrl": "nats://localhost:4222", "authenticationType": "kerberos"},
+		"missing client key":     {"serverUrl": "nats://localhost:4222", "tlsEnabled": true, "clientCertificate": "cert"},
+		"invalid ca certificate": {"serverUrl": "nats://localhost:4222", "tlsEnabled": true, "caCertificate": "not a certificate"},
+		"invalid client key":     {"serverUrl": "nats://localhost:4222", "tlsEnabled": true, "clientCertificate": "cert", "clientKey": "key"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			producer, err := NewProducer(&backendconfig.DestinationT{Config: destConfig}, common.Opts{})
+			require.Error(t, err)
+			require.Nil(t, producer)
+		})
+	}
+}
+
+func TestProduce(t *testing.T) {
+	t.Run("invalid payloads", func(t *testing.T) {
+		producer := &NatsJetStreamProducer{js: &mockPublisher{}, timeout: time.Second}
+		statusCode, _, responseMessage := producer.Produce([]byte(`{"userId":"user-1"}`), nil)
+		require.Equal(t, 400, statusCode)
+		require.Equal(t, "[NATS JetStream] error :: message from payload not found", responseMessage)
+
+		statusCode, _, responseMessage = producer.Produce([]byte(`{"message":{"a":1}}`), nil)
+		require.Equal(t, 400, statusCode)
+		require.Equal(t, "[NATS JetStream] error :: subject not found", responseMessage)
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		publisher := &mockPublisher{}
+		producer := &NatsJetStreamProducer{js: publisher, defaultTopic: "events", timeout: time.Second}
+		statusCode, respStatus, _ := producer.Produce([]byte(`{"message":{"messageId":"message-1"},"userId":"user-1"}`), nil)
+		require.Equal(t, 200, statusCode)
+		require.Equal(t, "Success", respStatus)
+		statusCode, _, _ = producer.Produce([]byte(`{"message":{"a":1},"topic":"events.other"}`), nil)
+		require.Equal(t, 200, statusCode)
+
+		require.Len(t, publisher.messages, 2)
+		require.Equal(t, "events", publisher.messages[0].Subject)
+		require.JSONEq(t, `{"messageId":"message-1"}`, string(publisher.messages[0].Data))
+		require.Equal(t, "user-1", publisher.messages[0].Header.Get(KeyHeader))
+		require.Equal(t, "message-1", publisher.messages[0].Header.Get(jetstream.MsgIDHeader))
+		require.Equal(t, "events.other", publisher.messages[1].Subject)
+		require.Empty(t, publisher.messages[1].Header)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			err        error
+			statusCode int
+		}{
+			{err: context.DeadlineExceeded, statusCode: 504},
+			{err: jetstream.ErrNoStreamResponse, statusCode: 503},
+			{err: nats.ErrNoResponders, statusCode: 503},
+			{err: nats.ErrMaxPayload, statusCode: 400},
+			{err: nats.ErrAuthorization, statusCode: 403},
+			{err: nats.ErrConnectionClosed, statusCode: 500},
+		} {
+			producer := &NatsJetStreamProducer{js: &mockPublisher{err: tc.err}, defaultTopic: "events", timeout: time.Second}
+			statusCode, respStatus, responseMessage := producer.Produce([]byte(`{"message":{"a":1}}`), nil)
+			require.Equal(t, tc.statusCode, statusCode, tc.err.Error())
+			require.Equal(t, "Failure", respStatus)
+			require.Equal(t, fmt.Sprintf(`[NATS JetStream] error :: could not publish to "events": %s`, tc.err), responseMessage)
+		}
+	})
+}
+
+func TestNatsJetStreamProducer(t *testing.T) {
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err)
+	container, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository:   "nats",
+		Tag:          "2.10",
+		Cmd:          []string{"-js", "--auth", "secret-token"},
+		ExposedPorts: []string{"4222/tcp"},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := pool.Purge(container); err != nil {
+			t.Log("Could not purge resource:", err)
+		}
+	})
+	serverURL := fmt.Sprintf("nats://127.0.0.1:%s", container.GetPort("4222/tcp"))
+
+	var conn *nats.Conn
+	require.NoError(t, pool.Retry(func() (err error) {
+		conn, err = nats.Connect(serverURL, nats.Token("secret-token"))
+		return err
+	}))
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	ctx := context.Background()
+	stream, err := js.CreateStream(ctx, jetstream.StreamConfig{Name: "EVENTS", Subjects: []string{"events.>"}})
+	require.NoError(t, err)
+
+	destination := backendconfig.DestinationT{
+		ID: "destination-id",
+		Config: map[string]interface{}{
+			"serverUrl":          serverURL,
+			"topic":              "events.default",
+			"authenticationType": "token",
+			"token":              "secret-token",
+		},
+	}
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		destination := destination
+		destination.Config = map[string]interface{}{"serverUrl": serverURL, "topic": "events.default", "authenticationType": "token", "token": "wrong-token"}
+		_, err := NewProducer(&destination, common.Opts{})
+		require.Error(t, err)
+	})
+
+	producer, err := NewProducer(&destination, common.Opts{Timeout: 10 * time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = producer.Close() })
+
+	for _, payload := range []string{
+		`{"message":{"messageId":"message-1","event":"a"},"userId":"user-1"}`,
+		`{"message":{"messageId":"message-2","event":"b"},"userId":"user-1","topic":"events.orders"}`,
+		`{"message":{"messageId":"message-1","event":"a"},"userId":"user-1"}`, // retried publish
+	} {
+		statusCode, respStatus, responseMessage := producer.Produce([]byte(payload), destination.Config)
+		require.Equalf(t, 200, statusCode, responseMessage)
+		require.Equal(t, "Success", respStatus)
+	}
+
+	statusCode, _, _ := producer.Produce([]byte(`{"message":{"event":"c"},"topic":"unknown"}`), destination.Config)
+	require.Equal(t, 503, statusCode)
+
+	consumer, err := stream.OrderedConsumer(ctx, jetstream.OrderedConsumerConfig{})
+	require.NoError(t, err)
+	batch, err := consumer.FetchNoWait(10)
+	require.NoError(t, err)
+	var messages []jetstream.Msg
+	for msg := range batch.Messages() {
+		messages = append(messages, msg)
+	}
+	require.NoError(t, batch.Error())
+	require.Len(t, messages, 2, "retried publish should be deduplicated")
+	require.Equal(t, "events.default", messages[0].Subject())
+	require.JSONEq(t, `{"messageId":"message-1","event":"a"}`, string(messages[0].Data()))
+	require.Equal(t, "user-1", messages[0].Headers().Get(KeyHeader))
+	require.Equal(t, "events.orders", messages[1].Subject())
+}
+
+type mockPublisher struct {
+	err      error
+	messages []*nats.Msg
+}
+
+func (m *mockPublisher) PublishMsg(_ context.Context, msg *nats.Msg, _ ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.messages = append(m.messages, msg)
+	return &jetstream.PubAck{Stream: "EVENTS", Sequence: uint64(len(m.messages))}, nil
+}
@@ -0,0 +1,264 @@
+package pulsar
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	apachepulsar "github.com/apache/pulsar-client-go/pulsar"
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	internalpulsar "github.com/rudderlabs/rudder-server/internal/pulsar"
+	"github.com/rudderlabs/rudder-server/services/streammanager/common"
+)
+
+const (
+	authenticationNone  = "none"
+	authenticationToken = "token"
+	authenticationTLS   = "tls"
+
+	defaultPublishTimeout = 10 * time.Second
+)
+
+// configuration is the config that is required to send data to Pulsar
+type configuration struct {
+	// ServiceURL is the url of the pulsar cluster, e.g. pulsar://localhost:6650 or pulsar+ssl://localhost:6651
+	ServiceURL string `json:"serviceUrl"`
+	// Topic is the default topic, used if the event doesn't specify one
+	Topic string `json:"topic"`
+
+	// CACertificate is the PEM encoded certificate of the CA the broker's certificate is signed with
+	CACertificate              string `json:"caCertificate"`
+	TLSAllowInsecureConnection bool   `json:"tlsAllowInsecureConnection"`
+
+	// AuthenticationType is one of none, token or tls
+	AuthenticationType string `json:"authenticationType"`
+	Token              string `json:"token"`
+	// ClientCertificate & ClientKey are the PEM encoded certificate and key used for tls authentication
+	ClientCertificate string `json:"clientCertificate"`
+	ClientKey         string `json:"clientKey"`
+}
+
+func (c *configuration) validate() error {
+	if c.ServiceURL == "" {
+		return fmt.Errorf("service url cannot be empty")
+	}
+	if !strings.HasPrefix(c.ServiceURL, "pulsar://") && !strings.HasPrefix(c.ServiceURL, "pulsar+ssl://") {
+		return fmt.Errorf("service url must start with pulsar:// or pulsar+ssl://")
+	}
+	switch c.AuthenticationType {
+	case "", authenticationNone:
+	case authenticationToken:
+		if c.Token == "" {
+			return fmt.Errorf("token cannot be empty")
+		}
+	case authenticationTLS:
+		if c.ClientCertificate == "" || c.ClientKey == "" {
+			return fmt.Errorf("client certificate and key cannot be empty")
+		}
+	default:
+		return fmt.Errorf("unsupported authentication type: %q", c.AuthenticationType)
+	}
+	return nil
+}
+
+func (c *configuration) clientOptions() (apachepulsar.ClientOptions, error) {
+	opts := apachepulsar.ClientOptions{
+		URL:                        c.ServiceURL,
+		OperationTimeout:           config.GetDurationVar(30, time.Second, "Router.PULSAR.operationTimeout"),
+		ConnectionTimeout:          config.GetDurationVar(10, time.Second, "Router.PULSAR.connectionTimeout"),
+		TLSAllowInsecureConnection: c.TLSAllowInsecureConnection,
+	}
+	if c.CACertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CACertificate)) {
+			return opts, fmt.Errorf("invalid CA certificate")
+		}
+		opts.TLSConfig = &tls.Config{
+			RootCAs:            pool,
+			InsecureSkipVerify: c.TLSAllowInsecureConnection, // skipcq: GSC-G402
+			MinVersion:         tls.VersionTLS12,
+		}
+	}
+	switch c.AuthenticationType {
+	case authenticationToken:
+		opts.Authentication = apachepulsar.NewAuthenticationToken(c.Token)
+	case authenticationTLS:
+		cert, err := tls.X509KeyPair([]byte(c.ClientCertificate), []byte(c.ClientKey))
+		if err != nil {
+			return opts, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		opts.Authentication = apachepulsar.NewAuthenticationFromTLSCertSupplier(func() (*tls.Certificate, error) {
+			return &cert, nil
+		})
+	}
+	return opts, nil
+}
+
+type client interface {
+	NewProducer(opts apachepulsar.ProducerOptions) (internalpulsar.ProducerAdapter, error)
+	Close()
+}
+
+// PulsarProducer publishes events to Pulsar topics, using the event's user id as key so that
+// events of the same user are delivered in order (also to key-shared subscriptions).
+// A producer is created lazily for every topic events are published to.
+type PulsarProducer struct {
+	client       client
+	defaultTopic string
+	timeout      time.Duration
+
+	producersMu sync.Mutex
+	producers   map[string]internalpulsar.ProducerAdapter
+}
+
+var pkgLogger logger.Logger
+
+func init() {
+	pkgLogger = logger.NewLogger().Child("streammanager").Child("pulsar")
+}
+
+// NewProducer creates a producer based on destination config
+func NewProducer(destination *backendconfig.DestinationT, o common.Opts) (*PulsarProducer, error) {
+	var destConfig configuration
+	jsonConfig, err := jsonrs.Marshal(destination.Config)
+	if err != nil {
+		return nil, fmt.Errorf("[Pulsar] Error while marshalling destination config: %w", err)
+	}
+	if err = jsonrs.Unmarshal(jsonConfig, &destConfig); err != nil {
+		return nil, fmt.Errorf("[Pulsar] Error while unmarshalling destination config: %w", err)
+	}
+	if err = destConfig.validate(); err != nil {
+		return nil, fmt.Errorf("[Pulsar] invalid configuration: %w", err)
+	}
+	clientOpts, err := destConfig.clientOptions()
+	if err != nil {
+		return nil, fmt.Errorf("[Pulsar] invalid configuration: %w", err)
+	}
+	c, err := internalpulsar.NewClientWithOptions(clientOpts, pkgLogger.Child(destination.ID))
+	if err != nil {
+		return nil, fmt.Errorf("[Pulsar] could not create client: %w", err)
+	}
+	return newProducer(&c, destConfig.Topic, o), nil
+}
+
+func newProducer(c client, defaultTopic string, o common.Opts) *PulsarProducer {
+	timeout := o.Timeout
+	if timeout < 1 {
+		timeout = defaultPublishTimeout
+	}
+	return &PulsarProducer{
+		client:       c,
+		defaultTopic: defaultTopic,
+		timeout:      timeout,
+		producers:    make(map[string]internalpulsar.ProducerAdapter),
+	}
+}
+
+// Produce publishes the message of the transformed event to its topic
+func (p *PulsarProducer) Produce(jsonData json.RawMessage, _ interface{}) (statusCode int, respStatus, responseMessage string) {
+	parsedJSON := gjson.ParseBytes(jsonData)
+	messageValue := parsedJSON.Get("message").Value()
+	if messageValue == nil {
+		return 400, "Failure", "[Pulsar] error :: message from payload not found"
+	}
+	value, err := jsonrs.Marshal(messageValue)
+	if err != nil {
+		return 400, "Failure", "[Pulsar] error :: " + err.Error()
+	}
+	topic := parsedJSON.Get("topic").String()
+	if topic == "" {
+		topic = p.defaultTopic
+	}
+	if topic == "" {
+		return 400, "Failure", "[Pulsar] error :: topic not found"
+	}
+
+	producer, err := p.producer(topic)
+	if err != nil {
+		return makeErrorResponse(fmt.Errorf("could not create producer for topic %q: %w", topic, err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	userID := parsedJSON.Get("userId").String()
+	if err := producer.SendMessage(ctx, userID, userID, value); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w: %w", apachepulsar.ErrContextExpired, err)
+		}
+		return makeErrorResponse(fmt.Errorf("could not publish to %q: %w", topic, err))
+	}
+	responseMessage = fmt.Sprintf("Message delivered to topic: %s", topic)
+	return 200, "Success", responseMessage
+}
+
+// producer returns the producer of the given topic, creating it if needed
+func (p *PulsarProducer) producer(topic string) (internalpulsar.ProducerAdapter, error) {
+	p.producersMu.Lock()
+	defer p.producersMu.Unlock()
+	if producer, ok := p.producers[topic]; ok {
+		return producer, nil
+	}
+	producer, err := p.client.NewProducer(apachepulsar.ProducerOptions{
+		Topic:              topic,
+		SendTimeout:        p.timeout,
+		BatcherBuilderType: apachepulsar.KeyBasedBatchBuilder,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.producers[topic] = producer
+	return producer, nil
+}
+
+// Close closes all producers and the client
+func (p *PulsarProducer) Close() error {
+	if p == nil || p.client == nil {
+		return nil
+	}
+	p.producersMu.Lock()
+	defer p.producersMu.Unlock()
+	for topic, producer := range p.producers {
+		producer.Close()
+		delete(p.producers, topic)
+	}
+	p.client.Close()
+	return nil
+}
+
+func makeErrorResponse(err error) (int, string, string) {
+	responseMessage := "[Pulsar] error :: " + err.Error()
+	pkgLogger.Errorn("Publishing to pulsar", obskit.Error(err))
+	return getStatusCodeFromError(err), "Failure", responseMessage
+}
+
+// getStatusCodeFromError parses the error and returns the status so that the event gets retried or aborted
+func getStatusCodeFromError(err error) int {
+	var pulsarErr *apachepulsar.Error
+	if !errors.As(err, &pulsarErr) {
+		return 500
+	}
+	switch pulsarErr.Result() {
+	case apachepulsar.TimeoutError:
+		return 504
+	case apachepulsar.ProducerQueueIsFull, apachepulsar.ProducerBlockedQuotaExceededError, apachepulsar.ProducerBlockedQuotaExceededException:
+		return 429
+	case apachepulsar.AuthenticationError, apachepulsar.AuthorizationError:
+		return 403
+	case apachepulsar.TopicNotFound, apachepulsar.InvalidTopicName, apachepulsar.MessageTooBig, apachepulsar.InvalidMessage:
+		return 400
+	default:
+		return 500
+	}
+}
@@ -0,0 +1,199 @@
+package pulsar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apachepulsar "github.com/apache/pulsar-client-go/pulsar"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/require"
+
+	pulsardocker "github.com/rudderlabs/rudder-go-kit/testhelper/docker/resource/pulsar"
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	internalpulsar "github.com/rudderlabs/rudder-server/internal/pulsar"
+	"github.com/rudderlabs/rudder-server/services/streammanager/common"
+)
+
+func TestNewProducerInvalidConfiguration(t *testing.T) {
+	for name, destConfig := range map[string]map[string]interface{}{
+		"missing service url":       {"topic": "t"},
+		"invalid service url":       {"serviceUrl": "http://localhost:6650"},
+		"missing token":             {"serviceUrl": "pulsar://localhost:6650", "authenticationType": "token"},
+		"missing client key":        {"serviceUrl": "pulsar+ssl://localhost:6651", "authenticationType": "tls", "clientCertificate": "cert"},
+		"unsupported auth":          {"serviceUrl": "pulsar://localhost:6650", "authenticationType": "kerberos"},
+		"invalid ca certificate":    {"serviceUrl": "pulsar+ssl://localhost:6651", "caCertificate": "not a certificate"},
+		"invalid client key pair":   {"serviceUrl": "pulsar+ssl://localhost:6651", "authenticationType": "tls", "clientCertificate": "cert", "clientKey": "key"},
+		"invalid type of attribute": {"serviceUrl": 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			producer, err := NewProducer(&backendconfig.DestinationT{Config: destConfig}, common.Opts{})
+			require.Error(t, err)
+			require.Nil(t, producer)
+		})
+	}
+}
+
+func TestProduce(t *testing.T) {
+	t.Run("invalid payloads", func(t *testing.T) {
+		producer := newProducer(&mockClient{}, "", common.Opts{})
+		statusCode, _, responseMessage := producer.Produce([]byte(`{"userId":"user-1"}`), nil)
+		require.Equal(t, 400, statusCode)
+		require.Equal(t, "[Pulsar] error :: message from payload not found", responseMessage)
+
+		statusCode, _, responseMessage = producer.Produce([]byte(`{"message":{"a":1}}`), nil)
+		require.Equal(t, 400, statusCode)
+		require.Equal(t, "[Pulsar] error :: topic not found", responseMessage)
+	})
+
+	t.Run("producers are created once per topic", func(t *testing.T) {
+		client := &mockClient{}
+		producer := newProducer(client, "default-topic", common.Opts{})
+		for _, payload := range []string{
+			`{"message":{"a":1},"userId":"user-1"}`,
+			`{"message":{"a":2},"userId":"user-2","topic":"other-topic"}`,
+			`{"message":{"a":3},"userId":"user-1"}`,
+		} {
+			statusCode, respStatus, _ := producer.Produce([]byte(payload), nil)
+			require.Equal(t, 200, statusCode)
+			require.Equal(t, "Success", respStatus)
+		}
+		require.Len(t, client.producers, 2)
+		require.Equal(t, []mockMessage{
+			{key: "user-1", orderingKey: "user-1", payload: `{"a":1}`},
+			{key: "user-1", orderingKey: "user-1", payload: `{"a":3}`},
+		}, client.producers["default-topic"].messages)
+		require.Equal(t, []mockMessage{
+			{key: "user-2", orderingKey: "user-2", payload: `{"a":2}`},
+		}, client.producers["other-topic"].messages)
+
+		require.NoError(t, producer.Close())
+		require.True(t, client.closed)
+		require.True(t, client.producers["default-topic"].closed)
+		require.True(t, client.producers["other-topic"].closed)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		client := &mockClient{createErr: errors.New("connection refused")}
+		statusCode, respStatus, responseMessage := newProducer(client, "default-topic", common.Opts{}).Produce([]byte(`{"message":{"a":1}}`), nil)
+		require.Equal(t, 500, statusCode)
+		require.Equal(t, "Failure", respStatus)
+		require.Equal(t, `[Pulsar] error :: could not create producer for topic "default-topic": connection refused`, responseMessage)
+
+		client = &mockClient{sendErr: context.DeadlineExceeded}
+		statusCode, _, _ = newProducer(client, "default-topic", common.Opts{}).Produce([]byte(`{"message":{"a":1}}`), nil)
+		require.Equal(t, 504, statusCode)
+
+		client = &mockClient{sendErr: apachepulsar.ErrSendQueueIsFull}
+		statusCode, _, _ = newProducer(client, "default-topic", common.Opts{}).Produce([]byte(`{"message":{"a":1}}`), nil)
+		require.Equal(t, 429, statusCode)
+
+		client = &mockClient{sendErr: apachepulsar.ErrMessageTooLarge}
+		statusCode, _, _ = newProducer(client, "default-topic", common.Opts{}).Produce([]byte(`{"message":{"a":1}}`), nil)
+		require.Equal(t, 400, statusCode)
+	})
+}
+
+func TestPulsarProducer(t *testing.T) {
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err)
+	pulsarContainer, err := pulsardocker.Setup(pool, t)
+	require.NoError(t, err)
+
+	destination := backendconfig.DestinationT{
+		ID: "destination-id",
+		Config: map[string]interface{}{
+			"serviceUrl": pulsarContainer.URL,
+			"topic":      "default-topic",
+		},
+	}
+	producer, err := NewProducer(&destination, common.Opts{Timeout: 30 * time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = producer.Close() })
+
+	client, err := apachepulsar.NewClient(apachepulsar.ClientOptions{URL: pulsarContainer.URL})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	subscribe := func(topic string) apachepulsar.Consumer {
+		consumer, err := client.Subscribe(apachepulsar.ConsumerOptions{
+			Topic:                       topic,
+			SubscriptionName:            "test-subscription",
+			SubscriptionInitialPosition: apachepulsar.SubscriptionPositionEarliest,
+		})
+		require.NoError(t, err)
+		t.Cleanup(consumer.Close)
+		return consumer
+	}
+	defaultConsumer := subscribe("default-topic")
+	otherConsumer := subscribe("other-topic")
+
+	for _, payload := range []string{
+		`{"message":{"event":"a"},"userId":"user-1"}`,
+		`{"message":{"event":"b"},"userId":"user-1"}`,
+		`{"message":{"event":"c"},"userId":"user-2","topic":"other-topic"}`,
+	} {
+		statusCode, respStatus, responseMessage := producer.Produce([]byte(payload), destination.Config)
+		require.Equalf(t, 200, statusCode, responseMessage)
+		require.Equal(t, "Success", respStatus)
+	}
+
+	receive := func(consumer apachepulsar.Consumer) apachepulsar.Message {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		msg, err := consumer.Receive(ctx)
+		require.NoError(t, err)
+		return msg
+	}
+	msg := receive(defaultConsumer)
+	require.Equal(t, "user-1", msg.Key())
+	require.JSONEq(t, `{"event":"a"}`, string(msg.Payload()))
+	msg = receive(defaultConsumer)
+	require.Equal(t, "user-1", msg.Key())
+	require.JSONEq(t, `{"event":"b"}`, string(msg.Payload()))
+	msg = receive(otherConsumer)
+	require.Equal(t, "user-2", msg.Key())
+	require.JSONEq(t, `{"event":"c"}`, string(msg.Payload()))
+}
+
+type mockMessage struct {
+	key, orderingKey, payload string
+}
+
+type mockProducer struct {
+	internalpulsar.ProducerAdapter
+	sendErr  error
+	messages []mockMessage
+	closed   bool
+}
+
+func (m *mockProducer) SendMessage(_ context.Context, key, orderingKey string, msg []byte) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+	m.messages = append(m.messages, mockMessage{key: key, orderingKey: orderingKey, payload: string(msg)})
+	return nil
+}
+
+func (m *mockProducer) Close() { m.closed = true }
+
+type mockClient struct {
+	createErr error
+	sendErr   error
+	producers map[string]*mockProducer
+	closed    bool
+}
+
+func (m *mockClient) NewProducer(opts apachepulsar.ProducerOptions) (internalpulsar.ProducerAdapter, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	if m.producers == nil {
+		m.producers = make(map[string]*mockProducer)
+	}
+	producer := &mockProducer{sendErr: m.sendErr}
+	m.producers[opts.Topic] = producer
+	return producer, nil
+}
+
+func (m *mockClient) Close() { m.closed = true }
@@ -0,0 +1,157 @@
+// Package useragent parses user agents into the browser, operating system and device they have been sent from,
+// using a database of regular expressions. A default database is embedded, which can be replaced by a newer one.
+package useragent
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+)
+
+// ErrInvalidDatabase is returned for databases which cannot be decoded or contain invalid regular expressions
+var ErrInvalidDatabase = errors.New("invalid user agent database")
+
+//go:embed regexes.json
+var defaultDatabase []byte
+
+type Browser struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Type is either "browser", "webview", "email", "library" or "bot"
+	Type string `json:"type,omitempty"`
+}
+
+type OS struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type Device struct {
+	// Type is either "desktop", "mobile", "tablet", "tv", "console" or "bot"
+	Type         string `json:"type,omitempty"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Model        string `json:"model,omitempty"`
+}
+
+// Info is the information parsed from a user agent, whose fields are empty if not matched by any rule of the database
+type Info struct {
+	Browser Browser
+	OS      OS
+	Device  Device
+}
+
+// rule is a regular expression of the database, along with the templates of the fields it sets, e.g. "$1"
+// for the first capturing group. The first rule matching a user agent wins.
+type rule struct {
+	Regex        string `json:"regex"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Type         string `json:"type"`
+	Manufacturer string `json:"manufacturer"`
+	Model        string `json:"model"`
+	// Versions maps the versions found by the rule to their public names, e.g. "6.1" to "7" for Windows
+	Versions map[string]string `json:"versions"`
+
+	regex *regexp.Regexp
+}
+
+type database struct {
+	Browsers []*rule `json:"browsers"`
+	OS       []*rule `json:"os"`
+	Devices  []*rule `json:"devices"`
+}
+
+type Parser struct {
+	db database
+}
+
+// NewDefaultParser returns a parser using the embedded database
+func NewDefaultParser() (*Parser, error) {
+	return NewParser(bytes.NewReader(defaultDatabase))
+}
+
+// NewParserFromFile returns a parser using the database of the file, e.g. downloaded from object storage
+func NewParserFromFile(dbLoc string) (*Parser, error) {
+	f, err := os.Open(dbLoc)
+	if err != nil {
+		return nil, fmt.Errorf("opening user agent database: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return NewParser(f)
+}
+
+// NewParser returns a parser using the json database read from r
+func NewParser(r io.Reader) (*Parser, error) {
+	var db database
+	if err := jsonrs.NewDecoder(r).Decode(&db); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDatabase, err)
+	}
+	for _, rules := range [][]*rule{db.Browsers, db.OS, db.Devices} {
+		for _, rule := range rules {
+			regex, err := regexp.Compile(rule.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidDatabase, err)
+			}
+			rule.regex = regex
+		}
+	}
+	return &Parser{db: db}, nil
+}
+
+// Parse returns the information found in the user agent
+func (p *Parser) Parse(userAgent string) Info {
+	var info Info
+	if rule, match := findRule(p.db.Browsers, userAgent); rule != nil {
+		info.Browser = Browser{
+			Name:    rule.expand(rule.Name, userAgent, match),
+			Version: rule.expandVersion(userAgent, match),
+			Type:    rule.Type,
+		}
+	}
+	if rule, match := findRule(p.db.OS, userAgent); rule != nil {
+		info.OS = OS{
+			Name:    rule.expand(rule.Name, userAgent, match),
+			Version: rule.expandVersion(userAgent, match),
+		}
+	}
+	if rule, match := findRule(p.db.Devices, userAgent); rule != nil {
+		info.Device = Device{
+			Type:         rule.Type,
+			Manufacturer: rule.expand(rule.Manufacturer, userAgent, match),
+			Model:        rule.expand(rule.Model, userAgent, match),
+		}
+	}
+	return info
+}
+
+func findRule(rules []*rule, userAgent string) (*rule, []int) {
+	for _, rule := range rules {
+		if match := rule.regex.FindStringSubmatchIndex(userAgent); match != nil {
+			return rule, match
+		}
+	}
+	return nil, nil
+}
+
+func (r *rule) expand(template, userAgent string, match []int) string {
+	if template == "" {
+		return ""
+	}
+	return strings.TrimSpace(string(r.regex.ExpandString(nil, template, userAgent, match)))
+}
+
+// expandVersion normalizes versions separated by underscores, e.g. "17_1" of iOS
+func (r *rule) expandVersion(userAgent string, match []int) string {
+	version := strings.ReplaceAll(r.expand(r.Version, userAgent, match), "_", ".")
+	if name, ok := r.Versions[version]; ok {
+		return name
+	}
+	return version
+}
@@ -0,0 +1,120 @@
+package useragent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	parser, err := NewDefaultParser()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		userAgent string
+		expected  Info
+	}{
+		{
+			name:      "chrome on windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.109 Safari/537.36",
+			expected: Info{
+				Browser: Browser{Name: "Chrome", Version: "120.0.6099.109", Type: "browser"},
+				OS:      OS{Name: "Windows", Version: "10"},
+				Device:  Device{Type: "desktop"},
+			},
+		},
+		{
+			name:      "safari on iphone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1.2 Mobile/15E148 Safari/604.1",
+			expected: Info{
+				Browser: Browser{Name: "Safari", Version: "17.1.2", Type: "browser"},
+				OS:      OS{Name: "iOS", Version: "17.1.2"},
+				Device:  Device{Type: "mobile", Manufacturer: "Apple", Model: "iPhone"},
+			},
+		},
+		{
+			name:      "edge on mac",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.77",
+			expected: Info{
+				Browser: Browser{Name: "Edge", Version: "120.0.2210.77", Type: "browser"},
+				OS:      OS{Name: "macOS", Version: "10.15.7"},
+				Device:  Device{Type: "desktop", Manufacturer: "Apple", Model: "Mac"},
+			},
+		},
+		{
+			name:      "samsung internet on galaxy",
+			userAgent: "Mozilla/5.0 (Linux; Android 13; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36",
+			expected: Info{
+				Browser: Browser{Name: "Samsung Internet", Version: "23.0", Type: "browser"},
+				OS:      OS{Name: "Android", Version: "13"},
+				Device:  Device{Type: "mobile", Manufacturer: "Samsung", Model: "SM-S918B"},
+			},
+		},
+		{
+			name:      "webview on pixel",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8 Build/UD1A.230803.041; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/119.0.6045.163 Mobile Safari/537.36",
+			expected: Info{
+				Browser: Browser{Name: "Chrome WebView", Version: "119.0.6045.163", Type: "webview"},
+				OS:      OS{Name: "Android", Version: "14"},
+				Device:  Device{Type: "mobile", Manufacturer: "Google", Model: "Pixel 8"},
+			},
+		},
+		{
+			name:      "firefox on linux",
+			userAgent: "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+			expected: Info{
+				Browser: Browser{Name: "Firefox", Version: "121.0", Type: "browser"},
+				OS:      OS{Name: "Ubuntu"},
+				Device:  Device{Type: "desktop"},
+			},
+		},
+		{
+			name:      "android tablet",
+			userAgent: "Mozilla/5.0 (Linux; Android 12; Lenovo TB-X606F) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			expected: Info{
+				Browser: Browser{Name: "Chrome", Version: "120.0.0.0", Type: "browser"},
+				OS:      OS{Name: "Android", Version: "12"},
+				Device:  Device{Type: "tablet"},
+			},
+		},
+		{
+			name:      "crawler",
+			userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			expected: Info{
+				Browser: Browser{Name: "Googlebot", Version: "2.1", Type: "bot"},
+				Device:  Device{Type: "bot"},
+			},
+		},
+		{
+			name:      "http library",
+			userAgent: "python-requests/2.31.0",
+			expected: Info{
+				Browser: Browser{Name: "python-requests", Version: "2.31.0", Type: "library"},
+			},
+		},
+		{
+			name:      "unknown",
+			userAgent: "unknown-agent",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, parser.Parse(tc.userAgent))
+		})
+	}
+}
+
+func TestNewParser(t *testing.T) {
+	parser, err := NewParser(strings.NewReader(`{"browsers": [{"regex": "Custom/(\\d+)", "name": "Custom", "version": "$1", "type": "browser"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, Info{Browser: Browser{Name: "Custom", Version: "2", Type: "browser"}}, parser.Parse("Custom/2"))
+
+	_, err = NewParser(strings.NewReader(`{"os": [{"regex": "("}]}`))
+	require.ErrorIs(t, err, ErrInvalidDatabase)
+	_, err = NewParser(strings.NewReader(`[`))
+	require.ErrorIs(t, err, ErrInvalidDatabase)
+	_, err = NewParserFromFile("./missing.json")
+	require.Error(t, err)
+}
@@ -0,0 +1,10 @@
+---
+--- End LSN of the last stored transaction of every postgres cdc source and replication slot
+---
+
+CREATE TABLE IF NOT EXISTS pg_cdc_checkpoints (
+		source_id TEXT NOT NULL,
+		slot_name TEXT NOT NULL,
+		lsn PG_LSN NOT NULL,
+		updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (source_id, slot_name));
@@ -0,0 +1,9 @@
+---
+--- Rows of the lookup tables joined with the events of sources by the lookup enricher
+---
+
+CREATE TABLE IF NOT EXISTS lookup_table_rows (
+		table_name TEXT NOT NULL,
+		key TEXT NOT NULL,
+		value JSONB NOT NULL,
+		PRIMARY KEY (table_name, key));
@@ -0,0 +1,9 @@
+---
+--- Deduplication keys of the postgres dedup backend, shared among replicas until they expire
+---
+
+CREATE TABLE IF NOT EXISTS dedup_keys (
+		key TEXT PRIMARY KEY,
+		expire_at TIMESTAMP WITH TIME ZONE NOT NULL);
+
+CREATE INDEX IF NOT EXISTS dedup_keys_expire_at_idx ON dedup_keys (expire_at);
@@ -0,0 +1,17 @@
+-- Decisions to widen a column instead of discarding the values not matching its type.
+-- The column's type is either altered to to_type, or the values are loaded as strings into shadow_column.
+CREATE TABLE IF NOT EXISTS wh_schema_column_widenings (
+    id BIGSERIAL PRIMARY KEY,
+    source_id VARCHAR(64) NOT NULL,
+    destination_id VARCHAR(64) NOT NULL,
+    destination_type VARCHAR(64) NOT NULL,
+    namespace VARCHAR(64) NOT NULL,
+    table_name TEXT NOT NULL,
+    column_name TEXT NOT NULL,
+    from_type VARCHAR(64) NOT NULL,
+    to_type VARCHAR(64) NOT NULL,
+    shadow_column TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_wh_schema_column_widening UNIQUE (destination_id, namespace, table_name, column_name)
+);
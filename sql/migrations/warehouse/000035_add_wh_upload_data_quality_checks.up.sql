@@ -0,0 +1,20 @@
+-- Results of the data quality checks run against the tables loaded by an upload.
+-- observed is the value measured by the check, e.g. the number of null values, compared against threshold.
+CREATE TABLE IF NOT EXISTS wh_upload_data_quality_checks (
+    id BIGSERIAL PRIMARY KEY,
+    upload_id BIGINT NOT NULL,
+    source_id VARCHAR(64) NOT NULL,
+    destination_id VARCHAR(64) NOT NULL,
+    namespace VARCHAR(64) NOT NULL,
+    table_name TEXT NOT NULL,
+    check_type VARCHAR(64) NOT NULL,
+    column_name TEXT NOT NULL DEFAULT '',
+    status VARCHAR(64) NOT NULL,
+    observed DOUBLE PRECISION NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS wh_upload_data_quality_checks_upload_id_idx ON wh_upload_data_quality_checks (upload_id);
+CREATE INDEX IF NOT EXISTS wh_upload_data_quality_checks_destination_table_idx ON wh_upload_data_quality_checks (destination_id, namespace, table_name, check_type, upload_id);
@@ -0,0 +1,11 @@
+-- Tables breaching their sync SLA, as last alerted by the SLA monitor for a source, destination and namespace.
+-- Kept, so that restarts neither re-alert ongoing breaches nor miss resolving them.
+CREATE TABLE IF NOT EXISTS wh_sla_breaches (
+    source_id VARCHAR(64) NOT NULL,
+    destination_id VARCHAR(64) NOT NULL,
+    namespace VARCHAR(64) NOT NULL,
+    tables TEXT[] NOT NULL,
+    alerted_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (source_id, destination_id, namespace)
+);
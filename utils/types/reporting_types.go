@@ -25,12 +25,15 @@ const (
 	DiffStatus        = "diff"
 	BotFlaggedStatus  = "bot_flagged"
 	BotDetectedStatus = "bot_detected"
+	PIIMaskedStatus   = "pii_masked"
 
 	// Module names
 	BOT_MANAGEMENT         = "bot_management"
 	EVENT_BLOCKING         = "event_blocking"
 	GATEWAY                = "gateway"
 	DESTINATION_FILTER     = "destination_filter"
+	CONSENT_FILTER         = "consent_filter"
+	PII_MASKING            = "pii_masking"
 	TRACKINGPLAN_VALIDATOR = "tracking_plan_validator"
 	USER_TRANSFORMER       = "user_transformer"
 	EVENT_FILTER           = "event_filter"
@@ -30,9 +30,16 @@ type ConfigurationTestOutput struct {
 	Error string
 }
 
+type PlanInput struct {
+	DestID   string
+	SourceID string
+	UploadID int64
+}
+
 type Admin struct {
 	connectionSources  connectionSourcesFetcher
 	createUploadAlways createUploadAlwaysSetter
+	planner            uploadPlanner
 	logger             logger.Logger
 }
 
@@ -44,14 +51,21 @@ type createUploadAlwaysSetter interface {
 	Store(bool)
 }
 
+type uploadPlanner interface {
+	PlanUpload(ctx context.Context, uploadID int64) (model.UploadPlan, error)
+	PlanPending(ctx context.Context, sourceID, destinationID string) (model.UploadPlan, error)
+}
+
 func New(
 	connectionSources connectionSourcesFetcher,
 	createUploadAlways createUploadAlwaysSetter,
+	planner uploadPlanner,
 	logger logger.Logger,
 ) *Admin {
 	return &Admin{
 		connectionSources:  connectionSources,
 		createUploadAlways: createUploadAlways,
+		planner:            planner,
 		logger:             logger.Child("admin"),
 	}
 }
@@ -138,3 +152,20 @@ func (a *Admin) ConfigurationTest(s ConfigurationTestInput, reply *Configuration
 	reply.Error = res.Error
 	return nil
 }
+
+// Plan shows what an upload would do to the underlying warehouse, without touching the warehouse
+func (a *Admin) Plan(s PlanInput, reply *model.UploadPlan) error {
+	var err error
+	if s.UploadID != 0 {
+		a.logger.Infof(`[WH Admin]: Planning upload: %d`, s.UploadID)
+		*reply, err = a.planner.PlanUpload(context.TODO(), s.UploadID)
+		return err
+	}
+	if strings.TrimSpace(s.DestID) == "" || strings.TrimSpace(s.SourceID) == "" {
+		return errors.New("please specify either the upload ID or both the source ID and destination ID to plan the upload")
+	}
+
+	a.logger.Infof(`[WH Admin]: Planning upload for pending staging files: %s:%s`, s.SourceID, s.DestID)
+	*reply, err = a.planner.PlanPending(context.TODO(), s.SourceID, s.DestID)
+	return err
+}
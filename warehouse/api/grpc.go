@@ -48,6 +48,7 @@ import (
 	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
 	lf "github.com/rudderlabs/rudder-server/warehouse/logfield"
 	"github.com/rudderlabs/rudder-server/warehouse/multitenant"
+	"github.com/rudderlabs/rudder-server/warehouse/router"
 	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
 	"github.com/rudderlabs/rudder-server/warehouse/validations"
 )
@@ -74,8 +75,10 @@ type GRPC struct {
 	stagingRepo        *repo.StagingFiles
 	schemaRepo         *repo.WHSchema
 	uploadRepo         *repo.Uploads
+	dataQualityRepo    *repo.DataQualityChecks
 	triggerStore       *sync.Map
 	fileManagerFactory filemanager.Factory
+	planner            uploadPlanner
 	now                func() time.Time
 
 	config struct {
@@ -93,6 +96,11 @@ type GRPC struct {
 	}
 }
 
+type uploadPlanner interface {
+	PlanUpload(ctx context.Context, uploadID int64) (model.UploadPlan, error)
+	PlanPending(ctx context.Context, sourceID, destinationID string) (model.UploadPlan, error)
+}
+
 func NewGRPCServer(
 	conf *config.Config,
 	logger logger.Logger,
@@ -111,7 +119,9 @@ func NewGRPCServer(
 		uploadRepo:         repo.NewUploads(db),
 		tableUploadsRepo:   repo.NewTableUploads(db, conf),
 		schemaRepo:         repo.NewWHSchemas(db, conf),
+		dataQualityRepo:    repo.NewDataQualityChecks(db),
 		triggerStore:       triggerStore,
+		planner:            router.NewPlanner(conf, logger, db, bcManager),
 		fileManagerFactory: filemanager.New,
 		now:                timeutil.Now,
 	}
@@ -501,6 +511,142 @@ func (g *GRPC) TriggerWHUpload(ctx context.Context, request *proto.WHUploadReque
 	}, nil
 }
 
+func (g *GRPC) PlanWHUpload(ctx context.Context, request *proto.PlanWHUploadRequest) (*proto.PlanWHUploadResponse, error) {
+	g.logger.Infow("Planning warehouse upload",
+		lf.WorkspaceID, request.WorkspaceId,
+		lf.UploadJobID, request.UploadId,
+		lf.SourceID, request.SourceId,
+		lf.DestinationID, request.DestinationId,
+	)
+
+	sourceIDs := g.bcManager.SourceIDsByWorkspace()[request.WorkspaceId]
+	if len(sourceIDs) == 0 {
+		return &proto.PlanWHUploadResponse{},
+			status.Errorf(codes.Code(code.Code_UNAUTHENTICATED), "no sources found for workspace: %v", request.WorkspaceId)
+	}
+
+	var (
+		uploadPlan model.UploadPlan
+		err        error
+	)
+	if request.UploadId != 0 {
+		var upload model.Upload
+		upload, err = g.uploadRepo.Get(ctx, request.UploadId)
+		if errors.Is(err, model.ErrUploadNotFound) {
+			return &proto.PlanWHUploadResponse{},
+				status.Errorf(codes.Code(code.Code_NOT_FOUND), "no sync found for id %d", request.UploadId)
+		}
+		if err != nil {
+			return &proto.PlanWHUploadResponse{},
+				status.Errorf(codes.Code(code.Code_INTERNAL), "unable to get sync id %d: %v", request.UploadId, err)
+		}
+		if !slices.Contains(sourceIDs, upload.SourceID) {
+			return &proto.PlanWHUploadResponse{},
+				status.Error(codes.Code(code.Code_UNAUTHENTICATED), "unauthorized request")
+		}
+
+		uploadPlan, err = g.planner.PlanUpload(ctx, request.UploadId)
+	} else {
+		if request.SourceId == "" || request.DestinationId == "" {
+			return &proto.PlanWHUploadResponse{},
+				status.Error(codes.Code(code.Code_INVALID_ARGUMENT), "please provide either upload id or both source id and destination id")
+		}
+		if !slices.Contains(sourceIDs, request.SourceId) {
+			return &proto.PlanWHUploadResponse{},
+				status.Error(codes.Code(code.Code_UNAUTHENTICATED), "unauthorized request")
+		}
+
+		uploadPlan, err = g.planner.PlanPending(ctx, request.SourceId, request.DestinationId)
+	}
+	if errors.Is(err, router.ErrPlanWarehouseNotFound) || errors.Is(err, router.ErrPlanNoStagingFiles) {
+		return &proto.PlanWHUploadResponse{},
+			status.Errorf(codes.Code(code.Code_NOT_FOUND), "unable to plan sync: %v", err)
+	}
+	if err != nil {
+		return &proto.PlanWHUploadResponse{},
+			status.Errorf(codes.Code(code.Code_INTERNAL), "unable to plan sync: %v", err)
+	}
+
+	return &proto.PlanWHUploadResponse{
+		UploadId:        uploadPlan.UploadID,
+		SourceId:        uploadPlan.SourceID,
+		DestinationId:   uploadPlan.DestinationID,
+		DestinationType: uploadPlan.DestinationType,
+		Namespace:       uploadPlan.Namespace,
+		StagingFiles:    int64(uploadPlan.StagingFiles),
+		Tables: lo.Map(uploadPlan.Tables, func(table model.TablePlan, _ int) *proto.WHTablePlan {
+			return &proto.WHTablePlan{
+				Name:           table.Name,
+				ToBeCreated:    table.TableToBeCreated,
+				AddedColumns:   table.AddedColumns,
+				AlteredColumns: table.AlteredColumns,
+				Rows:           table.Rows,
+				Columns: lo.Map(table.Columns, func(column model.ColumnPlan, _ int) *proto.WHColumnPlan {
+					return &proto.WHColumnPlan{
+						Name:                 column.Name,
+						Discards:             column.Discards,
+						ConstraintViolations: column.ConstraintViolations,
+					}
+				}),
+			}
+		}),
+	}, nil
+}
+
+func (g *GRPC) GetWHUploadDataQualityChecks(ctx context.Context, request *proto.WHUploadRequest) (*proto.WHDataQualityChecksResponse, error) {
+	g.logger.Infow("Getting warehouse upload data quality checks",
+		lf.WorkspaceID, request.WorkspaceId,
+		lf.UploadJobID, request.UploadId,
+	)
+
+	if request.UploadId < 1 {
+		return &proto.WHDataQualityChecksResponse{},
+			status.Errorf(codes.Code(code.Code_INVALID_ARGUMENT), "upload_id should be greater than 0")
+	}
+
+	sourceIDs := g.bcManager.SourceIDsByWorkspace()[request.WorkspaceId]
+	if len(sourceIDs) == 0 {
+		return &proto.WHDataQualityChecksResponse{},
+			status.Errorf(codes.Code(code.Code_UNAUTHENTICATED), "no sources found for workspace: %v", request.WorkspaceId)
+	}
+
+	upload, err := g.uploadRepo.Get(ctx, request.UploadId)
+	if errors.Is(err, model.ErrUploadNotFound) {
+		return &proto.WHDataQualityChecksResponse{},
+			status.Errorf(codes.Code(code.Code_NOT_FOUND), "no sync found for id %d", request.UploadId)
+	}
+	if err != nil {
+		return &proto.WHDataQualityChecksResponse{},
+			status.Errorf(codes.Code(code.Code_INTERNAL), "unable to get sync id %d: %v", request.UploadId, err)
+	}
+	if !slices.Contains(sourceIDs, upload.SourceID) {
+		return &proto.WHDataQualityChecksResponse{},
+			status.Error(codes.Code(code.Code_UNAUTHENTICATED), "unauthorized request")
+	}
+
+	results, err := g.dataQualityRepo.GetByUploadID(ctx, request.UploadId)
+	if err != nil {
+		return &proto.WHDataQualityChecksResponse{},
+			status.Errorf(codes.Code(code.Code_INTERNAL), "unable to get data quality checks for id %d: %v", request.UploadId, err)
+	}
+
+	return &proto.WHDataQualityChecksResponse{
+		UploadId: request.UploadId,
+		Checks: lo.Map(results, func(result model.DataQualityCheckResult, _ int) *proto.WHDataQualityCheck {
+			return &proto.WHDataQualityCheck{
+				TableName:  result.TableName,
+				CheckType:  result.CheckType,
+				ColumnName: result.ColumnName,
+				Status:     result.Status,
+				Observed:   result.Observed,
+				Threshold:  result.Threshold,
+				Message:    result.Message,
+				CreatedAt:  timestamppb.New(result.CreatedAt),
+			}
+		}),
+	}, nil
+}
+
 func (g *GRPC) RetryWHUploads(ctx context.Context, req *proto.RetryWHUploadsRequest) (response *proto.RetryWHUploadsResponse, err error) {
 	g.logger.Infow("Retrying warehouse syncs",
 		lf.WorkspaceID, req.WorkspaceId,
@@ -705,6 +705,170 @@ func TestGRPC(t *testing.T) {
 					require.EqualValues(t, noPendingEvents, res.GetMessage())
 				})
 			})
+
+			t.Run("PlanWHUpload", func(t *testing.T) {
+				t.Run("no sources", func(t *testing.T) {
+					res, err := grpcClient.PlanWHUpload(ctx, &proto.PlanWHUploadRequest{
+						UploadId:    1,
+						WorkspaceId: "unknown_workspace_id",
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.Unauthenticated, statusError.Code())
+					require.Equal(t, "no sources found for workspace: unknown_workspace_id", statusError.Message())
+				})
+
+				t.Run("no upload id + source + destination", func(t *testing.T) {
+					res, err := grpcClient.PlanWHUpload(ctx, &proto.PlanWHUploadRequest{
+						WorkspaceId: workspaceID,
+						SourceId:    sourceID,
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.InvalidArgument, statusError.Code())
+					require.Equal(t, "please provide either upload id or both source id and destination id", statusError.Message())
+				})
+
+				t.Run("unknown id", func(t *testing.T) {
+					res, err := grpcClient.PlanWHUpload(ctx, &proto.PlanWHUploadRequest{
+						UploadId:    -1,
+						WorkspaceId: workspaceID,
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.NotFound, statusError.Code())
+					require.Equal(t, "no sync found for id -1", statusError.Message())
+				})
+
+				t.Run("unauthorized", func(t *testing.T) {
+					res, err := grpcClient.PlanWHUpload(ctx, &proto.PlanWHUploadRequest{
+						UploadId:    1,
+						WorkspaceId: unusedWorkspaceID,
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.Unauthenticated, statusError.Code())
+					require.Equal(t, "unauthorized request", statusError.Message())
+				})
+
+				t.Run("no pending staging files", func(t *testing.T) {
+					res, err := grpcClient.PlanWHUpload(ctx, &proto.PlanWHUploadRequest{
+						WorkspaceId:   workspaceID,
+						SourceId:      sourceID,
+						DestinationId: destinationID,
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.NotFound, statusError.Code())
+					require.Equal(t, "unable to plan sync: no staging files to plan", statusError.Message())
+				})
+			})
+
+			t.Run("GetWHUploadDataQualityChecks", func(t *testing.T) {
+				t.Run("invalid id", func(t *testing.T) {
+					res, err := grpcClient.GetWHUploadDataQualityChecks(ctx, &proto.WHUploadRequest{
+						UploadId:    -1,
+						WorkspaceId: workspaceID,
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.InvalidArgument, statusError.Code())
+					require.Equal(t, "upload_id should be greater than 0", statusError.Message())
+				})
+
+				t.Run("no sources", func(t *testing.T) {
+					res, err := grpcClient.GetWHUploadDataQualityChecks(ctx, &proto.WHUploadRequest{
+						UploadId:    1,
+						WorkspaceId: "unknown_workspace_id",
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.Unauthenticated, statusError.Code())
+					require.Equal(t, "no sources found for workspace: unknown_workspace_id", statusError.Message())
+				})
+
+				t.Run("unknown id", func(t *testing.T) {
+					res, err := grpcClient.GetWHUploadDataQualityChecks(ctx, &proto.WHUploadRequest{
+						UploadId:    1001,
+						WorkspaceId: workspaceID,
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.NotFound, statusError.Code())
+					require.Equal(t, "no sync found for id 1001", statusError.Message())
+				})
+
+				t.Run("unauthorized", func(t *testing.T) {
+					res, err := grpcClient.GetWHUploadDataQualityChecks(ctx, &proto.WHUploadRequest{
+						UploadId:    1,
+						WorkspaceId: unusedWorkspaceID,
+					})
+					require.Error(t, err)
+					require.Empty(t, res)
+
+					statusError, ok := status.FromError(err)
+					require.True(t, ok)
+					require.Equal(t, codes.Unauthenticated, statusError.Code())
+					require.Equal(t, "unauthorized request", statusError.Message())
+				})
+
+				t.Run("success", func(t *testing.T) {
+					err := repo.NewDataQualityChecks(db, repo.WithNow(func() time.Time {
+						return now
+					})).Replace(ctx, 1, []model.DataQualityCheckResult{
+						{
+							SourceID:      sourceID,
+							DestinationID: destinationID,
+							TableName:     "tracks",
+							CheckType:     model.NotNullCheck,
+							ColumnName:    "user_id",
+							Status:        model.DataQualityCheckFailed,
+							Observed:      3,
+							Message:       "3 null values in column user_id",
+						},
+					})
+					require.NoError(t, err)
+
+					res, err := grpcClient.GetWHUploadDataQualityChecks(ctx, &proto.WHUploadRequest{
+						UploadId:    1,
+						WorkspaceId: workspaceID,
+					})
+					require.NoError(t, err)
+					require.EqualValues(t, 1, res.GetUploadId())
+					require.Len(t, res.GetChecks(), 1)
+					require.Equal(t, "tracks", res.GetChecks()[0].GetTableName())
+					require.Equal(t, model.NotNullCheck, res.GetChecks()[0].GetCheckType())
+					require.Equal(t, "user_id", res.GetChecks()[0].GetColumnName())
+					require.Equal(t, model.DataQualityCheckFailed, res.GetChecks()[0].GetStatus())
+					require.EqualValues(t, 3, res.GetChecks()[0].GetObserved())
+					require.Equal(t, "3 null values in column user_id", res.GetChecks()[0].GetMessage())
+					require.Equal(t, now, res.GetChecks()[0].GetCreatedAt().AsTime())
+				})
+			})
 		})
 
 		t.Run("Retry", func(t *testing.T) {
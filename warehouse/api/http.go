@@ -69,6 +69,22 @@ type triggerUploadRequest struct {
 	DestinationID string `json:"destination_id"`
 }
 
+type dataQualityChecksResponse struct {
+	UploadID int64              `json:"upload_id"`
+	Checks   []dataQualityCheck `json:"checks"`
+}
+
+type dataQualityCheck struct {
+	TableName  string    `json:"table_name"`
+	CheckType  string    `json:"check_type"`
+	ColumnName string    `json:"column_name,omitempty"`
+	Status     string    `json:"status"`
+	Observed   float64   `json:"observed"`
+	Threshold  float64   `json:"threshold"`
+	Message    string    `json:"message,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type Api struct {
 	mode          string
 	conf          *config.Config
@@ -83,6 +99,7 @@ type Api struct {
 	stagingRepo   *repo.StagingFiles
 	uploadRepo    *repo.Uploads
 	schemaRepo    *repo.WHSchema
+	dqRepo        *repo.DataQualityChecks
 	triggerStore  *sync.Map
 
 	config struct {
@@ -122,6 +139,7 @@ func NewApi(
 		stagingRepo:   repo.NewStagingFiles(db, conf),
 		uploadRepo:    repo.NewUploads(db),
 		schemaRepo:    repo.NewWHSchemas(db, conf),
+		dqRepo:        repo.NewDataQualityChecks(db),
 	}
 	a.config.healthTimeout = conf.GetDuration("Warehouse.healthTimeout", 10, time.Second)
 	a.config.readerHeaderTimeout = conf.GetDuration("Warehouse.readerHeaderTimeout", 3, time.Second)
@@ -187,6 +205,7 @@ func (a *Api) addMasterEndpoints(ctx context.Context, r chi.Router) {
 		r.Route("/warehouse", func(r chi.Router) {
 			r.Post("/pending-events", a.logMiddleware(a.pendingEventsHandler))
 			r.Post("/trigger-upload", a.logMiddleware(a.triggerUploadHandler))
+			r.Get("/data-quality-checks", a.logMiddleware(a.dataQualityChecksHandler))
 
 			r.Post("/jobs", a.logMiddleware(a.sourceManager.InsertJobHandler))       // TODO: add degraded mode
 			r.Get("/jobs/status", a.logMiddleware(a.sourceManager.StatusJobHandler)) // TODO: add degraded mode
@@ -421,6 +440,52 @@ func (a *Api) triggerUploadHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+func (a *Api) dataQualityChecksHandler(w http.ResponseWriter, r *http.Request) {
+	uploadID, err := strconv.ParseInt(r.URL.Query().Get("upload_id"), 10, 64)
+	if err != nil || uploadID < 1 {
+		a.logger.Warnw("invalid upload id for fetching data quality checks", lf.UploadJobID, r.URL.Query().Get("upload_id"))
+		http.Error(w, "upload_id should be greater than 0", http.StatusBadRequest)
+		return
+	}
+
+	results, err := a.dqRepo.GetByUploadID(r.Context(), uploadID)
+	if err != nil {
+		if errors.Is(r.Context().Err(), context.Canceled) {
+			http.Error(w, ierrors.ErrRequestCancelled.Error(), http.StatusBadRequest)
+			return
+		}
+		a.logger.Errorw("fetching data quality checks", lf.UploadJobID, uploadID, lf.Error, err.Error())
+		http.Error(w, "can't fetch data quality checks", http.StatusInternalServerError)
+		return
+	}
+
+	checks := make([]dataQualityCheck, 0, len(results))
+	for _, result := range results {
+		checks = append(checks, dataQualityCheck{
+			TableName:  result.TableName,
+			CheckType:  result.CheckType,
+			ColumnName: result.ColumnName,
+			Status:     result.Status,
+			Observed:   result.Observed,
+			Threshold:  result.Threshold,
+			Message:    result.Message,
+			CreatedAt:  result.CreatedAt,
+		})
+	}
+
+	resBody, err := jsonrs.Marshal(dataQualityChecksResponse{
+		UploadID: uploadID,
+		Checks:   checks,
+	})
+	if err != nil {
+		a.logger.Errorw("marshalling response for fetching data quality checks", lf.Error, err.Error())
+		http.Error(w, ierrors.ErrMarshallResponse.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = w.Write(resBody)
+}
+
 func (a *Api) fetchTablesHandler(w http.ResponseWriter, r *http.Request) {
 	defer func() { _ = r.Body.Close() }()
 
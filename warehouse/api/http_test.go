@@ -633,6 +633,61 @@ func TestHTTPApi(t *testing.T) {
 		})
 	})
 
+	t.Run("data quality checks handler", func(t *testing.T) {
+		t.Run("invalid upload id", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/warehouse/data-quality-checks?upload_id=invalid", nil)
+			resp := httptest.NewRecorder()
+
+			a := NewApi(config.MasterMode, config.New(), logger.NOP, stats.NOP, mockBackendConfig, db, n, tenantManager, bcManager, sourcesManager, triggerStore)
+			a.dataQualityChecksHandler(resp, req)
+			require.Equal(t, http.StatusBadRequest, resp.Code)
+
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, "upload_id should be greater than 0\n", string(b))
+		})
+
+		t.Run("succeed", func(t *testing.T) {
+			err := repo.NewDataQualityChecks(db, repo.WithNow(func() time.Time {
+				return now
+			})).Replace(ctx, uploadID, []model.DataQualityCheckResult{
+				{
+					SourceID:      sourceID,
+					DestinationID: destinationID,
+					Namespace:     namespace,
+					TableName:     "test_table_1",
+					CheckType:     model.UniqueIDCheck,
+					ColumnName:    "id",
+					Status:        model.DataQualityCheckPassed,
+				},
+			})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/warehouse/data-quality-checks?upload_id=%d", uploadID), nil)
+			resp := httptest.NewRecorder()
+
+			a := NewApi(config.MasterMode, config.New(), logger.NOP, stats.NOP, mockBackendConfig, db, n, tenantManager, bcManager, sourcesManager, triggerStore)
+			a.dataQualityChecksHandler(resp, req)
+			require.Equal(t, http.StatusOK, resp.Code)
+
+			var dqr dataQualityChecksResponse
+			err = jsonrs.NewDecoder(resp.Body).Decode(&dqr)
+			require.NoError(t, err)
+			require.Equal(t, dataQualityChecksResponse{
+				UploadID: uploadID,
+				Checks: []dataQualityCheck{
+					{
+						TableName:  "test_table_1",
+						CheckType:  model.UniqueIDCheck,
+						ColumnName: "id",
+						Status:     model.DataQualityCheckPassed,
+						CreatedAt:  now,
+					},
+				},
+			}, dqr)
+		})
+	})
+
 	t.Run("trigger uploads handler", func(t *testing.T) {
 		t.Run("invalid payload", func(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/v1/warehouse/trigger-upload", bytes.NewReader([]byte(`"Invalid payload"`)))
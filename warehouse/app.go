@@ -207,6 +207,7 @@ func (a *App) Setup(ctx context.Context) error {
 	a.admin = whadmin.New(
 		a.bcManager,
 		a.createUploadAlways,
+		router.NewPlanner(a.conf, a.logger, a.db, a.bcManager),
 		a.logger,
 	)
 
@@ -384,9 +384,22 @@ func (a *Archiver) archiveUploads(ctx context.Context, maxArchiveLimit int) erro
 			}
 		}
 
+		// delete data quality check results
+		stmt := fmt.Sprintf(`
+			DELETE FROM %s
+			WHERE upload_id = $1;`,
+			pq.QuoteIdentifier(warehouseutils.WarehouseUploadDataQualityChecksTable),
+		)
+		_, err = txn.ExecContext(ctx, stmt, u.uploadID)
+		if err != nil {
+			a.log.Errorf(`[Archiver]: Error running txn in archiveUploadFiles. Query: %s Error: %v`, stmt, err)
+			_ = txn.Rollback()
+			continue
+		}
+
 		// update upload metadata
 		u.uploadMetadata, _ = sjson.SetBytes(u.uploadMetadata, "archivedStagingAndLoadFiles", true)
-		stmt := fmt.Sprintf(`
+		stmt = fmt.Sprintf(`
 			UPDATE %s
 			SET metadata = $1
 			WHERE id = $2;`,
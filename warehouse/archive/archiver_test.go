@@ -151,6 +151,7 @@ func TestArchiver(t *testing.T) {
 			for _, table := range []string{
 				warehouseutils.WarehouseLoadFilesTable,
 				warehouseutils.WarehouseStagingFilesTable,
+				warehouseutils.WarehouseUploadDataQualityChecksTable,
 			} {
 				var count int
 				err := pgResource.DB.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM %q`, table)).Scan(&count)
@@ -94,4 +94,30 @@ VALUES
     'POSTGRES', 'test-table', 1, NOW(),
     '{}'
   );
+INSERT INTO wh_upload_data_quality_checks (
+  upload_id, source_id, destination_id,
+  namespace, table_name, check_type,
+  status, observed, threshold, created_at
+)
+VALUES
+  (
+    1, 'test-sourceID', 'test-destinationID',
+    'test-namespace', 'test-table', 'row_count_delta',
+    'passed', 1, 50, NOW()
+  ),
+  (
+    2, 'test-sourceID', 'test-destinationID',
+    'test-namespace', 'test-table', 'row_count_delta',
+    'passed', 1, 50, NOW()
+  ),
+  (
+    3, 'test-sourceID', 'test-destinationID',
+    'test-namespace', 'test-table', 'row_count_delta',
+    'passed', 1, 50, NOW()
+  ),
+  (
+    4, 'test-sourceID', 'test-destinationID',
+    'test-namespace', 'test-table', 'row_count_delta',
+    'passed', 1, 50, NOW()
+  );
 COMMIT;
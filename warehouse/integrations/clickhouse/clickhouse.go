@@ -155,6 +155,11 @@ type Clickhouse struct {
 		slowQueryThreshold          time.Duration
 		randomLoadDelay             func(string) time.Duration
 		disableLoadTableStats       func(string) bool
+		loadMode                    func(string) string
+		nativeLoadBlockSize         func(string) int
+		nativeLoadParallelism       func(string) int
+		nativeLoadAsyncInsert       bool
+		nativeLoadDedupWindow       int
 	}
 }
 
@@ -178,6 +183,7 @@ type clickHouseStat struct {
 	failRetries           stats.Counter
 	execTimeouts          stats.Counter
 	commitTimeouts        stats.Counter
+	sendBlockTime         stats.Timer
 }
 
 // newClickHouseStat Creates a new clickHouseStat instance
@@ -198,6 +204,7 @@ func (ch *Clickhouse) newClickHouseStat(tableName string) *clickHouseStat {
 	failRetries := ch.stats.NewTaggedStat("warehouse.clickhouse.failedRetries", stats.CountType, tags)
 	execTimeouts := ch.stats.NewTaggedStat("warehouse.clickhouse.execTimeouts", stats.CountType, tags)
 	commitTimeouts := ch.stats.NewTaggedStat("warehouse.clickhouse.commitTimeouts", stats.CountType, tags)
+	sendBlockTime := ch.stats.NewTaggedStat("warehouse.clickhouse.sendBlockTime", stats.TimerType, tags)
 
 	return &clickHouseStat{
 		numRowsLoadFile:       numRowsLoadFile,
@@ -207,6 +214,7 @@ func (ch *Clickhouse) newClickHouseStat(tableName string) *clickHouseStat {
 		failRetries:           failRetries,
 		execTimeouts:          execTimeouts,
 		commitTimeouts:        commitTimeouts,
+		sendBlockTime:         sendBlockTime,
 	}
 }
 
@@ -246,6 +254,31 @@ func New(conf *config.Config, log logger.Logger, stat stats.Stats) *Clickhouse {
 		)
 		return time.Duration(float64(maxDelay) * (1 - rand.Float64()))
 	}
+	ch.config.loadMode = func(workspaceID string) string {
+		return conf.GetStringVar(
+			"",
+			fmt.Sprintf("Warehouse.clickhouse.%s.loadMode", workspaceID),
+			"Warehouse.clickhouse.loadMode",
+		)
+	}
+	ch.config.nativeLoadBlockSize = func(tableName string) int {
+		return conf.GetIntVar(
+			100000,
+			1,
+			fmt.Sprintf("Warehouse.clickhouse.nativeLoad.%s.blockSize", tableName),
+			"Warehouse.clickhouse.nativeLoad.blockSize",
+		)
+	}
+	ch.config.nativeLoadParallelism = func(tableName string) int {
+		return conf.GetIntVar(
+			4,
+			1,
+			fmt.Sprintf("Warehouse.clickhouse.nativeLoad.%s.parallelism", tableName),
+			"Warehouse.clickhouse.nativeLoad.parallelism",
+		)
+	}
+	ch.config.nativeLoadAsyncInsert = conf.GetBool("Warehouse.clickhouse.nativeLoad.asyncInsert", false)
+	ch.config.nativeLoadDedupWindow = conf.GetInt("Warehouse.clickhouse.nativeLoad.deduplicationWindow", 1000)
 
 	return ch
 }
@@ -501,17 +534,21 @@ func (ch *Clickhouse) typecastDataFromType(data, dataType string) interface{} {
 	return dataI
 }
 
-// loadTable loads table to clickhouse from the load files
-func (ch *Clickhouse) loadTable(ctx context.Context, tableName string, tableSchemaInUpload model.TableSchema) (err error) {
+// loadTable loads table to clickhouse from the load files.
+// The number of rows loaded is only known when loading using the native protocol, otherwise it is -1.
+func (ch *Clickhouse) loadTable(ctx context.Context, tableName string, tableSchemaInUpload model.TableSchema) (rowsLoaded int64, err error) {
 	if delay := ch.config.randomLoadDelay(ch.Warehouse.WorkspaceID); delay > 0 {
 		if err = misc.SleepCtx(ctx, delay); err != nil {
-			return
+			return -1, err
 		}
 	}
 	if ch.UseS3CopyEngineForLoading() {
-		return ch.loadByCopyCommand(ctx, tableName, tableSchemaInUpload)
+		return -1, ch.loadByCopyCommand(ctx, tableName, tableSchemaInUpload)
 	}
-	return ch.loadByDownloadingLoadFiles(ctx, tableName, tableSchemaInUpload)
+	if ch.UseNativeProtocolForLoading() {
+		return ch.loadByNativeProtocol(ctx, tableName, tableSchemaInUpload)
+	}
+	return -1, ch.loadByDownloadingLoadFiles(ctx, tableName, tableSchemaInUpload)
 }
 
 func (ch *Clickhouse) UseS3CopyEngineForLoading() bool {
@@ -1021,7 +1058,7 @@ func (ch *Clickhouse) FetchSchema(ctx context.Context) (model.Schema, error) {
 
 func (ch *Clickhouse) LoadUserTables(ctx context.Context) (errorMap map[string]error) {
 	errorMap = map[string]error{warehouseutils.IdentifiesTable: nil}
-	err := ch.loadTable(ctx, warehouseutils.IdentifiesTable, ch.Uploader.GetTableSchemaInUpload(warehouseutils.IdentifiesTable))
+	_, err := ch.loadTable(ctx, warehouseutils.IdentifiesTable, ch.Uploader.GetTableSchemaInUpload(warehouseutils.IdentifiesTable))
 	if err != nil {
 		errorMap[warehouseutils.IdentifiesTable] = err
 		return
@@ -1031,7 +1068,7 @@ func (ch *Clickhouse) LoadUserTables(ctx context.Context) (errorMap map[string]e
 		return
 	}
 	errorMap[warehouseutils.UsersTable] = nil
-	err = ch.loadTable(ctx, warehouseutils.UsersTable, ch.Uploader.GetTableSchemaInUpload(warehouseutils.UsersTable))
+	_, err = ch.loadTable(ctx, warehouseutils.UsersTable, ch.Uploader.GetTableSchemaInUpload(warehouseutils.UsersTable))
 	if err != nil {
 		errorMap[warehouseutils.UsersTable] = err
 		return
@@ -1044,20 +1081,27 @@ func (ch *Clickhouse) LoadTable(ctx context.Context, tableName string) (*types.L
 		preLoadTableCount int64
 		err               error
 	)
-	if !ch.config.disableLoadTableStats(ch.Warehouse.WorkspaceID) {
+	// rows loaded using the native protocol are counted while loading, so there is no need to count them in the table
+	countRows := !ch.config.disableLoadTableStats(ch.Warehouse.WorkspaceID) && !ch.UseNativeProtocolForLoading()
+	if countRows {
 		preLoadTableCount, err = ch.totalCountIntable(ctx, tableName)
 		if err != nil {
 			return nil, fmt.Errorf("pre load table count: %w", err)
 		}
 	}
 
-	err = ch.loadTable(ctx, tableName, ch.Uploader.GetTableSchemaInUpload(tableName))
+	rowsLoaded, err := ch.loadTable(ctx, tableName, ch.Uploader.GetTableSchemaInUpload(tableName))
 	if err != nil {
 		return nil, fmt.Errorf("loading table: %w", err)
 	}
+	if rowsLoaded >= 0 {
+		return &types.LoadTableStats{
+			RowsInserted: rowsLoaded,
+		}, nil
+	}
 
 	var postLoadTableCount int64
-	if !ch.config.disableLoadTableStats(ch.Warehouse.WorkspaceID) {
+	if countRows {
 		postLoadTableCount, err = ch.totalCountIntable(ctx, tableName)
 		if err != nil {
 			return nil, fmt.Errorf("post load table count: %w", err)
@@ -558,6 +558,8 @@ func TestIntegration(t *testing.T) {
 			S3EngineEnabledWorkspaceIDs []string
 			disableNullable             bool
 			disableLoadTableStats       bool
+			loadMode                    string
+			nativeLoadAsyncInsert       bool
 		}{
 			{
 				name:                  "normal loading using downloading of load files",
@@ -588,6 +590,23 @@ func TestIntegration(t *testing.T) {
 				fileName:              "testdata/load.csv.gz",
 				disableLoadTableStats: true,
 			},
+			{
+				name:     "native loading",
+				fileName: "testdata/load.csv.gz",
+				loadMode: "native",
+			},
+			{
+				name:            "native loading with disable nullable",
+				fileName:        "testdata/load.csv.gz",
+				loadMode:        "native",
+				disableNullable: true,
+			},
+			{
+				name:                  "native loading using async inserts",
+				fileName:              "testdata/load.csv.gz",
+				loadMode:              "native",
+				nativeLoadAsyncInsert: true,
+			},
 		}
 
 		for i, tc := range testCases {
@@ -596,6 +615,9 @@ func TestIntegration(t *testing.T) {
 				conf.Set("Warehouse.clickhouse.s3EngineEnabledWorkspaceIDs", tc.S3EngineEnabledWorkspaceIDs)
 				conf.Set("Warehouse.clickhouse.disableNullable", tc.disableNullable)
 				conf.Set("Warehouse.clickhouse.disableLoadTableStats", tc.disableLoadTableStats)
+				conf.Set("Warehouse.clickhouse.loadMode", tc.loadMode)
+				conf.Set("Warehouse.clickhouse.nativeLoad.asyncInsert", tc.nativeLoadAsyncInsert)
+				conf.Set("Warehouse.clickhouse.nativeLoad.blockSize", 3)
 
 				ch := clickhouse.New(conf, logger.NOP, stats.NOP)
 
@@ -750,6 +772,17 @@ func TestIntegration(t *testing.T) {
 				if !tc.disableLoadTableStats {
 					require.NotEmpty(t, loadTableStats.RowsInserted)
 				}
+				if tc.loadMode == "native" {
+					var count int64
+					err = ch.DB.QueryRowContext(ctx, fmt.Sprintf(`SELECT count(*) FROM %q.%q`, warehouse.Namespace, table)).Scan(&count)
+					require.NoError(t, err)
+					require.Equal(t, count, loadTableStats.RowsInserted)
+
+					var createTableQuery string
+					err = ch.DB.QueryRowContext(ctx, `SELECT create_table_query FROM system.tables WHERE database = ? AND name = ?`, warehouse.Namespace, table).Scan(&createTableQuery)
+					require.NoError(t, err)
+					require.Contains(t, createTableQuery, "non_replicated_deduplication_window = 1000")
+				}
 
 				t.Log("Drop table")
 				err = ch.DropTable(ctx, table)
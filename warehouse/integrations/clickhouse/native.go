@@ -0,0 +1,339 @@
+package clickhouse
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	chgo "github.com/ClickHouse/ch-go"
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/cenkalti/backoff/v4"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rudderlabs/rudder-server/utils/misc"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+// nativeLoadMode loads tables by streaming the rows of the load files in blocks using the native protocol,
+// instead of inserting them one by one through a prepared statement.
+const nativeLoadMode = "native"
+
+func (ch *Clickhouse) UseNativeProtocolForLoading() bool {
+	return ch.config.loadMode(ch.Warehouse.WorkspaceID) == nativeLoadMode
+}
+
+// nativeClientOptions returns the options for connecting to clickhouse using the native protocol client
+func (ch *Clickhouse) nativeClientOptions() chgo.Options {
+	opts := chgo.Options{
+		Address:     net.JoinHostPort(ch.Warehouse.GetStringDestinationConfig(ch.conf, model.HostSetting), ch.Warehouse.GetStringDestinationConfig(ch.conf, model.PortSetting)),
+		Database:    ch.Warehouse.GetStringDestinationConfig(ch.conf, model.DatabaseSetting),
+		User:        ch.Warehouse.GetStringDestinationConfig(ch.conf, model.UserSetting),
+		Password:    ch.Warehouse.GetStringDestinationConfig(ch.conf, model.PasswordSetting),
+		DialTimeout: ch.connectTimeout,
+	}
+	if readTimeout, err := strconv.Atoi(ch.config.readTimeout); err == nil {
+		opts.ReadTimeout = time.Duration(readTimeout) * time.Second
+	}
+	if ch.config.compress {
+		opts.Compression = chgo.CompressionLZ4
+	}
+	if ch.Warehouse.GetBoolDestinationConfig(model.SecureSetting) {
+		opts.TLS = &tls.Config{
+			InsecureSkipVerify: ch.Warehouse.GetBoolDestinationConfig(model.SkipVerifySetting), // #nosec G402 -- opted in by the destination's configuration
+		}
+		if certificate := ch.Warehouse.GetStringDestinationConfig(ch.conf, model.CACertificateSetting); strings.TrimSpace(certificate) != "" {
+			caCertPool := x509.NewCertPool()
+			caCertPool.AppendCertsFromPEM([]byte(certificate))
+			opts.TLS.RootCAs = caCertPool
+		}
+	}
+	return opts
+}
+
+// loadByNativeProtocol loads the table by streaming the rows of the load files in blocks using the native protocol.
+// Up to nativeLoad.parallelism load files are loaded in parallel, each one in a single insert which is retried on failure.
+// Inserts carry a deduplication token, so that retried inserts are deduplicated. Replicated tables deduplicate inserts
+// by default, whereas non-replicated ones need a non_replicated_deduplication_window, which is set before loading.
+// It returns the number of rows loaded.
+func (ch *Clickhouse) loadByNativeProtocol(ctx context.Context, tableName string, tableSchemaInUpload model.TableSchema) (int64, error) {
+	ch.logger.Infof("%s LoadTable using native protocol Started", ch.GetLogIdentifier(tableName))
+	defer ch.logger.Infof("%s LoadTable using native protocol Completed", ch.GetLogIdentifier(tableName))
+
+	chStats := ch.newClickHouseStat(tableName)
+
+	columnKeys := warehouseutils.SortColumnKeysFromColumnMap(tableSchemaInUpload)
+	columnTypes, err := ch.columnTypes(ctx, tableName)
+	if err != nil {
+		return 0, fmt.Errorf("fetching column types: %w", err)
+	}
+	for _, columnName := range columnKeys {
+		columnType, ok := columnTypes[columnName]
+		if !ok {
+			return 0, fmt.Errorf("column %s not found in table %s", columnName, tableName)
+		}
+		if _, err := newNativeColumn(columnType); err != nil {
+			return 0, fmt.Errorf("column %s: %w", columnName, err)
+		}
+	}
+	if err := ch.ensureDeduplicationWindow(ctx, tableName); err != nil {
+		return 0, fmt.Errorf("ensuring deduplication window: %w", err)
+	}
+
+	downloadStart := time.Now()
+	fileNames, err := ch.LoadFileDownloader.Download(ctx, tableName)
+	chStats.downloadLoadFilesTime.Since(downloadStart)
+	if err != nil {
+		return 0, fmt.Errorf("downloading load files: %w", err)
+	}
+	defer misc.RemoveFilePaths(fileNames...)
+
+	loader := &nativeLoader{
+		ch:          ch,
+		schema:      tableSchemaInUpload,
+		columnKeys:  columnKeys,
+		columnTypes: columnTypes,
+		blockSize:   max(ch.config.nativeLoadBlockSize(tableName), 1),
+		stats:       chStats,
+	}
+	loader.query = fmt.Sprintf(`INSERT INTO %q.%q (%s) VALUES`, ch.Namespace, tableName, warehouseutils.DoubleQuoteAndJoinByComma(columnKeys))
+	loader.settings = []chgo.Setting{
+		{Key: "insert_deduplicate", Value: "1", Important: true},
+	}
+	if ch.config.nativeLoadAsyncInsert {
+		loader.settings = append(loader.settings,
+			chgo.Setting{Key: "async_insert", Value: "1", Important: true},
+			chgo.Setting{Key: "wait_for_async_insert", Value: "1", Important: true},
+			chgo.Setting{Key: "async_insert_deduplicate", Value: "1", Important: true},
+		)
+	}
+
+	var rowsLoaded atomic.Int64
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(max(ch.config.nativeLoadParallelism(tableName), 1))
+	for _, fileName := range fileNames {
+		g.Go(func() error {
+			operation := func() error {
+				rows, err := loader.loadFile(gCtx, fileName)
+				if err != nil {
+					return err
+				}
+				rowsLoaded.Add(rows)
+				return nil
+			}
+			backoffWithMaxRetry := backoff.WithContext(backoff.WithMaxRetries(backoff.NewConstantBackOff(1*time.Second), uint64(ch.config.loadTableFailureRetries)), gCtx)
+			return backoff.RetryNotify(operation, backoffWithMaxRetry, func(err error, t time.Duration) {
+				ch.logger.Warnf("%s Retrying loading file %s in %s with error: %v", ch.GetLogIdentifier(tableName), fileName, t, err)
+				chStats.failRetries.Count(1)
+			})
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return 0, fmt.Errorf("loading table using native protocol: %w", err)
+	}
+	return rowsLoaded.Load(), nil
+}
+
+// columnTypes returns the clickhouse types of the columns of the table
+func (ch *Clickhouse) columnTypes(ctx context.Context, tableName string) (map[string]string, error) {
+	rows, err := ch.DB.QueryContext(ctx, `SELECT name, type FROM system.columns WHERE database = ? AND table = ?`, ch.Namespace, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("querying columns: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columnTypes := make(map[string]string)
+	for rows.Next() {
+		var name, columnType string
+		if err := rows.Scan(&name, &columnType); err != nil {
+			return nil, fmt.Errorf("scanning columns: %w", err)
+		}
+		columnTypes[name] = columnType
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating columns: %w", err)
+	}
+	return columnTypes, nil
+}
+
+var nonReplicatedDedupWindowRegex = regexp.MustCompile(`non_replicated_deduplication_window\s*=\s*(\d+)`)
+
+// ensureDeduplicationWindow makes sure that a non-replicated table keeps the hashes of at least nativeLoad.deduplicationWindow
+// recent inserts, since otherwise insert_deduplication_token has no effect and retried inserts would load duplicates.
+// Replicated tables deduplicate inserts by default.
+func (ch *Clickhouse) ensureDeduplicationWindow(ctx context.Context, tableName string) error {
+	var engine, createTableQuery string
+	err := ch.DB.QueryRowContext(ctx, `SELECT engine, create_table_query FROM system.tables WHERE database = ? AND name = ?`, ch.Namespace, tableName).Scan(&engine, &createTableQuery)
+	if err != nil {
+		return fmt.Errorf("querying table engine: %w", err)
+	}
+	if strings.HasPrefix(engine, "Replicated") || !strings.HasSuffix(engine, "MergeTree") {
+		return nil
+	}
+
+	var window int
+	if match := nonReplicatedDedupWindowRegex.FindStringSubmatch(createTableQuery); match != nil {
+		window, _ = strconv.Atoi(match[1])
+	} else {
+		var defaultWindow string
+		err := ch.DB.QueryRowContext(ctx, `SELECT value FROM system.merge_tree_settings WHERE name = 'non_replicated_deduplication_window'`).Scan(&defaultWindow)
+		if err != nil {
+			return fmt.Errorf("querying default deduplication window: %w", err)
+		}
+		window, _ = strconv.Atoi(defaultWindow)
+	}
+	if window >= ch.config.nativeLoadDedupWindow {
+		return nil
+	}
+
+	sqlStatement := fmt.Sprintf(`ALTER TABLE %q.%q MODIFY SETTING non_replicated_deduplication_window = %d`, ch.Namespace, tableName, ch.config.nativeLoadDedupWindow)
+	ch.logger.Infof("%s Setting deduplication window: %s", ch.GetLogIdentifier(tableName), sqlStatement)
+	if _, err := ch.DB.ExecContext(ctx, sqlStatement); err != nil {
+		return fmt.Errorf("setting deduplication window: %w", err)
+	}
+	return nil
+}
+
+type nativeLoader struct {
+	ch          *Clickhouse
+	query       string
+	settings    []chgo.Setting
+	schema      model.TableSchema
+	columnKeys  []string
+	columnTypes map[string]string
+	blockSize   int
+	stats       *clickHouseStat
+}
+
+// loadFile loads a load file in a single insert of blocks of up to blockSize rows and returns the number of rows loaded
+func (l *nativeLoader) loadFile(ctx context.Context, fileName string) (int64, error) {
+	syncStart := time.Now()
+
+	gzipFile, err := os.Open(fileName)
+	if err != nil {
+		return 0, fmt.Errorf("opening file %s: %w", fileName, err)
+	}
+	defer func() { _ = gzipFile.Close() }()
+
+	gzipReader, err := gzip.NewReader(gzipFile)
+	if err != nil {
+		return 0, fmt.Errorf("reading file %s using gzip: %w", fileName, err)
+	}
+	defer func() { _ = gzipReader.Close() }()
+
+	columns := make([]nativeColumn, len(l.columnKeys))
+	input := make(proto.Input, len(l.columnKeys))
+	for i, columnName := range l.columnKeys {
+		if columns[i], err = newNativeColumn(l.columnTypes[columnName]); err != nil {
+			return 0, fmt.Errorf("column %s: %w", columnName, err)
+		}
+		input[i] = proto.InputColumn{Name: columnName, Data: columns[i]}
+	}
+
+	var (
+		csvReader  = csv.NewReader(gzipReader)
+		rowsLoaded int64
+		blockStart time.Time
+	)
+	// readBlock reads the next block of rows of the load file into the columns, returning io.EOF if there are none left
+	readBlock := func() error {
+		for _, column := range columns {
+			column.Reset()
+		}
+		for rows := 0; rows < l.blockSize; rows++ {
+			record, err := csvReader.Read()
+			if errors.Is(err, io.EOF) {
+				if rows == 0 {
+					return io.EOF
+				}
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("reading csv file %s: %w", fileName, err)
+			}
+			if len(l.columnKeys) != len(record) {
+				return fmt.Errorf("load file CSV columns for a row mismatch number found in upload schema. Columns in CSV row: %d, Columns in upload schema of table: %d. Processed rows in csv file until mismatch: %d", len(record), len(l.columnKeys), rowsLoaded)
+			}
+			for index, value := range record {
+				columnName := l.columnKeys[index]
+				if err := columns[index].AppendValue(l.ch.typecastDataForNativeLoad(value, l.schema[columnName])); err != nil {
+					return fmt.Errorf("column %s: %w", columnName, err)
+				}
+			}
+			rowsLoaded++
+		}
+		blockStart = time.Now()
+		return nil
+	}
+	if err := readBlock(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	client, err := chgo.Dial(ctx, l.ch.nativeClientOptions())
+	if err != nil {
+		return 0, fmt.Errorf("connecting to clickhouse: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, l.ch.config.execTimeout)
+	defer cancel()
+
+	err = client.Do(ctx, chgo.Query{
+		Body:  l.query,
+		Input: input,
+		OnInput: func(context.Context) error {
+			l.stats.sendBlockTime.Since(blockStart)
+			return readBlock()
+		},
+		Settings: append([]chgo.Setting{
+			{Key: "insert_deduplication_token", Value: filepath.Base(fileName), Important: true},
+		}, l.settings...),
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			l.stats.execTimeouts.Count(1)
+		}
+		return 0, fmt.Errorf("inserting file %s: %w", fileName, err)
+	}
+
+	l.stats.numRowsLoadFile.Count(int(rowsLoaded))
+	l.stats.syncLoadFileTime.Since(syncStart)
+	return rowsLoaded, nil
+}
+
+// typecastDataForNativeLoad typeCasts string data to the Go types expected by nativeColumn for the mentioned data type
+func (ch *Clickhouse) typecastDataForNativeLoad(data, dataType string) any {
+	switch v := ch.typecastDataFromType(data, dataType).(type) {
+	case int:
+		if dataType == "boolean" {
+			return uint8(v)
+		}
+		return int64(v)
+	case []bool:
+		dataUInt8 := make([]uint8, len(v))
+		for i, b := range v {
+			if b {
+				dataUInt8[i] = 1
+			}
+		}
+		return dataUInt8
+	default:
+		return v
+	}
+}
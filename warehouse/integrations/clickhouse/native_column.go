@@ -0,0 +1,174 @@
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/ch-go/proto"
+)
+
+// nativeColumn is a column of a native protocol insert, accepting the values returned by typecastDataForNativeLoad
+type nativeColumn interface {
+	proto.ColInput
+	proto.Preparable
+	proto.StateEncoder
+	Reset()
+	AppendValue(v any) error
+}
+
+// typedNativeColumn is a nativeColumn reporting the type of the table's column,
+// e.g. SimpleAggregateFunction(anyLast, Nullable(String)) for a column encoded as Nullable(String)
+type typedNativeColumn struct {
+	proto.Column
+	columnType  proto.ColumnType
+	appendValue func(v any) error
+}
+
+func (c *typedNativeColumn) Type() proto.ColumnType {
+	return c.columnType
+}
+
+func (c *typedNativeColumn) AppendValue(v any) error {
+	return c.appendValue(v)
+}
+
+func (c *typedNativeColumn) Prepare() error {
+	if p, ok := c.Column.(proto.Preparable); ok {
+		return p.Prepare()
+	}
+	return nil
+}
+
+func (c *typedNativeColumn) EncodeState(b *proto.Buffer) {
+	if s, ok := c.Column.(proto.StateEncoder); ok {
+		s.EncodeState(b)
+	}
+}
+
+// newNativeColumn returns a nativeColumn for a clickhouse column type, as created by CreateTable and AddColumns
+func newNativeColumn(columnType string) (nativeColumn, error) {
+	baseType := columnType
+	if args, ok := unwrapColumnType(baseType, "SimpleAggregateFunction"); ok {
+		_, baseType, _ = strings.Cut(args, ",")
+		baseType = strings.TrimSpace(baseType)
+	}
+	if elementType, ok := unwrapColumnType(baseType, "Array"); ok {
+		switch elementType {
+		case "Int64":
+			return newArrayNativeColumn[int64](columnType, new(proto.ColInt64)), nil
+		case "Float64":
+			return newArrayNativeColumn[float64](columnType, new(proto.ColFloat64)), nil
+		case "String":
+			return newArrayNativeColumn[string](columnType, new(proto.ColStr)), nil
+		case "DateTime":
+			return newArrayNativeColumn(columnType, new(proto.ColDateTime)), nil
+		case "UInt8":
+			return newArrayNativeColumn[uint8](columnType, new(proto.ColUInt8)), nil
+		}
+		return nil, fmt.Errorf("unsupported column type for native loading: %s", columnType)
+	}
+
+	lowCardinality, nullable := false, false
+	if t, ok := unwrapColumnType(baseType, "LowCardinality"); ok {
+		lowCardinality, baseType = true, t
+	}
+	if t, ok := unwrapColumnType(baseType, "Nullable"); ok {
+		nullable, baseType = true, t
+	}
+	if lowCardinality && nullable {
+		return nil, fmt.Errorf("unsupported column type for native loading: %s", columnType)
+	}
+	switch baseType {
+	case "Int64":
+		return newScalarNativeColumn[int64](columnType, new(proto.ColInt64), nullable, lowCardinality), nil
+	case "Float64":
+		return newScalarNativeColumn[float64](columnType, new(proto.ColFloat64), nullable, lowCardinality), nil
+	case "String":
+		return newScalarNativeColumn[string](columnType, new(proto.ColStr), nullable, lowCardinality), nil
+	case "DateTime":
+		return newScalarNativeColumn(columnType, new(proto.ColDateTime), nullable, lowCardinality), nil
+	case "UInt8":
+		return newScalarNativeColumn[uint8](columnType, new(proto.ColUInt8), nullable, lowCardinality), nil
+	}
+	return nil, fmt.Errorf("unsupported column type for native loading: %s", columnType)
+}
+
+func newScalarNativeColumn[T comparable](columnType string, column proto.ColumnOf[T], nullable, lowCardinality bool) nativeColumn {
+	c := &typedNativeColumn{columnType: proto.ColumnType(columnType)}
+	valueOf := func(v any) (value T, valid bool, err error) {
+		if v == nil {
+			return value, false, nil
+		}
+		if value, valid = v.(T); !valid {
+			return value, false, fmt.Errorf("unexpected value %v of type %T for column of type %s", v, v, columnType)
+		}
+		return value, true, nil
+	}
+	switch {
+	case lowCardinality:
+		lowCardinalityColumn := proto.NewLowCardinality(column)
+		c.Column = lowCardinalityColumn
+		c.appendValue = func(v any) error {
+			value, _, err := valueOf(v)
+			if err != nil {
+				return err
+			}
+			lowCardinalityColumn.Append(value)
+			return nil
+		}
+	case nullable:
+		nullableColumn := proto.NewColNullable(column)
+		c.Column = nullableColumn
+		c.appendValue = func(v any) error {
+			value, valid, err := valueOf(v)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				nullableColumn.Append(proto.Null[T]())
+				return nil
+			}
+			nullableColumn.Append(proto.NewNullable(value))
+			return nil
+		}
+	default:
+		c.Column = column
+		c.appendValue = func(v any) error {
+			value, _, err := valueOf(v)
+			if err != nil {
+				return err
+			}
+			column.Append(value)
+			return nil
+		}
+	}
+	return c
+}
+
+func newArrayNativeColumn[T any](columnType string, column proto.ColumnOf[T]) nativeColumn {
+	arrayColumn := proto.NewArray(column)
+	return &typedNativeColumn{
+		Column:     arrayColumn,
+		columnType: proto.ColumnType(columnType),
+		appendValue: func(v any) error {
+			if v == nil {
+				arrayColumn.Append(nil)
+				return nil
+			}
+			values, ok := v.([]T)
+			if !ok {
+				return fmt.Errorf("unexpected value %v of type %T for column of type %s", v, v, columnType)
+			}
+			arrayColumn.Append(values)
+			return nil
+		},
+	}
+}
+
+// unwrapColumnType returns the arguments of a parametric column type, e.g. String for Nullable(String)
+func unwrapColumnType(columnType, name string) (string, bool) {
+	if !strings.HasPrefix(columnType, name+"(") || !strings.HasSuffix(columnType, ")") {
+		return "", false
+	}
+	return columnType[len(name)+1 : len(columnType)-1], true
+}
@@ -0,0 +1,80 @@
+package clickhouse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+)
+
+func TestNewNativeColumn(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+
+	testCases := []struct {
+		columnType string
+		values     []any
+	}{
+		{columnType: "Int64", values: []any{int64(1), nil}},
+		{columnType: "Nullable(Float64)", values: []any{1.5, nil}},
+		{columnType: "LowCardinality(String)", values: []any{"event", "event"}},
+		{columnType: "Nullable(DateTime)", values: []any{now, nil}},
+		{columnType: "UInt8", values: []any{uint8(1), uint8(0)}},
+		{columnType: "SimpleAggregateFunction(anyLast, Nullable(String))", values: []any{"a", nil}},
+		{columnType: "Array(Int64)", values: []any{[]int64{1, 2}, nil}},
+		{columnType: "Array(DateTime)", values: []any{[]time.Time{now}, []time.Time{}}},
+		{columnType: "Array(UInt8)", values: []any{[]uint8{1, 0}, nil}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.columnType, func(t *testing.T) {
+			column, err := newNativeColumn(tc.columnType)
+			require.NoError(t, err)
+			require.Equal(t, proto.ColumnType(tc.columnType), column.Type())
+
+			for _, v := range tc.values {
+				require.NoError(t, column.AppendValue(v))
+			}
+			require.Equal(t, len(tc.values), column.Rows())
+			require.NoError(t, column.Prepare())
+
+			var b proto.Buffer
+			column.EncodeState(&b)
+			column.EncodeColumn(&b)
+			require.NotEmpty(t, b.Buf)
+
+			column.Reset()
+			require.Zero(t, column.Rows())
+		})
+	}
+
+	t.Run("unexpected value", func(t *testing.T) {
+		column, err := newNativeColumn("Nullable(Int64)")
+		require.NoError(t, err)
+		require.EqualError(t, column.AppendValue("1"), "unexpected value 1 of type string for column of type Nullable(Int64)")
+
+		column, err = newNativeColumn("Array(String)")
+		require.NoError(t, err)
+		require.Error(t, column.AppendValue([]int64{1}))
+	})
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, columnType := range []string{"Int32", "Array(Nullable(String))", "LowCardinality(Nullable(String))", "Map(String, String)"} {
+			_, err := newNativeColumn(columnType)
+			require.ErrorContains(t, err, "unsupported column type for native loading", columnType)
+		}
+	})
+}
+
+func TestTypecastDataForNativeLoad(t *testing.T) {
+	ch := New(config.New(), logger.NOP, stats.NOP)
+	require.Equal(t, int64(12), ch.typecastDataForNativeLoad("12", "int"))
+	require.Equal(t, uint8(1), ch.typecastDataForNativeLoad("true", "boolean"))
+	require.Equal(t, 1.5, ch.typecastDataForNativeLoad("1.5", "float"))
+	require.Equal(t, "a", ch.typecastDataForNativeLoad("a", "string"))
+	require.Equal(t, []uint8{1, 0}, ch.typecastDataForNativeLoad("[true,false]", "array(boolean)"))
+	require.Nil(t, ch.typecastDataForNativeLoad("not a number", "int"))
+}
@@ -6,12 +6,16 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/tidwall/gjson"
+
 	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/filemanager"
 
 	"github.com/rudderlabs/rudder-server/warehouse/integrations/types"
 
 	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
 
+	"github.com/rudderlabs/rudder-server/warehouse/integrations/datalake/iceberg"
 	schemarepository "github.com/rudderlabs/rudder-server/warehouse/integrations/datalake/schema-repository"
 
 	"github.com/rudderlabs/rudder-go-kit/logger"
@@ -38,6 +42,11 @@ type Datalake struct {
 	Uploader         warehouseutils.Uploader
 	conf             *config.Config
 	logger           logger.Logger
+	iceberg          *iceberg.Writer
+
+	config struct {
+		icebergRESTCatalogTimeout time.Duration
+	}
 }
 
 func New(conf *config.Config, log logger.Logger) *Datalake {
@@ -46,6 +55,8 @@ func New(conf *config.Config, log logger.Logger) *Datalake {
 	d.conf = conf
 	d.logger = log.Child("integrations").Child("datalake")
 
+	d.config.icebergRESTCatalogTimeout = conf.GetDurationVar(30, time.Second, "Warehouse.datalake.iceberg.restCatalogTimeout")
+
 	return d
 }
 
@@ -54,8 +65,55 @@ func (d *Datalake) Setup(_ context.Context, warehouse model.Warehouse, uploader
 	d.Uploader = uploader
 	useGlueV2 := d.conf.GetBool("FileManager.useAWSV2", false)
 	d.SchemaRepository, err = schemarepository.NewSchemaRepository(d.conf, d.logger, d.Warehouse, d.Uploader, useGlueV2)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if d.Warehouse.GetBoolDestinationConfig(model.EnableIcebergSetting) {
+		if d.iceberg, err = d.newIcebergWriter(); err != nil {
+			return fmt.Errorf("creating iceberg writer: %w", err)
+		}
+	}
+	return nil
+}
+
+// newIcebergWriter returns a writer committing the load files of uploads as snapshots of iceberg tables,
+// tracked by the catalog configured for the destination
+func (d *Datalake) newIcebergWriter() (*iceberg.Writer, error) {
+	provider := warehouseutils.ObjectStorageType(d.Warehouse.Destination.DestinationDefinition.Name, d.Warehouse.Destination.Config, d.Uploader.UseRudderStorage())
+	storageConfig := misc.GetObjectStorageConfig(misc.ObjectStorageOptsT{
+		Provider:         provider,
+		Config:           d.Warehouse.Destination.Config,
+		UseRudderStorage: d.Uploader.UseRudderStorage(),
+		WorkspaceID:      d.Warehouse.Destination.WorkspaceID,
+	})
+	fm, err := filemanager.New(&filemanager.Settings{
+		Provider: provider,
+		Config:   storageConfig,
+		Conf:     d.conf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating filemanager: %w", err)
+	}
+	storage, err := iceberg.NewStorage(fm, provider, storageConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalog iceberg.Catalog
+	switch catalogType := d.Warehouse.GetStringDestinationConfig(d.conf, model.IcebergCatalogTypeSetting); catalogType {
+	case "", iceberg.FilesystemCatalog:
+		catalog = iceberg.NewFilesystemCatalog(storage)
+	case iceberg.RESTCatalog:
+		catalog = iceberg.NewRESTCatalog(
+			d.Warehouse.GetStringDestinationConfig(d.conf, model.IcebergCatalogURISetting),
+			d.Warehouse.GetStringDestinationConfig(d.conf, model.IcebergCatalogTokenSetting),
+			d.config.icebergRESTCatalogTimeout,
+		)
+	default:
+		return nil, fmt.Errorf("unsupported iceberg catalog type: %s", catalogType)
+	}
+	return iceberg.NewWriter(catalog, storage, d.logger), nil
 }
 
 func (d *Datalake) FetchSchema(ctx context.Context) (model.Schema, error) {
@@ -82,16 +140,56 @@ func (d *Datalake) AlterColumn(ctx context.Context, tableName, columnName, colum
 	return d.SchemaRepository.AlterColumn(ctx, tableName, columnName, columnType)
 }
 
-func (d *Datalake) LoadTable(_ context.Context, tableName string) (*types.LoadTableStats, error) {
-	d.logger.Infof("Skipping load for table %s : %s is a datalake destination", tableName, d.Warehouse.Destination.ID)
-	return &types.LoadTableStats{}, nil
+func (d *Datalake) LoadTable(ctx context.Context, tableName string) (*types.LoadTableStats, error) {
+	if d.iceberg == nil {
+		d.logger.Infof("Skipping load for table %s : %s is a datalake destination", tableName, d.Warehouse.Destination.ID)
+		return &types.LoadTableStats{}, nil
+	}
+	return d.appendToIcebergTable(ctx, tableName)
+}
+
+// appendToIcebergTable commits the load files of the table as a snapshot of the table's iceberg table
+func (d *Datalake) appendToIcebergTable(ctx context.Context, tableName string) (*types.LoadTableStats, error) {
+	loadFiles, err := d.Uploader.GetLoadFilesMetadata(ctx, warehouseutils.GetLoadFilesOptions{Table: tableName})
+	if err != nil {
+		return nil, fmt.Errorf("getting load files metadata: %w", err)
+	}
+
+	var rowsInserted int64
+	dataFiles := make([]iceberg.DataFile, 0, len(loadFiles))
+	for _, loadFile := range loadFiles {
+		// load files with deterministic names which already existed have no location, as they weren't uploaded again
+		if loadFile.Location == "" {
+			continue
+		}
+		dataFiles = append(dataFiles, iceberg.DataFile{
+			Location:        loadFile.Location,
+			RecordCount:     loadFile.TotalRows,
+			FileSizeInBytes: gjson.GetBytes(loadFile.Metadata, "content_length").Int(),
+		})
+		rowsInserted += loadFile.TotalRows
+	}
+
+	if err := d.iceberg.Append(ctx, d.Warehouse.Namespace, tableName, d.Uploader.GetTableSchemaInUpload(tableName), dataFiles); err != nil {
+		return nil, fmt.Errorf("appending to iceberg table %s: %w", tableName, err)
+	}
+	return &types.LoadTableStats{RowsInserted: rowsInserted}, nil
 }
 
 func (*Datalake) DeleteBy(context.Context, []string, warehouseutils.DeleteByParams) (err error) {
 	return fmt.Errorf(warehouseutils.NotImplementedErrorCode)
 }
 
-func (d *Datalake) LoadUserTables(context.Context) map[string]error {
+func (d *Datalake) LoadUserTables(ctx context.Context) map[string]error {
+	if d.iceberg != nil {
+		errorMap := make(map[string]error)
+		_, errorMap[warehouseutils.IdentifiesTable] = d.appendToIcebergTable(ctx, warehouseutils.IdentifiesTable)
+		if len(d.Uploader.GetTableSchemaInUpload(warehouseutils.UsersTable)) > 0 {
+			_, errorMap[warehouseutils.UsersTable] = d.appendToIcebergTable(ctx, warehouseutils.UsersTable)
+		}
+		return errorMap
+	}
+
 	d.logger.Infof("Skipping load for user tables : %s is a datalake destination", d.Warehouse.Destination.ID)
 	// return map with nil error entries for identifies and users(if any) tables
 	// this is so that they are marked as succeeded
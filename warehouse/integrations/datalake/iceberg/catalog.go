@@ -0,0 +1,155 @@
+package iceberg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FilesystemCatalog = "filesystem"
+	RESTCatalog       = "rest"
+)
+
+var (
+	// ErrNoSuchTable is returned by catalogs when loading a table which doesn't exist
+	ErrNoSuchTable = errors.New("table does not exist")
+	// ErrCommitConflict is returned by catalogs when the table changed since it was loaded
+	ErrCommitConflict = errors.New("table changed concurrently")
+
+	metadataFileRegex = regexp.MustCompile(`(?:^|/)v(\d+)\.metadata\.json$`)
+)
+
+// Table is an iceberg table loaded from a catalog
+type Table struct {
+	Namespace        string
+	Name             string
+	MetadataLocation string
+	Metadata         TableMetadata
+}
+
+// Catalog tracks the current metadata of iceberg tables
+type Catalog interface {
+	// LoadTable returns the table, or ErrNoSuchTable if it doesn't exist
+	LoadTable(ctx context.Context, namespace, tableName string) (*Table, error)
+	// CreateTable creates a table without snapshots, along with its namespace if needed
+	CreateTable(ctx context.Context, namespace, tableName, location string, schema Schema, properties map[string]string) (*Table, error)
+	// CommitTable applies the commit to the table, failing with ErrCommitConflict if the table changed since it was loaded
+	CommitTable(ctx context.Context, table *Table, commit Commit) (*Table, error)
+}
+
+// filesystemCatalog keeps the metadata of tables as versioned files next to their data, like the hadoop catalog does:
+// <table>/metadata/v<N>.metadata.json, with <table>/metadata/version-hint.text pointing to the current version.
+// Object storages don't support atomic renames, so tables must have a single writer,
+// which holds as uploads of a namespace are processed sequentially.
+type filesystemCatalog struct {
+	storage *Storage
+	now     func() time.Time
+}
+
+func NewFilesystemCatalog(storage *Storage) Catalog {
+	return &filesystemCatalog{
+		storage: storage,
+		now:     time.Now,
+	}
+}
+
+func (c *filesystemCatalog) LoadTable(ctx context.Context, namespace, tableName string) (*Table, error) {
+	version, err := c.currentVersion(ctx, namespace, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if version == 0 {
+		return nil, ErrNoSuchTable
+	}
+
+	metadataLocation := c.storage.URI(c.metadataKey(namespace, tableName, version))
+	contents, err := c.storage.read(ctx, metadataLocation)
+	if err != nil {
+		return nil, fmt.Errorf("reading table metadata: %w", err)
+	}
+	table := &Table{Namespace: namespace, Name: tableName, MetadataLocation: metadataLocation}
+	if err := json.Unmarshal(contents, &table.Metadata); err != nil {
+		return nil, fmt.Errorf("unmarshalling table metadata %s: %w", metadataLocation, err)
+	}
+	return table, nil
+}
+
+func (c *filesystemCatalog) CreateTable(ctx context.Context, namespace, tableName, location string, schema Schema, properties map[string]string) (*Table, error) {
+	version, err := c.currentVersion(ctx, namespace, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 {
+		return nil, fmt.Errorf("creating table %s.%s: table already exists", namespace, tableName)
+	}
+
+	metadata := newTableMetadata(uuid.NewString(), location, schema, properties, c.now().UnixMilli())
+	return c.writeMetadata(ctx, namespace, tableName, 1, metadata)
+}
+
+func (c *filesystemCatalog) CommitTable(ctx context.Context, table *Table, commit Commit) (*Table, error) {
+	version, err := c.currentVersion(ctx, table.Namespace, table.Name)
+	if err != nil {
+		return nil, err
+	}
+	if c.storage.URI(c.metadataKey(table.Namespace, table.Name, version)) != table.MetadataLocation {
+		return nil, ErrCommitConflict
+	}
+
+	metadata := table.Metadata.apply(commit)
+	metadata.MetadataLog = append(append([]MetadataLogEntry{}, metadata.MetadataLog...), MetadataLogEntry{
+		MetadataFile: table.MetadataLocation,
+		TimestampMS:  table.Metadata.LastUpdatedMS,
+	})
+	return c.writeMetadata(ctx, table.Namespace, table.Name, version+1, metadata)
+}
+
+func (c *filesystemCatalog) writeMetadata(ctx context.Context, namespace, tableName string, version int, metadata TableMetadata) (*Table, error) {
+	contents, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling table metadata: %w", err)
+	}
+	metadataLocation, err := c.storage.write(ctx, c.metadataKey(namespace, tableName, version), contents)
+	if err != nil {
+		return nil, fmt.Errorf("writing table metadata: %w", err)
+	}
+	versionHintKey := path.Join(c.storage.TableKey(namespace, tableName), "metadata", "version-hint.text")
+	if _, err := c.storage.write(ctx, versionHintKey, []byte(strconv.Itoa(version))); err != nil {
+		return nil, fmt.Errorf("writing version hint: %w", err)
+	}
+	return &Table{
+		Namespace:        namespace,
+		Name:             tableName,
+		MetadataLocation: metadataLocation,
+		Metadata:         metadata,
+	}, nil
+}
+
+// currentVersion returns the latest version of the table's metadata files, or 0 if there are none.
+// The metadata files are listed instead of reading version-hint.text, since not all object storages report missing keys alike.
+func (c *filesystemCatalog) currentVersion(ctx context.Context, namespace, tableName string) (int, error) {
+	keys, err := c.storage.list(ctx, path.Join(c.storage.TableKey(namespace, tableName), "metadata")+"/")
+	if err != nil {
+		return 0, fmt.Errorf("listing table metadata: %w", err)
+	}
+	var version int
+	for _, key := range keys {
+		if matches := metadataFileRegex.FindStringSubmatch(key); matches != nil {
+			v, _ := strconv.Atoi(matches[1])
+			version = max(version, v)
+		}
+	}
+	return version, nil
+}
+
+func (c *filesystemCatalog) metadataKey(namespace, tableName string, version int) string {
+	return path.Join(c.storage.TableKey(namespace, tableName), "metadata", fmt.Sprintf("v%d.metadata.json", version))
+}
@@ -0,0 +1,498 @@
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/filemanager"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+// memoryStorage is an in-memory ObjectStorage, with locations of the form https://bucket/<key>
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{objects: make(map[string][]byte)}
+}
+
+func (m *memoryStorage) ListFilesWithPrefix(_ context.Context, _, prefix string, _ int64) filemanager.ListSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var files []*filemanager.FileInfo
+	for key := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			files = append(files, &filemanager.FileInfo{Key: key})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Key < files[j].Key })
+	return &memoryListSession{files: files}
+}
+
+func (m *memoryStorage) Download(_ context.Context, output io.WriterAt, key string, _ ...filemanager.DownloadOption) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	contents, ok := m.objects[key]
+	if !ok {
+		return filemanager.ErrKeyNotFound
+	}
+	_, err := output.WriteAt(contents, 0)
+	return err
+}
+
+func (m *memoryStorage) UploadReader(_ context.Context, objName string, rdr io.Reader) (filemanager.UploadedFile, error) {
+	contents, err := io.ReadAll(rdr)
+	if err != nil {
+		return filemanager.UploadedFile{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[objName] = contents
+	return filemanager.UploadedFile{Location: "https://bucket/" + objName, ObjectName: objName}, nil
+}
+
+func (*memoryStorage) Prefix() string {
+	return "prefix"
+}
+
+func (*memoryStorage) GetObjectNameFromLocation(location string) (string, error) {
+	return strings.TrimPrefix(location, "https://bucket/"), nil
+}
+
+func (m *memoryStorage) get(t *testing.T, key string) []byte {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	contents, ok := m.objects[key]
+	require.True(t, ok, "object %s not found", key)
+	return contents
+}
+
+type memoryListSession struct {
+	files []*filemanager.FileInfo
+}
+
+func (s *memoryListSession) Next() ([]*filemanager.FileInfo, error) {
+	files := s.files
+	s.files = nil
+	return files, nil
+}
+
+// restCatalogServer is a fake REST catalog, keeping table metadata in memory
+type restCatalogServer struct {
+	t      *testing.T
+	mu     sync.Mutex
+	tables map[string]TableMetadata
+	// commits counts the commits per table
+	commits map[string]int
+}
+
+func newRESTCatalogServer(t *testing.T) *restCatalogServer {
+	return &restCatalogServer{t: t, tables: make(map[string]TableMetadata), commits: make(map[string]int)}
+}
+
+func (s *restCatalogServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON := func(status int, v interface{}) {
+		w.WriteHeader(status)
+		require.NoError(s.t, json.NewEncoder(w).Encode(v))
+	}
+	writeError := func(status int, message string) {
+		writeJSON(status, map[string]interface{}{"error": map[string]interface{}{"message": message, "code": status}})
+	}
+	tableResponse := func(key string) map[string]interface{} {
+		return map[string]interface{}{
+			"metadata-location": fmt.Sprintf("s3://bucket/%s/metadata/%d.metadata.json", key, s.commits[key]),
+			"metadata":          s.tables[key],
+		}
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	switch {
+	case r.Method == http.MethodGet && path == "config":
+		writeJSON(http.StatusOK, map[string]interface{}{"overrides": map[string]string{"prefix": "catalog"}})
+	case r.Method == http.MethodPost && path == "catalog/namespaces":
+		writeJSON(http.StatusOK, map[string]interface{}{})
+	case strings.HasPrefix(path, "catalog/namespaces/"):
+		parts := strings.Split(strings.TrimPrefix(path, "catalog/namespaces/"), "/")
+		switch {
+		case r.Method == http.MethodPost && len(parts) == 2:
+			var request struct {
+				Name       string            `json:"name"`
+				Location   string            `json:"location"`
+				Schema     Schema            `json:"schema"`
+				Properties map[string]string `json:"properties"`
+			}
+			require.NoError(s.t, json.NewDecoder(r.Body).Decode(&request))
+			key := parts[0] + "." + request.Name
+			if _, ok := s.tables[key]; ok {
+				writeError(http.StatusConflict, "table already exists")
+				return
+			}
+			// catalogs assign fresh field ids to the schemas of new tables
+			for i := range request.Schema.Fields {
+				request.Schema.Fields[i].ID = i + 100
+			}
+			s.tables[key] = newTableMetadata("table-uuid", request.Location, request.Schema, request.Properties, time.Now().UnixMilli())
+			writeJSON(http.StatusOK, tableResponse(key))
+		case r.Method == http.MethodGet && len(parts) == 3:
+			key := parts[0] + "." + parts[2]
+			if _, ok := s.tables[key]; !ok {
+				writeError(http.StatusNotFound, "table does not exist")
+				return
+			}
+			writeJSON(http.StatusOK, tableResponse(key))
+		case r.Method == http.MethodPost && len(parts) == 3:
+			key := parts[0] + "." + parts[2]
+			metadata, ok := s.tables[key]
+			if !ok {
+				writeError(http.StatusNotFound, "table does not exist")
+				return
+			}
+			var request struct {
+				Requirements []map[string]json.RawMessage `json:"requirements"`
+				Updates      []map[string]json.RawMessage `json:"updates"`
+			}
+			require.NoError(s.t, json.NewDecoder(r.Body).Decode(&request))
+
+			for _, requirement := range request.Requirements {
+				if string(requirement["type"]) != `"assert-ref-snapshot-id"` {
+					continue
+				}
+				var snapshotID *int64
+				require.NoError(s.t, json.Unmarshal(requirement["snapshot-id"], &snapshotID))
+				current := metadata.CurrentSnapshot()
+				if (snapshotID == nil) != (current == nil) || (current != nil && *snapshotID != current.SnapshotID) {
+					writeError(http.StatusConflict, "branch main has changed")
+					return
+				}
+			}
+
+			var commit Commit
+			for _, update := range request.Updates {
+				switch string(update["action"]) {
+				case `"add-schema"`:
+					commit.Schema = new(Schema)
+					require.NoError(s.t, json.Unmarshal(update["schema"], commit.Schema))
+					require.NoError(s.t, json.Unmarshal(update["last-column-id"], &commit.LastColumnID))
+				case `"set-properties"`:
+					require.NoError(s.t, json.Unmarshal(update["updates"], &commit.Properties))
+				case `"add-snapshot"`:
+					require.NoError(s.t, json.Unmarshal(update["snapshot"], &commit.Snapshot))
+				}
+			}
+			s.tables[key] = metadata.apply(commit)
+			s.commits[key]++
+			writeJSON(http.StatusOK, tableResponse(key))
+		default:
+			writeError(http.StatusNotFound, "not found")
+		}
+	default:
+		writeError(http.StatusNotFound, "not found")
+	}
+}
+
+func dataFile(key string, records int64) DataFile {
+	return DataFile{Location: "https://bucket/" + key, RecordCount: records, FileSizeInBytes: 10 * records}
+}
+
+// requireManifestList returns the manifests of the manifest list of the snapshot
+func requireManifestList(t *testing.T, fm *memoryStorage, storage *Storage, snapshot *Snapshot) []manifestFile {
+	t.Helper()
+
+	require.NotNil(t, snapshot)
+	key, err := storage.Key(snapshot.ManifestList)
+	require.NoError(t, err)
+	manifests, err := readManifestList(fm.get(t, key))
+	require.NoError(t, err)
+	return manifests
+}
+
+// requireManifestFilePaths returns the paths of the data files of the manifest
+func requireManifestFilePaths(t *testing.T, fm *memoryStorage, storage *Storage, manifest manifestFile) []string {
+	t.Helper()
+
+	key, err := storage.Key(manifest.Path)
+	require.NoError(t, err)
+	reader, err := goavro.NewOCFReader(bytes.NewReader(fm.get(t, key)))
+	require.NoError(t, err)
+
+	var paths []string
+	for reader.Scan() {
+		datum, err := reader.Read()
+		require.NoError(t, err)
+		entry := datum.(map[string]interface{})
+		require.EqualValues(t, manifestEntryStatusAdded, entry["status"])
+		paths = append(paths, entry["data_file"].(map[string]interface{})["file_path"].(string))
+	}
+	require.NoError(t, reader.Err())
+	return paths
+}
+
+func TestWriter(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name       string
+		newCatalog func(t *testing.T, storage *Storage) Catalog
+	}{
+		{
+			name: "filesystem catalog",
+			newCatalog: func(t *testing.T, storage *Storage) Catalog {
+				return NewFilesystemCatalog(storage)
+			},
+		},
+		{
+			name: "rest catalog",
+			newCatalog: func(t *testing.T, storage *Storage) Catalog {
+				server := httptest.NewServer(newRESTCatalogServer(t))
+				t.Cleanup(server.Close)
+				return NewRESTCatalog(server.URL+"/", "token", time.Minute)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fm := newMemoryStorage()
+			storage, err := NewStorage(fm, warehouseutils.S3, map[string]interface{}{"bucketName": "bucket"})
+			require.NoError(t, err)
+			catalog := tc.newCatalog(t, storage)
+			w := NewWriter(catalog, storage, logger.NOP)
+
+			firstFiles := []DataFile{
+				dataFile("prefix/rudder-datalake/namespace/tracks/2024/01/01/00/a.parquet", 2),
+				dataFile("prefix/rudder-datalake/namespace/tracks/2024/01/01/00/b.parquet", 3),
+			}
+			require.NoError(t, w.Append(ctx, "namespace", "tracks", model.TableSchema{
+				"id":          "string",
+				"received_at": "datetime",
+			}, firstFiles))
+
+			table, err := catalog.LoadTable(ctx, "namespace", "tracks")
+			require.NoError(t, err)
+			require.Equal(t, "s3://bucket/prefix/rudder-datalake/namespace/tracks", table.Metadata.Location)
+			schema, err := table.Metadata.CurrentSchema()
+			require.NoError(t, err)
+			require.Len(t, schema.Fields, 2)
+			require.Equal(t, []string{"id", "received_at"}, []string{schema.Fields[0].Name, schema.Fields[1].Name})
+			require.Equal(t, []string{"string", "timestamptz"}, []string{schema.Fields[0].Type, schema.Fields[1].Type})
+			nameMapping, err := schema.nameMapping()
+			require.NoError(t, err)
+			require.Equal(t, nameMapping, table.Metadata.Properties[nameMappingProperty])
+
+			firstSnapshot := table.Metadata.CurrentSnapshot()
+			require.NotNil(t, firstSnapshot)
+			require.Nil(t, firstSnapshot.ParentSnapshotID)
+			require.EqualValues(t, 1, firstSnapshot.SequenceNumber)
+			require.Equal(t, "append", firstSnapshot.Summary["operation"])
+			require.Equal(t, "5", firstSnapshot.Summary["total-records"])
+			require.Equal(t, "50", firstSnapshot.Summary["total-files-size"])
+
+			manifests := requireManifestList(t, fm, storage, firstSnapshot)
+			require.Len(t, manifests, 1)
+			require.EqualValues(t, 2, manifests[0].AddedFilesCount)
+			require.EqualValues(t, 5, manifests[0].AddedRowsCount)
+			require.Equal(t, []string{
+				"s3://bucket/prefix/rudder-datalake/namespace/tracks/2024/01/01/00/a.parquet",
+				"s3://bucket/prefix/rudder-datalake/namespace/tracks/2024/01/01/00/b.parquet",
+			}, requireManifestFilePaths(t, fm, storage, manifests[0]))
+
+			t.Run("retrying an append is a no-op", func(t *testing.T) {
+				require.NoError(t, w.Append(ctx, "namespace", "tracks", model.TableSchema{"id": "string", "received_at": "datetime"}, []DataFile{firstFiles[1], firstFiles[0]}))
+
+				table, err := catalog.LoadTable(ctx, "namespace", "tracks")
+				require.NoError(t, err)
+				require.Len(t, table.Metadata.Snapshots, 1)
+			})
+
+			t.Run("appending with new columns", func(t *testing.T) {
+				require.NoError(t, w.Append(ctx, "namespace", "tracks", model.TableSchema{
+					"id":          "string",
+					"received_at": "datetime",
+					"count":       "int",
+				}, []DataFile{
+					dataFile("prefix/rudder-datalake/namespace/tracks/2024/01/01/01/c.parquet", 4),
+				}))
+
+				table, err := catalog.LoadTable(ctx, "namespace", "tracks")
+				require.NoError(t, err)
+				require.Len(t, table.Metadata.Schemas, 2)
+				schema, err := table.Metadata.CurrentSchema()
+				require.NoError(t, err)
+				require.Len(t, schema.Fields, 3)
+				require.Equal(t, SchemaField{ID: table.Metadata.LastColumnID, Name: "count", Type: "long"}, schema.Fields[2])
+				nameMapping, err := schema.nameMapping()
+				require.NoError(t, err)
+				require.Equal(t, nameMapping, table.Metadata.Properties[nameMappingProperty])
+
+				snapshot := table.Metadata.CurrentSnapshot()
+				require.Equal(t, &firstSnapshot.SnapshotID, snapshot.ParentSnapshotID)
+				require.EqualValues(t, 2, snapshot.SequenceNumber)
+				require.Equal(t, schema.SchemaID, *snapshot.SchemaID)
+				require.Equal(t, "9", snapshot.Summary["total-records"])
+				require.Equal(t, "3", snapshot.Summary["total-data-files"])
+
+				manifests := requireManifestList(t, fm, storage, snapshot)
+				require.Len(t, manifests, 2)
+				require.Equal(t, firstSnapshot.SnapshotID, manifests[0].AddedSnapshotID)
+				require.Equal(t, snapshot.SnapshotID, manifests[1].AddedSnapshotID)
+				require.EqualValues(t, 2, manifests[1].SequenceNumber)
+			})
+
+			t.Run("appending with a changed column type", func(t *testing.T) {
+				err := w.Append(ctx, "namespace", "tracks", model.TableSchema{"id": "int"}, []DataFile{
+					dataFile("prefix/rudder-datalake/namespace/tracks/2024/01/01/02/d.parquet", 1),
+				})
+				require.ErrorContains(t, err, "column id has type string in the table, but long in the upload")
+			})
+
+			t.Run("appending nothing", func(t *testing.T) {
+				require.NoError(t, w.Append(ctx, "namespace", "pages", model.TableSchema{"id": "string"}, nil))
+
+				_, err := catalog.LoadTable(ctx, "namespace", "pages")
+				require.ErrorIs(t, err, ErrNoSuchTable)
+			})
+		})
+	}
+}
+
+func TestFilesystemCatalog(t *testing.T) {
+	ctx := context.Background()
+
+	fm := newMemoryStorage()
+	storage, err := NewStorage(fm, warehouseutils.GCS, map[string]interface{}{"bucketName": "bucket"})
+	require.NoError(t, err)
+	catalog := NewFilesystemCatalog(storage)
+
+	_, err = catalog.LoadTable(ctx, "namespace", "tracks")
+	require.ErrorIs(t, err, ErrNoSuchTable)
+
+	schema, err := toSchema(model.TableSchema{"id": "string"})
+	require.NoError(t, err)
+	table, err := catalog.CreateTable(ctx, "namespace", "tracks", storage.URI(storage.TableKey("namespace", "tracks")), schema, nil)
+	require.NoError(t, err)
+	require.Equal(t, "gs://bucket/prefix/rudder-datalake/namespace/tracks/metadata/v1.metadata.json", table.MetadataLocation)
+	require.EqualValues(t, -1, table.Metadata.CurrentSnapshotID)
+
+	_, err = catalog.CreateTable(ctx, "namespace", "tracks", table.Metadata.Location, schema, nil)
+	require.ErrorContains(t, err, "table already exists")
+
+	committed, err := catalog.CommitTable(ctx, table, Commit{Snapshot: Snapshot{SnapshotID: 1, SequenceNumber: 1}})
+	require.NoError(t, err)
+	require.Equal(t, "gs://bucket/prefix/rudder-datalake/namespace/tracks/metadata/v2.metadata.json", committed.MetadataLocation)
+	require.Equal(t, []MetadataLogEntry{{MetadataFile: table.MetadataLocation, TimestampMS: table.Metadata.LastUpdatedMS}}, committed.Metadata.MetadataLog)
+	require.Equal(t, "2", string(fm.get(t, "prefix/rudder-datalake/namespace/tracks/metadata/version-hint.text")))
+
+	loaded, err := catalog.LoadTable(ctx, "namespace", "tracks")
+	require.NoError(t, err)
+	require.Equal(t, committed, loaded)
+
+	_, err = catalog.CommitTable(ctx, table, Commit{Snapshot: Snapshot{SnapshotID: 2, SequenceNumber: 2}})
+	require.ErrorIs(t, err, ErrCommitConflict)
+}
+
+func TestRESTCatalogConflict(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(newRESTCatalogServer(t))
+	defer server.Close()
+	catalog := NewRESTCatalog(server.URL, "token", time.Minute)
+
+	schema, err := toSchema(model.TableSchema{"id": "string"})
+	require.NoError(t, err)
+	table, err := catalog.CreateTable(ctx, "namespace", "tracks", "s3://bucket/tracks", schema, nil)
+	require.NoError(t, err)
+	require.Equal(t, 100, table.Metadata.Schemas[0].Fields[0].ID)
+
+	_, err = catalog.CommitTable(ctx, table, Commit{Snapshot: Snapshot{SnapshotID: 1, SequenceNumber: 1}})
+	require.NoError(t, err)
+	_, err = catalog.CommitTable(ctx, table, Commit{Snapshot: Snapshot{SnapshotID: 2, SequenceNumber: 1}})
+	require.ErrorIs(t, err, ErrCommitConflict)
+
+	unauthorized := NewRESTCatalog(server.URL, "", time.Minute)
+	_, err = unauthorized.LoadTable(ctx, "namespace", "tracks")
+	require.ErrorContains(t, err, "rest catalog responded with status 401")
+}
+
+func TestStorage(t *testing.T) {
+	testCases := []struct {
+		provider    string
+		config      map[string]interface{}
+		expectedURI string
+	}{
+		{provider: warehouseutils.S3, config: map[string]interface{}{"bucketName": "bucket"}, expectedURI: "s3://bucket/prefix/key"},
+		{provider: warehouseutils.MINIO, config: map[string]interface{}{"bucketName": "bucket"}, expectedURI: "s3://bucket/prefix/key"},
+		{provider: warehouseutils.GCS, config: map[string]interface{}{"bucketName": "bucket"}, expectedURI: "gs://bucket/prefix/key"},
+		{provider: warehouseutils.AzureBlob, config: map[string]interface{}{"containerName": "container", "accountName": "account"}, expectedURI: "abfss://container@account.dfs.core.windows.net/prefix/key"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.provider, func(t *testing.T) {
+			storage, err := NewStorage(newMemoryStorage(), tc.provider, tc.config)
+			require.NoError(t, err)
+
+			uri := storage.URI("prefix/key")
+			require.Equal(t, tc.expectedURI, uri)
+			key, err := storage.Key(uri)
+			require.NoError(t, err)
+			require.Equal(t, "prefix/key", key)
+
+			key, err = storage.Key("s3a://other-bucket/prefix/key")
+			require.NoError(t, err)
+			require.Equal(t, "prefix/key", key)
+		})
+	}
+
+	_, err := NewStorage(newMemoryStorage(), "UNKNOWN", nil)
+	require.ErrorContains(t, err, "unsupported object storage provider for iceberg: UNKNOWN")
+}
+
+func TestReadManifestList(t *testing.T) {
+	parentSnapshotID := int64(1)
+	manifests := []manifestFile{
+		{Path: "s3://bucket/m0.avro", Length: 10, SequenceNumber: 1, MinSequenceNumber: 1, AddedSnapshotID: 1, AddedFilesCount: 2, AddedRowsCount: 5},
+		{Path: "s3://bucket/m1.avro", Length: 20, Content: 1, SequenceNumber: 2, MinSequenceNumber: 2, AddedSnapshotID: 2, DeletedFilesCount: 1, DeletedRowsCount: 3},
+	}
+	contents, err := writeManifestList(Snapshot{SnapshotID: 2, ParentSnapshotID: &parentSnapshotID, SequenceNumber: 2}, manifests)
+	require.NoError(t, err)
+
+	reader, err := goavro.NewOCFReader(bytes.NewReader(contents))
+	require.NoError(t, err)
+	require.Equal(t, "1", string(reader.MetaData()["parent-snapshot-id"]))
+	require.Contains(t, string(reader.MetaData()["avro.schema"]), `"field-id": 500`)
+
+	read, err := readManifestList(contents)
+	require.NoError(t, err)
+	require.Equal(t, manifests, read)
+
+	_, err = readManifestList(bytes.Repeat([]byte{0}, 10))
+	require.Error(t, err)
+}
@@ -0,0 +1,252 @@
+package iceberg
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+const (
+	manifestEntryStatusAdded = 1
+	dataFileContentData      = 0
+	manifestContentData      = 0
+)
+
+// manifestEntrySchema is the avro schema of the entries of manifest files for unpartitioned tables, as described in https://iceberg.apache.org/spec/#manifests
+const manifestEntrySchema = `{
+  "type": "record",
+  "name": "manifest_entry",
+  "fields": [
+    {"name": "status", "type": "int", "field-id": 0},
+    {"name": "snapshot_id", "type": ["null", "long"], "default": null, "field-id": 1},
+    {"name": "sequence_number", "type": ["null", "long"], "default": null, "field-id": 3},
+    {"name": "file_sequence_number", "type": ["null", "long"], "default": null, "field-id": 4},
+    {"name": "data_file", "field-id": 2, "type": {
+      "type": "record",
+      "name": "r2",
+      "fields": [
+        {"name": "content", "type": "int", "field-id": 134},
+        {"name": "file_path", "type": "string", "field-id": 100},
+        {"name": "file_format", "type": "string", "field-id": 101},
+        {"name": "partition", "field-id": 102, "type": {"type": "record", "name": "r102", "fields": []}},
+        {"name": "record_count", "type": "long", "field-id": 103},
+        {"name": "file_size_in_bytes", "type": "long", "field-id": 104}
+      ]
+    }}
+  ]
+}`
+
+// manifestFileSchema is the avro schema of the entries of manifest lists, as described in https://iceberg.apache.org/spec/#manifest-lists
+const manifestFileSchema = `{
+  "type": "record",
+  "name": "manifest_file",
+  "fields": [
+    {"name": "manifest_path", "type": "string", "field-id": 500},
+    {"name": "manifest_length", "type": "long", "field-id": 501},
+    {"name": "partition_spec_id", "type": "int", "field-id": 502},
+    {"name": "content", "type": "int", "field-id": 517},
+    {"name": "sequence_number", "type": "long", "field-id": 515},
+    {"name": "min_sequence_number", "type": "long", "field-id": 516},
+    {"name": "added_snapshot_id", "type": "long", "field-id": 503},
+    {"name": "added_files_count", "type": "int", "field-id": 504},
+    {"name": "existing_files_count", "type": "int", "field-id": 505},
+    {"name": "deleted_files_count", "type": "int", "field-id": 506},
+    {"name": "added_rows_count", "type": "long", "field-id": 512},
+    {"name": "existing_rows_count", "type": "long", "field-id": 513},
+    {"name": "deleted_rows_count", "type": "long", "field-id": 514}
+  ]
+}`
+
+var (
+	manifestEntryCodec = mustCodec(manifestEntrySchema)
+	manifestFileCodec  = mustCodec(manifestFileSchema)
+)
+
+func mustCodec(schema string) *goavro.Codec {
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		panic(fmt.Errorf("creating avro codec: %w", err))
+	}
+	return codec
+}
+
+// DataFile is a parquet file appended to a table
+type DataFile struct {
+	// Location is the location of the file, as returned by the filemanager on upload
+	Location        string
+	RecordCount     int64
+	FileSizeInBytes int64
+}
+
+// manifestFile is an entry of a manifest list
+type manifestFile struct {
+	Path               string
+	Length             int64
+	PartitionSpecID    int32
+	Content            int32
+	SequenceNumber     int64
+	MinSequenceNumber  int64
+	AddedSnapshotID    int64
+	AddedFilesCount    int32
+	ExistingFilesCount int32
+	DeletedFilesCount  int32
+	AddedRowsCount     int64
+	ExistingRowsCount  int64
+	DeletedRowsCount   int64
+}
+
+// writeManifest returns the avro manifest file listing the data files as added by the snapshot
+func writeManifest(schema Schema, snapshotID int64, dataFileURIs []string, dataFiles []DataFile) ([]byte, error) {
+	schemaJSON, err := jsonString(schema)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]interface{}, 0, len(dataFiles))
+	for i, dataFile := range dataFiles {
+		records = append(records, map[string]interface{}{
+			"status":               int32(manifestEntryStatusAdded),
+			"snapshot_id":          goavro.Union("long", snapshotID),
+			"sequence_number":      nil,
+			"file_sequence_number": nil,
+			"data_file": map[string]interface{}{
+				"content":            int32(dataFileContentData),
+				"file_path":          dataFileURIs[i],
+				"file_format":        "PARQUET",
+				"partition":          map[string]interface{}{},
+				"record_count":       dataFile.RecordCount,
+				"file_size_in_bytes": dataFile.FileSizeInBytes,
+			},
+		})
+	}
+	return writeOCF(manifestEntryCodec, map[string]string{
+		"schema":            schemaJSON,
+		"schema-id":         strconv.Itoa(schema.SchemaID),
+		"partition-spec":    "[]",
+		"partition-spec-id": "0",
+		"format-version":    strconv.Itoa(formatVersion),
+		"content":           "data",
+	}, records)
+}
+
+// writeManifestList returns the avro manifest list of the snapshot
+func writeManifestList(snapshot Snapshot, manifests []manifestFile) ([]byte, error) {
+	parentSnapshotID := "null"
+	if snapshot.ParentSnapshotID != nil {
+		parentSnapshotID = strconv.FormatInt(*snapshot.ParentSnapshotID, 10)
+	}
+	records := make([]interface{}, 0, len(manifests))
+	for _, m := range manifests {
+		records = append(records, map[string]interface{}{
+			"manifest_path":        m.Path,
+			"manifest_length":      m.Length,
+			"partition_spec_id":    m.PartitionSpecID,
+			"content":              m.Content,
+			"sequence_number":      m.SequenceNumber,
+			"min_sequence_number":  m.MinSequenceNumber,
+			"added_snapshot_id":    m.AddedSnapshotID,
+			"added_files_count":    m.AddedFilesCount,
+			"existing_files_count": m.ExistingFilesCount,
+			"deleted_files_count":  m.DeletedFilesCount,
+			"added_rows_count":     m.AddedRowsCount,
+			"existing_rows_count":  m.ExistingRowsCount,
+			"deleted_rows_count":   m.DeletedRowsCount,
+		})
+	}
+	return writeOCF(manifestFileCodec, map[string]string{
+		"snapshot-id":        strconv.FormatInt(snapshot.SnapshotID, 10),
+		"parent-snapshot-id": parentSnapshotID,
+		"sequence-number":    strconv.FormatInt(snapshot.SequenceNumber, 10),
+		"format-version":     strconv.Itoa(formatVersion),
+	}, records)
+}
+
+// readManifestList returns the entries of a manifest list, which might have been written by another engine
+func readManifestList(contents []byte) ([]manifestFile, error) {
+	reader, err := goavro.NewOCFReader(bytes.NewReader(contents))
+	if err != nil {
+		return nil, fmt.Errorf("creating manifest list reader: %w", err)
+	}
+
+	var manifests []manifestFile
+	for reader.Scan() {
+		datum, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest list: %w", err)
+		}
+		record, ok := datum.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected manifest list entry of type %T", datum)
+		}
+		manifests = append(manifests, manifestFile{
+			Path:               avroString(record["manifest_path"]),
+			Length:             avroLong(record["manifest_length"]),
+			PartitionSpecID:    int32(avroLong(record["partition_spec_id"])),
+			Content:            int32(avroLong(record["content"])),
+			SequenceNumber:     avroLong(record["sequence_number"]),
+			MinSequenceNumber:  avroLong(record["min_sequence_number"]),
+			AddedSnapshotID:    avroLong(record["added_snapshot_id"]),
+			AddedFilesCount:    int32(avroLong(record["added_files_count"])),
+			ExistingFilesCount: int32(avroLong(record["existing_files_count"])),
+			DeletedFilesCount:  int32(avroLong(record["deleted_files_count"])),
+			AddedRowsCount:     avroLong(record["added_rows_count"]),
+			ExistingRowsCount:  avroLong(record["existing_rows_count"]),
+			DeletedRowsCount:   avroLong(record["deleted_rows_count"]),
+		})
+	}
+	if err := reader.Err(); err != nil {
+		return nil, fmt.Errorf("scanning manifest list: %w", err)
+	}
+	return manifests, nil
+}
+
+func writeOCF(codec *goavro.Codec, metadata map[string]string, records []interface{}) ([]byte, error) {
+	ocfMetadata := make(map[string][]byte, len(metadata))
+	for k, v := range metadata {
+		ocfMetadata[k] = []byte(v)
+	}
+
+	var buf bytes.Buffer
+	writer, err := goavro.NewOCFWriter(goavro.OCFConfig{
+		W:               &buf,
+		Codec:           codec,
+		CompressionName: goavro.CompressionDeflateLabel,
+		MetaData:        ocfMetadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating avro writer: %w", err)
+	}
+	if err := writer.Append(records); err != nil {
+		return nil, fmt.Errorf("writing avro records: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// avroString returns the string value of a decoded avro datum, unwrapping unions
+func avroString(datum interface{}) string {
+	switch v := datum.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		return avroString(v["string"])
+	}
+	return ""
+}
+
+// avroLong returns the integer value of a decoded avro int or long datum, unwrapping unions
+func avroLong(datum interface{}) int64 {
+	switch v := datum.(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case map[string]interface{}:
+		for _, t := range []string{"long", "int"} {
+			if u, ok := v[t]; ok {
+				return avroLong(u)
+			}
+		}
+	}
+	return 0
+}
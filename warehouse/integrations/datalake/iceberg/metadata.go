@@ -0,0 +1,259 @@
+package iceberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+const (
+	formatVersion = 2
+	mainBranch    = "main"
+
+	// nameMappingProperty maps the columns of the load files, which have no field ids, to the fields of the table's schema
+	nameMappingProperty = "schema.name-mapping.default"
+	// loadFilesChecksumSummary is the snapshot summary property recording the load files appended by the snapshot
+	loadFilesChecksumSummary = "rudder.load-files-checksum"
+)
+
+// dataTypesMap maps rudder data types to iceberg primitive types
+var dataTypesMap = map[string]string{
+	"boolean":  "boolean",
+	"int":      "long",
+	"bigint":   "long",
+	"float":    "double",
+	"string":   "string",
+	"text":     "string",
+	"datetime": "timestamptz",
+}
+
+// TableMetadata is the metadata of an iceberg table, as described in https://iceberg.apache.org/spec/#table-metadata
+type TableMetadata struct {
+	FormatVersion      int                    `json:"format-version"`
+	TableUUID          string                 `json:"table-uuid"`
+	Location           string                 `json:"location"`
+	LastSequenceNumber int64                  `json:"last-sequence-number"`
+	LastUpdatedMS      int64                  `json:"last-updated-ms"`
+	LastColumnID       int                    `json:"last-column-id"`
+	CurrentSchemaID    int                    `json:"current-schema-id"`
+	Schemas            []Schema               `json:"schemas"`
+	DefaultSpecID      int                    `json:"default-spec-id"`
+	PartitionSpecs     []PartitionSpec        `json:"partition-specs"`
+	LastPartitionID    int                    `json:"last-partition-id"`
+	DefaultSortOrderID int                    `json:"default-sort-order-id"`
+	SortOrders         []SortOrder            `json:"sort-orders"`
+	Properties         map[string]string      `json:"properties,omitempty"`
+	CurrentSnapshotID  int64                  `json:"current-snapshot-id"`
+	Snapshots          []Snapshot             `json:"snapshots,omitempty"`
+	SnapshotLog        []SnapshotLogEntry     `json:"snapshot-log,omitempty"`
+	MetadataLog        []MetadataLogEntry     `json:"metadata-log,omitempty"`
+	Refs               map[string]SnapshotRef `json:"refs,omitempty"`
+}
+
+type Schema struct {
+	Type     string        `json:"type"`
+	SchemaID int           `json:"schema-id"`
+	Fields   []SchemaField `json:"fields"`
+}
+
+type SchemaField struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Type     string `json:"type"`
+}
+
+type PartitionSpec struct {
+	SpecID int              `json:"spec-id"`
+	Fields []PartitionField `json:"fields"`
+}
+
+type PartitionField struct {
+	SourceID  int    `json:"source-id"`
+	FieldID   int    `json:"field-id"`
+	Name      string `json:"name"`
+	Transform string `json:"transform"`
+}
+
+type SortOrder struct {
+	OrderID int               `json:"order-id"`
+	Fields  []json.RawMessage `json:"fields"`
+}
+
+type Snapshot struct {
+	SnapshotID       int64             `json:"snapshot-id"`
+	ParentSnapshotID *int64            `json:"parent-snapshot-id,omitempty"`
+	SequenceNumber   int64             `json:"sequence-number"`
+	TimestampMS      int64             `json:"timestamp-ms"`
+	ManifestList     string            `json:"manifest-list"`
+	Summary          map[string]string `json:"summary"`
+	SchemaID         *int              `json:"schema-id,omitempty"`
+}
+
+type SnapshotRef struct {
+	SnapshotID int64  `json:"snapshot-id"`
+	Type       string `json:"type"`
+}
+
+type SnapshotLogEntry struct {
+	SnapshotID  int64 `json:"snapshot-id"`
+	TimestampMS int64 `json:"timestamp-ms"`
+}
+
+type MetadataLogEntry struct {
+	MetadataFile string `json:"metadata-file"`
+	TimestampMS  int64  `json:"timestamp-ms"`
+}
+
+// newTableMetadata returns the metadata of a new unpartitioned and unsorted table without snapshots
+func newTableMetadata(tableUUID, location string, schema Schema, properties map[string]string, nowMS int64) TableMetadata {
+	lastColumnID := 0
+	for _, field := range schema.Fields {
+		lastColumnID = max(lastColumnID, field.ID)
+	}
+	return TableMetadata{
+		FormatVersion:      formatVersion,
+		TableUUID:          tableUUID,
+		Location:           location,
+		LastUpdatedMS:      nowMS,
+		LastColumnID:       lastColumnID,
+		CurrentSchemaID:    schema.SchemaID,
+		Schemas:            []Schema{schema},
+		PartitionSpecs:     []PartitionSpec{{Fields: []PartitionField{}}},
+		LastPartitionID:    999,
+		SortOrders:         []SortOrder{{Fields: []json.RawMessage{}}},
+		Properties:         properties,
+		CurrentSnapshotID:  -1,
+		DefaultSpecID:      0,
+		DefaultSortOrderID: 0,
+	}
+}
+
+// CurrentSchema returns the current schema of the table
+func (m *TableMetadata) CurrentSchema() (Schema, error) {
+	for _, schema := range m.Schemas {
+		if schema.SchemaID == m.CurrentSchemaID {
+			return schema, nil
+		}
+	}
+	return Schema{}, fmt.Errorf("current schema %d not found", m.CurrentSchemaID)
+}
+
+// CurrentSnapshot returns the current snapshot of the table, or nil if the table has no snapshots
+func (m *TableMetadata) CurrentSnapshot() *Snapshot {
+	snapshotID := m.CurrentSnapshotID
+	if ref, ok := m.Refs[mainBranch]; ok {
+		snapshotID = ref.SnapshotID
+	}
+	for i := range m.Snapshots {
+		if m.Snapshots[i].SnapshotID == snapshotID {
+			return &m.Snapshots[i]
+		}
+	}
+	return nil
+}
+
+// Commit describes the changes of an append to a table
+type Commit struct {
+	// Schema is the evolved schema of the table, or nil if the schema didn't change
+	Schema       *Schema
+	LastColumnID int
+	Properties   map[string]string
+	Snapshot     Snapshot
+}
+
+// apply returns the metadata with the changes of the commit applied
+func (m TableMetadata) apply(commit Commit) TableMetadata {
+	m.Schemas = append([]Schema{}, m.Schemas...)
+	if commit.Schema != nil {
+		m.Schemas = append(m.Schemas, *commit.Schema)
+		m.CurrentSchemaID = commit.Schema.SchemaID
+		m.LastColumnID = commit.LastColumnID
+	}
+
+	if len(commit.Properties) > 0 {
+		properties := make(map[string]string, len(m.Properties)+len(commit.Properties))
+		for k, v := range m.Properties {
+			properties[k] = v
+		}
+		for k, v := range commit.Properties {
+			properties[k] = v
+		}
+		m.Properties = properties
+	}
+
+	m.Snapshots = append(append([]Snapshot{}, m.Snapshots...), commit.Snapshot)
+	m.SnapshotLog = append(append([]SnapshotLogEntry{}, m.SnapshotLog...), SnapshotLogEntry{
+		SnapshotID:  commit.Snapshot.SnapshotID,
+		TimestampMS: commit.Snapshot.TimestampMS,
+	})
+	m.CurrentSnapshotID = commit.Snapshot.SnapshotID
+	refs := make(map[string]SnapshotRef, len(m.Refs)+1)
+	for k, v := range m.Refs {
+		refs[k] = v
+	}
+	refs[mainBranch] = SnapshotRef{SnapshotID: commit.Snapshot.SnapshotID, Type: "branch"}
+	m.Refs = refs
+	m.LastSequenceNumber = commit.Snapshot.SequenceNumber
+	m.LastUpdatedMS = commit.Snapshot.TimestampMS
+	return m
+}
+
+// toSchema returns the iceberg schema for the table schema, with fields ordered by name and ids starting from 1
+func toSchema(tableSchema model.TableSchema) (Schema, error) {
+	schema := Schema{Type: "struct", Fields: []SchemaField{}}
+	evolved, _, err := schema.evolve(tableSchema, 0)
+	return evolved, err
+}
+
+// evolve returns the schema with the columns of the table schema missing from it added as optional fields,
+// along with the last assigned field id. Columns whose type doesn't match the type of the existing field result in an error.
+func (s Schema) evolve(tableSchema model.TableSchema, lastColumnID int) (Schema, int, error) {
+	existing := make(map[string]SchemaField, len(s.Fields))
+	for _, field := range s.Fields {
+		existing[field.Name] = field
+	}
+
+	evolved := Schema{Type: "struct", SchemaID: s.SchemaID, Fields: append([]SchemaField{}, s.Fields...)}
+	for _, columnName := range warehouseutils.SortColumnKeysFromColumnMap(tableSchema) {
+		fieldType, ok := dataTypesMap[tableSchema[columnName]]
+		if !ok {
+			return Schema{}, 0, fmt.Errorf("unsupported data type %s for column %s", tableSchema[columnName], columnName)
+		}
+		if field, ok := existing[columnName]; ok {
+			if field.Type != fieldType {
+				return Schema{}, 0, fmt.Errorf("column %s has type %s in the table, but %s in the upload", columnName, field.Type, fieldType)
+			}
+			continue
+		}
+		lastColumnID++
+		evolved.Fields = append(evolved.Fields, SchemaField{ID: lastColumnID, Name: columnName, Type: fieldType})
+	}
+	return evolved, lastColumnID, nil
+}
+
+// nameMapping returns the default name mapping of the schema, mapping columns to fields by name
+func (s Schema) nameMapping() (string, error) {
+	type mappedField struct {
+		FieldID int      `json:"field-id"`
+		Names   []string `json:"names"`
+	}
+	mapping := make([]mappedField, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		mapping = append(mapping, mappedField{FieldID: field.ID, Names: []string{field.Name}})
+	}
+	b, err := json.Marshal(mapping)
+	if err != nil {
+		return "", fmt.Errorf("marshalling name mapping: %w", err)
+	}
+	return string(b), nil
+}
+
+// summaryInt returns the integer value of a snapshot summary property, or 0 if it is missing
+func summaryInt(summary map[string]string, key string) int64 {
+	v, _ := strconv.ParseInt(summary[key], 10, 64)
+	return v
+}
@@ -0,0 +1,238 @@
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// restCatalog tracks tables using a catalog implementing the iceberg REST catalog API,
+// as described in https://github.com/apache/iceberg/blob/main/open-api/rest-catalog-open-api.yaml
+type restCatalog struct {
+	uri    string
+	token  string
+	client *http.Client
+
+	prefixMu     sync.Mutex
+	prefix       string
+	prefixLoaded bool
+}
+
+func NewRESTCatalog(uri, token string, timeout time.Duration) Catalog {
+	return &restCatalog{
+		uri:    strings.TrimSuffix(uri, "/"),
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type restTableResponse struct {
+	MetadataLocation string        `json:"metadata-location"`
+	Metadata         TableMetadata `json:"metadata"`
+}
+
+type restErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+// restError is a non-successful response of the REST catalog
+type restError struct {
+	statusCode int
+	message    string
+}
+
+func (e *restError) Error() string {
+	return fmt.Sprintf("rest catalog responded with status %d: %s", e.statusCode, e.message)
+}
+
+func (c *restCatalog) LoadTable(ctx context.Context, namespace, tableName string) (*Table, error) {
+	var response restTableResponse
+	if err := c.do(ctx, http.MethodGet, c.tablePath(namespace, tableName), nil, &response); err != nil {
+		var restErr *restError
+		if errors.As(err, &restErr) && restErr.statusCode == http.StatusNotFound {
+			return nil, ErrNoSuchTable
+		}
+		return nil, fmt.Errorf("loading table %s.%s: %w", namespace, tableName, err)
+	}
+	return &Table{
+		Namespace:        namespace,
+		Name:             tableName,
+		MetadataLocation: response.MetadataLocation,
+		Metadata:         response.Metadata,
+	}, nil
+}
+
+func (c *restCatalog) CreateTable(ctx context.Context, namespace, tableName, location string, schema Schema, properties map[string]string) (*Table, error) {
+	err := c.do(ctx, http.MethodPost, "namespaces", map[string]interface{}{
+		"namespace":  []string{namespace},
+		"properties": map[string]string{},
+	}, nil)
+	var restErr *restError
+	if err != nil && !(errors.As(err, &restErr) && restErr.statusCode == http.StatusConflict) {
+		return nil, fmt.Errorf("creating namespace %s: %w", namespace, err)
+	}
+
+	var response restTableResponse
+	err = c.do(ctx, http.MethodPost, "namespaces/"+url.PathEscape(namespace)+"/tables", map[string]interface{}{
+		"name":         tableName,
+		"location":     location,
+		"schema":       schema,
+		"properties":   properties,
+		"stage-create": false,
+	}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("creating table %s.%s: %w", namespace, tableName, err)
+	}
+	return &Table{
+		Namespace:        namespace,
+		Name:             tableName,
+		MetadataLocation: response.MetadataLocation,
+		Metadata:         response.Metadata,
+	}, nil
+}
+
+func (c *restCatalog) CommitTable(ctx context.Context, table *Table, commit Commit) (*Table, error) {
+	var currentSnapshotID *int64
+	if snapshot := table.Metadata.CurrentSnapshot(); snapshot != nil {
+		currentSnapshotID = &snapshot.SnapshotID
+	}
+	requirements := []map[string]interface{}{
+		{"type": "assert-table-uuid", "uuid": table.Metadata.TableUUID},
+		{"type": "assert-ref-snapshot-id", "ref": mainBranch, "snapshot-id": currentSnapshotID},
+	}
+
+	var updates []map[string]interface{}
+	if commit.Schema != nil {
+		requirements = append(requirements, map[string]interface{}{
+			"type": "assert-last-assigned-field-id", "last-assigned-field-id": table.Metadata.LastColumnID,
+		})
+		updates = append(updates,
+			map[string]interface{}{"action": "add-schema", "schema": commit.Schema, "last-column-id": commit.LastColumnID},
+			map[string]interface{}{"action": "set-current-schema", "schema-id": -1},
+		)
+	}
+	if len(commit.Properties) > 0 {
+		updates = append(updates, map[string]interface{}{"action": "set-properties", "updates": commit.Properties})
+	}
+	updates = append(updates,
+		map[string]interface{}{"action": "add-snapshot", "snapshot": commit.Snapshot},
+		map[string]interface{}{"action": "set-snapshot-ref", "ref-name": mainBranch, "type": "branch", "snapshot-id": commit.Snapshot.SnapshotID},
+	)
+
+	var response restTableResponse
+	err := c.do(ctx, http.MethodPost, c.tablePath(table.Namespace, table.Name), map[string]interface{}{
+		"requirements": requirements,
+		"updates":      updates,
+	}, &response)
+	if err != nil {
+		var restErr *restError
+		if errors.As(err, &restErr) && restErr.statusCode == http.StatusConflict {
+			return nil, fmt.Errorf("committing table %s.%s: %w: %w", table.Namespace, table.Name, ErrCommitConflict, err)
+		}
+		return nil, fmt.Errorf("committing table %s.%s: %w", table.Namespace, table.Name, err)
+	}
+	return &Table{
+		Namespace:        table.Namespace,
+		Name:             table.Name,
+		MetadataLocation: response.MetadataLocation,
+		Metadata:         response.Metadata,
+	}, nil
+}
+
+func (c *restCatalog) tablePath(namespace, tableName string) string {
+	return "namespaces/" + url.PathEscape(namespace) + "/tables/" + url.PathEscape(tableName)
+}
+
+// catalogPrefix returns the prefix of the catalog's resources, as configured by the catalog's config endpoint
+func (c *restCatalog) catalogPrefix(ctx context.Context) (string, error) {
+	c.prefixMu.Lock()
+	defer c.prefixMu.Unlock()
+
+	if c.prefixLoaded {
+		return c.prefix, nil
+	}
+	var response struct {
+		Overrides map[string]string `json:"overrides"`
+		Defaults  map[string]string `json:"defaults"`
+	}
+	if err := c.request(ctx, http.MethodGet, c.uri+"/v1/config", nil, &response); err != nil {
+		return "", fmt.Errorf("getting catalog config: %w", err)
+	}
+	c.prefix = response.Defaults["prefix"]
+	if prefix, ok := response.Overrides["prefix"]; ok {
+		c.prefix = prefix
+	}
+	c.prefixLoaded = true
+	return c.prefix, nil
+}
+
+// do sends a request for the resource to the catalog, resolving the resource path using the catalog's prefix
+func (c *restCatalog) do(ctx context.Context, method, resource string, body, response interface{}) error {
+	prefix, err := c.catalogPrefix(ctx)
+	if err != nil {
+		return err
+	}
+	endpoint := c.uri + "/v1/"
+	if prefix != "" {
+		endpoint += prefix + "/"
+	}
+	return c.request(ctx, method, endpoint+resource, body, response)
+}
+
+func (c *restCatalog) request(ctx context.Context, method, endpoint string, body, response interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshalling request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResponse restErrorResponse
+		message := string(respBody)
+		if err := json.Unmarshal(respBody, &errResponse); err == nil && errResponse.Error.Message != "" {
+			message = errResponse.Error.Message
+		}
+		return &restError{statusCode: resp.StatusCode, message: message}
+	}
+	if response == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, response); err != nil {
+		return fmt.Errorf("unmarshalling response: %w", err)
+	}
+	return nil
+}
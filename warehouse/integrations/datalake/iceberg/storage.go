@@ -0,0 +1,129 @@
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/rudderlabs/rudder-go-kit/filemanager"
+
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+// ObjectStorage is the subset of filemanager.FileManager used for reading and writing table metadata
+type ObjectStorage interface {
+	ListFilesWithPrefix(ctx context.Context, startAfter, prefix string, maxItems int64) filemanager.ListSession
+	Download(ctx context.Context, output io.WriterAt, key string, opts ...filemanager.DownloadOption) error
+	UploadReader(ctx context.Context, objName string, rdr io.Reader) (filemanager.UploadedFile, error)
+	Prefix() string
+	GetObjectNameFromLocation(location string) (string, error)
+}
+
+// Storage reads and writes the files of iceberg tables stored in the datalake's bucket,
+// translating between object keys and the URIs used for referencing them in table metadata
+type Storage struct {
+	fm      ObjectStorage
+	baseURI string
+}
+
+// NewStorage returns the storage for the bucket described by the object storage config of the provider
+func NewStorage(fm ObjectStorage, provider string, config map[string]interface{}) (*Storage, error) {
+	stringConfig := func(key string) string {
+		v, _ := config[key].(string)
+		return v
+	}
+
+	var baseURI string
+	switch provider {
+	case warehouseutils.S3, warehouseutils.MINIO:
+		baseURI = "s3://" + stringConfig("bucketName")
+	case warehouseutils.GCS:
+		baseURI = "gs://" + stringConfig("bucketName")
+	case warehouseutils.AzureBlob:
+		baseURI = fmt.Sprintf("abfss://%s@%s.dfs.core.windows.net", stringConfig("containerName"), stringConfig("accountName"))
+	default:
+		return nil, fmt.Errorf("unsupported object storage provider for iceberg: %s", provider)
+	}
+	return &Storage{fm: fm, baseURI: baseURI}, nil
+}
+
+// TableKey returns the key under which the data and metadata of the table are stored
+func (s *Storage) TableKey(namespace, tableName string) string {
+	return path.Join(s.fm.Prefix(), warehouseutils.GetTablePathInObjectStorage(namespace, tableName))
+}
+
+// URI returns the URI of the object with the key
+func (s *Storage) URI(key string) string {
+	return s.baseURI + "/" + strings.TrimPrefix(key, "/")
+}
+
+// LocationURI returns the URI of the object with the location returned by the filemanager on upload
+func (s *Storage) LocationURI(location string) (string, error) {
+	key, err := s.fm.GetObjectNameFromLocation(location)
+	if err != nil {
+		return "", fmt.Errorf("getting object name of %s: %w", location, err)
+	}
+	return s.URI(key), nil
+}
+
+// Key returns the key of the object with the URI
+func (s *Storage) Key(uri string) (string, error) {
+	if key, ok := strings.CutPrefix(uri, s.baseURI+"/"); ok {
+		return key, nil
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("parsing uri %s: %w", uri, err)
+	}
+	return strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// read returns the contents of the object with the URI
+func (s *Storage) read(ctx context.Context, uri string) ([]byte, error) {
+	key, err := s.Key(uri)
+	if err != nil {
+		return nil, err
+	}
+	var buf writeAtBuffer
+	if err := s.fm.Download(ctx, &buf, key); err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", uri, err)
+	}
+	return buf.buf, nil
+}
+
+// write stores the contents as the object with the key and returns its URI
+func (s *Storage) write(ctx context.Context, key string, contents []byte) (string, error) {
+	if _, err := s.fm.UploadReader(ctx, key, bytes.NewReader(contents)); err != nil {
+		return "", fmt.Errorf("uploading %s: %w", key, err)
+	}
+	return s.URI(key), nil
+}
+
+// list returns the keys of the objects with the prefix
+func (s *Storage) list(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	iter := filemanager.NewListIterator(s.fm.ListFilesWithPrefix(ctx, "", prefix, 1000))
+	for iter.Next() {
+		keys = append(keys, iter.Get().Key)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("listing files with prefix %s: %w", prefix, err)
+	}
+	return keys, nil
+}
+
+// writeAtBuffer is an in-memory io.WriterAt
+type writeAtBuffer struct {
+	buf []byte
+}
+
+func (b *writeAtBuffer) WriteAt(p []byte, off int64) (int, error) {
+	if end := int(off) + len(p); end > len(b.buf) {
+		b.buf = append(b.buf, make([]byte, end-len(b.buf))...)
+	}
+	return copy(b.buf[off:], p), nil
+}
@@ -0,0 +1,236 @@
+package iceberg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+)
+
+// Writer appends load files to iceberg tables, committing each append as a snapshot
+// so that readers only see the data of an upload once all of its load files are committed
+type Writer struct {
+	catalog Catalog
+	storage *Storage
+	logger  logger.Logger
+	now     func() time.Time
+}
+
+func NewWriter(catalog Catalog, storage *Storage, log logger.Logger) *Writer {
+	return &Writer{
+		catalog: catalog,
+		storage: storage,
+		logger:  log.Child("iceberg"),
+		now:     time.Now,
+	}
+}
+
+// Append commits the data files to the table as a new snapshot, creating the table if it doesn't exist
+// and adding the columns of the table schema missing from the table's schema.
+// Appending data files which were already appended by the table's current snapshot is a no-op, so that appends can be retried.
+func (w *Writer) Append(ctx context.Context, namespace, tableName string, tableSchema model.TableSchema, dataFiles []DataFile) error {
+	if len(dataFiles) == 0 {
+		return nil
+	}
+
+	table, err := w.catalog.LoadTable(ctx, namespace, tableName)
+	if errors.Is(err, ErrNoSuchTable) {
+		table, err = w.createTable(ctx, namespace, tableName, tableSchema)
+	}
+	if err != nil {
+		return err
+	}
+
+	checksum := dataFilesChecksum(dataFiles)
+	parent := table.Metadata.CurrentSnapshot()
+	if parent != nil && parent.Summary[loadFilesChecksumSummary] == checksum {
+		w.logger.Infon("Skipping append of already committed data files",
+			logger.NewStringField("namespace", namespace),
+			logger.NewStringField("tableName", tableName),
+			logger.NewIntField("snapshotID", parent.SnapshotID),
+		)
+		return nil
+	}
+
+	commit, err := w.prepareCommit(ctx, table, tableSchema, dataFiles, checksum)
+	if err != nil {
+		return fmt.Errorf("preparing commit for table %s.%s: %w", namespace, tableName, err)
+	}
+	if _, err := w.catalog.CommitTable(ctx, table, commit); err != nil {
+		return fmt.Errorf("committing table %s.%s: %w", namespace, tableName, err)
+	}
+	return nil
+}
+
+func (w *Writer) createTable(ctx context.Context, namespace, tableName string, tableSchema model.TableSchema) (*Table, error) {
+	schema, err := toSchema(tableSchema)
+	if err != nil {
+		return nil, fmt.Errorf("creating schema of table %s.%s: %w", namespace, tableName, err)
+	}
+	nameMapping, err := schema.nameMapping()
+	if err != nil {
+		return nil, err
+	}
+	location := w.storage.URI(w.storage.TableKey(namespace, tableName))
+	table, err := w.catalog.CreateTable(ctx, namespace, tableName, location, schema, map[string]string{
+		nameMappingProperty: nameMapping,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating table %s.%s: %w", namespace, tableName, err)
+	}
+	return table, nil
+}
+
+// prepareCommit writes the manifest and manifest list of a snapshot appending the data files to the table's current snapshot
+func (w *Writer) prepareCommit(ctx context.Context, table *Table, tableSchema model.TableSchema, dataFiles []DataFile, checksum string) (Commit, error) {
+	var commit Commit
+
+	currentSchema, err := table.Metadata.CurrentSchema()
+	if err != nil {
+		return Commit{}, err
+	}
+	schema, lastColumnID, err := currentSchema.evolve(tableSchema, table.Metadata.LastColumnID)
+	if err != nil {
+		return Commit{}, fmt.Errorf("evolving schema: %w", err)
+	}
+	if lastColumnID != table.Metadata.LastColumnID {
+		for _, s := range table.Metadata.Schemas {
+			schema.SchemaID = max(schema.SchemaID, s.SchemaID+1)
+		}
+		commit.Schema = &schema
+		commit.LastColumnID = lastColumnID
+	}
+	// catalogs might assign field ids of their own when creating tables, so the name mapping is checked on every commit
+	nameMapping, err := schema.nameMapping()
+	if err != nil {
+		return Commit{}, err
+	}
+	if table.Metadata.Properties[nameMappingProperty] != nameMapping {
+		commit.Properties = map[string]string{nameMappingProperty: nameMapping}
+	}
+
+	snapshot := Snapshot{
+		SnapshotID:     rand.Int63(), // #nosec G404 -- snapshot ids only need to be unique within the table
+		SequenceNumber: table.Metadata.LastSequenceNumber + 1,
+		TimestampMS:    w.now().UnixMilli(),
+		SchemaID:       &schema.SchemaID,
+	}
+
+	var manifests []manifestFile
+	parent := table.Metadata.CurrentSnapshot()
+	if parent != nil {
+		snapshot.ParentSnapshotID = &parent.SnapshotID
+		contents, err := w.storage.read(ctx, parent.ManifestList)
+		if err != nil {
+			return Commit{}, fmt.Errorf("reading manifest list of snapshot %d: %w", parent.SnapshotID, err)
+		}
+		if manifests, err = readManifestList(contents); err != nil {
+			return Commit{}, err
+		}
+	}
+
+	metadataKey, err := w.storage.Key(strings.TrimSuffix(table.Metadata.Location, "/") + "/metadata")
+	if err != nil {
+		return Commit{}, err
+	}
+	dataFileURIs := make([]string, 0, len(dataFiles))
+	for _, dataFile := range dataFiles {
+		uri, err := w.storage.LocationURI(dataFile.Location)
+		if err != nil {
+			return Commit{}, err
+		}
+		dataFileURIs = append(dataFileURIs, uri)
+	}
+	manifestContents, err := writeManifest(schema, snapshot.SnapshotID, dataFileURIs, dataFiles)
+	if err != nil {
+		return Commit{}, fmt.Errorf("creating manifest: %w", err)
+	}
+	manifestLocation, err := w.storage.write(ctx, path.Join(metadataKey, uuid.NewString()+"-m0.avro"), manifestContents)
+	if err != nil {
+		return Commit{}, fmt.Errorf("writing manifest: %w", err)
+	}
+
+	var addedRecords, addedFilesSize int64
+	for _, dataFile := range dataFiles {
+		addedRecords += dataFile.RecordCount
+		addedFilesSize += dataFile.FileSizeInBytes
+	}
+	manifests = append(manifests, manifestFile{
+		Path:              manifestLocation,
+		Length:            int64(len(manifestContents)),
+		Content:           manifestContentData,
+		SequenceNumber:    snapshot.SequenceNumber,
+		MinSequenceNumber: snapshot.SequenceNumber,
+		AddedSnapshotID:   snapshot.SnapshotID,
+		AddedFilesCount:   int32(len(dataFiles)),
+		AddedRowsCount:    addedRecords,
+	})
+
+	var parentSummary map[string]string
+	if parent != nil {
+		parentSummary = parent.Summary
+	}
+	snapshot.Summary = map[string]string{
+		"operation":              "append",
+		"added-data-files":       strconv.Itoa(len(dataFiles)),
+		"added-records":          strconv.FormatInt(addedRecords, 10),
+		"added-files-size":       strconv.FormatInt(addedFilesSize, 10),
+		"total-data-files":       strconv.FormatInt(summaryInt(parentSummary, "total-data-files")+int64(len(dataFiles)), 10),
+		"total-records":          strconv.FormatInt(summaryInt(parentSummary, "total-records")+addedRecords, 10),
+		"total-files-size":       strconv.FormatInt(summaryInt(parentSummary, "total-files-size")+addedFilesSize, 10),
+		"total-delete-files":     strconv.FormatInt(summaryInt(parentSummary, "total-delete-files"), 10),
+		"total-position-deletes": strconv.FormatInt(summaryInt(parentSummary, "total-position-deletes"), 10),
+		"total-equality-deletes": strconv.FormatInt(summaryInt(parentSummary, "total-equality-deletes"), 10),
+		loadFilesChecksumSummary: checksum,
+	}
+
+	manifestListContents, err := writeManifestList(snapshot, manifests)
+	if err != nil {
+		return Commit{}, fmt.Errorf("creating manifest list: %w", err)
+	}
+	manifestListKey := path.Join(metadataKey, fmt.Sprintf("snap-%d-1-%s.avro", snapshot.SnapshotID, uuid.NewString()))
+	if snapshot.ManifestList, err = w.storage.write(ctx, manifestListKey, manifestListContents); err != nil {
+		return Commit{}, fmt.Errorf("writing manifest list: %w", err)
+	}
+
+	commit.Snapshot = snapshot
+	return commit, nil
+}
+
+// dataFilesChecksum returns a checksum identifying the set of data files
+func dataFilesChecksum(dataFiles []DataFile) string {
+	locations := make([]string, 0, len(dataFiles))
+	for _, dataFile := range dataFiles {
+		locations = append(locations, dataFile.Location)
+	}
+	slices.Sort(locations)
+
+	h := sha256.New()
+	for _, location := range locations {
+		h.Write([]byte(location))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func jsonString(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("marshalling %T: %w", v, err)
+	}
+	return string(b), nil
+}
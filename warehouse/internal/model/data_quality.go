@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+type DataQualityCheckType = string
+
+const (
+	// NotNullCheck counts the null values of a column within the rows loaded by the upload
+	NotNullCheck DataQualityCheckType = "not_null"
+	// UniqueIDCheck counts the duplicated ids within the rows loaded by the upload
+	UniqueIDCheck DataQualityCheckType = "unique_id"
+	// FreshnessCheck counts the rows received within the freshness window
+	FreshnessCheck DataQualityCheckType = "freshness"
+	// RowCountDeltaCheck compares the number of rows in the table with the one observed by the previous upload
+	RowCountDeltaCheck DataQualityCheckType = "row_count_delta"
+)
+
+type DataQualityCheckStatus = string
+
+const (
+	DataQualityCheckPassed DataQualityCheckStatus = "passed"
+	DataQualityCheckFailed DataQualityCheckStatus = "failed"
+	// DataQualityCheckErrored is the status of a check which couldn't be run, e.g. because of a query error
+	DataQualityCheckErrored DataQualityCheckStatus = "errored"
+)
+
+// DataQualityChecksConfig is the configuration of the data quality checks run against a table after it is loaded
+type DataQualityChecksConfig struct {
+	NotNull                 []string `json:"notNull"`
+	UniqueID                bool     `json:"uniqueId"`
+	FreshnessInMinutes      int      `json:"freshnessInMinutes"`
+	MaxRowCountDeltaPercent float64  `json:"maxRowCountDeltaPercent"`
+}
+
+// DataQualityCheckResult is the result of a data quality check run against a table loaded by an upload
+type DataQualityCheckResult struct {
+	ID            int64
+	UploadID      int64
+	SourceID      string
+	DestinationID string
+	Namespace     string
+	TableName     string
+	CheckType     DataQualityCheckType
+	ColumnName    string
+	Status        DataQualityCheckStatus
+	// Observed is the value measured by the check, e.g. the number of null values for NotNullCheck
+	Observed  float64
+	Threshold float64
+	Message   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,30 @@
+package model
+
+// UploadPlan describes what an upload would do to the warehouse, without the upload touching the warehouse
+type UploadPlan struct {
+	UploadID        int64
+	SourceID        string
+	DestinationID   string
+	DestinationType string
+	Namespace       string
+	StagingFiles    int
+	Tables          []TablePlan
+}
+
+// TablePlan describes the changes an upload would make to a table
+type TablePlan struct {
+	Name             string
+	TableToBeCreated bool
+	AddedColumns     TableSchema
+	AlteredColumns   TableSchema
+	Rows             int64
+	// Columns are the columns with values which would be discarded or would violate constraints
+	Columns []ColumnPlan
+}
+
+// ColumnPlan describes the values of a column which would not be loaded as is
+type ColumnPlan struct {
+	Name                 string
+	Discards             int64
+	ConstraintViolations int64
+}
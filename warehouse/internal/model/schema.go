@@ -31,3 +31,20 @@ type WHSchema struct {
 	UpdatedAt       time.Time
 	ExpiresAt       time.Time
 }
+
+// ColumnWidening records the decision to widen a column instead of discarding the values not matching its type
+type ColumnWidening struct {
+	ID              int64
+	SourceID        string
+	DestinationID   string
+	DestinationType string
+	Namespace       string
+	TableName       string
+	ColumnName      string
+	FromType        string
+	ToType          string
+	// ShadowColumn receives the values not matching the column's type as strings, when the column's type can't be altered in the warehouse
+	ShadowColumn string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -60,10 +60,18 @@ var (
 	PartitionColumnSetting           DestinationConfigSetting = destConfSetting("partitionColumn")
 	PartitionTypeSetting             DestinationConfigSetting = destConfSetting("partitionType")
 	EnableIcebergSetting             DestinationConfigSetting = destConfSetting("enableIceberg")
+	IcebergCatalogTypeSetting        DestinationConfigSetting = destConfSetting("icebergCatalogType")
+	IcebergCatalogURISetting         DestinationConfigSetting = destConfSetting("icebergCatalogURI")
+	IcebergCatalogTokenSetting       DestinationConfigSetting = destConfSetting("icebergCatalogToken")
 	ExternalVolumeSetting            DestinationConfigSetting = destConfSetting("externalVolume")
 	CleanupObjectStorageFilesSetting DestinationConfigSetting = destConfSetting("cleanupObjectStorageFiles")
 	UseOauthSetting                  DestinationConfigSetting = destConfSetting("useOauth")
 	OauthClientIDSetting             DestinationConfigSetting = destConfSetting("oauthClientID")
 	OauthClientSecretSetting         DestinationConfigSetting = destConfSetting("oauthClientSecret")
 	SkipViewsSetting                 DestinationConfigSetting = destConfSetting("skipViews")
+	ColumnTypeWideningSetting        DestinationConfigSetting = destConfSetting("columnTypeWidening")
+	FreshnessSLASetting              DestinationConfigSetting = destConfSetting("freshnessSLAInMinutes")
+	TableFreshnessSLAsSetting        DestinationConfigSetting = destConfSetting("tableFreshnessSLAsInMinutes")
+	DataQualityChecksSetting         DestinationConfigSetting = destConfSetting("dataQualityChecks")
+	FailOnDataQualityChecksSetting   DestinationConfigSetting = destConfSetting("failUploadOnDataQualityChecks")
 )
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// SLABreach are the tables of a source, destination and namespace breaching their sync SLA, as last alerted
+type SLABreach struct {
+	SourceID      string
+	DestinationID string
+	Namespace     string
+	Tables        []string
+	AlertedAt     time.Time
+}
@@ -17,6 +17,7 @@ const (
 	GeneratedLoadFiles        = "generated_load_files"
 	UpdatedTableUploadsCounts = "updated_table_uploads_counts"
 	CreatedRemoteSchema       = "created_remote_schema"
+	LoadedData                = "loaded_data"
 	ExportedData              = "exported_data"
 	ExportingData             = "exporting_data"
 	ExportingDataFailed       = "exporting_data_failed"
@@ -0,0 +1,170 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rudderlabs/rudder-server/utils/timeutil"
+	sqlmiddleware "github.com/rudderlabs/rudder-server/warehouse/integrations/middleware/sqlquerywrapper"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+const (
+	dataQualityChecksTableName    = whutils.WarehouseUploadDataQualityChecksTable
+	dataQualityChecksTableColumns = `
+		id,
+		upload_id,
+		source_id,
+		destination_id,
+		namespace,
+		table_name,
+		check_type,
+		column_name,
+		status,
+		observed,
+		threshold,
+		message,
+		created_at
+	`
+)
+
+var ErrNoDataQualityCheckResult = errors.New("no data quality check result found")
+
+// DataQualityChecks is a repository for the results of the data quality checks run by the uploads
+type DataQualityChecks repo
+
+// NewDataQualityChecks creates a new DataQualityChecks using the given DB connection.
+func NewDataQualityChecks(db *sqlmiddleware.DB, opts ...Opt) *DataQualityChecks {
+	r := &DataQualityChecks{
+		db:  db,
+		now: timeutil.Now,
+	}
+	for _, opt := range opts {
+		opt((*repo)(r))
+	}
+	return r
+}
+
+// Replace replaces the results of the data quality checks of the upload, so that retrying the checks doesn't keep the results of previous attempts.
+func (r *DataQualityChecks) Replace(ctx context.Context, uploadID int64, results []model.DataQualityCheckResult) error {
+	return (*repo)(r).WithTx(ctx, func(tx *sqlmiddleware.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM `+dataQualityChecksTableName+` WHERE upload_id = $1;`, uploadID)
+		if err != nil {
+			return fmt.Errorf("deleting data quality check results: %w", err)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO `+dataQualityChecksTableName+` (
+				upload_id, source_id, destination_id, namespace,
+				table_name, check_type, column_name, status,
+				observed, threshold, message, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+		`)
+		if err != nil {
+			return fmt.Errorf("preparing statement: %w", err)
+		}
+		defer func() { _ = stmt.Close() }()
+
+		now := r.now()
+		for _, result := range results {
+			_, err = stmt.ExecContext(ctx,
+				uploadID,
+				result.SourceID,
+				result.DestinationID,
+				result.Namespace,
+				result.TableName,
+				result.CheckType,
+				result.ColumnName,
+				result.Status,
+				result.Observed,
+				result.Threshold,
+				result.Message,
+				now.UTC(),
+			)
+			if err != nil {
+				return fmt.Errorf("inserting data quality check result: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetByUploadID returns the results of the data quality checks of the upload.
+func (r *DataQualityChecks) GetByUploadID(ctx context.Context, uploadID int64) ([]model.DataQualityCheckResult, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+dataQualityChecksTableColumns+`
+		FROM `+dataQualityChecksTableName+`
+		WHERE upload_id = $1
+		ORDER BY id;
+	`, uploadID)
+	if err != nil {
+		return nil, fmt.Errorf("querying data quality check results: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []model.DataQualityCheckResult
+	for rows.Next() {
+		result, err := scanDataQualityCheckResult(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning data quality check result: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating data quality check results: %w", err)
+	}
+	return results, nil
+}
+
+// GetPrevious returns the latest result of the check for the table recorded by an upload before the given one.
+// Returns ErrNoDataQualityCheckResult if not found.
+func (r *DataQualityChecks) GetPrevious(ctx context.Context, destinationID, namespace, tableName string, checkType model.DataQualityCheckType, uploadID int64) (model.DataQualityCheckResult, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+dataQualityChecksTableColumns+`
+		FROM `+dataQualityChecksTableName+`
+		WHERE
+			destination_id = $1 AND
+			namespace = $2 AND
+			table_name = $3 AND
+			check_type = $4 AND
+			upload_id < $5
+		ORDER BY upload_id DESC, id DESC
+		LIMIT 1;
+	`, destinationID, namespace, tableName, checkType, uploadID)
+
+	result, err := scanDataQualityCheckResult(row.Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.DataQualityCheckResult{}, ErrNoDataQualityCheckResult
+	}
+	if err != nil {
+		return model.DataQualityCheckResult{}, fmt.Errorf("scanning previous data quality check result: %w", err)
+	}
+	return result, nil
+}
+
+func scanDataQualityCheckResult(scan func(dest ...any) error) (model.DataQualityCheckResult, error) {
+	var result model.DataQualityCheckResult
+	err := scan(
+		&result.ID,
+		&result.UploadID,
+		&result.SourceID,
+		&result.DestinationID,
+		&result.Namespace,
+		&result.TableName,
+		&result.CheckType,
+		&result.ColumnName,
+		&result.Status,
+		&result.Observed,
+		&result.Threshold,
+		&result.Message,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return model.DataQualityCheckResult{}, err
+	}
+	result.CreatedAt = result.CreatedAt.UTC()
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
+)
+
+func TestDataQualityChecks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	r := repo.NewDataQualityChecks(setupDB(t), repo.WithNow(func() time.Time {
+		return now
+	}))
+
+	result := func(uploadID int64, tableName string, checkType model.DataQualityCheckType, observed float64) model.DataQualityCheckResult {
+		return model.DataQualityCheckResult{
+			UploadID:      uploadID,
+			SourceID:      "source_id",
+			DestinationID: "destination_id",
+			Namespace:     "namespace",
+			TableName:     tableName,
+			CheckType:     checkType,
+			Status:        model.DataQualityCheckPassed,
+			Observed:      observed,
+			CreatedAt:     now,
+		}
+	}
+
+	t.Run("no results", func(t *testing.T) {
+		results, err := r.GetByUploadID(ctx, 1)
+		require.NoError(t, err)
+		require.Empty(t, results)
+
+		_, err = r.GetPrevious(ctx, "destination_id", "namespace", "tracks", model.RowCountDeltaCheck, 1)
+		require.ErrorIs(t, err, repo.ErrNoDataQualityCheckResult)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		require.NoError(t, r.Replace(ctx, 1, []model.DataQualityCheckResult{
+			result(1, "tracks", model.RowCountDeltaCheck, 10),
+			result(1, "tracks", model.UniqueIDCheck, 0),
+		}))
+		require.NoError(t, r.Replace(ctx, 1, []model.DataQualityCheckResult{
+			result(1, "tracks", model.RowCountDeltaCheck, 20),
+		}))
+
+		results, err := r.GetByUploadID(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		expected := result(1, "tracks", model.RowCountDeltaCheck, 20)
+		expected.ID = results[0].ID
+		require.Equal(t, expected, results[0])
+	})
+
+	t.Run("previous", func(t *testing.T) {
+		require.NoError(t, r.Replace(ctx, 2, []model.DataQualityCheckResult{
+			result(2, "tracks", model.RowCountDeltaCheck, 30),
+			result(2, "pages", model.RowCountDeltaCheck, 5),
+		}))
+		require.NoError(t, r.Replace(ctx, 3, []model.DataQualityCheckResult{
+			result(3, "tracks", model.RowCountDeltaCheck, 40),
+		}))
+
+		previous, err := r.GetPrevious(ctx, "destination_id", "namespace", "tracks", model.RowCountDeltaCheck, 3)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, previous.UploadID)
+		require.EqualValues(t, 30, previous.Observed)
+
+		previous, err = r.GetPrevious(ctx, "destination_id", "namespace", "pages", model.RowCountDeltaCheck, 3)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, previous.Observed)
+
+		_, err = r.GetPrevious(ctx, "destination_id", "namespace", "tracks", model.RowCountDeltaCheck, 1)
+		require.ErrorIs(t, err, repo.ErrNoDataQualityCheckResult)
+
+		_, err = r.GetPrevious(ctx, "destination_id", "other_namespace", "tracks", model.RowCountDeltaCheck, 3)
+		require.ErrorIs(t, err, repo.ErrNoDataQualityCheckResult)
+	})
+}
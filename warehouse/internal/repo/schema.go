@@ -18,7 +18,10 @@ import (
 	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
 )
 
-const whSchemaTableName = warehouseutils.WarehouseSchemasTable
+const (
+	whSchemaTableName                = warehouseutils.WarehouseSchemasTable
+	whSchemaColumnWideningsTableName = warehouseutils.WarehouseSchemaColumnWideningsTable
+)
 
 const whSchemaTableColumns = `
 	id,
@@ -32,6 +35,21 @@ const whSchemaTableColumns = `
 	expires_at
 `
 
+const whSchemaColumnWideningsTableColumns = `
+	id,
+	source_id,
+	destination_id,
+	destination_type,
+	namespace,
+	table_name,
+	column_name,
+	from_type,
+	to_type,
+	shadow_column,
+	created_at,
+	updated_at
+`
+
 type WHSchema struct {
 	*repo
 
@@ -506,3 +524,91 @@ func (sh *WHSchema) SetExpiryForDestination(ctx context.Context, destinationID s
 	_, err := sh.db.ExecContext(ctx, query, expiresAt, sh.now(), destinationID)
 	return err
 }
+
+// InsertColumnWidening records the decision to widen a column, replacing any previous decision for the column
+func (sh *WHSchema) InsertColumnWidening(ctx context.Context, widening *model.ColumnWidening) error {
+	now := sh.now()
+
+	_, err := sh.db.ExecContext(ctx, `
+		INSERT INTO `+whSchemaColumnWideningsTableName+` (
+		  source_id, destination_id, destination_type,
+		  namespace, table_name, column_name,
+		  from_type, to_type, shadow_column,
+		  created_at, updated_at
+		)
+		VALUES
+		  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (
+			destination_id, namespace, table_name, column_name
+		) DO
+		UPDATE
+		SET
+		  to_type = $8,
+		  shadow_column = $9,
+		  updated_at = $11;
+	`,
+		widening.SourceID,
+		widening.DestinationID,
+		widening.DestinationType,
+		widening.Namespace,
+		widening.TableName,
+		widening.ColumnName,
+		widening.FromType,
+		widening.ToType,
+		widening.ShadowColumn,
+		now.UTC(),
+		now.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("inserting column widening: %w", err)
+	}
+	return nil
+}
+
+// GetColumnWidenings returns the column widening decisions for a namespace
+func (sh *WHSchema) GetColumnWidenings(ctx context.Context, destID, namespace string) ([]model.ColumnWidening, error) {
+	rows, err := sh.db.QueryContext(ctx, `
+		SELECT `+whSchemaColumnWideningsTableColumns+` FROM `+whSchemaColumnWideningsTableName+`
+		WHERE
+			destination_id = $1 AND
+			namespace = $2
+		ORDER BY
+			id ASC;
+	`,
+		destID,
+		namespace,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying column widenings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var widenings []model.ColumnWidening
+	for rows.Next() {
+		var widening model.ColumnWidening
+		err := rows.Scan(
+			&widening.ID,
+			&widening.SourceID,
+			&widening.DestinationID,
+			&widening.DestinationType,
+			&widening.Namespace,
+			&widening.TableName,
+			&widening.ColumnName,
+			&widening.FromType,
+			&widening.ToType,
+			&widening.ShadowColumn,
+			&widening.CreatedAt,
+			&widening.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning row: %w", err)
+		}
+		widening.CreatedAt = widening.CreatedAt.UTC()
+		widening.UpdatedAt = widening.UpdatedAt.UTC()
+		widenings = append(widenings, widening)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %w", err)
+	}
+	return widenings, nil
+}
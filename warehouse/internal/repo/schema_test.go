@@ -227,6 +227,45 @@ func TestWHSchemasRepo(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, updatedSchema.Schema, secondRetrieved.Schema)
 	})
+
+	t.Run("ColumnWidenings", func(t *testing.T) {
+		widenings, err := r.GetColumnWidenings(ctx, destinationID, namespace)
+		require.NoError(t, err)
+		require.Empty(t, widenings)
+
+		widening := model.ColumnWidening{
+			SourceID:        sourceID,
+			DestinationID:   destinationID,
+			DestinationType: destinationType,
+			Namespace:       namespace,
+			TableName:       "table_name_1",
+			ColumnName:      "column_name_2",
+			FromType:        "int",
+			ToType:          "float",
+		}
+		require.NoError(t, r.InsertColumnWidening(ctx, &widening))
+
+		t.Log("widening again replaces the previous decision")
+		widening.ToType = "string"
+		widening.ShadowColumn = "column_name_2_str"
+		require.NoError(t, r.InsertColumnWidening(ctx, &widening))
+
+		widenings, err = r.GetColumnWidenings(ctx, destinationID, namespace)
+		require.NoError(t, err)
+		require.Len(t, widenings, 1)
+		require.Equal(t, "int", widenings[0].FromType)
+		require.Equal(t, "string", widenings[0].ToType)
+		require.Equal(t, "column_name_2_str", widenings[0].ShadowColumn)
+		require.Equal(t, now, widenings[0].CreatedAt)
+		require.Equal(t, now, widenings[0].UpdatedAt)
+
+		widenings, err = r.GetColumnWidenings(ctx, destinationID, notFound)
+		require.NoError(t, err)
+		require.Empty(t, widenings)
+
+		_, err = r.GetColumnWidenings(cancelledCtx, destinationID, namespace)
+		require.ErrorIs(t, err, context.Canceled)
+	})
 }
 
 func TestWHSchemasRepoWithTableLevel(t *testing.T) {
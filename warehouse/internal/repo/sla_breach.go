@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/rudderlabs/rudder-server/utils/timeutil"
+	sqlmiddleware "github.com/rudderlabs/rudder-server/warehouse/integrations/middleware/sqlquerywrapper"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+const slaBreachesTableName = whutils.WarehouseSLABreachesTable
+
+var ErrNoSLABreach = errors.New("no sla breach found")
+
+// SLABreaches is a repository for the SLA breaches alerted by the SLA monitor
+type SLABreaches repo
+
+// NewSLABreaches creates a new SLABreaches using the given DB connection.
+func NewSLABreaches(db *sqlmiddleware.DB, opts ...Opt) *SLABreaches {
+	r := &SLABreaches{
+		db:  db,
+		now: timeutil.Now,
+	}
+	for _, opt := range opts {
+		opt((*repo)(r))
+	}
+	return r
+}
+
+// Get returns the breach of the source, destination and namespace. Returns ErrNoSLABreach if not found.
+func (r *SLABreaches) Get(ctx context.Context, sourceID, destinationID, namespace string) (model.SLABreach, error) {
+	breach := model.SLABreach{
+		SourceID:      sourceID,
+		DestinationID: destinationID,
+		Namespace:     namespace,
+	}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT tables, alerted_at
+		FROM `+slaBreachesTableName+`
+		WHERE
+			source_id = $1 AND
+			destination_id = $2 AND
+			namespace = $3;
+	`, sourceID, destinationID, namespace).Scan(pq.Array(&breach.Tables), &breach.AlertedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.SLABreach{}, ErrNoSLABreach
+	}
+	if err != nil {
+		return model.SLABreach{}, fmt.Errorf("querying sla breach: %w", err)
+	}
+	breach.AlertedAt = breach.AlertedAt.UTC()
+	return breach, nil
+}
+
+// Upsert inserts the breach, or updates it if the source, destination and namespace already have one.
+func (r *SLABreaches) Upsert(ctx context.Context, breach model.SLABreach) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO `+slaBreachesTableName+` (source_id, destination_id, namespace, tables, alerted_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (source_id, destination_id, namespace)
+		DO UPDATE SET tables = EXCLUDED.tables, alerted_at = EXCLUDED.alerted_at, updated_at = EXCLUDED.updated_at;
+	`,
+		breach.SourceID,
+		breach.DestinationID,
+		breach.Namespace,
+		pq.Array(breach.Tables),
+		breach.AlertedAt.UTC(),
+		r.now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("upserting sla breach: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes the breach of the source, destination and namespace, if any.
+func (r *SLABreaches) Delete(ctx context.Context, sourceID, destinationID, namespace string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM `+slaBreachesTableName+`
+		WHERE
+			source_id = $1 AND
+			destination_id = $2 AND
+			namespace = $3;
+	`, sourceID, destinationID, namespace)
+	if err != nil {
+		return fmt.Errorf("deleting sla breach: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
+)
+
+func TestSLABreaches(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	r := repo.NewSLABreaches(setupDB(t), repo.WithNow(func() time.Time {
+		return now
+	}))
+
+	_, err := r.Get(ctx, "source_id", "destination_id", "namespace")
+	require.ErrorIs(t, err, repo.ErrNoSLABreach)
+
+	breach := model.SLABreach{
+		SourceID:      "source_id",
+		DestinationID: "destination_id",
+		Namespace:     "namespace",
+		Tables:        []string{"tracks"},
+		AlertedAt:     now,
+	}
+	require.NoError(t, r.Upsert(ctx, breach))
+	got, err := r.Get(ctx, "source_id", "destination_id", "namespace")
+	require.NoError(t, err)
+	require.Equal(t, breach, got)
+
+	breach.Tables = []string{"pages", "tracks"}
+	breach.AlertedAt = now.Add(time.Hour)
+	require.NoError(t, r.Upsert(ctx, breach))
+	got, err = r.Get(ctx, "source_id", "destination_id", "namespace")
+	require.NoError(t, err)
+	require.Equal(t, breach, got)
+
+	_, err = r.Get(ctx, "source_id", "destination_id", "other_namespace")
+	require.ErrorIs(t, err, repo.ErrNoSLABreach)
+
+	require.NoError(t, r.Delete(ctx, "source_id", "destination_id", "namespace"))
+	_, err = r.Get(ctx, "source_id", "destination_id", "namespace")
+	require.ErrorIs(t, err, repo.ErrNoSLABreach)
+}
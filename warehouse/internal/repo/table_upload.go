@@ -422,6 +422,53 @@ func (tu *TableUploads) SyncsInfo(ctx context.Context, uploadID int64) ([]model.
 	return tableUploadInfos, nil
 }
 
+// LastExportedAt returns, for every table, the last time it was exported for the source, destination and namespace.
+// Only the table uploads updated after since are considered.
+func (tu *TableUploads) LastExportedAt(ctx context.Context, sourceID, destinationID, namespace string, since time.Time) (map[string]time.Time, error) {
+	rows, err := tu.db.QueryContext(ctx, `
+		SELECT
+			ut.table_name,
+			MAX(ut.updated_at)
+		FROM
+			`+tableUploadTableName+` ut
+			JOIN `+uploadsTableName+` u ON ut.wh_upload_id = u.id
+		WHERE
+			u.source_id = $1 AND
+			u.destination_id = $2 AND
+			u.namespace = $3 AND
+			ut.status = $4 AND
+			ut.updated_at > $5
+		GROUP BY
+			ut.table_name;
+`,
+		sourceID,
+		destinationID,
+		namespace,
+		model.TableUploadExported,
+		since.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying last exported at: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	lastExportedAt := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			tableName  string
+			exportedAt time.Time
+		)
+		if err := rows.Scan(&tableName, &exportedAt); err != nil {
+			return nil, fmt.Errorf("scanning last exported at: %w", err)
+		}
+		lastExportedAt[tableName] = exportedAt.UTC()
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating last exported at: %w", err)
+	}
+	return lastExportedAt, nil
+}
+
 func (tu *TableUploads) GetByJobRunTaskRun(
 	ctx context.Context,
 	sourceID,
@@ -538,3 +538,83 @@ func TestTableUploads_GetByJobRunTaskRun(t *testing.T) {
 		require.Empty(t, tableUploads)
 	})
 }
+
+func TestTableUploads_LastExportedAt(t *testing.T) {
+	const (
+		sourceID      = "test_source_id"
+		destinationID = "test_destination_id"
+		destType      = "test_destination_type"
+		workspaceID   = "test_workspace_id"
+		namespace     = "test_namespace"
+	)
+
+	db, ctx := setupDB(t), context.Background()
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	repoUpload := repo.NewUploads(db, repo.WithNow(func() time.Time {
+		return now
+	}))
+	repoStaging := repo.NewStagingFiles(db, config.New(), repo.WithNow(func() time.Time {
+		return now
+	}))
+
+	for i, exportedAt := range []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Hour)} {
+		stagingID, err := repoStaging.Insert(ctx, &model.StagingFileWithSchema{})
+		require.NoError(t, err)
+
+		uploadID, err := repoUpload.CreateWithStagingFiles(ctx, model.Upload{
+			WorkspaceID:     workspaceID,
+			Namespace:       namespace,
+			SourceID:        sourceID,
+			DestinationID:   destinationID,
+			DestinationType: destType,
+			Status:          model.ExportedData,
+		}, []*model.StagingFile{{
+			ID:            stagingID,
+			SourceID:      sourceID,
+			DestinationID: destinationID,
+		}})
+		require.NoError(t, err)
+
+		repoTableUpload := repo.NewTableUploads(db, config.New(), repo.WithNow(func() time.Time {
+			return exportedAt
+		}))
+		require.NoError(t, repoTableUpload.Insert(ctx, uploadID, []string{"table1", "table2", "table3"}))
+		require.NoError(t, repoTableUpload.Set(ctx, uploadID, "table1", repo.TableUploadSetOptions{Status: lo.ToPtr(model.TableUploadExported)}))
+		if i == 0 {
+			require.NoError(t, repoTableUpload.Set(ctx, uploadID, "table2", repo.TableUploadSetOptions{Status: lo.ToPtr(model.TableUploadExported)}))
+		}
+		require.NoError(t, repoTableUpload.Set(ctx, uploadID, "table3", repo.TableUploadSetOptions{Status: lo.ToPtr(model.TableUploadExportingFailed)}))
+	}
+
+	repoTableUpload := repo.NewTableUploads(db, config.New())
+
+	t.Run("known", func(t *testing.T) {
+		lastExportedAt, err := repoTableUpload.LastExportedAt(ctx, sourceID, destinationID, namespace, now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, map[string]time.Time{
+			"table1": now.Add(-time.Hour),
+			"table2": now.Add(-2 * time.Hour),
+		}, lastExportedAt)
+	})
+	t.Run("since", func(t *testing.T) {
+		lastExportedAt, err := repoTableUpload.LastExportedAt(ctx, sourceID, destinationID, namespace, now.Add(-90*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, map[string]time.Time{
+			"table1": now.Add(-time.Hour),
+		}, lastExportedAt)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		lastExportedAt, err := repoTableUpload.LastExportedAt(ctx, sourceID, destinationID, "some-other-namespace", now.Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, lastExportedAt)
+	})
+	t.Run("cancelled context", func(t *testing.T) {
+		lastExportedAt, err := repoTableUpload.LastExportedAt(cancelledCtx, sourceID, destinationID, namespace, now.Add(-24*time.Hour))
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, lastExportedAt)
+	})
+}
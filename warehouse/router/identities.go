@@ -440,7 +440,7 @@ func (r *Router) populateHistoricIdentities(ctx context.Context, warehouse model
 			return
 		}
 		defer whManager.Cleanup(ctx)
-		_ = job.setUploadStatus(UploadStatusOpts{Status: inProgressState(model.ExportedData)})
+		_ = job.setUploadStatus(UploadStatusOpts{Status: inProgressState(model.LoadedData)})
 		loadErrors, err := job.loadIdentityTables(true)
 		if err != nil {
 			r.logger.Errorf(`[WH]: Identity table upload errors: %v`, err)
@@ -0,0 +1,339 @@
+package router
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/filemanager"
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/utils/misc"
+	"github.com/rudderlabs/rudder-server/warehouse/constraints"
+	"github.com/rudderlabs/rudder-server/warehouse/integrations/middleware/sqlquerywrapper"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/service"
+	"github.com/rudderlabs/rudder-server/warehouse/schema"
+	"github.com/rudderlabs/rudder-server/warehouse/slave"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+	"github.com/rudderlabs/rudder-server/warehouse/utils/types"
+)
+
+const planFileNamePattern = "plan.*.json.gz"
+
+var (
+	ErrPlanWarehouseNotFound = errors.New("warehouse not found for source and destination")
+	ErrPlanNoStagingFiles    = errors.New("no staging files to plan")
+)
+
+type connectionSourcesFetcher interface {
+	ConnectionSourcesMap(destID string) (map[string]model.Warehouse, bool)
+}
+
+// Planner computes what an upload would do to the warehouse by running the upload pipeline up to generate_upload_schema,
+// using the schema stored for the namespace instead of the warehouse's. It never connects to the warehouse.
+type Planner struct {
+	conf               *config.Config
+	logger             logger.Logger
+	connectionSources  connectionSourcesFetcher
+	uploadRepo         *repo.Uploads
+	stagingRepo        *repo.StagingFiles
+	schemaRepo         *repo.WHSchema
+	fileManagerFactory filemanager.Factory
+	constraintsManager *constraints.Manager
+
+	config struct {
+		stagingFilesBatchSize               config.ValueLoader[int]
+		maxStagingFileReadBufferCapacityInK config.ValueLoader[int]
+	}
+}
+
+func NewPlanner(
+	conf *config.Config,
+	log logger.Logger,
+	db *sqlquerywrapper.DB,
+	connectionSources connectionSourcesFetcher,
+) *Planner {
+	p := &Planner{
+		conf:               conf,
+		logger:             log.Child("planner"),
+		connectionSources:  connectionSources,
+		uploadRepo:         repo.NewUploads(db),
+		stagingRepo:        repo.NewStagingFiles(db, conf),
+		schemaRepo:         repo.NewWHSchemas(db, conf),
+		fileManagerFactory: filemanager.New,
+		constraintsManager: constraints.New(conf),
+	}
+	p.config.stagingFilesBatchSize = conf.GetReloadableIntVar(960, 1, "Warehouse.stagingFilesBatchSize")
+	p.config.maxStagingFileReadBufferCapacityInK = conf.GetReloadableIntVar(10240, 1, "Warehouse.maxStagingFileReadBufferCapacityInK")
+	return p
+}
+
+// PlanUpload plans the upload using its staging files
+func (p *Planner) PlanUpload(ctx context.Context, uploadID int64) (model.UploadPlan, error) {
+	upload, err := p.uploadRepo.Get(ctx, uploadID)
+	if err != nil {
+		return model.UploadPlan{}, fmt.Errorf("getting upload: %w", err)
+	}
+	warehouse, err := p.warehouse(upload.SourceID, upload.DestinationID)
+	if err != nil {
+		return model.UploadPlan{}, err
+	}
+	stagingFiles, err := p.stagingRepo.GetForUploadID(ctx, uploadID)
+	if err != nil {
+		return model.UploadPlan{}, fmt.Errorf("getting staging files for upload: %w", err)
+	}
+
+	uploadPlan, err := p.plan(ctx, warehouse, stagingFiles)
+	if err != nil {
+		return model.UploadPlan{}, err
+	}
+	uploadPlan.UploadID = uploadID
+	return uploadPlan, nil
+}
+
+// PlanPending plans the next upload the router would create from the pending staging files of the source and destination
+func (p *Planner) PlanPending(ctx context.Context, sourceID, destinationID string) (model.UploadPlan, error) {
+	warehouse, err := p.warehouse(sourceID, destinationID)
+	if err != nil {
+		return model.UploadPlan{}, err
+	}
+	stagingFiles, err := p.stagingRepo.Pending(ctx, sourceID, destinationID)
+	if err != nil {
+		return model.UploadPlan{}, fmt.Errorf("getting pending staging files: %w", err)
+	}
+	if len(stagingFiles) > 0 {
+		stagingFiles = service.StageFileBatching(stagingFiles, p.config.stagingFilesBatchSize.Load())[0]
+	}
+	return p.plan(ctx, warehouse, stagingFiles)
+}
+
+func (p *Planner) warehouse(sourceID, destinationID string) (model.Warehouse, error) {
+	sourcesMap, ok := p.connectionSources.ConnectionSourcesMap(destinationID)
+	if !ok {
+		return model.Warehouse{}, fmt.Errorf("%w: sourceID: %s, destinationID: %s", ErrPlanWarehouseNotFound, sourceID, destinationID)
+	}
+	warehouse, ok := sourcesMap[sourceID]
+	if !ok {
+		return model.Warehouse{}, fmt.Errorf("%w: sourceID: %s, destinationID: %s", ErrPlanWarehouseNotFound, sourceID, destinationID)
+	}
+	return warehouse, nil
+}
+
+func (p *Planner) plan(ctx context.Context, warehouse model.Warehouse, stagingFiles []*model.StagingFile) (model.UploadPlan, error) {
+	if len(stagingFiles) == 0 {
+		return model.UploadPlan{}, ErrPlanNoStagingFiles
+	}
+
+	p.logger.Infon("Planning upload",
+		obskit.SourceID(warehouse.Source.ID),
+		obskit.DestinationID(warehouse.Destination.ID),
+		obskit.DestinationType(warehouse.Type),
+		obskit.Namespace(warehouse.Namespace),
+		logger.NewIntField("stagingFiles", int64(len(stagingFiles))),
+	)
+
+	schemaHandle, err := schema.NewOffline(ctx, warehouse, p.conf, p.logger, p.schemaRepo, p.stagingRepo)
+	if err != nil {
+		return model.UploadPlan{}, fmt.Errorf("creating schema handler: %w", err)
+	}
+	uploadSchema, err := schemaHandle.ConsolidateStagingFilesSchema(ctx, stagingFiles)
+	if err != nil {
+		return model.UploadPlan{}, fmt.Errorf("consolidate staging files schema using warehouse schema: %w", err)
+	}
+
+	tableStats := make(map[string]*planTableStats)
+	for _, stagingFile := range stagingFiles {
+		if err := p.scanStagingFile(ctx, warehouse, stagingFile, uploadSchema, tableStats); err != nil {
+			return model.UploadPlan{}, fmt.Errorf("scanning staging file %d: %w", stagingFile.ID, err)
+		}
+	}
+
+	uploadPlan := model.UploadPlan{
+		SourceID:        warehouse.Source.ID,
+		DestinationID:   warehouse.Destination.ID,
+		DestinationType: warehouse.Type,
+		Namespace:       warehouse.Namespace,
+		StagingFiles:    len(stagingFiles),
+	}
+	for _, tableName := range slices.Sorted(maps.Keys(uploadSchema)) {
+		diff, err := schemaHandle.TableSchemaDiff(ctx, tableName, uploadSchema[tableName])
+		if err != nil {
+			return model.UploadPlan{}, fmt.Errorf("table schema diff for %s: %w", tableName, err)
+		}
+		tablePlan := model.TablePlan{
+			Name:             tableName,
+			TableToBeCreated: diff.TableToBeCreated,
+			AddedColumns:     diff.ColumnMap,
+			AlteredColumns:   diff.AlteredColumnMap,
+		}
+		if stats, ok := tableStats[tableName]; ok {
+			tablePlan.Rows = stats.rows
+			for _, columnName := range slices.Sorted(maps.Keys(stats.columns)) {
+				tablePlan.Columns = append(tablePlan.Columns, *stats.columns[columnName])
+			}
+		}
+		uploadPlan.Tables = append(uploadPlan.Tables, tablePlan)
+	}
+	return uploadPlan, nil
+}
+
+type planTableStats struct {
+	rows    int64
+	columns map[string]*model.ColumnPlan
+}
+
+func (s *planTableStats) column(columnName string) *model.ColumnPlan {
+	if _, ok := s.columns[columnName]; !ok {
+		s.columns[columnName] = &model.ColumnPlan{Name: columnName}
+	}
+	return s.columns[columnName]
+}
+
+// scanStagingFile counts the rows of the staging file per table, along with the values which would be discarded or would violate constraints,
+// the same way the slave does while generating load files
+func (p *Planner) scanStagingFile(
+	ctx context.Context,
+	warehouse model.Warehouse,
+	stagingFile *model.StagingFile,
+	uploadSchema model.Schema,
+	tableStats map[string]*planTableStats,
+) error {
+	tableStatsFor := func(tableName string) *planTableStats {
+		if _, ok := tableStats[tableName]; !ok {
+			tableStats[tableName] = &planTableStats{columns: make(map[string]*model.ColumnPlan)}
+		}
+		return tableStats[tableName]
+	}
+	discardsTable := whutils.ToProviderCase(warehouse.Type, whutils.DiscardsTable)
+
+	reader, cleanup, err := p.downloadStagingFile(ctx, warehouse, stagingFile)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	maxCapacity := p.config.maxStagingFileReadBufferCapacityInK.Load() * 1024
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, maxCapacity), maxCapacity)
+
+	for scanner.Scan() {
+		var batchRouterEvent types.BatchRouterEvent
+		if err := jsonrs.Unmarshal(scanner.Bytes(), &batchRouterEvent); err != nil {
+			continue
+		}
+
+		tableName := batchRouterEvent.Metadata.Table
+		stats := tableStatsFor(tableName)
+		stats.rows++
+
+		for columnName, dataTypeInSchema := range uploadSchema[tableName] {
+			columnInfo, ok := batchRouterEvent.GetColumnInfo(columnName)
+			if !ok {
+				continue
+			}
+			columnType, columnVal := columnInfo.Type, columnInfo.Value
+			if columnType == model.IntDataType || columnType == model.BigIntDataType {
+				floatVal, ok := columnVal.(float64)
+				if !ok {
+					continue
+				}
+				columnVal = int(floatVal)
+			}
+
+			violatedConstraints := p.constraintsManager.ViolatedConstraints(warehouse.Type, &batchRouterEvent, columnName)
+			if violatedConstraints.IsViolated {
+				stats.column(columnName).ConstraintViolations++
+				stats.column(columnName).Discards++
+				tableStatsFor(discardsTable).rows++
+				continue
+			}
+			if columnType == dataTypeInSchema {
+				continue
+			}
+			if _, convErr := slave.HandleSchemaChange(dataTypeInSchema, columnType, columnVal); convErr != nil {
+				if isLoadedIntoShadowColumn(warehouse, &batchRouterEvent, uploadSchema[tableName], columnName) {
+					continue
+				}
+				stats.column(columnName).Discards++
+				tableStatsFor(discardsTable).rows++
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading staging file: %w", err)
+	}
+	return nil
+}
+
+// isLoadedIntoShadowColumn reports whether a value not matching the type of its column would be loaded into the column's shadow column instead of being discarded
+func isLoadedIntoShadowColumn(warehouse model.Warehouse, event *types.BatchRouterEvent, tableSchema model.TableSchema, columnName string) bool {
+	if !warehouse.GetBoolDestinationConfig(model.ColumnTypeWideningSetting) {
+		return false
+	}
+	shadowColumn := schema.ShadowColumnName(warehouse.Type, columnName)
+	if _, ok := tableSchema[shadowColumn]; !ok {
+		return false
+	}
+	_, ok := event.GetColumnInfo(shadowColumn)
+	return !ok
+}
+
+// downloadStagingFile downloads the staging file to a temporary file, returning a reader for its contents and a function removing it
+func (p *Planner) downloadStagingFile(ctx context.Context, warehouse model.Warehouse, stagingFile *model.StagingFile) (io.Reader, func(), error) {
+	storageProvider := whutils.ObjectStorageType(warehouse.Destination.DestinationDefinition.Name, warehouse.Destination.Config, stagingFile.UseRudderStorage)
+	fm, err := p.fileManagerFactory(&filemanager.Settings{
+		Provider: storageProvider,
+		Config: misc.GetObjectStorageConfig(misc.ObjectStorageOptsT{
+			Provider:         storageProvider,
+			Config:           warehouse.Destination.Config,
+			UseRudderStorage: stagingFile.UseRudderStorage,
+			WorkspaceID:      warehouse.WorkspaceID,
+		}),
+		Conf: p.conf,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating file manager: %w", err)
+	}
+
+	tmpDirPath, err := misc.CreateTMPDIR()
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating tmp dir: %w", err)
+	}
+	file, err := os.CreateTemp(tmpDirPath, planFileNamePattern)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating file for staging file: %w", err)
+	}
+	cleanup := func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}
+
+	if err := fm.Download(ctx, file, stagingFile.Location); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("downloading staging file from %s: %w", stagingFile.Location, err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("seeking staging file: %w", err)
+	}
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return reader, cleanup, nil
+}
@@ -0,0 +1,89 @@
+package router
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/filemanager"
+	"github.com/rudderlabs/rudder-go-kit/filemanager/mock_filemanager"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/warehouse/constraints"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+func TestPlanner_ScanStagingFile(t *testing.T) {
+	stagingFileContents := strings.Join([]string{
+		`{"data":{"id":"1","count":1},"metadata":{"table":"tracks","columns":{"id":"string","count":"int"}}}`,
+		`{"data":{"id":"2","count":"many"},"metadata":{"table":"tracks","columns":{"id":"string","count":"string"}}}`,
+		`{"data":{"merge_property_1_type":"email","merge_property_1_value":"` + strings.Repeat("a", 600) + `"},"metadata":{"table":"rudder_identity_merge_rules","columns":{"merge_property_1_type":"string","merge_property_1_value":"string"}}}`,
+		`not a json line`,
+	}, "\n")
+
+	ctrl := gomock.NewController(t)
+	mockFileManager := mock_filemanager.NewMockFileManager(ctrl)
+	mockFileManager.EXPECT().Download(gomock.Any(), gomock.Any(), "staging-file-location").DoAndReturn(func(_ context.Context, output io.WriterAt, _ string, _ ...filemanager.DownloadOption) error {
+		var buf bytes.Buffer
+		gzWriter := gzip.NewWriter(&buf)
+		if _, err := gzWriter.Write([]byte(stagingFileContents)); err != nil {
+			return err
+		}
+		if err := gzWriter.Close(); err != nil {
+			return err
+		}
+		_, err := output.WriteAt(buf.Bytes(), 0)
+		return err
+	}).Times(1)
+
+	p := &Planner{
+		conf:   config.New(),
+		logger: logger.NOP,
+		fileManagerFactory: func(*filemanager.Settings) (filemanager.FileManager, error) {
+			return mockFileManager, nil
+		},
+		constraintsManager: constraints.New(config.New()),
+	}
+	p.config.maxStagingFileReadBufferCapacityInK = config.SingleValueLoader(1024)
+
+	warehouse := model.Warehouse{
+		Type: warehouseutils.BQ,
+		Destination: backendconfig.DestinationT{
+			Config: map[string]interface{}{"bucketName": "bucket"},
+			DestinationDefinition: backendconfig.DestinationDefinitionT{
+				Name: warehouseutils.BQ,
+			},
+		},
+	}
+	uploadSchema := model.Schema{
+		"tracks": {"id": "string", "count": "int"},
+		"rudder_identity_merge_rules": {
+			"merge_property_1_type":  "string",
+			"merge_property_1_value": "string",
+		},
+	}
+
+	tableStats := make(map[string]*planTableStats)
+	err := p.scanStagingFile(context.Background(), warehouse, &model.StagingFile{ID: 1, Location: "staging-file-location"}, uploadSchema, tableStats)
+	require.NoError(t, err)
+
+	require.Len(t, tableStats, 3)
+	require.EqualValues(t, 2, tableStats["tracks"].rows)
+	require.Equal(t, map[string]*model.ColumnPlan{
+		"count": {Name: "count", Discards: 1},
+	}, tableStats["tracks"].columns)
+	require.EqualValues(t, 1, tableStats["rudder_identity_merge_rules"].rows)
+	require.Equal(t, map[string]*model.ColumnPlan{
+		"merge_property_1_value": {Name: "merge_property_1_value", Discards: 1, ConstraintViolations: 1},
+	}, tableStats["rudder_identity_merge_rules"].columns)
+	require.EqualValues(t, 2, tableStats[warehouseutils.DiscardsTable].rows)
+}
@@ -87,6 +87,7 @@ type Router struct {
 	bcManager        *bcm.BackendConfigManager
 	uploadJobFactory UploadJobFactory
 	notifier         *notifier.Notifier
+	slaMonitor       *slaMonitor
 
 	config struct {
 		maxConcurrentUploadJobs           int
@@ -174,6 +175,12 @@ func New(
 	}
 	loadfiles.WithConfig(r.uploadJobFactory.loadFile, r.conf)
 
+	r.slaMonitor = newSLAMonitor(destType, r.conf, r.logger, r.statsFactory,
+		repo.NewTableUploads(db, r.conf),
+		repo.NewSLABreaches(db),
+		r.uploadRepo,
+	)
+
 	r.loadReloadableConfig(warehouseutils.WHDestNameMap[destType])
 	r.loadStats()
 	return r
@@ -200,6 +207,9 @@ func (r *Router) Start(ctx context.Context) error {
 	g.Go(crash.NotifyWarehouse(func() error {
 		return r.cronTracker(gCtx)
 	}))
+	g.Go(crash.NotifyWarehouse(func() error {
+		return r.slaMonitor.run(gCtx, r.copyWarehouses)
+	}))
 	return g.Wait()
 }
 
@@ -0,0 +1,403 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/services/alert"
+	"github.com/rudderlabs/rudder-server/services/alerta"
+	"github.com/rudderlabs/rudder-server/utils/timeutil"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+const slaAlertResource = "warehouse-sync-sla-breached"
+
+type slaTableUploadsRepo interface {
+	LastExportedAt(ctx context.Context, sourceID, destinationID, namespace string, since time.Time) (map[string]time.Time, error)
+}
+
+type slaBreachesRepo interface {
+	Get(ctx context.Context, sourceID, destinationID, namespace string) (model.SLABreach, error)
+	Upsert(ctx context.Context, breach model.SLABreach) error
+	Delete(ctx context.Context, sourceID, destinationID, namespace string) error
+}
+
+type slaUploadsRepo interface {
+	GetFirstAbortedUploadInContinuousAbortsByDestination(ctx context.Context, workspaceID string, start time.Time) ([]model.FirstAbortedUploadResponse, error)
+}
+
+// slaMonitor evaluates the freshness SLAs configured for the destinations, i.e. the maximum age of the last successful upload of every table.
+// It emits stats for every warehouse and alerts, using alerta and the configured alert manager (PagerDuty/VictorOps), when the SLAs are breached.
+// Alerted breaches are persisted, so that restarts neither re-alert ongoing breaches nor miss resolving them.
+type slaMonitor struct {
+	destType     string
+	logger       logger.Logger
+	statsFactory stats.Stats
+	now          func() time.Time
+
+	tableUploadsRepo slaTableUploadsRepo
+	breachesRepo     slaBreachesRepo
+	uploadsRepo      slaUploadsRepo
+	alertSender      alerta.AlertSender
+	alertManager     alert.AlertManager
+
+	config struct {
+		enabled            config.ValueLoader[bool]
+		checkInterval      config.ValueLoader[time.Duration]
+		realertInterval    config.ValueLoader[time.Duration]
+		lookbackMultiplier config.ValueLoader[int]
+		recentTablesWindow config.ValueLoader[time.Duration]
+		enableAlerta       config.ValueLoader[bool]
+		enableAlertManager config.ValueLoader[bool]
+	}
+}
+
+// tableFreshness is the freshness of a table against its SLA
+type tableFreshness struct {
+	tableName      string
+	sla            time.Duration
+	lastExportedAt time.Time // zero if the table wasn't exported within the lookback window
+	age            time.Duration
+}
+
+func (t tableFreshness) breached() bool {
+	return t.age > t.sla
+}
+
+func newSLAMonitor(
+	destType string,
+	conf *config.Config,
+	log logger.Logger,
+	statsFactory stats.Stats,
+	tableUploadsRepo slaTableUploadsRepo,
+	breachesRepo slaBreachesRepo,
+	uploadsRepo slaUploadsRepo,
+) *slaMonitor {
+	m := &slaMonitor{
+		destType:         destType,
+		logger:           log.Child("sla"),
+		statsFactory:     statsFactory,
+		now:              timeutil.Now,
+		tableUploadsRepo: tableUploadsRepo,
+		breachesRepo:     breachesRepo,
+		uploadsRepo:      uploadsRepo,
+		alertSender: alerta.NewClient(
+			conf.GetString("ALERTA_URL", "https://alerta.rudderstack.com/api/"),
+		),
+	}
+	if alertManager, err := alert.New(); err == nil {
+		m.alertManager = alertManager
+	}
+
+	m.config.enabled = conf.GetReloadableBoolVar(true, "Warehouse.sla.enabled")
+	m.config.checkInterval = conf.GetReloadableDurationVar(5, time.Minute, "Warehouse.sla.checkInterval")
+	m.config.realertInterval = conf.GetReloadableDurationVar(6, time.Hour, "Warehouse.sla.realertInterval")
+	m.config.lookbackMultiplier = conf.GetReloadableIntVar(2, 1, "Warehouse.sla.lookbackMultiplier")
+	m.config.recentTablesWindow = conf.GetReloadableDurationVar(7*24, time.Hour, "Warehouse.sla.recentTablesWindow")
+	m.config.enableAlerta = conf.GetReloadableBoolVar(true, "Warehouse.sla.enableAlerta")
+	m.config.enableAlertManager = conf.GetReloadableBoolVar(false, "Warehouse.sla.enableAlertManager")
+	return m
+}
+
+// run evaluates the SLAs of the warehouses every check interval, until the context is cancelled
+func (m *slaMonitor) run(ctx context.Context, warehouses func() []model.Warehouse) error {
+	for {
+		if m.config.enabled.Load() {
+			m.evaluate(ctx, warehouses())
+		}
+
+		select {
+		case <-ctx.Done():
+			m.logger.Infon("Context cancelled. Exiting SLA monitor")
+			return nil
+		case <-time.After(m.config.checkInterval.Load()):
+		}
+	}
+}
+
+func (m *slaMonitor) evaluate(ctx context.Context, warehouses []model.Warehouse) {
+	for _, warehouse := range warehouses {
+		freshness, err := m.tablesFreshness(ctx, warehouse)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			m.logger.Warnn("Evaluating sync SLAs",
+				obskit.SourceID(warehouse.Source.ID),
+				obskit.DestinationID(warehouse.Destination.ID),
+				obskit.Namespace(warehouse.Namespace),
+				obskit.Error(err),
+			)
+			continue
+		}
+		m.recordFreshnessStats(warehouse, freshness)
+
+		if err := m.alert(ctx, warehouse, freshness); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			m.logger.Warnn("Alerting sync SLA breaches",
+				obskit.SourceID(warehouse.Source.ID),
+				obskit.DestinationID(warehouse.Destination.ID),
+				obskit.Namespace(warehouse.Namespace),
+				obskit.Error(err),
+			)
+		}
+	}
+}
+
+// tablesFreshness returns the freshness of the tables having an SLA, sorted by table name
+func (m *slaMonitor) tablesFreshness(ctx context.Context, warehouse model.Warehouse) ([]tableFreshness, error) {
+	if !warehouse.IsEnabled() {
+		return nil, nil
+	}
+	defaultSLA, hasDefaultSLA := slaDuration(warehouse.Destination.Config[model.FreshnessSLASetting.String()])
+	tableSLAsConfig := warehouse.GetMapDestinationConfig(model.TableFreshnessSLAsSetting)
+	if !hasDefaultSLA && len(tableSLAsConfig) == 0 {
+		return nil, nil
+	}
+
+	now := m.now()
+	recentTablesSince := now.Add(-m.config.recentTablesWindow.Load())
+	maxSLA := defaultSLA
+	for _, value := range tableSLAsConfig {
+		if sla, ok := slaDuration(value); ok {
+			maxSLA = max(maxSLA, sla)
+		}
+	}
+	lookback := max(time.Duration(m.config.lookbackMultiplier.Load())*maxSLA, now.Sub(recentTablesSince))
+	lastExportedAt, err := m.tableUploadsRepo.LastExportedAt(ctx, warehouse.Source.ID, warehouse.Destination.ID, warehouse.Namespace, now.Add(-lookback))
+	if err != nil {
+		return nil, fmt.Errorf("last exported at: %w", err)
+	}
+	recentTables := lo.Keys(lo.PickBy(lastExportedAt, func(_ string, exportedAt time.Time) bool {
+		return exportedAt.After(recentTablesSince)
+	}))
+
+	slas := m.tableSLAs(warehouse, recentTables)
+	freshness := make([]tableFreshness, 0, len(slas))
+	for _, tableName := range slices.Sorted(maps.Keys(slas)) {
+		f := tableFreshness{
+			tableName:      tableName,
+			sla:            slas[tableName],
+			lastExportedAt: lastExportedAt[tableName],
+			age:            lookback,
+		}
+		if !f.lastExportedAt.IsZero() {
+			f.age = now.Sub(f.lastExportedAt)
+		}
+		freshness = append(freshness, f)
+	}
+	return freshness, nil
+}
+
+// tableSLAs returns the SLAs of the tables, using the destination's freshness SLA for the recently exported tables
+// and the table freshness SLAs as overrides. Tables with a non-positive override aren't monitored.
+// Tables which haven't been exported within Warehouse.sla.recentTablesWindow are only monitored if they have their own SLA,
+// so that tables which are no longer loaded, e.g. of events which aren't sent anymore, don't breach the destination's SLA forever.
+func (m *slaMonitor) tableSLAs(warehouse model.Warehouse, recentTables []string) map[string]time.Duration {
+	defaultSLA, hasDefaultSLA := slaDuration(warehouse.Destination.Config[model.FreshnessSLASetting.String()])
+	tableSLAsConfig := warehouse.GetMapDestinationConfig(model.TableFreshnessSLAsSetting)
+
+	slas := make(map[string]time.Duration)
+	if hasDefaultSLA {
+		excludedTables := []string{
+			whutils.ToProviderCase(warehouse.Type, whutils.DiscardsTable),
+			whutils.ToProviderCase(warehouse.Type, whutils.IdentityMergeRulesTable),
+			whutils.ToProviderCase(warehouse.Type, whutils.IdentityMappingsTable),
+		}
+		for _, tableName := range recentTables {
+			if !slices.Contains(excludedTables, tableName) {
+				slas[tableName] = defaultSLA
+			}
+		}
+	}
+	for tableName, value := range tableSLAsConfig {
+		tableName = whutils.ToProviderCase(warehouse.Type, tableName)
+		if sla, ok := slaDuration(value); ok {
+			slas[tableName] = sla
+		} else {
+			delete(slas, tableName)
+		}
+	}
+	return slas
+}
+
+// slaDuration parses an SLA in minutes, provided either as a number or as a string
+func slaDuration(value any) (time.Duration, bool) {
+	var minutes float64
+	switch v := value.(type) {
+	case float64:
+		minutes = v
+	case int:
+		minutes = float64(v)
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false
+		}
+		minutes = parsed
+	default:
+		return 0, false
+	}
+	if minutes <= 0 {
+		return 0, false
+	}
+	return time.Duration(minutes * float64(time.Minute)), true
+}
+
+// recordFreshnessStats records the number of monitored and breached tables, along with the age of the stalest table.
+// Breached tables are listed by the alerts instead, keeping the cardinality of the stats independent of the number of tables.
+func (m *slaMonitor) recordFreshnessStats(warehouse model.Warehouse, freshness []tableFreshness) {
+	tags := m.statsTags(warehouse)
+	breached := lo.CountBy(freshness, func(f tableFreshness) bool {
+		return f.breached()
+	})
+	maxAge := lo.Max(lo.Map(freshness, func(f tableFreshness, _ int) time.Duration {
+		return f.age
+	}))
+	m.statsFactory.NewTaggedStat("warehouse_sla_monitored_tables", stats.GaugeType, tags).Gauge(len(freshness))
+	m.statsFactory.NewTaggedStat("warehouse_sla_breached_tables", stats.GaugeType, tags).Gauge(breached)
+	m.statsFactory.NewTaggedStat("warehouse_sla_max_freshness_age_seconds", stats.GaugeType, tags).Gauge(maxAge.Seconds())
+}
+
+func (m *slaMonitor) statsTags(warehouse model.Warehouse) stats.Tags {
+	return stats.Tags{
+		"module":        moduleName,
+		"workspaceId":   warehouse.WorkspaceID,
+		"destType":      m.destType,
+		"sourceId":      warehouse.Source.ID,
+		"destinationId": warehouse.Destination.ID,
+	}
+}
+
+// alert alerts when tables start breaching their SLA, and once every realert interval while they keep breaching it.
+// Once all the tables are within their SLA again, the alert is resolved.
+func (m *slaMonitor) alert(ctx context.Context, warehouse model.Warehouse, freshness []tableFreshness) error {
+	breached := lo.Filter(freshness, func(f tableFreshness, _ int) bool {
+		return f.breached()
+	})
+	breachedTables := lo.Map(breached, func(f tableFreshness, _ int) string {
+		return f.tableName
+	})
+	previous, err := m.breachesRepo.Get(ctx, warehouse.Source.ID, warehouse.Destination.ID, warehouse.Namespace)
+	wasBreached := err == nil
+	if err != nil && !errors.Is(err, repo.ErrNoSLABreach) {
+		return fmt.Errorf("getting previous breach: %w", err)
+	}
+
+	if len(breached) == 0 {
+		if !wasBreached {
+			return nil
+		}
+		if err := m.sendAlert(ctx, warehouse, alerta.SeverityOk, fmt.Sprintf(
+			"Warehouse syncs for source %s to destination %s (%s) in namespace %s are within their SLA again",
+			warehouse.Source.ID, warehouse.Destination.ID, m.destType, warehouse.Namespace,
+		)); err != nil {
+			return err
+		}
+		return m.breachesRepo.Delete(ctx, warehouse.Source.ID, warehouse.Destination.ID, warehouse.Namespace)
+	}
+
+	breach := model.SLABreach{
+		SourceID:      warehouse.Source.ID,
+		DestinationID: warehouse.Destination.ID,
+		Namespace:     warehouse.Namespace,
+		Tables:        breachedTables,
+		AlertedAt:     m.now(),
+	}
+	newlyBreached := len(lo.Without(breachedTables, previous.Tables...)) > 0
+	if wasBreached && !newlyBreached && m.now().Sub(previous.AlertedAt) < m.config.realertInterval.Load() {
+		breach.AlertedAt = previous.AlertedAt
+		return m.breachesRepo.Upsert(ctx, breach)
+	}
+
+	message, err := m.breachMessage(ctx, warehouse, breached)
+	if err != nil {
+		return fmt.Errorf("breach message: %w", err)
+	}
+	if err := m.sendAlert(ctx, warehouse, alerta.SeverityCritical, message); err != nil {
+		return err
+	}
+	return m.breachesRepo.Upsert(ctx, breach)
+}
+
+func (m *slaMonitor) breachMessage(ctx context.Context, warehouse model.Warehouse, breached []tableFreshness) (string, error) {
+	tables := lo.Map(breached, func(f tableFreshness, _ int) string {
+		if f.lastExportedAt.IsZero() {
+			return fmt.Sprintf("%s not synced for more than %s (SLA %s)", f.tableName, f.age, f.sla)
+		}
+		return fmt.Sprintf("%s last synced %s ago (SLA %s)", f.tableName, f.age.Truncate(time.Second), f.sla)
+	})
+	message := fmt.Sprintf("Warehouse syncs for source %s to destination %s (%s) in namespace %s are late: %s",
+		warehouse.Source.ID, warehouse.Destination.ID, m.destType, warehouse.Namespace, strings.Join(tables, ", "),
+	)
+
+	// continuous aborts are the usual reason for late syncs
+	lookback := slices.Max(lo.Map(breached, func(f tableFreshness, _ int) time.Duration {
+		return f.age
+	}))
+	abortedUploads, err := m.uploadsRepo.GetFirstAbortedUploadInContinuousAbortsByDestination(ctx, warehouse.WorkspaceID, m.now().Add(-lookback))
+	if err != nil {
+		return "", fmt.Errorf("first aborted upload in continuous aborts: %w", err)
+	}
+	if abortedUpload, ok := lo.Find(abortedUploads, func(u model.FirstAbortedUploadResponse) bool {
+		return u.DestinationID == warehouse.Destination.ID
+	}); ok {
+		message += fmt.Sprintf(". Uploads are continuously aborting since %s (upload %d)", abortedUpload.CreatedAt.Format(time.RFC3339), abortedUpload.ID)
+	}
+	return message, nil
+}
+
+func (m *slaMonitor) sendAlert(ctx context.Context, warehouse model.Warehouse, severity alerta.Severity, message string) error {
+	status := "breached"
+	if severity == alerta.SeverityOk {
+		status = "resolved"
+	}
+	tags := m.statsTags(warehouse)
+	tags["status"] = status
+	m.statsFactory.NewTaggedStat("warehouse_sla_alerts", stats.CountType, tags).Increment()
+
+	m.logger.Warnn("Warehouse sync SLA "+status,
+		obskit.SourceID(warehouse.Source.ID),
+		obskit.DestinationID(warehouse.Destination.ID),
+		obskit.Namespace(warehouse.Namespace),
+		logger.NewStringField("message", message),
+	)
+
+	if m.config.enableAlertManager.Load() && m.alertManager != nil && severity != alerta.SeverityOk {
+		m.alertManager.Alert(message)
+	}
+	if !m.config.enableAlerta.Load() {
+		return nil
+	}
+	// tags identify the alert, so that the resolution matches the breach
+	return m.alertSender.SendAlert(ctx, slaAlertResource, alerta.SendAlertOpts{
+		Severity:    severity,
+		Priority:    alerta.PriorityP2,
+		Environment: alerta.PROXYMODE,
+		Text:        message,
+		Tags: alerta.Tags{
+			"destID":      warehouse.Destination.ID,
+			"destType":    m.destType,
+			"workspaceID": warehouse.WorkspaceID,
+			"sourceID":    warehouse.Source.ID,
+			"whNamespace": warehouse.Namespace,
+		},
+	})
+}
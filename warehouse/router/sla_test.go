@@ -0,0 +1,312 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+	"github.com/rudderlabs/rudder-go-kit/stats/memstats"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/services/alerta"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+type mockSLATableUploadsRepo struct {
+	lastExportedAt map[string]time.Time
+	since          time.Time
+}
+
+func (m *mockSLATableUploadsRepo) LastExportedAt(_ context.Context, _, _, _ string, since time.Time) (map[string]time.Time, error) {
+	m.since = since
+	return m.lastExportedAt, nil
+}
+
+type mockSLABreachesRepo struct {
+	breaches map[string]model.SLABreach
+}
+
+func (m *mockSLABreachesRepo) Get(_ context.Context, sourceID, destinationID, namespace string) (model.SLABreach, error) {
+	breach, ok := m.breaches[sourceID+destinationID+namespace]
+	if !ok {
+		return model.SLABreach{}, repo.ErrNoSLABreach
+	}
+	return breach, nil
+}
+
+func (m *mockSLABreachesRepo) Upsert(_ context.Context, breach model.SLABreach) error {
+	m.breaches[breach.SourceID+breach.DestinationID+breach.Namespace] = breach
+	return nil
+}
+
+func (m *mockSLABreachesRepo) Delete(_ context.Context, sourceID, destinationID, namespace string) error {
+	delete(m.breaches, sourceID+destinationID+namespace)
+	return nil
+}
+
+type mockSLAUploadsRepo struct {
+	abortedUploads []model.FirstAbortedUploadResponse
+}
+
+func (m *mockSLAUploadsRepo) GetFirstAbortedUploadInContinuousAbortsByDestination(context.Context, string, time.Time) ([]model.FirstAbortedUploadResponse, error) {
+	return m.abortedUploads, nil
+}
+
+type mockSLAAlertSender struct {
+	alerts []alerta.SendAlertOpts
+}
+
+func (m *mockSLAAlertSender) SendAlert(_ context.Context, _ string, opts alerta.SendAlertOpts) error {
+	m.alerts = append(m.alerts, opts)
+	return nil
+}
+
+type mockSLAAlertManager struct {
+	messages []string
+}
+
+func (m *mockSLAAlertManager) Alert(message string) {
+	m.messages = append(m.messages, message)
+}
+
+func TestSLAMonitor(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	warehouse := model.Warehouse{
+		WorkspaceID: "test-workspaceID",
+		Source: backendconfig.SourceT{
+			ID:      "test-sourceID",
+			Enabled: true,
+		},
+		Destination: backendconfig.DestinationT{
+			ID:      "test-destinationID",
+			Enabled: true,
+			Config: map[string]any{
+				model.FreshnessSLASetting.String(): "60",
+				model.TableFreshnessSLAsSetting.String(): map[string]any{
+					"pages":    float64(240),
+					"products": 0,
+				},
+			},
+		},
+		Namespace:  "test_namespace",
+		Type:       whutils.POSTGRES,
+		Identifier: "test-identifier",
+	}
+	newMonitor := func(t *testing.T, tableUploadsRepo *mockSLATableUploadsRepo, uploadsRepo *mockSLAUploadsRepo) (*slaMonitor, *memstats.Store, *mockSLAAlertSender, *mockSLAAlertManager) {
+		t.Helper()
+
+		statsStore, err := memstats.New()
+		require.NoError(t, err)
+
+		c := config.New()
+		c.Set("Warehouse.sla.enableAlertManager", true)
+
+		alertSender := &mockSLAAlertSender{}
+		alertManager := &mockSLAAlertManager{}
+
+		m := newSLAMonitor(whutils.POSTGRES, c, logger.NOP, statsStore, tableUploadsRepo, &mockSLABreachesRepo{breaches: make(map[string]model.SLABreach)}, uploadsRepo)
+		m.now = func() time.Time { return now }
+		m.alertSender = alertSender
+		m.alertManager = alertManager
+		return m, statsStore, alertSender, alertManager
+	}
+	statsTags := func() stats.Tags {
+		return stats.Tags{
+			"module":        moduleName,
+			"workspaceId":   warehouse.WorkspaceID,
+			"destType":      whutils.POSTGRES,
+			"sourceId":      warehouse.Source.ID,
+			"destinationId": warehouse.Destination.ID,
+		}
+	}
+
+	t.Run("table SLAs", func(t *testing.T) {
+		m, _, _, _ := newMonitor(t, &mockSLATableUploadsRepo{}, &mockSLAUploadsRepo{})
+
+		slas := m.tableSLAs(warehouse, []string{
+			"tracks",
+			"products",
+			"identifies",
+			whutils.DiscardsTable,
+			whutils.IdentityMergeRulesTable,
+			whutils.IdentityMappingsTable,
+		})
+		require.Equal(t, map[string]time.Duration{
+			"tracks":     time.Hour,
+			"pages":      4 * time.Hour,
+			"identifies": time.Hour,
+		}, slas, "the default SLA only applies to recent tables, whereas configured ones are always monitored")
+
+		t.Run("no SLA", func(t *testing.T) {
+			slas := m.tableSLAs(model.Warehouse{Destination: backendconfig.DestinationT{Config: map[string]any{}}}, []string{"tracks"})
+			require.Empty(t, slas)
+		})
+		t.Run("only table SLAs", func(t *testing.T) {
+			slas := m.tableSLAs(model.Warehouse{
+				Type: whutils.SNOWFLAKE,
+				Destination: backendconfig.DestinationT{Config: map[string]any{
+					model.TableFreshnessSLAsSetting.String(): map[string]any{"tracks": "30"},
+				}},
+			}, []string{"TRACKS", "PAGES"})
+			require.Equal(t, map[string]time.Duration{"TRACKS": 30 * time.Minute}, slas)
+		})
+	})
+
+	t.Run("freshness", func(t *testing.T) {
+		tableUploadsRepo := &mockSLATableUploadsRepo{lastExportedAt: map[string]time.Time{
+			"tracks":     now.Add(-30 * time.Minute),
+			"pages":      now.Add(-5 * time.Hour),
+			"identifies": now.Add(-90 * time.Minute),
+			"old_events": now.Add(-8 * 24 * time.Hour),
+		}}
+		m, statsStore, _, _ := newMonitor(t, tableUploadsRepo, &mockSLAUploadsRepo{})
+
+		freshness, err := m.tablesFreshness(context.Background(), warehouse)
+		require.NoError(t, err)
+		require.Equal(t, now.Add(-7*24*time.Hour), tableUploadsRepo.since)
+		require.Equal(t, []tableFreshness{
+			{tableName: "identifies", sla: time.Hour, lastExportedAt: now.Add(-90 * time.Minute), age: 90 * time.Minute},
+			{tableName: "pages", sla: 4 * time.Hour, lastExportedAt: now.Add(-5 * time.Hour), age: 5 * time.Hour},
+			{tableName: "tracks", sla: time.Hour, lastExportedAt: now.Add(-30 * time.Minute), age: 30 * time.Minute},
+		}, freshness)
+
+		m.recordFreshnessStats(warehouse, freshness)
+		require.EqualValues(t, 3, statsStore.Get("warehouse_sla_monitored_tables", statsTags()).LastValue())
+		require.EqualValues(t, 2, statsStore.Get("warehouse_sla_breached_tables", statsTags()).LastValue())
+		require.EqualValues(t, 5*3600, statsStore.Get("warehouse_sla_max_freshness_age_seconds", statsTags()).LastValue())
+
+		t.Run("configured table not exported within the lookback window", func(t *testing.T) {
+			tableUploadsRepo := &mockSLATableUploadsRepo{lastExportedAt: map[string]time.Time{}}
+			m, _, _, _ := newMonitor(t, tableUploadsRepo, &mockSLAUploadsRepo{})
+			m.config.recentTablesWindow = config.SingleValueLoader(time.Hour)
+
+			freshness, err := m.tablesFreshness(context.Background(), warehouse)
+			require.NoError(t, err)
+			require.Equal(t, now.Add(-8*time.Hour), tableUploadsRepo.since)
+			require.Equal(t, []tableFreshness{
+				{tableName: "pages", sla: 4 * time.Hour, age: 8 * time.Hour},
+			}, freshness)
+		})
+
+		t.Run("disabled warehouse", func(t *testing.T) {
+			disabledWarehouse := warehouse
+			disabledWarehouse.Destination.Enabled = false
+
+			freshness, err := m.tablesFreshness(context.Background(), disabledWarehouse)
+			require.NoError(t, err)
+			require.Empty(t, freshness)
+		})
+	})
+
+	t.Run("alerts", func(t *testing.T) {
+		tableUploadsRepo := &mockSLATableUploadsRepo{lastExportedAt: map[string]time.Time{
+			"tracks":     now.Add(-90 * time.Minute),
+			"pages":      now,
+			"identifies": now,
+		}}
+		uploadsRepo := &mockSLAUploadsRepo{abortedUploads: []model.FirstAbortedUploadResponse{
+			{ID: 1, DestinationID: "other-destinationID", CreatedAt: now.Add(-3 * time.Hour)},
+			{ID: 2, DestinationID: warehouse.Destination.ID, CreatedAt: now.Add(-2 * time.Hour)},
+		}}
+		m, statsStore, alertSender, alertManager := newMonitor(t, tableUploadsRepo, uploadsRepo)
+		alertsCount := func(status string) float64 {
+			tags := statsTags()
+			tags["status"] = status
+			if s := statsStore.Get("warehouse_sla_alerts", tags); s != nil {
+				return s.LastValue()
+			}
+			return 0
+		}
+
+		m.evaluate(context.Background(), []model.Warehouse{warehouse})
+		require.Len(t, alertSender.alerts, 1)
+		require.Equal(t, alerta.SeverityCritical, alertSender.alerts[0].Severity)
+		require.Equal(t, "Warehouse syncs for source test-sourceID to destination test-destinationID (POSTGRES) in namespace test_namespace are late: tracks last synced 1h30m0s ago (SLA 1h0m0s). Uploads are continuously aborting since 2023-01-01T10:00:00Z (upload 2)", alertSender.alerts[0].Text)
+		require.Equal(t, alerta.Tags{
+			"destID":      warehouse.Destination.ID,
+			"destType":    whutils.POSTGRES,
+			"workspaceID": warehouse.WorkspaceID,
+			"sourceID":    warehouse.Source.ID,
+			"whNamespace": warehouse.Namespace,
+		}, alertSender.alerts[0].Tags)
+		require.Equal(t, []string{alertSender.alerts[0].Text}, alertManager.messages)
+		require.EqualValues(t, 1, alertsCount("breached"))
+
+		t.Log("same breach within the realert interval")
+		now = now.Add(time.Hour)
+		tableUploadsRepo.lastExportedAt["tracks"] = now.Add(-150 * time.Minute)
+		tableUploadsRepo.lastExportedAt["pages"] = now
+		tableUploadsRepo.lastExportedAt["identifies"] = now
+		m.evaluate(context.Background(), []model.Warehouse{warehouse})
+		require.Len(t, alertSender.alerts, 1)
+
+		t.Log("same breach after a restart")
+		restarted, _, restartedAlertSender, _ := newMonitor(t, tableUploadsRepo, uploadsRepo)
+		restarted.breachesRepo = m.breachesRepo
+		restarted.evaluate(context.Background(), []model.Warehouse{warehouse})
+		require.Empty(t, restartedAlertSender.alerts)
+
+		t.Log("new table breaching its SLA")
+		tableUploadsRepo.lastExportedAt["identifies"] = now.Add(-2 * time.Hour)
+		m.evaluate(context.Background(), []model.Warehouse{warehouse})
+		require.Len(t, alertSender.alerts, 2)
+		require.Contains(t, alertSender.alerts[1].Text, "identifies last synced 2h0m0s ago (SLA 1h0m0s), tracks last synced 2h30m0s ago (SLA 1h0m0s)")
+
+		t.Log("same breach after the realert interval")
+		now = now.Add(7 * time.Hour)
+		tableUploadsRepo.lastExportedAt["pages"] = now
+		m.evaluate(context.Background(), []model.Warehouse{warehouse})
+		require.Len(t, alertSender.alerts, 3)
+		require.Contains(t, alertSender.alerts[2].Text, "identifies last synced 9h0m0s ago (SLA 1h0m0s), tracks last synced 9h30m0s ago (SLA 1h0m0s)")
+		require.EqualValues(t, 3, alertsCount("breached"))
+
+		t.Log("within SLAs again")
+		for tableName := range tableUploadsRepo.lastExportedAt {
+			tableUploadsRepo.lastExportedAt[tableName] = now
+		}
+		m.evaluate(context.Background(), []model.Warehouse{warehouse})
+		require.Len(t, alertSender.alerts, 4)
+		require.Equal(t, alerta.SeverityOk, alertSender.alerts[3].Severity)
+		require.Equal(t, alertSender.alerts[0].Tags, alertSender.alerts[3].Tags)
+		require.Len(t, alertManager.messages, 3)
+		require.EqualValues(t, 1, alertsCount("resolved"))
+
+		t.Log("still within SLAs")
+		m.evaluate(context.Background(), []model.Warehouse{warehouse})
+		require.Len(t, alertSender.alerts, 4)
+	})
+}
+
+func TestSLADuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    any
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "string", value: "90", expected: 90 * time.Minute, ok: true},
+		{name: "string with spaces", value: " 1.5 ", expected: 90 * time.Second, ok: true},
+		{name: "float", value: float64(30), expected: 30 * time.Minute, ok: true},
+		{name: "int", value: 15, expected: 15 * time.Minute, ok: true},
+		{name: "zero", value: "0"},
+		{name: "negative", value: float64(-10)},
+		{name: "invalid string", value: "hourly"},
+		{name: "nil", value: nil},
+		{name: "bool", value: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			duration, ok := slaDuration(tc.value)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, duration)
+		})
+	}
+}
@@ -17,7 +17,7 @@ type state struct {
 var stateTransitions map[string]*state
 
 func init() {
-	stateTransitions = make(map[string]*state, 8)
+	stateTransitions = make(map[string]*state, 9)
 
 	waitingState := &state{
 		completed: model.Waiting,
@@ -62,9 +62,16 @@ func init() {
 	exportDataState := &state{
 		inProgress: "exporting_data",
 		failed:     "exporting_data_failed",
+		completed:  model.LoadedData,
+	}
+	stateTransitions[model.LoadedData] = exportDataState
+
+	checkDataQualityState := &state{
+		inProgress: "checking_data_quality",
+		failed:     "checking_data_quality_failed",
 		completed:  model.ExportedData,
 	}
-	stateTransitions[model.ExportedData] = exportDataState
+	stateTransitions[model.ExportedData] = checkDataQualityState
 
 	abortState := &state{
 		completed: model.Aborted,
@@ -77,7 +84,8 @@ func init() {
 	generateLoadFilesState.nextState = updateTableUploadCountsState
 	updateTableUploadCountsState.nextState = createRemoteSchemaState
 	createRemoteSchemaState.nextState = exportDataState
-	exportDataState.nextState = nil
+	exportDataState.nextState = checkDataQualityState
+	checkDataQualityState.nextState = nil
 	abortState.nextState = nil
 }
 
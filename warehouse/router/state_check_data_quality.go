@@ -0,0 +1,357 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/rudder-go-kit/jsonrs"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/warehouse/integrations/manager"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+type dataQualityChecksRepo interface {
+	Replace(ctx context.Context, uploadID int64, results []model.DataQualityCheckResult) error
+	GetPrevious(ctx context.Context, destinationID, namespace, tableName string, checkType model.DataQualityCheckType, uploadID int64) (model.DataQualityCheckResult, error)
+}
+
+type dataQualityQuerier interface {
+	Query(statement string) (whutils.QueryResult, error)
+}
+
+// checkDataQuality runs the data quality checks configured for the destination against the tables loaded by the upload.
+// Failed checks, as well as errors while running or saving them, only fail the upload if the destination is configured to do so.
+func (job *UploadJob) checkDataQuality(whManager manager.Manager) error {
+	checksConfigs := job.dataQualityChecksToRun()
+	if len(checksConfigs) == 0 {
+		return nil
+	}
+	failOnDataQualityChecks := job.warehouse.GetBoolDestinationConfig(model.FailOnDataQualityChecksSetting)
+
+	whClient, err := whManager.Connect(job.ctx, job.warehouse)
+	if err != nil {
+		return job.dataQualityChecksError("connect", fmt.Errorf("connecting to warehouse: %w", err), failOnDataQualityChecks)
+	}
+	defer whClient.Close()
+
+	checker := &dataQualityChecker{
+		warehouse:   job.warehouse,
+		uploadID:    job.upload.ID,
+		loadedSince: job.upload.FirstAttemptAt,
+		querier:     &whClient,
+		repo:        job.dataQualityRepo,
+		tableSchema: job.GetTableSchemaInWarehouse,
+		now:         job.now,
+	}
+
+	tableNames := lo.Keys(checksConfigs)
+	slices.Sort(tableNames)
+
+	var results []model.DataQualityCheckResult
+	for _, tableName := range tableNames {
+		results = append(results, checker.check(job.ctx, tableName, checksConfigs[tableName])...)
+	}
+	if err := job.dataQualityRepo.Replace(job.ctx, job.upload.ID, results); err != nil {
+		return job.dataQualityChecksError("save", fmt.Errorf("saving data quality check results: %w", err), failOnDataQualityChecks)
+	}
+
+	var failures []string
+	for _, result := range results {
+		job.counterStat("warehouse_data_quality_checks",
+			whutils.Tag{Name: "checkType", Value: result.CheckType},
+			whutils.Tag{Name: "status", Value: result.Status},
+		).Increment()
+
+		if result.Status != model.DataQualityCheckPassed {
+			failures = append(failures, result.TableName+"."+result.CheckType+": "+result.Message)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	job.logger.Warnn("Data quality checks failed",
+		logger.NewIntField("failedChecks", int64(len(failures))),
+		logger.NewStringField("failures", strings.Join(failures, "; ")),
+	)
+	if failOnDataQualityChecks {
+		return fmt.Errorf("data quality checks failed: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// dataQualityChecksToRun returns the data quality checks to run against the tables loaded by the upload, keyed by table name.
+// Data quality checks are opt-in, none being returned unless enabled.
+func (job *UploadJob) dataQualityChecksToRun() map[string]model.DataQualityChecksConfig {
+	if !job.config.enableDataQualityChecks.Load() {
+		return nil
+	}
+
+	checksConfigs, err := dataQualityChecksConfigs(job.warehouse)
+	if err != nil {
+		job.logger.Warnn("Skipping data quality checks with invalid configuration", obskit.Error(err))
+		return nil
+	}
+	checksConfigs = lo.PickBy(checksConfigs, func(tableName string, _ model.DataQualityChecksConfig) bool {
+		_, ok := job.upload.UploadSchema[tableName]
+		return ok
+	})
+	if len(checksConfigs) == 0 {
+		return nil
+	}
+	if whutils.IsDatalakeDestination(job.warehouse.Type) {
+		job.logger.Warnn("Skipping data quality checks not supported for datalake destinations")
+		return nil
+	}
+	return checksConfigs
+}
+
+// dataQualityChecksError records the error preventing the data quality checks from running or being saved.
+// It is only returned if the destination is configured to fail the upload on data quality checks.
+func (job *UploadJob) dataQualityChecksError(stage string, err error, failOnDataQualityChecks bool) error {
+	job.counterStat("warehouse_data_quality_checks_errors", whutils.Tag{Name: "stage", Value: stage}).Increment()
+	if failOnDataQualityChecks {
+		return err
+	}
+	job.logger.Warnn("Skipping data quality checks", obskit.Error(err))
+	return nil
+}
+
+// dataQualityChecksConfigs returns the data quality checks configured for the destination, keyed by table name.
+//
+//	"dataQualityChecks": {
+//	  "tracks": {"notNull": ["user_id"], "uniqueId": true, "freshnessInMinutes": 60, "maxRowCountDeltaPercent": 50}
+//	}
+func dataQualityChecksConfigs(warehouse model.Warehouse) (map[string]model.DataQualityChecksConfig, error) {
+	rawConfigs := warehouse.GetMapDestinationConfig(model.DataQualityChecksSetting)
+	if len(rawConfigs) == 0 {
+		return nil, nil
+	}
+	rawConfigsJSON, err := jsonrs.Marshal(rawConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling data quality checks: %w", err)
+	}
+	var configs map[string]model.DataQualityChecksConfig
+	if err := jsonrs.Unmarshal(rawConfigsJSON, &configs); err != nil {
+		return nil, fmt.Errorf("unmarshalling data quality checks: %w", err)
+	}
+	return lo.MapKeys(configs, func(_ model.DataQualityChecksConfig, tableName string) string {
+		return whutils.ToProviderCase(warehouse.Type, tableName)
+	}), nil
+}
+
+// dataQualityChecker runs the data quality checks against a table in the warehouse.
+// Row level checks only consider the rows loaded since loadedSince, i.e. by the upload.
+type dataQualityChecker struct {
+	warehouse   model.Warehouse
+	uploadID    int64
+	loadedSince time.Time
+	querier     dataQualityQuerier
+	repo        dataQualityChecksRepo
+	tableSchema func(tableName string) model.TableSchema
+	now         func() time.Time
+}
+
+// check runs the data quality checks configured for the table.
+// A check which cannot be run is recorded as errored, without preventing the other checks from running.
+func (c *dataQualityChecker) check(ctx context.Context, tableName string, conf model.DataQualityChecksConfig) []model.DataQualityCheckResult {
+	var results []model.DataQualityCheckResult
+	for _, columnName := range conf.NotNull {
+		columnName = whutils.ToProviderCase(c.warehouse.Type, columnName)
+		result, err := c.checkNotNull(tableName, columnName)
+		if err != nil {
+			result = c.errored(c.newResult(tableName, model.NotNullCheck, columnName, 0), fmt.Errorf("not null check: %w", err))
+		}
+		results = append(results, result)
+	}
+	if conf.UniqueID {
+		result, err := c.checkUniqueID(tableName)
+		if err != nil {
+			result = c.errored(c.newResult(tableName, model.UniqueIDCheck, whutils.ToProviderCase(c.warehouse.Type, "id"), 0), fmt.Errorf("unique id check: %w", err))
+		}
+		results = append(results, result)
+	}
+	if conf.FreshnessInMinutes > 0 {
+		freshness := time.Duration(conf.FreshnessInMinutes) * time.Minute
+		result, err := c.checkFreshness(tableName, freshness)
+		if err != nil {
+			result = c.errored(c.newResult(tableName, model.FreshnessCheck, whutils.ToProviderCase(c.warehouse.Type, "received_at"), freshness.Minutes()), fmt.Errorf("freshness check: %w", err))
+		}
+		results = append(results, result)
+	}
+	if conf.MaxRowCountDeltaPercent > 0 {
+		result, err := c.checkRowCountDelta(ctx, tableName, conf.MaxRowCountDeltaPercent)
+		if err != nil {
+			result = c.errored(c.newResult(tableName, model.RowCountDeltaCheck, "", conf.MaxRowCountDeltaPercent), fmt.Errorf("row count delta check: %w", err))
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (c *dataQualityChecker) checkNotNull(tableName, columnName string) (model.DataQualityCheckResult, error) {
+	result := c.newResult(tableName, model.NotNullCheck, columnName, 0)
+	if _, ok := c.tableSchema(tableName)[columnName]; !ok {
+		return c.failed(result, 0, "column %s not found", columnName), nil
+	}
+
+	nulls, err := c.count(`SELECT COUNT(*) FROM ` + c.table(tableName) + ` WHERE ` + c.loadedRowsCondition(tableName, c.quote(columnName)+` IS NULL`))
+	if err != nil {
+		return model.DataQualityCheckResult{}, err
+	}
+	if nulls > 0 {
+		return c.failed(result, nulls, "%.0f null values in column %s", nulls, columnName), nil
+	}
+	return c.passed(result, nulls), nil
+}
+
+func (c *dataQualityChecker) checkUniqueID(tableName string) (model.DataQualityCheckResult, error) {
+	idColumn := whutils.ToProviderCase(c.warehouse.Type, "id")
+	result := c.newResult(tableName, model.UniqueIDCheck, idColumn, 0)
+	if _, ok := c.tableSchema(tableName)[idColumn]; !ok {
+		return c.failed(result, 0, "column %s not found", idColumn), nil
+	}
+
+	id := c.quote(idColumn)
+	duplicates, err := c.count(`SELECT COUNT(` + id + `) - COUNT(DISTINCT ` + id + `) FROM ` + c.table(tableName) + ` WHERE ` + c.loadedRowsCondition(tableName, "1 = 1"))
+	if err != nil {
+		return model.DataQualityCheckResult{}, err
+	}
+	if duplicates > 0 {
+		return c.failed(result, duplicates, "%.0f duplicated values in column %s", duplicates, idColumn), nil
+	}
+	return c.passed(result, duplicates), nil
+}
+
+func (c *dataQualityChecker) checkFreshness(tableName string, freshness time.Duration) (model.DataQualityCheckResult, error) {
+	receivedAtColumn := whutils.ToProviderCase(c.warehouse.Type, "received_at")
+	result := c.newResult(tableName, model.FreshnessCheck, receivedAtColumn, freshness.Minutes())
+	if _, ok := c.tableSchema(tableName)[receivedAtColumn]; !ok {
+		return c.failed(result, 0, "column %s not found", receivedAtColumn), nil
+	}
+
+	freshRows, err := c.count(`SELECT COUNT(*) FROM ` + c.table(tableName) + ` WHERE ` + c.quote(receivedAtColumn) + ` >= ` + c.timestamp(c.now().Add(-freshness)))
+	if err != nil {
+		return model.DataQualityCheckResult{}, err
+	}
+	if freshRows == 0 {
+		return c.failed(result, freshRows, "no rows received in the last %s", freshness), nil
+	}
+	return c.passed(result, freshRows), nil
+}
+
+func (c *dataQualityChecker) checkRowCountDelta(ctx context.Context, tableName string, maxDeltaPercent float64) (model.DataQualityCheckResult, error) {
+	result := c.newResult(tableName, model.RowCountDeltaCheck, "", maxDeltaPercent)
+
+	rowCount, err := c.count(`SELECT COUNT(*) FROM ` + c.table(tableName))
+	if err != nil {
+		return model.DataQualityCheckResult{}, err
+	}
+
+	previous, err := c.repo.GetPrevious(ctx, c.warehouse.Destination.ID, c.warehouse.Namespace, tableName, model.RowCountDeltaCheck, c.uploadID)
+	if errors.Is(err, repo.ErrNoDataQualityCheckResult) || (err == nil && previous.Observed == 0) {
+		result = c.passed(result, rowCount)
+		result.Message = "no previous row count to compare with"
+		return result, nil
+	}
+	if err != nil {
+		return model.DataQualityCheckResult{}, fmt.Errorf("getting previous row count: %w", err)
+	}
+
+	deltaPercent := (rowCount - previous.Observed) / previous.Observed * 100
+	if math.Abs(deltaPercent) > maxDeltaPercent {
+		return c.failed(result, rowCount, "row count changed by %.2f%% from %.0f to %.0f", deltaPercent, previous.Observed, rowCount), nil
+	}
+	result = c.passed(result, rowCount)
+	result.Message = fmt.Sprintf("row count changed by %.2f%% from %.0f to %.0f", deltaPercent, previous.Observed, rowCount)
+	return result, nil
+}
+
+func (c *dataQualityChecker) newResult(tableName string, checkType model.DataQualityCheckType, columnName string, threshold float64) model.DataQualityCheckResult {
+	return model.DataQualityCheckResult{
+		UploadID:      c.uploadID,
+		SourceID:      c.warehouse.Source.ID,
+		DestinationID: c.warehouse.Destination.ID,
+		Namespace:     c.warehouse.Namespace,
+		TableName:     tableName,
+		CheckType:     checkType,
+		ColumnName:    columnName,
+		Threshold:     threshold,
+	}
+}
+
+func (*dataQualityChecker) passed(result model.DataQualityCheckResult, observed float64) model.DataQualityCheckResult {
+	result.Status = model.DataQualityCheckPassed
+	result.Observed = observed
+	return result
+}
+
+func (*dataQualityChecker) failed(result model.DataQualityCheckResult, observed float64, format string, args ...any) model.DataQualityCheckResult {
+	result.Status = model.DataQualityCheckFailed
+	result.Observed = observed
+	result.Message = fmt.Sprintf(format, args...)
+	return result
+}
+
+func (*dataQualityChecker) errored(result model.DataQualityCheckResult, err error) model.DataQualityCheckResult {
+	result.Status = model.DataQualityCheckErrored
+	result.Message = err.Error()
+	return result
+}
+
+// loadedRowsCondition restricts the condition to the rows loaded by the upload, using the uuid_ts set while generating the load files
+func (c *dataQualityChecker) loadedRowsCondition(tableName, condition string) string {
+	uuidTSColumn := whutils.ToProviderCase(c.warehouse.Type, "uuid_ts")
+	if _, ok := c.tableSchema(tableName)[uuidTSColumn]; !ok || c.loadedSince.IsZero() {
+		return condition
+	}
+	return c.quote(uuidTSColumn) + ` >= ` + c.timestamp(c.loadedSince) + ` AND ` + condition
+}
+
+func (c *dataQualityChecker) count(statement string) (float64, error) {
+	result, err := c.querier.Query(statement)
+	if err != nil {
+		return 0, fmt.Errorf("querying %q: %w", statement, err)
+	}
+	if len(result.Values) == 0 || len(result.Values[0]) == 0 {
+		return 0, fmt.Errorf("no result for %q", statement)
+	}
+	count, err := strconv.ParseFloat(result.Values[0][0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing result for %q: %w", statement, err)
+	}
+	return count, nil
+}
+
+func (c *dataQualityChecker) table(tableName string) string {
+	return c.quote(c.warehouse.Namespace) + "." + c.quote(tableName)
+}
+
+func (c *dataQualityChecker) quote(identifier string) string {
+	switch c.warehouse.Type {
+	case whutils.BQ, whutils.DELTALAKE:
+		return "`" + identifier + "`"
+	default:
+		return `"` + identifier + `"`
+	}
+}
+
+// timestamp returns the timestamp literal in UTC, ClickHouse not accepting the offset while comparing with its DateTime columns
+func (c *dataQualityChecker) timestamp(t time.Time) string {
+	if c.warehouse.Type == whutils.CLICKHOUSE {
+		return `'` + t.UTC().Format(time.DateTime) + `'`
+	}
+	return `'` + t.UTC().Format(time.DateTime) + `+00:00'`
+}
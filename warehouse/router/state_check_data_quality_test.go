@@ -0,0 +1,270 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-go-kit/config"
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	"github.com/rudderlabs/rudder-go-kit/stats"
+
+	backendconfig "github.com/rudderlabs/rudder-server/backend-config"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/internal/repo"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+type mockDataQualityQuerier struct {
+	statements []string
+	results    map[string]string
+}
+
+func (m *mockDataQualityQuerier) Query(statement string) (whutils.QueryResult, error) {
+	m.statements = append(m.statements, statement)
+
+	// the longest matching prefix wins
+	var matched string
+	for prefix := range m.results {
+		if strings.HasPrefix(statement, prefix) && len(prefix) > len(matched) {
+			matched = prefix
+		}
+	}
+	if matched == "" {
+		return whutils.QueryResult{}, errors.New("unexpected statement: " + statement)
+	}
+	return whutils.QueryResult{Columns: []string{"count"}, Values: [][]string{{m.results[matched]}}}, nil
+}
+
+type mockDataQualityChecksRepo struct {
+	previous *model.DataQualityCheckResult
+}
+
+func (*mockDataQualityChecksRepo) Replace(context.Context, int64, []model.DataQualityCheckResult) error {
+	return nil
+}
+
+func (m *mockDataQualityChecksRepo) GetPrevious(context.Context, string, string, string, model.DataQualityCheckType, int64) (model.DataQualityCheckResult, error) {
+	if m.previous == nil {
+		return model.DataQualityCheckResult{}, repo.ErrNoDataQualityCheckResult
+	}
+	return *m.previous, nil
+}
+
+func TestDataQualityChecksConfigs(t *testing.T) {
+	warehouse := func(whType string, config map[string]any) model.Warehouse {
+		return model.Warehouse{
+			Type:        whType,
+			Destination: backendconfig.DestinationT{Config: config},
+		}
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		configs, err := dataQualityChecksConfigs(warehouse(whutils.POSTGRES, map[string]any{}))
+		require.NoError(t, err)
+		require.Empty(t, configs)
+	})
+	t.Run("configured", func(t *testing.T) {
+		configs, err := dataQualityChecksConfigs(warehouse(whutils.SNOWFLAKE, map[string]any{
+			model.DataQualityChecksSetting.String(): map[string]any{
+				"tracks": map[string]any{
+					"notNull":                 []any{"user_id"},
+					"uniqueId":                true,
+					"freshnessInMinutes":      float64(60),
+					"maxRowCountDeltaPercent": 12.5,
+				},
+			},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, map[string]model.DataQualityChecksConfig{
+			"TRACKS": {
+				NotNull:                 []string{"user_id"},
+				UniqueID:                true,
+				FreshnessInMinutes:      60,
+				MaxRowCountDeltaPercent: 12.5,
+			},
+		}, configs)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := dataQualityChecksConfigs(warehouse(whutils.POSTGRES, map[string]any{
+			model.DataQualityChecksSetting.String(): map[string]any{
+				"tracks": map[string]any{"notNull": "user_id"},
+			},
+		}))
+		require.Error(t, err)
+	})
+}
+
+func TestDataQualityChecksToRun(t *testing.T) {
+	newJob := func(enabled bool, whType string) *UploadJob {
+		c := config.New()
+		c.Set("Warehouse.dataQualityChecks.enabled", enabled)
+
+		ujf := &UploadJobFactory{
+			conf:         c,
+			logger:       logger.NOP,
+			statsFactory: stats.NOP,
+		}
+		return ujf.NewUploadJob(context.Background(), &model.UploadJob{
+			Upload: model.Upload{
+				UploadSchema: model.Schema{"tracks": model.TableSchema{}},
+			},
+			Warehouse: model.Warehouse{
+				Type: whType,
+				Destination: backendconfig.DestinationT{Config: map[string]any{
+					model.DataQualityChecksSetting.String(): map[string]any{
+						"tracks": map[string]any{"uniqueId": true},
+						"pages":  map[string]any{"uniqueId": true},
+					},
+				}},
+			},
+		}, nil)
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		ujf := &UploadJobFactory{conf: config.New(), logger: logger.NOP, statsFactory: stats.NOP}
+		job := ujf.NewUploadJob(context.Background(), &model.UploadJob{}, nil)
+		require.False(t, job.config.enableDataQualityChecks.Load())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		require.Empty(t, newJob(false, whutils.POSTGRES).dataQualityChecksToRun())
+	})
+	t.Run("enabled", func(t *testing.T) {
+		require.Equal(t, map[string]model.DataQualityChecksConfig{
+			"tracks": {UniqueID: true},
+		}, newJob(true, whutils.POSTGRES).dataQualityChecksToRun())
+	})
+	t.Run("datalake", func(t *testing.T) {
+		require.Empty(t, newJob(true, whutils.S3Datalake).dataQualityChecksToRun())
+	})
+}
+
+func TestDataQualityChecker(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	loadedSince := now.Add(-time.Hour)
+
+	newChecker := func(whType string, querier *mockDataQualityQuerier, checksRepo *mockDataQualityChecksRepo) *dataQualityChecker {
+		return &dataQualityChecker{
+			warehouse: model.Warehouse{
+				Type:        whType,
+				Namespace:   "namespace",
+				Source:      backendconfig.SourceT{ID: "source_id"},
+				Destination: backendconfig.DestinationT{ID: "destination_id"},
+			},
+			uploadID:    2,
+			loadedSince: loadedSince,
+			querier:     querier,
+			repo:        checksRepo,
+			tableSchema: func(string) model.TableSchema {
+				return model.TableSchema{"id": "string", "user_id": "string", "received_at": "datetime", "uuid_ts": "datetime"}
+			},
+			now: func() time.Time { return now },
+		}
+	}
+
+	t.Run("passed", func(t *testing.T) {
+		querier := &mockDataQualityQuerier{results: map[string]string{
+			`SELECT COUNT(*) FROM "namespace"."tracks" WHERE "uuid_ts"`:     "0",
+			`SELECT COUNT("id") - COUNT(DISTINCT "id")`:                     "0",
+			`SELECT COUNT(*) FROM "namespace"."tracks" WHERE "received_at"`: "10",
+			`SELECT COUNT(*) FROM "namespace"."tracks"`:                     "110",
+		}}
+		checker := newChecker(whutils.POSTGRES, querier, &mockDataQualityChecksRepo{
+			previous: &model.DataQualityCheckResult{Observed: 100},
+		})
+
+		results := checker.check(context.Background(), "tracks", model.DataQualityChecksConfig{
+			NotNull:                 []string{"user_id"},
+			UniqueID:                true,
+			FreshnessInMinutes:      30,
+			MaxRowCountDeltaPercent: 20,
+		})
+		require.Len(t, results, 4)
+		for _, result := range results {
+			require.Equal(t, model.DataQualityCheckPassed, result.Status, result.CheckType)
+			require.EqualValues(t, 2, result.UploadID)
+			require.Equal(t, "tracks", result.TableName)
+		}
+		require.Equal(t, "row count changed by 10.00% from 100 to 110", results[3].Message)
+		require.EqualValues(t, 110, results[3].Observed)
+
+		require.Equal(t, []string{
+			`SELECT COUNT(*) FROM "namespace"."tracks" WHERE "uuid_ts" >= '2024-01-02 02:04:05+00:00' AND "user_id" IS NULL`,
+			`SELECT COUNT("id") - COUNT(DISTINCT "id") FROM "namespace"."tracks" WHERE "uuid_ts" >= '2024-01-02 02:04:05+00:00' AND 1 = 1`,
+			`SELECT COUNT(*) FROM "namespace"."tracks" WHERE "received_at" >= '2024-01-02 02:34:05+00:00'`,
+			`SELECT COUNT(*) FROM "namespace"."tracks"`,
+		}, querier.statements)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		querier := &mockDataQualityQuerier{results: map[string]string{
+			"SELECT COUNT(*) FROM `namespace`.`tracks` WHERE `uuid_ts`":     "3",
+			"SELECT COUNT(`id`) - COUNT(DISTINCT `id`)":                     "2",
+			"SELECT COUNT(*) FROM `namespace`.`tracks` WHERE `received_at`": "0",
+			"SELECT COUNT(*) FROM `namespace`.`tracks`":                     "50",
+		}}
+		checker := newChecker(whutils.BQ, querier, &mockDataQualityChecksRepo{
+			previous: &model.DataQualityCheckResult{Observed: 100},
+		})
+
+		results := checker.check(context.Background(), "tracks", model.DataQualityChecksConfig{
+			NotNull:                 []string{"user_id", "missing"},
+			UniqueID:                true,
+			FreshnessInMinutes:      30,
+			MaxRowCountDeltaPercent: 20,
+		})
+		require.Len(t, results, 5)
+		for _, result := range results {
+			require.Equal(t, model.DataQualityCheckFailed, result.Status, result.CheckType)
+		}
+		require.Equal(t, []string{
+			"3 null values in column user_id",
+			"column missing not found",
+			"2 duplicated values in column id",
+			"no rows received in the last 30m0s",
+			"row count changed by -50.00% from 100 to 50",
+		}, []string{results[0].Message, results[1].Message, results[2].Message, results[3].Message, results[4].Message})
+	})
+
+	t.Run("no previous row count", func(t *testing.T) {
+		querier := &mockDataQualityQuerier{results: map[string]string{
+			`SELECT COUNT(*) FROM "namespace"."tracks"`: "50",
+		}}
+		checker := newChecker(whutils.POSTGRES, querier, &mockDataQualityChecksRepo{})
+
+		results := checker.check(context.Background(), "tracks", model.DataQualityChecksConfig{
+			MaxRowCountDeltaPercent: 20,
+		})
+		require.Len(t, results, 1)
+		require.Equal(t, model.DataQualityCheckPassed, results[0].Status)
+		require.EqualValues(t, 50, results[0].Observed)
+		require.EqualValues(t, 20, results[0].Threshold)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		querier := &mockDataQualityQuerier{results: map[string]string{
+			`SELECT COUNT(*) FROM "namespace"."tracks" WHERE "received_at"`: "10",
+		}}
+		checker := newChecker(whutils.POSTGRES, querier, &mockDataQualityChecksRepo{})
+
+		results := checker.check(context.Background(), "tracks", model.DataQualityChecksConfig{
+			UniqueID:           true,
+			FreshnessInMinutes: 30,
+		})
+		require.Len(t, results, 2)
+		require.Equal(t, model.DataQualityCheckErrored, results[0].Status)
+		require.Equal(t, model.UniqueIDCheck, results[0].CheckType)
+		require.Equal(t, "id", results[0].ColumnName)
+		require.Contains(t, results[0].Message, "unique id check: querying")
+		require.Equal(t, model.DataQualityCheckPassed, results[1].Status)
+	})
+
+	t.Run("clickhouse timestamps", func(t *testing.T) {
+		checker := newChecker(whutils.CLICKHOUSE, &mockDataQualityQuerier{}, &mockDataQualityChecksRepo{})
+		require.Equal(t, `'2024-01-02 03:04:05'`, checker.timestamp(now))
+	})
+}
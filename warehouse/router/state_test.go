@@ -25,7 +25,8 @@ func TestState(t *testing.T) {
 				{current: model.GeneratedLoadFiles, inProgressState: "generating_load_files"},
 				{current: model.UpdatedTableUploadsCounts, inProgressState: "updating_table_uploads_counts"},
 				{current: model.CreatedRemoteSchema, inProgressState: "creating_remote_schema"},
-				{current: model.ExportedData, inProgressState: "exporting_data"},
+				{current: model.LoadedData, inProgressState: "exporting_data"},
+				{current: model.ExportedData, inProgressState: "checking_data_quality"},
 			}
 
 			for index, tc := range testcases {
@@ -46,7 +47,8 @@ func TestState(t *testing.T) {
 			{current: model.CreatedTableUploads, next: stateTransitions[model.GeneratedLoadFiles]},
 			{current: model.GeneratedLoadFiles, next: stateTransitions[model.UpdatedTableUploadsCounts]},
 			{current: model.UpdatedTableUploadsCounts, next: stateTransitions[model.CreatedRemoteSchema]},
-			{current: model.CreatedRemoteSchema, next: stateTransitions[model.ExportedData]},
+			{current: model.CreatedRemoteSchema, next: stateTransitions[model.LoadedData]},
+			{current: model.LoadedData, next: stateTransitions[model.ExportedData]},
 			{current: model.ExportedData, next: nil},
 			{current: model.Aborted, next: nil},
 
@@ -56,7 +58,8 @@ func TestState(t *testing.T) {
 			{current: "generating_load_files", next: stateTransitions[model.GeneratedLoadFiles]},
 			{current: "updating_table_uploads_counts", next: stateTransitions[model.UpdatedTableUploadsCounts]},
 			{current: "creating_remote_schema", next: stateTransitions[model.CreatedRemoteSchema]},
-			{current: "exporting_data", next: stateTransitions[model.ExportedData]},
+			{current: "exporting_data", next: stateTransitions[model.LoadedData]},
+			{current: "checking_data_quality", next: stateTransitions[model.ExportedData]},
 
 			// failed states
 			{current: "generating_upload_schema_failed", next: stateTransitions[model.GeneratedUploadSchema]},
@@ -64,7 +67,8 @@ func TestState(t *testing.T) {
 			{current: "generating_load_files_failed", next: stateTransitions[model.GeneratedLoadFiles]},
 			{current: "updating_table_uploads_counts_failed", next: stateTransitions[model.UpdatedTableUploadsCounts]},
 			{current: "creating_remote_schema_failed", next: stateTransitions[model.CreatedRemoteSchema]},
-			{current: "exporting_data_failed", next: stateTransitions[model.ExportedData]},
+			{current: "exporting_data_failed", next: stateTransitions[model.LoadedData]},
+			{current: "checking_data_quality_failed", next: stateTransitions[model.ExportedData]},
 		}
 		for index, tc := range testCases {
 			require.Equal(t, tc.next, nextState(tc.current), "test case %d", index)
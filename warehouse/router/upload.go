@@ -88,6 +88,7 @@ type UploadJob struct {
 	stagingFileRepo      stagingFilesRepo
 	loadFilesRepo        loadFilesRepo
 	whSchemaRepo         *repo.WHSchema
+	dataQualityRepo      dataQualityChecksRepo
 	whManager            manager.Manager
 	schemaHandle         schema.Handler
 	conf                 *config.Config
@@ -120,6 +121,7 @@ type UploadJob struct {
 		longRunningUploadStatThresholdInMin time.Duration
 		skipPreviouslyFailedTables          bool
 		queryLoadFilesWithUploadID          config.ValueLoader[bool]
+		enableDataQualityChecks             config.ValueLoader[bool]
 		// max number of parallel delete requests to filemanager (applies to GCS only)
 		maxConcurrentObjDeleteRequests func(workspaceID string) int
 		// batch size for parallel deletion of staging and loadfiles (applies to GCS only)
@@ -190,6 +192,7 @@ func (f *UploadJobFactory) NewUploadJob(ctx context.Context, dto *model.UploadJo
 		stagingFileRepo:      repo.NewStagingFiles(f.db, f.conf),
 		loadFilesRepo:        repo.NewLoadFiles(f.db, f.conf),
 		whSchemaRepo:         repo.NewWHSchemas(f.db, f.conf),
+		dataQualityRepo:      repo.NewDataQualityChecks(f.db),
 		upload:               dto.Upload,
 		warehouse:            dto.Warehouse,
 		stagingFiles:         dto.StagingFiles,
@@ -221,6 +224,7 @@ func (f *UploadJobFactory) NewUploadJob(ctx context.Context, dto *model.UploadJo
 	uj.config.retryTimeWindow = f.conf.GetDurationVar(180, time.Minute, "Warehouse.retryTimeWindow", "Warehouse.retryTimeWindowInMins")
 	uj.config.skipPreviouslyFailedTables = f.conf.GetBool("Warehouse.skipPreviouslyFailedTables", false)
 	uj.config.queryLoadFilesWithUploadID = f.conf.GetReloadableBoolVar(false, "Warehouse.loadFiles.queryWithUploadID.enable")
+	uj.config.enableDataQualityChecks = f.conf.GetReloadableBoolVar(false, "Warehouse.dataQualityChecks.enabled")
 	uj.config.maxConcurrentObjDeleteRequests = func(workspaceID string) int {
 		return f.conf.GetIntVar(10, 1,
 			fmt.Sprintf("Warehouse.filemanager.%s.GCS.maxConcurrentObjDeleteRequests", workspaceID),
@@ -401,7 +405,7 @@ func (job *UploadJob) run() (err error) {
 			}
 			newStatus = nextUploadState.completed
 
-		case model.ExportedData:
+		case model.LoadedData:
 			newStatus = nextUploadState.failed
 			if err = job.exportData(); err != nil {
 				break
@@ -410,6 +414,17 @@ func (job *UploadJob) run() (err error) {
 				break
 			}
 			newStatus = nextUploadState.completed
+			// uploads without data quality checks to run are exported right away, never going through the loaded_data state
+			if len(job.dataQualityChecksToRun()) == 0 {
+				newStatus = model.ExportedData
+			}
+
+		case model.ExportedData:
+			newStatus = nextUploadState.failed
+			if err = job.checkDataQuality(whManager); err != nil {
+				break
+			}
+			newStatus = nextUploadState.completed
 
 		default:
 			// If unknown state, start again
@@ -934,13 +949,15 @@ func (job *UploadJob) GetLoadFilesMetadata(ctx context.Context, options whutils.
 	for rows.Next() {
 		var location string
 		var metadata json.RawMessage
-		err := rows.Scan(&location, &metadata)
+		var totalRows sql.NullInt64
+		err := rows.Scan(&location, &metadata, &totalRows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan result from query: %s\nwith Error : %w", sqlStatement, err)
 		}
 		loadFiles = append(loadFiles, whutils.LoadFile{
-			Location: location,
-			Metadata: metadata,
+			Location:  location,
+			Metadata:  metadata,
+			TotalRows: totalRows.Int64,
 		})
 	}
 	if err = rows.Err(); err != nil {
@@ -954,7 +971,8 @@ func (job *UploadJob) getLoadFilesMetadataQuery(tableFilterSQL, limitSQL string)
 		return fmt.Sprintf(`
 			SELECT
 			  location,
-			  metadata
+			  metadata,
+			  total_events
 			FROM
 			  %[1]s
 			WHERE
@@ -973,6 +991,7 @@ func (job *UploadJob) getLoadFilesMetadataQuery(tableFilterSQL, limitSQL string)
 		  SELECT
 			location,
 			metadata,
+			total_events,
 			row_number() OVER (
 			  PARTITION BY staging_file_id,
 			  table_name
@@ -986,7 +1005,8 @@ func (job *UploadJob) getLoadFilesMetadataQuery(tableFilterSQL, limitSQL string)
 		)
 		SELECT
 		  location,
-		  metadata
+		  metadata,
+		  total_events
 		FROM
 		  row_numbered_load_files
 		WHERE
@@ -44,6 +44,14 @@ func (m *mockSchemaRepo) Insert(context.Context, *model.WHSchema) error {
 	return nil
 }
 
+func (m *mockSchemaRepo) GetColumnWidenings(context.Context, string, string) ([]model.ColumnWidening, error) {
+	return nil, nil
+}
+
+func (m *mockSchemaRepo) InsertColumnWidening(context.Context, *model.ColumnWidening) error {
+	return nil
+}
+
 type mockFetchSchemaRepo struct{}
 
 func (m *mockFetchSchemaRepo) FetchSchema(context.Context) (model.Schema, error) {
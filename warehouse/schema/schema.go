@@ -36,6 +36,8 @@ var deprecatedColumnsRegex = regexp.MustCompile(
 type schemaRepo interface {
 	GetForNamespace(ctx context.Context, destID, namespace string) (model.WHSchema, error)
 	Insert(ctx context.Context, whSchema *model.WHSchema) error
+	GetColumnWidenings(ctx context.Context, destID, namespace string) ([]model.ColumnWidening, error)
+	InsertColumnWidening(ctx context.Context, widening *model.ColumnWidening) error
 }
 
 type stagingFileRepo interface {
@@ -75,6 +77,8 @@ type schema struct {
 	stagingFilesSchemaPaginationSize int
 	stagingFileRepo                  stagingFileRepo
 	enableIDResolution               bool
+	columnTypeWidening               bool
+	offline                          bool
 	fetchSchemaRepo                  fetchSchemaRepo
 	now                              func() time.Time
 	cachedSchema                     model.Schema
@@ -91,26 +95,7 @@ func New(
 	schemaRepo schemaRepo,
 	stagingFileRepo stagingFileRepo,
 ) (Handler, error) {
-	ttlInMinutes := conf.GetDurationVar(720, time.Minute, "Warehouse.schemaTTLInMinutes")
-	sh := &schema{
-		warehouse:                        warehouse,
-		log:                              slogger.Child("schema"),
-		ttlInMinutes:                     ttlInMinutes,
-		schemaRepo:                       schemaRepo,
-		stagingFilesSchemaPaginationSize: conf.GetInt("Warehouse.stagingFilesSchemaPaginationSize", 100),
-		stagingFileRepo:                  stagingFileRepo,
-		fetchSchemaRepo:                  fetchSchemaRepo,
-		enableIDResolution:               conf.GetBool("Warehouse.enableIDResolution", false),
-		now:                              timeutil.Now,
-	}
-	sh.stats.schemaSize = statsFactory.NewTaggedStat("warehouse_schema_size", stats.HistogramType, stats.Tags{
-		"module":        "warehouse",
-		"workspaceId":   sh.warehouse.WorkspaceID,
-		"sourceId":      sh.warehouse.Source.ID,
-		"sourceType":    sh.warehouse.Source.SourceDefinition.Name,
-		"destinationId": sh.warehouse.Destination.ID,
-		"destType":      sh.warehouse.Destination.DestinationDefinition.Name,
-	})
+	sh := newSchemaHandler(warehouse, conf, slogger, statsFactory, fetchSchemaRepo, schemaRepo, stagingFileRepo)
 	// cachedSchema can be computed in the constructor
 	// we need not worry about it getting expired in the middle of the job
 	// since we need the schema to be the same for the entireduration of the job
@@ -138,6 +123,67 @@ func New(
 	return sh, sh.fetchSchemaFromWarehouse(ctx)
 }
 
+// NewOffline returns a handler which never fetches the schema from the warehouse.
+// The schema stored for the namespace is used even if it has expired, and a missing schema is treated as an empty one.
+// It is meant for planning uploads without connecting to the warehouse, so the schema must not be updated using it.
+func NewOffline(
+	ctx context.Context,
+	warehouse model.Warehouse,
+	conf *config.Config,
+	slogger logger.Logger,
+	schemaRepo schemaRepo,
+	stagingFileRepo stagingFileRepo,
+) (Handler, error) {
+	sh := newSchemaHandler(warehouse, conf, slogger, stats.NOP, nil, schemaRepo, stagingFileRepo)
+	sh.offline = true
+	whSchema, err := sh.schemaRepo.GetForNamespace(
+		ctx,
+		sh.warehouse.Destination.ID,
+		sh.warehouse.Namespace,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("getting schema for namespace: %w", err)
+	}
+	sh.cachedSchema = whSchema.Schema
+	if sh.cachedSchema == nil {
+		sh.cachedSchema = model.Schema{}
+	}
+	return sh, nil
+}
+
+func newSchemaHandler(
+	warehouse model.Warehouse,
+	conf *config.Config,
+	slogger logger.Logger,
+	statsFactory stats.Stats,
+	fetchSchemaRepo fetchSchemaRepo,
+	schemaRepo schemaRepo,
+	stagingFileRepo stagingFileRepo,
+) *schema {
+	ttlInMinutes := conf.GetDurationVar(720, time.Minute, "Warehouse.schemaTTLInMinutes")
+	sh := &schema{
+		warehouse:                        warehouse,
+		log:                              slogger.Child("schema"),
+		ttlInMinutes:                     ttlInMinutes,
+		schemaRepo:                       schemaRepo,
+		stagingFilesSchemaPaginationSize: conf.GetInt("Warehouse.stagingFilesSchemaPaginationSize", 100),
+		stagingFileRepo:                  stagingFileRepo,
+		fetchSchemaRepo:                  fetchSchemaRepo,
+		enableIDResolution:               conf.GetBool("Warehouse.enableIDResolution", false),
+		columnTypeWidening:               warehouse.GetBoolDestinationConfig(model.ColumnTypeWideningSetting),
+		now:                              timeutil.Now,
+	}
+	sh.stats.schemaSize = statsFactory.NewTaggedStat("warehouse_schema_size", stats.HistogramType, stats.Tags{
+		"module":        "warehouse",
+		"workspaceId":   sh.warehouse.WorkspaceID,
+		"sourceId":      sh.warehouse.Source.ID,
+		"sourceType":    sh.warehouse.Source.SourceDefinition.Name,
+		"destinationId": sh.warehouse.Destination.ID,
+		"destType":      sh.warehouse.Destination.DestinationDefinition.Name,
+	})
+	return sh
+}
+
 func (sh *schema) fetchSchemaFromWarehouse(ctx context.Context) error {
 	start := sh.now()
 	warehouseSchema, err := sh.fetchSchemaRepo.FetchSchema(ctx)
@@ -196,7 +242,11 @@ func (sh *schema) GetColumnsCount(ctx context.Context, tableName string) (int, e
 }
 
 func (sh *schema) ConsolidateStagingFilesSchema(ctx context.Context, stagingFiles []*model.StagingFile) (model.Schema, error) {
+	sh.cachedSchemaMu.RLock()
+	defer sh.cachedSchemaMu.RUnlock()
+
 	consolidatedSchema := model.Schema{}
+	widenedTypes := make(map[string]model.TableSchema)
 	batches := lo.Chunk(stagingFiles, sh.stagingFilesSchemaPaginationSize)
 	for _, batch := range batches {
 		schemas, err := sh.stagingFileRepo.GetSchemasByIDs(ctx, repo.StagingFileIDs(batch))
@@ -205,10 +255,19 @@ func (sh *schema) ConsolidateStagingFilesSchema(ctx context.Context, stagingFile
 		}
 
 		consolidatedSchema = consolidateStagingSchemas(consolidatedSchema, schemas)
+		if sh.columnTypeWidening {
+			widenedTypes = collectColumnWidenings(widenedTypes, schemas, sh.cachedSchema)
+		}
+	}
+	var widenings []model.ColumnWidening
+	if sh.columnTypeWidening {
+		var err error
+		if widenings, err = sh.columnWidenings(ctx, widenedTypes, consolidatedSchema); err != nil {
+			return nil, fmt.Errorf("widening columns: %w", err)
+		}
 	}
-	sh.cachedSchemaMu.RLock()
-	defer sh.cachedSchemaMu.RUnlock()
 	consolidatedSchema = consolidateWarehouseSchema(consolidatedSchema, sh.cachedSchema)
+	consolidatedSchema = applyColumnWidenings(consolidatedSchema, widenings)
 	consolidatedSchema = overrideUsersWithIdentifiesSchema(consolidatedSchema, sh.warehouse.Type, sh.cachedSchema)
 	consolidatedSchema = enhanceDiscardsSchema(consolidatedSchema, sh.warehouse.Type)
 	consolidatedSchema = enhanceSchemaWithIDResolution(consolidatedSchema, sh.isIDResolutionEnabled(), sh.warehouse.Type)
@@ -223,7 +282,7 @@ func (sh *schema) isIDResolutionEnabled() bool {
 func (sh *schema) TableSchemaDiff(ctx context.Context, tableName string, tableSchema model.TableSchema) (whutils.TableSchemaDiff, error) {
 	sh.cachedSchemaMu.RLock()
 	defer sh.cachedSchemaMu.RUnlock()
-	return tableSchemaDiff(tableName, sh.cachedSchema, tableSchema), nil
+	return tableSchemaDiff(tableName, sh.cachedSchema, tableSchema, sh.columnTypeWidening), nil
 }
 
 func (sh *schema) saveSchema(ctx context.Context, updatedSchema model.Schema) error {
@@ -396,7 +455,7 @@ func removeDeprecatedColumns(schema model.Schema, warehouse model.Warehouse, log
 	}
 }
 
-func tableSchemaDiff(tableName string, schemaMap model.Schema, tableSchema model.TableSchema) whutils.TableSchemaDiff {
+func tableSchemaDiff(tableName string, schemaMap model.Schema, tableSchema model.TableSchema, columnTypeWidening bool) whutils.TableSchemaDiff {
 	diff := whutils.TableSchemaDiff{
 		ColumnMap:        make(model.TableSchema),
 		UpdatedSchema:    make(model.TableSchema),
@@ -430,6 +489,10 @@ func tableSchemaDiff(tableName string, schemaMap model.Schema, tableSchema model
 			diff.AlteredColumnMap[columnName] = columnType
 			diff.UpdatedSchema[columnName] = columnType
 			diff.Exists = true
+		} else if widenedType, ok := widenedDataType(currentTableSchema[columnName], columnType); columnTypeWidening && ok && widenedType == columnType {
+			diff.AlteredColumnMap[columnName] = columnType
+			diff.UpdatedSchema[columnName] = columnType
+			diff.Exists = true
 		}
 	}
 	return diff
@@ -33,8 +33,10 @@ func schemaKey(destinationID, namespace string) string {
 }
 
 type mockSchemaRepo struct {
-	schemaMap map[string]model.WHSchema
-	mu        sync.RWMutex
+	schemaMap  map[string]model.WHSchema
+	widenings  []model.ColumnWidening
+	wideningMu sync.RWMutex
+	mu         sync.RWMutex
 }
 
 func (m *mockSchemaRepo) GetForNamespace(_ context.Context, destinationID, namespace string) (model.WHSchema, error) {
@@ -57,6 +59,25 @@ func (m *mockSchemaRepo) Insert(_ context.Context, schema *model.WHSchema) error
 	return nil
 }
 
+func (m *mockSchemaRepo) GetColumnWidenings(_ context.Context, destinationID, namespace string) ([]model.ColumnWidening, error) {
+	m.wideningMu.RLock()
+	defer m.wideningMu.RUnlock()
+
+	return lo.Filter(m.widenings, func(widening model.ColumnWidening, _ int) bool {
+		return widening.DestinationID == destinationID && widening.Namespace == namespace
+	}), nil
+}
+
+func (m *mockSchemaRepo) InsertColumnWidening(_ context.Context, widening *model.ColumnWidening) error {
+	m.wideningMu.Lock()
+	defer m.wideningMu.Unlock()
+
+	m.widenings = append(lo.Reject(m.widenings, func(w model.ColumnWidening, _ int) bool {
+		return w.DestinationID == widening.DestinationID && w.Namespace == widening.Namespace && w.TableName == widening.TableName && w.ColumnName == widening.ColumnName
+	}), *widening)
+	return nil
+}
+
 type mockFetchSchemaRepo struct {
 	err error
 }
@@ -1448,6 +1469,204 @@ func TestSchema(t *testing.T) {
 			require.Contains(t, err.Error(), "warehouse fetch error")
 		})
 	})
+
+	t.Run("offline schema initialization", func(t *testing.T) {
+		t.Run("DB returns no schema", func(t *testing.T) {
+			schemaRepo := &mockSchemaRepo{schemaMap: make(map[string]model.WHSchema)}
+			sh, err := NewOffline(context.Background(), warehouse, config.New(), logger.NOP, schemaRepo, nil)
+			require.NoError(t, err)
+			require.True(t, sh.IsSchemaEmpty(context.Background()))
+
+			diff, err := sh.TableSchemaDiff(context.Background(), "table1", model.TableSchema{"column1": "string"})
+			require.NoError(t, err)
+			require.True(t, diff.TableToBeCreated)
+		})
+
+		t.Run("DB returns expired schema", func(t *testing.T) {
+			schemaRepo := &mockSchemaRepo{schemaMap: map[string]model.WHSchema{
+				"dest_id_namespace": {
+					Schema:    model.Schema{"table1": {"column1": "string"}},
+					ExpiresAt: timeutil.Now().Add(-10 * time.Minute),
+				},
+			}}
+			sh, err := NewOffline(context.Background(), warehouse, config.New(), logger.NOP, schemaRepo, &mockStagingFileRepo{
+				schemas: []model.Schema{
+					{"table1": {"column1": "int", "column2": "text"}},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"column1": "string"}, sh.GetTableSchema(context.Background(), "table1"))
+
+			uploadSchema, err := sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"column1": "string", "column2": "text"}, uploadSchema["table1"])
+		})
+	})
+
+	t.Run("column type widening", func(t *testing.T) {
+		widenedWarehouse := func(whType string) model.Warehouse {
+			return model.Warehouse{
+				Type: whType,
+				Destination: backendconfig.DestinationT{
+					ID:     "dest_id",
+					Config: map[string]any{model.ColumnTypeWideningSetting.String(): true},
+				},
+				Namespace: "namespace",
+				Source: backendconfig.SourceT{
+					ID: "source_id",
+				},
+			}
+		}
+		newSchemaRepo := func() *mockSchemaRepo {
+			return &mockSchemaRepo{schemaMap: map[string]model.WHSchema{
+				"dest_id_namespace": {
+					Schema:    model.Schema{"tracks": {"id": "string", "count": "int", "price": "float", "active": "boolean"}},
+					ExpiresAt: timeutil.Now().Add(10 * time.Minute),
+				},
+			}}
+		}
+		stagingFileRepo := &mockStagingFileRepo{
+			schemas: []model.Schema{
+				{"tracks": {"id": "string", "count": "int", "price": "int"}},
+				{"tracks": {"id": "int", "count": "bigint", "active": "string"}},
+				{"tracks": {"count": "float"}},
+			},
+		}
+
+		t.Run("disabled", func(t *testing.T) {
+			schemaRepo := newSchemaRepo()
+			warehouse := widenedWarehouse(whutils.RS)
+			warehouse.Destination.Config = nil
+
+			sh, err := New(context.Background(), warehouse, config.New(), logger.NOP, stats.NOP, &mockFetchSchemaRepo{}, schemaRepo, stagingFileRepo)
+			require.NoError(t, err)
+
+			uploadSchema, err := sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"id": "string", "count": "int", "price": "float", "active": "boolean"}, uploadSchema["tracks"])
+			require.Empty(t, schemaRepo.widenings)
+		})
+		t.Run("in place", func(t *testing.T) {
+			schemaRepo := newSchemaRepo()
+
+			sh, err := New(context.Background(), widenedWarehouse(whutils.RS), config.New(), logger.NOP, stats.NOP, &mockFetchSchemaRepo{}, schemaRepo, stagingFileRepo)
+			require.NoError(t, err)
+
+			uploadSchema, err := sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"id": "string", "count": "float", "price": "float", "active": "boolean", "active_str": "string"}, uploadSchema["tracks"])
+			require.ElementsMatch(t, []model.ColumnWidening{
+				{SourceID: "source_id", DestinationID: "dest_id", DestinationType: whutils.RS, Namespace: "namespace", TableName: "tracks", ColumnName: "count", FromType: "int", ToType: "float"},
+				{SourceID: "source_id", DestinationID: "dest_id", DestinationType: whutils.RS, Namespace: "namespace", TableName: "tracks", ColumnName: "active", FromType: "boolean", ToType: "string", ShadowColumn: "active_str"},
+			}, schemaRepo.widenings)
+
+			diff, err := sh.TableSchemaDiff(context.Background(), "tracks", uploadSchema["tracks"])
+			require.NoError(t, err)
+			require.Equal(t, whutils.TableSchemaDiff{
+				Exists:           true,
+				ColumnMap:        model.TableSchema{"active_str": "string"},
+				UpdatedSchema:    model.TableSchema{"id": "string", "count": "float", "price": "float", "active": "boolean", "active_str": "string"},
+				AlteredColumnMap: model.TableSchema{"count": "float"},
+			}, diff)
+		})
+		t.Run("shadow columns", func(t *testing.T) {
+			schemaRepo := newSchemaRepo()
+
+			sh, err := New(context.Background(), widenedWarehouse(whutils.SNOWFLAKE), config.New(), logger.NOP, stats.NOP, &mockFetchSchemaRepo{}, schemaRepo, stagingFileRepo)
+			require.NoError(t, err)
+
+			uploadSchema, err := sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"id": "string", "count": "int", "COUNT_STR": "string", "price": "float", "active": "boolean", "ACTIVE_STR": "string"}, uploadSchema["tracks"])
+			require.ElementsMatch(t, []model.ColumnWidening{
+				{SourceID: "source_id", DestinationID: "dest_id", DestinationType: whutils.SNOWFLAKE, Namespace: "namespace", TableName: "tracks", ColumnName: "count", FromType: "int", ToType: "string", ShadowColumn: "COUNT_STR"},
+				{SourceID: "source_id", DestinationID: "dest_id", DestinationType: whutils.SNOWFLAKE, Namespace: "namespace", TableName: "tracks", ColumnName: "active", FromType: "boolean", ToType: "string", ShadowColumn: "ACTIVE_STR"},
+			}, schemaRepo.widenings)
+
+			diff, err := sh.TableSchemaDiff(context.Background(), "tracks", uploadSchema["tracks"])
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"COUNT_STR": "string", "ACTIVE_STR": "string"}, diff.ColumnMap)
+			require.Empty(t, diff.AlteredColumnMap)
+
+			// recorded shadow columns keep receiving the mismatched values, even when the staging files types match
+			sh, err = New(context.Background(), widenedWarehouse(whutils.SNOWFLAKE), config.New(), logger.NOP, stats.NOP, &mockFetchSchemaRepo{}, schemaRepo, &mockStagingFileRepo{
+				schemas: []model.Schema{{"tracks": {"id": "string", "count": "int"}}},
+			})
+			require.NoError(t, err)
+
+			uploadSchema, err = sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"id": "string", "count": "int", "COUNT_STR": "string"}, uploadSchema["tracks"])
+		})
+		t.Run("recorded shadow column takes precedence over in place", func(t *testing.T) {
+			schemaRepo := newSchemaRepo()
+			schemaRepo.widenings = []model.ColumnWidening{
+				{DestinationID: "dest_id", Namespace: "namespace", TableName: "tracks", ColumnName: "count", FromType: "int", ToType: "string", ShadowColumn: "count_str"},
+			}
+
+			sh, err := New(context.Background(), widenedWarehouse(whutils.RS), config.New(), logger.NOP, stats.NOP, &mockFetchSchemaRepo{}, schemaRepo, stagingFileRepo)
+			require.NoError(t, err)
+
+			uploadSchema, err := sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"id": "string", "count": "int", "count_str": "string", "price": "float", "active": "boolean", "active_str": "string"}, uploadSchema["tracks"])
+		})
+		t.Run("shadow column with a different type", func(t *testing.T) {
+			schemaRepo := newSchemaRepo()
+
+			sh, err := New(context.Background(), widenedWarehouse(whutils.POSTGRES), config.New(), logger.NOP, stats.NOP, &mockFetchSchemaRepo{}, schemaRepo, &mockStagingFileRepo{
+				schemas: []model.Schema{{"tracks": {"count": "string", "count_str": "int"}}},
+			})
+			require.NoError(t, err)
+
+			uploadSchema, err := sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"count": "int", "count_str": "int"}, uploadSchema["tracks"])
+			require.Empty(t, schemaRepo.widenings)
+		})
+		t.Run("offline", func(t *testing.T) {
+			schemaRepo := newSchemaRepo()
+
+			sh, err := NewOffline(context.Background(), widenedWarehouse(whutils.POSTGRES), config.New(), logger.NOP, schemaRepo, stagingFileRepo)
+			require.NoError(t, err)
+
+			uploadSchema, err := sh.ConsolidateStagingFilesSchema(context.Background(), []*model.StagingFile{{ID: 1}})
+			require.NoError(t, err)
+			require.Equal(t, model.TableSchema{"id": "string", "count": "int", "count_str": "string", "price": "float", "active": "boolean", "active_str": "string"}, uploadSchema["tracks"])
+			require.Empty(t, schemaRepo.widenings)
+		})
+	})
+}
+
+func TestWidenedDataType(t *testing.T) {
+	testCases := []struct {
+		existingType string
+		incomingType string
+		widenedType  string
+		widened      bool
+	}{
+		{existingType: "int", incomingType: "int"},
+		{existingType: "int", incomingType: "bigint", widenedType: "bigint", widened: true},
+		{existingType: "int", incomingType: "float", widenedType: "float", widened: true},
+		{existingType: "int", incomingType: "string", widenedType: "string", widened: true},
+		{existingType: "int", incomingType: "text", widenedType: "text", widened: true},
+		{existingType: "int", incomingType: "boolean", widenedType: "string", widened: true},
+		{existingType: "bigint", incomingType: "int"},
+		{existingType: "float", incomingType: "int"},
+		{existingType: "float", incomingType: "bigint"},
+		{existingType: "datetime", incomingType: "int", widenedType: "string", widened: true},
+		{existingType: "boolean", incomingType: "string", widenedType: "string", widened: true},
+		{existingType: "string", incomingType: "int"},
+		{existingType: "text", incomingType: "float"},
+		{existingType: "json", incomingType: "boolean"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.existingType+" to "+tc.incomingType, func(t *testing.T) {
+			widenedType, widened := widenedDataType(tc.existingType, tc.incomingType)
+			require.Equal(t, tc.widened, widened)
+			require.Equal(t, tc.widenedType, widenedType)
+		})
+	}
 }
 
 func setupDB(t testing.TB) *sqlmiddleware.DB {
@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rudderlabs/rudder-go-kit/logger"
+	obskit "github.com/rudderlabs/rudder-observability-kit/go/labels"
+
+	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	whutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+// shadowColumnSuffix is the suffix of the columns receiving, as strings, the values not matching the type of a column which can't be altered
+const shadowColumnSuffix = "_str"
+
+// wideningOrder is the order in which column types are widened, each type being able to represent the values of the previous ones
+var wideningOrder = []string{model.IntDataType, model.BigIntDataType, model.FloatDataType, model.StringDataType}
+
+// inPlaceWideningWarehouses are the warehouses whose column types can be altered using Manager.AlterColumn
+var inPlaceWideningWarehouses = []string{whutils.RS}
+
+// widenedDataType returns the type a column of existingType needs to be widened to, so that values of incomingType aren't discarded.
+// Values of any type can be represented as strings, so types outside of the widening order are widened to string.
+func widenedDataType(existingType, incomingType string) (string, bool) {
+	if existingType == incomingType {
+		return "", false
+	}
+	switch existingType {
+	case model.StringDataType, model.TextDataType, model.JSONDataType:
+		// values of any type are already converted to these types while generating load files
+		return "", false
+	}
+	if incomingType == model.TextDataType {
+		return model.TextDataType, true
+	}
+
+	existingIndex := slices.Index(wideningOrder, existingType)
+	incomingIndex := slices.Index(wideningOrder, incomingType)
+	if existingIndex == -1 || incomingIndex == -1 {
+		return model.StringDataType, true
+	}
+	if incomingIndex < existingIndex {
+		return "", false
+	}
+	return wideningOrder[incomingIndex], true
+}
+
+// isInPlaceWidening reports whether columns of fromType can be widened by altering their type.
+// Only types within the widening order can be cast to the wider types, e.g. booleans can't be cast to strings on Redshift.
+func isInPlaceWidening(warehouseType, fromType string) bool {
+	return slices.Contains(inPlaceWideningWarehouses, warehouseType) && slices.Contains(wideningOrder, fromType)
+}
+
+// ShadowColumnName returns the name of the column receiving the values not matching the type of the column
+func ShadowColumnName(warehouseType, columnName string) string {
+	return whutils.ToProviderCase(warehouseType, columnName+shadowColumnSuffix)
+}
+
+// collectColumnWidenings collects the types the columns of the warehouse schema need to be widened to for the staging files schemas
+func collectColumnWidenings(widenedTypes map[string]model.TableSchema, schemas []model.Schema, warehouseSchema model.Schema) map[string]model.TableSchema {
+	for _, schema := range schemas {
+		for tableName, columnMap := range schema {
+			for columnName, columnType := range columnMap {
+				existingType, ok := warehouseSchema[tableName][columnName]
+				if !ok {
+					continue
+				}
+				if widenedType, ok := widenedTypes[tableName][columnName]; ok {
+					existingType = widenedType
+				}
+				widenedType, ok := widenedDataType(existingType, columnType)
+				if !ok {
+					continue
+				}
+				if _, ok := widenedTypes[tableName]; !ok {
+					widenedTypes[tableName] = model.TableSchema{}
+				}
+				widenedTypes[tableName][columnName] = widenedType
+			}
+		}
+	}
+	return widenedTypes
+}
+
+// columnWidenings decides how the columns are widened, either by altering their type or by adding shadow columns,
+// keeping the decisions recorded for the namespace and recording the new ones
+func (sh *schema) columnWidenings(ctx context.Context, widenedTypes map[string]model.TableSchema, consolidatedSchema model.Schema) ([]model.ColumnWidening, error) {
+	recorded, err := sh.schemaRepo.GetColumnWidenings(ctx, sh.warehouse.Destination.ID, sh.warehouse.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("getting column widenings: %w", err)
+	}
+	recordedByColumn := make(map[string]map[string]model.ColumnWidening)
+	for _, widening := range recorded {
+		if _, ok := recordedByColumn[widening.TableName]; !ok {
+			recordedByColumn[widening.TableName] = make(map[string]model.ColumnWidening)
+		}
+		recordedByColumn[widening.TableName][widening.ColumnName] = widening
+	}
+
+	var widenings []model.ColumnWidening
+	for tableName, columnMap := range consolidatedSchema {
+		for columnName := range columnMap {
+			recordedWidening, isRecorded := recordedByColumn[tableName][columnName]
+			widenedType, isWidened := widenedTypes[tableName][columnName]
+			if !isWidened {
+				// shadow columns keep receiving the values not matching the column's type
+				if isRecorded && recordedWidening.ShadowColumn != "" {
+					widenings = append(widenings, recordedWidening)
+				}
+				continue
+			}
+
+			widening := model.ColumnWidening{
+				SourceID:        sh.warehouse.Source.ID,
+				DestinationID:   sh.warehouse.Destination.ID,
+				DestinationType: sh.warehouse.Type,
+				Namespace:       sh.warehouse.Namespace,
+				TableName:       tableName,
+				ColumnName:      columnName,
+				FromType:        sh.cachedSchema[tableName][columnName],
+				ToType:          widenedType,
+			}
+			if !isInPlaceWidening(sh.warehouse.Type, widening.FromType) || (isRecorded && recordedWidening.ShadowColumn != "") {
+				widening.ShadowColumn = ShadowColumnName(sh.warehouse.Type, columnName)
+				if widenedType != model.TextDataType {
+					widening.ToType = model.StringDataType
+				}
+				if !sh.isShadowColumnAvailable(tableName, widening.ShadowColumn, consolidatedSchema) {
+					continue
+				}
+			}
+			widenings = append(widenings, widening)
+
+			if sh.offline || (isRecorded && recordedWidening.ToType == widening.ToType && recordedWidening.ShadowColumn == widening.ShadowColumn) {
+				continue
+			}
+			if err := sh.schemaRepo.InsertColumnWidening(ctx, &widening); err != nil {
+				return nil, fmt.Errorf("recording column widening: %w", err)
+			}
+			sh.log.Infon("Widening column instead of discarding values",
+				obskit.DestinationID(sh.warehouse.Destination.ID),
+				obskit.Namespace(sh.warehouse.Namespace),
+				logger.NewStringField("tableName", tableName),
+				logger.NewStringField("columnName", columnName),
+				logger.NewStringField("fromType", widening.FromType),
+				logger.NewStringField("toType", widening.ToType),
+				logger.NewStringField("shadowColumn", widening.ShadowColumn),
+			)
+		}
+	}
+	return widenings, nil
+}
+
+// isShadowColumnAvailable reports whether the shadow column can receive string values, i.e. it is either missing or a string column
+func (sh *schema) isShadowColumnAvailable(tableName, shadowColumn string, consolidatedSchema model.Schema) bool {
+	for _, columnType := range []string{consolidatedSchema[tableName][shadowColumn], sh.cachedSchema[tableName][shadowColumn]} {
+		if columnType != "" && columnType != model.StringDataType && columnType != model.TextDataType {
+			return false
+		}
+	}
+	return true
+}
+
+// applyColumnWidenings widens the columns of the consolidated schema, either by altering their type or by adding their shadow columns
+func applyColumnWidenings(consolidatedSchema model.Schema, widenings []model.ColumnWidening) model.Schema {
+	for _, widening := range widenings {
+		if _, ok := consolidatedSchema[widening.TableName]; !ok {
+			continue
+		}
+		if widening.ShadowColumn == "" {
+			consolidatedSchema[widening.TableName][widening.ColumnName] = widening.ToType
+			continue
+		}
+		if _, ok := consolidatedSchema[widening.TableName][widening.ShadowColumn]; !ok {
+			consolidatedSchema[widening.TableName][widening.ShadowColumn] = widening.ToType
+		}
+	}
+	return consolidatedSchema
+}
@@ -368,6 +368,9 @@ func (w *worker) processSingleStagingFile(
 			}
 		}
 
+		// values not matching the type of their column, to be loaded into the shadow columns instead of being discarded
+		shadowColumnValues := make(map[string]any)
+
 		for _, columnName := range sortedTableColumnMap[tableName] {
 			if eventLoader.IsLoadTimeColumn(columnName) {
 				timestampFormat := eventLoader.GetLoadTimeFormat(columnName)
@@ -377,6 +380,10 @@ func (w *worker) processSingleStagingFile(
 
 			columnInfo, ok := batchRouterEvent.GetColumnInfo(columnName)
 			if !ok {
+				if shadowVal, ok := shadowColumnValues[columnName]; ok {
+					eventLoader.AddColumn(columnName, job.UploadSchema[tableName][columnName], shadowVal)
+					continue
+				}
 				eventLoader.AddEmptyColumn(columnName)
 				continue
 			}
@@ -433,6 +440,15 @@ func (w *worker) processSingleStagingFile(
 					columnVal,
 				)
 
+				if convError != nil && !violatedConstraints.IsViolated {
+					// shadow columns are sorted after their column, so the value is loaded once they are reached
+					if shadowColumn, shadowVal, ok := job.shadowColumnValue(&batchRouterEvent, tableName, columnName, columnType, columnVal); ok {
+						shadowColumnValues[shadowColumn] = shadowVal
+						eventLoader.AddEmptyColumn(columnName)
+						continue
+					}
+				}
+
 				if convError != nil || violatedConstraints.IsViolated {
 					var reason string
 					if violatedConstraints.IsViolated {
@@ -711,6 +727,8 @@ func HandleSchemaChange(existingDataType, currentDataType model.SchemaType, valu
 		} else {
 			newColumnVal = float64(intVal)
 		}
+	} else if currentDataType == model.IntDataType && existingDataType == model.BigIntDataType {
+		newColumnVal = value
 	} else if currentDataType == model.FloatDataType && (existingDataType == model.IntDataType || existingDataType == model.BigIntDataType) {
 		floatVal, ok := value.(float64)
 		if !ok {
@@ -34,6 +34,7 @@ import (
 	"github.com/rudderlabs/rudder-server/utils/misc"
 	"github.com/rudderlabs/rudder-server/warehouse/encoding"
 	"github.com/rudderlabs/rudder-server/warehouse/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/schema"
 	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
 )
 
@@ -97,6 +98,27 @@ func (p *basePayload) sortedColumnMapForAllTables() map[string][]string {
 	})
 }
 
+// shadowColumnValue returns the shadow column receiving the value not matching the type of its column, along with the value converted to the shadow column's type.
+// Shadow columns are only used if column type widening is enabled and the event doesn't have its own value for them.
+func (p *basePayload) shadowColumnValue(event *types.BatchRouterEvent, tableName, columnName, columnType string, columnVal any) (string, any, bool) {
+	if enabled, _ := p.DestinationConfig[model.ColumnTypeWideningSetting.String()].(bool); !enabled {
+		return "", nil, false
+	}
+	shadowColumn := schema.ShadowColumnName(p.DestinationType, columnName)
+	shadowColumnType, ok := p.UploadSchema[tableName][shadowColumn]
+	if !ok {
+		return "", nil, false
+	}
+	if _, ok := event.GetColumnInfo(shadowColumn); ok {
+		return "", nil, false
+	}
+	shadowVal, err := HandleSchemaChange(shadowColumnType, columnType, columnVal)
+	if err != nil {
+		return "", nil, false
+	}
+	return shadowColumn, shadowVal, true
+}
+
 func (p *basePayload) fileManager(config interface{}, useRudderStorage bool) (filemanager.FileManager, error) {
 	configMap, ok := config.(map[string]interface{})
 	if !ok {
@@ -1177,6 +1177,13 @@ func TestHandleSchemaChange(t *testing.T) {
 			value:             1.501,
 			expectedColumnVal: 1,
 		},
+		{
+			name:              "should send int values if existing datatype is bigint, new datatype is int",
+			existingDatatype:  "bigint",
+			currentDataType:   "int",
+			value:             1,
+			expectedColumnVal: 1,
+		},
 		{
 			name:              "should send float values if existing datatype is float, new datatype is int",
 			existingDatatype:  "float",
@@ -1563,6 +1570,73 @@ func TestStagingFileDuplicateEventsMetric(t *testing.T) {
 	})
 }
 
+func TestColumnTypeWidening(t *testing.T) {
+	workerIdx := 1
+	events := []string{
+		`{"data":{"id":"1","count":1},"metadata":{"table":"tracks","columns":{"id":"string","count":"int"}}}`,
+		`{"data":{"id":"2","count":"many"},"metadata":{"table":"tracks","columns":{"id":"string","count":"string"}}}`,
+		`{"data":{"id":"3","count":true,"count_str":"own"},"metadata":{"table":"tracks","columns":{"id":"string","count":"boolean","count_str":"string"}}}`,
+	}
+
+	processStagingFile := func(t *testing.T, destConf map[string]any) (*jobRun, [][]string) {
+		t.Helper()
+
+		f, err := os.CreateTemp(t.TempDir(), "staging.json.gz")
+		require.NoError(t, err)
+		gzWriter := gzip.NewWriter(f)
+		_, err = gzWriter.Write([]byte(strings.Join(events, "\n")))
+		require.NoError(t, err)
+		require.NoError(t, gzWriter.Close())
+		require.NoError(t, f.Close())
+
+		w := newWorker(config.New(), logger.NOP, stats.NOP, nil, nil, constraints.New(config.New()), encoding.NewFactory(config.New()), workerIdx)
+		jr := newJobRun(basePayload{
+			UploadSchema: model.Schema{
+				"tracks": {"id": "string", "count": "int", "count_str": "string"},
+			},
+			DestinationType:   warehouseutils.POSTGRES,
+			DestinationConfig: destConf,
+			LoadFileType:      warehouseutils.LoadFileTypeCsv,
+		}, workerIdx, config.New(), logger.NOP, stats.NOP, w.encodingFactory)
+		jr.downloadStagingFile = func(context.Context, stagingFileInfo) error {
+			jr.stagingFilePaths = map[int64]string{1: f.Name()}
+			return nil
+		}
+		require.NoError(t, w.processSingleStagingFile(context.Background(), jr, &jr.job, stagingFileInfo{ID: 1}, ""))
+		jr.closeLoadFiles()
+
+		loadFile, err := os.Open(jr.outputFileWritersMap["tracks"].GetLoadFile().Name())
+		require.NoError(t, err)
+		defer func() { _ = loadFile.Close() }()
+		gzReader, err := gzip.NewReader(loadFile)
+		require.NoError(t, err)
+		records, err := csv.NewReader(gzReader).ReadAll()
+		require.NoError(t, err)
+		return jr, records
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		jr, records := processStagingFile(t, map[string]any{model.ColumnTypeWideningSetting.String(): true})
+		require.Equal(t, [][]string{
+			{"1", "", "1"},
+			{"", "many", "2"},
+			{"", "own", "3"},
+		}, records)
+		require.Equal(t, 3, jr.tableEventCountMap["tracks"])
+		require.Equal(t, 1, jr.tableEventCountMap[warehouseutils.DiscardsTable])
+	})
+	t.Run("disabled", func(t *testing.T) {
+		jr, records := processStagingFile(t, map[string]any{})
+		require.Equal(t, [][]string{
+			{"1", "", "1"},
+			{"", "", "2"},
+			{"", "own", "3"},
+		}, records)
+		require.Equal(t, 3, jr.tableEventCountMap["tracks"])
+		require.Equal(t, 2, jr.tableEventCountMap[warehouseutils.DiscardsTable])
+	})
+}
+
 func TestLoadFileDeterministicNaming(t *testing.T) {
 	misc.Init()
 	const (
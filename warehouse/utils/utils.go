@@ -72,6 +72,9 @@ const (
 	WarehouseUploadsTable                   = "wh_uploads"
 	WarehouseTableUploadsTable              = "wh_table_uploads"
 	WarehouseSchemasTable                   = "wh_schemas"
+	WarehouseSchemaColumnWideningsTable     = "wh_schema_column_widenings"
+	WarehouseUploadDataQualityChecksTable   = "wh_upload_data_quality_checks"
+	WarehouseSLABreachesTable               = "wh_sla_breaches"
 	WarehouseAsyncJobTable                  = "wh_async_jobs"
 )
 
@@ -224,8 +227,9 @@ type GetLoadFilesOptions struct {
 }
 
 type LoadFile struct {
-	Location string
-	Metadata json.RawMessage
+	Location  string
+	Metadata  json.RawMessage
+	TotalRows int64
 }
 
 type (